@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/snow/audit"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	defaultAuditLogLimit = 1024
+	maxAuditLogLimit     = 8192
+)
+
+var errAuditLogDisabled = errors.New("consensus audit log is disabled")
+
+// GetConsensusAuditLogArgs are the arguments for calling
+// GetConsensusAuditLog
+type GetConsensusAuditLogArgs struct {
+	Chain       string         `json:"chain"`
+	StartHeight avajson.Uint64 `json:"startHeight"`
+	EndHeight   avajson.Uint64 `json:"endHeight"`
+	// Limit is the maximum number of entries to return. Defaults to 1024.
+	Limit avajson.Uint32 `json:"limit"`
+}
+
+// GetConsensusAuditLogReply are the audit log entries of the requested
+// heights
+type GetConsensusAuditLogReply struct {
+	Entries []audit.Entry `json:"entries"`
+}
+
+// GetConsensusAuditLog returns the entries of a chain's consensus audit log
+// with a height in [StartHeight, EndHeight], in the order they were recorded.
+func (a *Admin) GetConsensusAuditLog(_ *http.Request, args *GetConsensusAuditLogArgs, reply *GetConsensusAuditLogReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getConsensusAuditLog"),
+		logging.UserString("chain", args.Chain),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+		zap.Uint32("limit", uint32(args.Limit)),
+	)
+
+	if len(a.AuditLogDir) == 0 {
+		return errAuditLogDisabled
+	}
+	limit := int(args.Limit)
+	switch {
+	case limit == 0:
+		limit = defaultAuditLogLimit
+	case limit > maxAuditLogLimit:
+		return fmt.Errorf("%w: %d > %d", errLimitTooLarge, limit, maxAuditLogLimit)
+	}
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.Entries, err = audit.Read(
+		a.AuditLogDir,
+		chainID,
+		uint64(args.StartHeight),
+		uint64(args.EndHeight),
+		limit,
+	)
+	return err
+}
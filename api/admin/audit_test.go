@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/audit"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestGetConsensusAuditLog(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	chainID := ids.GenerateTestID()
+	blkID := ids.GenerateTestID()
+	auditLog := audit.New(logging.NoLog{}, audit.Config{
+		Enabled:   true,
+		Directory: dir,
+		MaxSize:   1,
+		MaxFiles:  1,
+	}, chainID)
+	auditLog.Accepted(blkID, 1)
+	auditLog.Accepted(ids.GenerateTestID(), 2)
+
+	a := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chains.TestManager,
+		AuditLogDir:  dir,
+	}}
+
+	reply := &GetConsensusAuditLogReply{}
+	require.NoError(a.GetConsensusAuditLog(nil, &GetConsensusAuditLogArgs{
+		Chain:       chainID.String(),
+		StartHeight: 1,
+		EndHeight:   1,
+	}, reply))
+	require.Len(reply.Entries, 1)
+	require.Equal(audit.AcceptedType, reply.Entries[0].Type)
+	require.Equal(blkID, *reply.Entries[0].BlockID)
+
+	err := a.GetConsensusAuditLog(nil, &GetConsensusAuditLogArgs{
+		Chain: chainID.String(),
+		Limit: maxAuditLogLimit + 1,
+	}, &GetConsensusAuditLogReply{})
+	require.ErrorIs(err, errLimitTooLarge)
+
+	a.AuditLogDir = ""
+	err = a.GetConsensusAuditLog(nil, &GetConsensusAuditLogArgs{
+		Chain: chainID.String(),
+	}, &GetConsensusAuditLogReply{})
+	require.ErrorIs(err, errAuditLogDisabled)
+}
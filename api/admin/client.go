@@ -9,7 +9,10 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/acl"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
@@ -31,6 +34,18 @@ type Client interface {
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
 	DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error)
+	DBIterate(ctx context.Context, namespace DBNamespace, start []byte, prefix []byte, limit uint32, options ...rpc.Option) ([]KeyValue, []byte, error)
+	DBStats(ctx context.Context, chain string, options ...rpc.Option) (*DBStatsReply, error)
+	ExportSnapshot(ctx context.Context, name string, options ...rpc.Option) (*ExportSnapshotReply, error)
+	GetConsensusAuditLog(ctx context.Context, chain string, startHeight, endHeight uint64, limit uint32, options ...rpc.Option) ([]audit.Entry, error)
+	GetPeerACL(ctx context.Context, options ...rpc.Option) (acl.Config, error)
+	SetPeerACL(ctx context.Context, config acl.Config, options ...rpc.Option) error
+}
+
+// KeyValue is a key/value pair returned by DBIterate
+type KeyValue struct {
+	Key   []byte
+	Value []byte
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -163,3 +178,99 @@ func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) (
 	}
 	return formatting.Decode(formatting.HexNC, res.Value)
 }
+
+// DBIterate returns up to [limit] key/value pairs with [prefix], starting at
+// [start], from [namespace]. The returned key, if non-nil, is the start of the
+// next page.
+func (c *client) DBIterate(
+	ctx context.Context,
+	namespace DBNamespace,
+	start []byte,
+	prefix []byte,
+	limit uint32,
+	options ...rpc.Option,
+) ([]KeyValue, []byte, error) {
+	startStr, err := DBBase64.encode(start)
+	if err != nil {
+		return nil, nil, err
+	}
+	prefixStr, err := DBBase64.encode(prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &DBIterateReply{}
+	err = c.requester.SendRequest(ctx, "admin.dbIterate", &DBIterateArgs{
+		DBNamespace: namespace,
+		Prefix:      prefixStr,
+		Start:       startStr,
+		Limit:       json.Uint32(limit),
+		Encoding:    DBBase64,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyValues := make([]KeyValue, len(res.KeyValues))
+	for i, kv := range res.KeyValues {
+		keyValues[i].Key, err = DBBase64.decode(kv.Key)
+		if err != nil {
+			return nil, nil, err
+		}
+		keyValues[i].Value, err = DBBase64.decode(kv.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(res.NextKey) == 0 {
+		return keyValues, nil, nil
+	}
+	nextKey, err := DBBase64.decode(res.NextKey)
+	return keyValues, nextKey, err
+}
+
+func (c *client) DBStats(ctx context.Context, chain string, options ...rpc.Option) (*DBStatsReply, error) {
+	res := &DBStatsReply{}
+	err := c.requester.SendRequest(ctx, "admin.dbStats", &DBStatsArgs{
+		Chain: chain,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) ExportSnapshot(ctx context.Context, name string, options ...rpc.Option) (*ExportSnapshotReply, error) {
+	res := &ExportSnapshotReply{}
+	err := c.requester.SendRequest(ctx, "admin.exportSnapshot", &ExportSnapshotArgs{
+		Name: name,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetConsensusAuditLog(
+	ctx context.Context,
+	chain string,
+	startHeight uint64,
+	endHeight uint64,
+	limit uint32,
+	options ...rpc.Option,
+) ([]audit.Entry, error) {
+	res := &GetConsensusAuditLogReply{}
+	err := c.requester.SendRequest(ctx, "admin.getConsensusAuditLog", &GetConsensusAuditLogArgs{
+		Chain:       chain,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+		Limit:       json.Uint32(limit),
+	}, res, options...)
+	return res.Entries, err
+}
+
+func (c *client) GetPeerACL(ctx context.Context, options ...rpc.Option) (acl.Config, error) {
+	res := &PeerACL{}
+	err := c.requester.SendRequest(ctx, "admin.getPeerACL", struct{}{}, res, options...)
+	return res.Config, err
+}
+
+func (c *client) SetPeerACL(ctx context.Context, config acl.Config, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.setPeerACL", &PeerACL{
+		Config: config,
+	}, &api.EmptyReply{}, options...)
+}
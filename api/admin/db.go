@@ -0,0 +1,456 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/migrate"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	defaultDBIterateLimit = 100
+	maxDBIterateLimit     = 1024
+
+	// dbStatsSampleSize is the maximum number of keys that are read from a
+	// namespace by admin.dbStats. The statistics of larger namespaces are
+	// estimated.
+	dbStatsSampleSize = 1024
+	// maxDBStatsPrefixes is the maximum number of namespaces that are
+	// reported by admin.dbStats.
+	maxDBStatsPrefixes = 4096
+)
+
+var (
+	errUnsupportedEncoding = errors.New("unsupported encoding")
+	errLimitTooLarge       = errors.New("limit too large")
+
+	// chainDBPrefixes are the namespaces that the chain manager creates inside
+	// of every chain's database.
+	chainDBPrefixes = [][]byte{
+		chains.VMDBPrefix,
+		chains.VertexDBPrefix,
+		chains.VertexBootstrappingDBPrefix,
+		chains.TxBootstrappingDBPrefix,
+		chains.BlockBootstrappingDBPrefix,
+		chains.ChainBootstrappingDBPrefix,
+	}
+)
+
+// DBEncoding is the format of the keys and values of the database endpoints.
+// In addition to the hex formats of [formatting.Encoding], raw database
+// contents can be encoded as base64 to halve the size of the replies.
+type DBEncoding string
+
+const (
+	DBHex    DBEncoding = "hex"
+	DBHexNC  DBEncoding = "hexnc"
+	DBHexC   DBEncoding = "hexc"
+	DBBase64 DBEncoding = "base64"
+)
+
+func (e DBEncoding) hexEncoding() (formatting.Encoding, error) {
+	switch e {
+	case DBHex:
+		return formatting.Hex, nil
+	case DBHexNC:
+		return formatting.HexNC, nil
+	case DBHexC:
+		return formatting.HexC, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnsupportedEncoding, e)
+	}
+}
+
+func (e DBEncoding) encode(bytes []byte) (string, error) {
+	if e == DBBase64 {
+		return base64.StdEncoding.EncodeToString(bytes), nil
+	}
+	encoding, err := e.hexEncoding()
+	if err != nil {
+		return "", err
+	}
+	return formatting.Encode(encoding, bytes)
+}
+
+func (e DBEncoding) decode(str string) ([]byte, error) {
+	if e == DBBase64 {
+		return base64.StdEncoding.DecodeString(str)
+	}
+	encoding, err := e.hexEncoding()
+	if err != nil {
+		return nil, err
+	}
+	return formatting.Decode(encoding, str)
+}
+
+// DBNamespace selects a prefixdb namespace of the node's database.
+type DBNamespace struct {
+	// Chain, if provided, restricts the request to the database of this
+	// chain.
+	Chain string `json:"chain"`
+	// Namespaces are applied, in order, as nested prefixdb prefixes. For
+	// example, ["vm"] selects the database of [Chain]'s VM.
+	Namespaces []string `json:"namespaces"`
+}
+
+// namespace returns the database selected by [ns].
+func (a *Admin) namespace(ns DBNamespace) (database.Database, error) {
+	db := a.DB
+	if len(ns.Chain) > 0 {
+		chainID, err := a.ChainManager.Lookup(ns.Chain)
+		if err != nil {
+			return nil, err
+		}
+		db = prefixdb.New(chainID[:], db)
+	}
+	for _, namespace := range ns.Namespaces {
+		db = prefixdb.New([]byte(namespace), db)
+	}
+	return db, nil
+}
+
+type chainNamespace struct {
+	name   string
+	prefix []byte
+	db     database.Database
+}
+
+// chainNamespaces returns the namespaces that the chain manager creates for
+// [chainID]. Because nested prefixdbs hash their prefixes together, each of
+// these namespaces has its own prefix in the node's database.
+func (a *Admin) chainNamespaces(chainID ids.ID) []chainNamespace {
+	var (
+		alias       = a.ChainManager.PrimaryAliasOrDefault(chainID)
+		chainPrefix = prefixdb.MakePrefix(chainID[:])
+		chainDB     = prefixdb.New(chainID[:], a.DB)
+		namespaces  = make([]chainNamespace, 0, len(chainDBPrefixes)+1)
+	)
+	namespaces = append(namespaces, chainNamespace{
+		name:   alias,
+		prefix: chainPrefix,
+		db:     chainDB,
+	})
+	for _, namespace := range chainDBPrefixes {
+		namespaces = append(namespaces, chainNamespace{
+			name:   alias + "/" + string(namespace),
+			prefix: prefixdb.JoinPrefixes(chainPrefix, namespace),
+			db:     prefixdb.New(namespace, chainDB),
+		})
+	}
+	return namespaces
+}
+
+type DBIterateArgs struct {
+	DBNamespace
+
+	// Prefix, if provided, restricts the iteration to keys with this prefix.
+	Prefix string `json:"prefix"`
+	// Start, if provided, is the first key to return. Used to page through
+	// the results by passing the NextKey of the previous reply.
+	Start    string         `json:"start"`
+	Limit    avajson.Uint32 `json:"limit"`
+	Encoding DBEncoding     `json:"encoding"`
+}
+
+type DBKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type DBIterateReply struct {
+	KeyValues []DBKeyValue `json:"keyValues"`
+	// NextKey is the start key of the next page. If empty, the iteration
+	// has finished.
+	NextKey  string     `json:"nextKey"`
+	Encoding DBEncoding `json:"encoding"`
+}
+
+//nolint:stylecheck // renaming this method to DBIterate would change the API method from "dbIterate" to "dBIterate"
+func (a *Admin) DbIterate(_ *http.Request, args *DBIterateArgs, reply *DBIterateReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "dbIterate"),
+		logging.UserString("chain", args.Chain),
+		zap.Strings("namespaces", args.Namespaces),
+		logging.UserString("prefix", args.Prefix),
+		logging.UserString("start", args.Start),
+		zap.Uint32("limit", uint32(args.Limit)),
+	)
+
+	encoding := args.Encoding
+	if encoding == "" {
+		encoding = DBHex
+	}
+	limit := int(args.Limit)
+	switch {
+	case limit == 0:
+		limit = defaultDBIterateLimit
+	case limit > maxDBIterateLimit:
+		return fmt.Errorf("%w: %d > %d", errLimitTooLarge, limit, maxDBIterateLimit)
+	}
+
+	prefix, err := encoding.decode(args.Prefix)
+	if err != nil {
+		return fmt.Errorf("couldn't decode prefix: %w", err)
+	}
+	start, err := encoding.decode(args.Start)
+	if err != nil {
+		return fmt.Errorf("couldn't decode start: %w", err)
+	}
+
+	db, err := a.namespace(args.DBNamespace)
+	if err != nil {
+		return err
+	}
+
+	it := db.NewIteratorWithStartAndPrefix(start, prefix)
+	defer it.Release()
+
+	reply.KeyValues = make([]DBKeyValue, 0, limit)
+	reply.Encoding = encoding
+	for it.Next() {
+		key, err := encoding.encode(it.Key())
+		if err != nil {
+			return err
+		}
+		// An additional key is read to determine where the next page starts.
+		if len(reply.KeyValues) == limit {
+			reply.NextKey = key
+			break
+		}
+
+		value, err := encoding.encode(it.Value())
+		if err != nil {
+			return err
+		}
+		reply.KeyValues = append(reply.KeyValues, DBKeyValue{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return it.Error()
+}
+
+type DBStatsArgs struct {
+	// Chain, if provided, restricts the reply to the namespaces of this
+	// chain.
+	Chain string `json:"chain"`
+}
+
+type DBPrefixStats struct {
+	// Prefix is the hex encoded prefixdb prefix of the keys.
+	Prefix string `json:"prefix"`
+	// Name of the namespace, if known.
+	Name    string `json:"name,omitempty"`
+	NumKeys uint64 `json:"numKeys"`
+	// Size is the total number of bytes of all keys and values.
+	Size uint64 `json:"size"`
+	// Estimated is true if the namespace was too large to be read entirely,
+	// in which case NumKeys and Size are estimates.
+	Estimated bool `json:"estimated"`
+}
+
+type DBStatsReply struct {
+	Prefixes []DBPrefixStats `json:"prefixes"`
+	NumKeys  uint64          `json:"numKeys"`
+	Size     uint64          `json:"size"`
+	// Truncated is true if the database has more namespaces than are
+	// reported.
+	Truncated bool `json:"truncated"`
+}
+
+// DbStats reports the approximate number of keys and their size for every
+// prefixdb namespace in the node's database.
+//
+// Keys are grouped by their first [migrate.DefaultPrefixLen] bytes, so keys
+// that were not written through a prefixdb are reported individually. At most
+// [dbStatsSampleSize] keys are read from each namespace, so the cost of a
+// request doesn't depend on the size of the database.
+//
+//nolint:stylecheck // renaming this method to DBStats would change the API method from "dbStats" to "dBStats"
+func (a *Admin) DbStats(_ *http.Request, args *DBStatsArgs, reply *DBStatsReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "dbStats"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	reply.Prefixes = []DBPrefixStats{}
+	if len(args.Chain) > 0 {
+		chainID, err := a.ChainManager.Lookup(args.Chain)
+		if err != nil {
+			return err
+		}
+
+		// Only the namespaces of the chain need to be read.
+		for _, namespace := range a.chainNamespaces(chainID) {
+			stats, err := a.prefixStats(namespace.prefix)
+			if err != nil {
+				return err
+			}
+			if stats.NumKeys == 0 {
+				continue
+			}
+			stats.Name = namespace.name
+			reply.Prefixes = append(reply.Prefixes, stats)
+		}
+	} else {
+		names := make(map[string]string)
+		for _, chainID := range a.chains.trackedChainIDs() {
+			for _, namespace := range a.chainNamespaces(chainID) {
+				names[string(namespace.prefix)] = namespace.name
+			}
+		}
+
+		var start []byte
+		for {
+			prefix, stats, err := a.nextPrefixStats(start)
+			if err != nil {
+				return err
+			}
+			if prefix == nil {
+				break
+			}
+			if len(reply.Prefixes) == maxDBStatsPrefixes {
+				reply.Truncated = true
+				break
+			}
+
+			stats.Name = names[string(prefix)]
+			reply.Prefixes = append(reply.Prefixes, stats)
+
+			// Skip over the remaining keys of the namespace.
+			if len(prefix) < migrate.DefaultPrefixLen {
+				// The smallest key after [prefix]
+				start = make([]byte, len(prefix)+1)
+				copy(start, prefix)
+			} else {
+				start = prefixToUpperBound(prefix)
+				if start == nil {
+					break
+				}
+			}
+		}
+	}
+
+	for _, stats := range reply.Prefixes {
+		reply.NumKeys += stats.NumKeys
+		reply.Size += stats.Size
+	}
+	slices.SortFunc(reply.Prefixes, func(i, j DBPrefixStats) int {
+		return strings.Compare(i.Prefix, j.Prefix)
+	})
+	return nil
+}
+
+// nextPrefixStats returns the prefix of the first key at or after [start],
+// along with the statistics of the keys with that prefix. Keys shorter than a
+// prefix are their own prefix. If there are no keys at or after [start], nil
+// is returned.
+func (a *Admin) nextPrefixStats(start []byte) ([]byte, DBPrefixStats, error) {
+	it := a.DB.NewIteratorWithStart(start)
+	defer it.Release()
+
+	if !it.Next() {
+		return nil, DBPrefixStats{}, it.Error()
+	}
+
+	key := it.Key()
+	if len(key) >= migrate.DefaultPrefixLen {
+		prefix := slices.Clone(key[:migrate.DefaultPrefixLen])
+		stats, err := a.prefixStats(prefix)
+		return prefix, stats, err
+	}
+
+	prefix := slices.Clone(key)
+	prefixStr, err := formatting.Encode(formatting.HexNC, prefix)
+	return prefix, DBPrefixStats{
+		Prefix:  prefixStr,
+		NumKeys: 1,
+		Size:    uint64(len(key) + len(it.Value())),
+	}, err
+}
+
+// prefixStats returns the statistics of the keys with [prefix]. If there are
+// more than [dbStatsSampleSize] keys, the statistics are estimated from the
+// first [dbStatsSampleSize] keys.
+func (a *Admin) prefixStats(prefix []byte) (DBPrefixStats, error) {
+	prefixStr, err := formatting.Encode(formatting.HexNC, prefix)
+	if err != nil {
+		return DBPrefixStats{}, err
+	}
+
+	it := a.DB.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	stats := DBPrefixStats{
+		Prefix: prefixStr,
+	}
+	for it.Next() {
+		if stats.NumKeys == dbStatsSampleSize {
+			return a.estimatePrefixStats(prefix, stats)
+		}
+		stats.NumKeys++
+		stats.Size += uint64(len(it.Key()) + len(it.Value()))
+	}
+	return stats, it.Error()
+}
+
+// estimatePrefixStats extrapolates [sample], the statistics of some of the
+// keys with [prefix], to all of the keys with [prefix] using the disk usage
+// of the prefix. If the disk usage can't be estimated, [sample] is returned as
+// a lower bound.
+func (a *Admin) estimatePrefixStats(prefix []byte, sample DBPrefixStats) (DBPrefixStats, error) {
+	sample.Estimated = true
+
+	limit := prefixToUpperBound(prefix)
+	if a.SizeEstimator == nil || limit == nil {
+		return sample, nil
+	}
+	size, err := a.SizeEstimator.EstimateSize(prefix, limit)
+	if err != nil {
+		return DBPrefixStats{}, err
+	}
+	// Recently written keys may not be included in the estimate, and the keys
+	// on disk may be compressed.
+	if size <= sample.Size {
+		return sample, nil
+	}
+
+	// Assume that the sampled keys are representative of the average key
+	// size.
+	sample.NumKeys = size / (sample.Size / sample.NumKeys)
+	sample.Size = size
+	return sample, nil
+}
+
+// prefixToUpperBound returns the smallest key that is greater than every key
+// with [prefix], or nil if there is no such key.
+func prefixToUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upperBound := make([]byte, i+1)
+			copy(upperBound, prefix)
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
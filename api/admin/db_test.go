@@ -0,0 +1,265 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ rpc.EndpointRequester = (*adminRequester)(nil)
+
+// adminRequester serves client requests directly from an Admin service.
+type adminRequester struct {
+	admin *Admin
+}
+
+func (r *adminRequester) SendRequest(_ context.Context, method string, args interface{}, reply interface{}, _ ...rpc.Option) error {
+	switch method {
+	case "admin.dbIterate":
+		return r.admin.DbIterate(nil, args.(*DBIterateArgs), reply.(*DBIterateReply))
+	default:
+		return fmt.Errorf("unexpected method %q", method)
+	}
+}
+
+func newTestDBAdmin(require *require.Assertions, numKeys int) *Admin {
+	db := memdb.New()
+	for i := 0; i < numKeys; i++ {
+		require.NoError(db.Put(
+			[]byte(fmt.Sprintf("key-%04d", i)),
+			[]byte(fmt.Sprintf("value-%04d", i)),
+		))
+	}
+	return &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  db,
+	}}
+}
+
+func TestServiceDBIterate(t *testing.T) {
+	require := require.New(t)
+
+	a := newTestDBAdmin(require, 5)
+
+	var (
+		keys  []string
+		start string
+	)
+	for {
+		reply := &DBIterateReply{}
+		require.NoError(a.DbIterate(nil, &DBIterateArgs{
+			Start:    start,
+			Limit:    2,
+			Encoding: DBBase64,
+		}, reply))
+		require.LessOrEqual(len(reply.KeyValues), 2)
+		require.Equal(DBBase64, reply.Encoding)
+
+		for _, kv := range reply.KeyValues {
+			key, err := DBBase64.decode(kv.Key)
+			require.NoError(err)
+			keys = append(keys, string(key))
+		}
+		if len(reply.NextKey) == 0 {
+			break
+		}
+		start = reply.NextKey
+	}
+	require.Equal([]string{"key-0000", "key-0001", "key-0002", "key-0003", "key-0004"}, keys)
+}
+
+func TestServiceDBIteratePrefix(t *testing.T) {
+	require := require.New(t)
+
+	a := newTestDBAdmin(require, 20)
+
+	prefix, err := formatting.Encode(formatting.HexNC, []byte("key-001"))
+	require.NoError(err)
+
+	reply := &DBIterateReply{}
+	require.NoError(a.DbIterate(nil, &DBIterateArgs{
+		Prefix:   prefix,
+		Encoding: DBHexNC,
+	}, reply))
+	require.Len(reply.KeyValues, 10)
+	require.Empty(reply.NextKey)
+
+	value, err := formatting.Decode(formatting.HexNC, reply.KeyValues[0].Value)
+	require.NoError(err)
+	require.Equal([]byte("value-0010"), value)
+}
+
+func TestServiceDBIterateInvalidArgs(t *testing.T) {
+	a := newTestDBAdmin(require.New(t), 0)
+
+	tests := []struct {
+		name        string
+		args        *DBIterateArgs
+		expectedErr error
+	}{
+		{
+			name: "json encoding",
+			args: &DBIterateArgs{
+				Encoding: "json",
+			},
+			expectedErr: errUnsupportedEncoding,
+		},
+		{
+			name: "limit too large",
+			args: &DBIterateArgs{
+				Limit: maxDBIterateLimit + 1,
+			},
+			expectedErr: errLimitTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := a.DbIterate(nil, test.args, &DBIterateReply{})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type testChainManager struct {
+	chains.Manager
+}
+
+func (testChainManager) PrimaryAliasOrDefault(ids.ID) string {
+	return "X"
+}
+
+func TestServiceDBStats(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	a := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		DB:           db,
+		ChainManager: testChainManager{Manager: chains.TestManager},
+	}}
+
+	chainID := ids.GenerateTestID()
+	a.chains.RegisterChain("X", &snow.ConsensusContext{
+		Context: &snow.Context{
+			ChainID: chainID,
+		},
+	}, nil)
+
+	chainDB := prefixdb.New(chainID[:], db)
+	vmDB := prefixdb.New(chains.VMDBPrefix, chainDB)
+	require.NoError(vmDB.Put([]byte("hello"), []byte("world")))
+	require.NoError(vmDB.Put([]byte("foo"), []byte("bar")))
+	require.NoError(chainDB.Put([]byte("key"), []byte("value")))
+	require.NoError(db.Put([]byte("unnamed"), []byte("value")))
+
+	{
+		reply := &DBStatsReply{}
+		require.NoError(a.DbStats(nil, &DBStatsArgs{}, reply))
+		require.Equal(uint64(4), reply.NumKeys)
+
+		stats := make(map[string]DBPrefixStats)
+		for _, prefixStats := range reply.Prefixes {
+			stats[prefixStats.Name] = prefixStats
+		}
+		require.Len(stats, 3)
+		require.Equal(uint64(2), stats["X/vm"].NumKeys)
+		require.Equal(uint64(1), stats["X"].NumKeys)
+		require.Equal(uint64(1), stats[""].NumKeys)
+	}
+
+	{
+		reply := &DBStatsReply{}
+		require.NoError(a.DbStats(nil, &DBStatsArgs{
+			Chain: chainID.String(),
+		}, reply))
+		require.Equal(uint64(3), reply.NumKeys)
+		require.Len(reply.Prefixes, 2)
+
+		expectedVMSize := uint64(2*hashing.HashLen + len("hello") + len("world") + len("foo") + len("bar"))
+		for _, prefixStats := range reply.Prefixes {
+			if prefixStats.Name == "X/vm" {
+				require.Equal(expectedVMSize, prefixStats.Size)
+			}
+		}
+	}
+}
+
+type testSizeEstimator uint64
+
+func (s testSizeEstimator) EstimateSize([]byte, []byte) (uint64, error) {
+	return uint64(s), nil
+}
+
+func TestServiceDBStatsEstimated(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	prefixDB := prefixdb.New([]byte("large"), db)
+	for i := 0; i < 2*dbStatsSampleSize; i++ {
+		require.NoError(prefixDB.Put(
+			[]byte(fmt.Sprintf("key-%04d", i)),
+			[]byte(fmt.Sprintf("value-%04d", i)),
+		))
+	}
+	a := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  db,
+	}}
+
+	// Without an estimate of the disk usage, the sampled keys are reported.
+	reply := &DBStatsReply{}
+	require.NoError(a.DbStats(nil, &DBStatsArgs{}, reply))
+	require.Len(reply.Prefixes, 1)
+	require.True(reply.Prefixes[0].Estimated)
+	require.Equal(uint64(dbStatsSampleSize), reply.Prefixes[0].NumKeys)
+
+	entrySize := uint64(hashing.HashLen + len("key-0000") + len("value-0000"))
+	a.SizeEstimator = testSizeEstimator(4 * dbStatsSampleSize * entrySize)
+	reply = &DBStatsReply{}
+	require.NoError(a.DbStats(nil, &DBStatsArgs{}, reply))
+	require.Len(reply.Prefixes, 1)
+	require.True(reply.Prefixes[0].Estimated)
+	require.Equal(uint64(4*dbStatsSampleSize), reply.Prefixes[0].NumKeys)
+	require.Equal(uint64(4*dbStatsSampleSize)*entrySize, reply.Size)
+}
+
+func TestKeyValueReaderIterator(t *testing.T) {
+	require := require.New(t)
+
+	a := newTestDBAdmin(require, maxDBIterateLimit+10)
+	reader := NewKeyValueReader(&client{
+		requester: &adminRequester{admin: a},
+	})
+
+	it := reader.NewIterator()
+	defer it.Release()
+
+	var numKeys int
+	for it.Next() {
+		value, err := a.DB.Get(it.Key())
+		require.NoError(err)
+		require.Equal(value, it.Value())
+		numKeys++
+	}
+	require.NoError(it.Error())
+
+	count, err := database.Count(a.DB)
+	require.NoError(err)
+	require.Equal(count, numKeys)
+}
@@ -9,7 +9,11 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 )
 
-var _ database.KeyValueReader = (*KeyValueReader)(nil)
+var (
+	_ database.KeyValueReader = (*KeyValueReader)(nil)
+	_ database.Iteratee       = (*KeyValueReader)(nil)
+	_ database.Iterator       = (*iterator)(nil)
+)
 
 type KeyValueReader struct {
 	client Client
@@ -32,3 +36,80 @@ func (r *KeyValueReader) Has(key []byte) (bool, error) {
 func (r *KeyValueReader) Get(key []byte) ([]byte, error) {
 	return r.client.DBGet(context.Background(), key)
 }
+
+func (r *KeyValueReader) NewIterator() database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (r *KeyValueReader) NewIteratorWithStart(start []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (r *KeyValueReader) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns an iterator that lazily fetches pages
+// of key/value pairs using DBIterate.
+func (r *KeyValueReader) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		client: r.client,
+		start:  start,
+		prefix: prefix,
+	}
+}
+
+type iterator struct {
+	client Client
+	// start is the first key of the next page to fetch. If nil after the
+	// first page has been fetched, there are no more pages.
+	start     []byte
+	prefix    []byte
+	fetched   bool
+	keyValues []KeyValue
+
+	key, value []byte
+	err        error
+}
+
+func (it *iterator) Next() bool {
+	for len(it.keyValues) == 0 {
+		if it.err != nil || (it.fetched && it.start == nil) {
+			it.key = nil
+			it.value = nil
+			return false
+		}
+
+		it.keyValues, it.start, it.err = it.client.DBIterate(
+			context.Background(),
+			DBNamespace{},
+			it.start,
+			it.prefix,
+			maxDBIterateLimit,
+		)
+		it.fetched = true
+	}
+
+	it.key = it.keyValues[0].Key
+	it.value = it.keyValues[0].Value
+	it.keyValues = it.keyValues[1:]
+	return true
+}
+
+func (it *iterator) Error() error {
+	return it.err
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+func (it *iterator) Release() {
+	it.fetched = true
+	it.start = nil
+	it.keyValues = nil
+}
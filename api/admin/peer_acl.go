@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/network/acl"
+)
+
+var errPeerACLDisabled = errors.New("peer ACL is disabled")
+
+// PeerACL are the lists of peers that this node is allowed or denied to
+// connect to
+type PeerACL struct {
+	acl.Config
+}
+
+// GetPeerACL returns the current peer allow and deny lists.
+func (a *Admin) GetPeerACL(_ *http.Request, _ *struct{}, reply *PeerACL) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getPeerACL"),
+	)
+
+	if a.PeerACL == nil {
+		return errPeerACLDisabled
+	}
+	reply.Config = a.PeerACL.Config()
+	return nil
+}
+
+// SetPeerACL replaces the peer allow and deny lists and persists them.
+// Connections to peers that are denied by the new lists are closed.
+func (a *Admin) SetPeerACL(_ *http.Request, args *PeerACL, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setPeerACL"),
+		zap.Int("numAllowed", len(args.Allow.NodeIDs)+len(args.Allow.IPs)+len(args.Allow.CIDRs)),
+		zap.Int("numDenied", len(args.Deny.NodeIDs)+len(args.Deny.IPs)+len(args.Deny.CIDRs)),
+	)
+
+	if a.PeerACL == nil {
+		return errPeerACLDisabled
+	}
+	return a.PeerACL.Set(args.Config)
+}
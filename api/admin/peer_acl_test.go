@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/acl"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestPeerACL(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "peer-acl.json")
+	peerACL, err := acl.New(path)
+	require.NoError(err)
+
+	a := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		PeerACL: peerACL,
+	}}
+
+	config := acl.Config{
+		Deny: acl.List{
+			NodeIDs: []ids.NodeID{ids.GenerateTestNodeID()},
+			IPs:     []netip.Addr{netip.MustParseAddr("1.2.3.4")},
+		},
+	}
+	require.NoError(a.SetPeerACL(nil, &PeerACL{Config: config}, &api.EmptyReply{}))
+
+	reply := &PeerACL{}
+	require.NoError(a.GetPeerACL(nil, nil, reply))
+	require.Equal(config, reply.Config)
+
+	// The lists should have been persisted.
+	peerACL, err = acl.New(path)
+	require.NoError(err)
+	require.Equal(config, peerACL.Config())
+
+	a.PeerACL = nil
+	err = a.GetPeerACL(nil, nil, &PeerACL{})
+	require.ErrorIs(err, errPeerACLDisabled)
+	err = a.SetPeerACL(nil, &PeerACL{}, &api.EmptyReply{})
+	require.ErrorIs(err, errPeerACLDisabled)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/acl"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -53,6 +54,26 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+
+	// Snapshotter is used to take consistent snapshots of the database. If
+	// nil, snapshots can not be exported.
+	Snapshotter database.Snapshotter
+	SnapshotDir string
+
+	// SizeEstimator is used to estimate the size of large namespaces of the
+	// database. If nil, the sizes of large namespaces are underestimated.
+	SizeEstimator database.SizeEstimator
+
+	// AuditLogDir is the directory the consensus audit logs are written to.
+	// If empty, the audit logs can not be read.
+	AuditLogDir string
+
+	// PeerACL decides which peers the node may connect to. If nil, the peer
+	// ACL can not be read or changed.
+	PeerACL *acl.ACL
+
+	NetworkID   uint32
+	GenesisHash ids.ID
 }
 
 // Admin is the API service for node admin management
@@ -60,6 +81,9 @@ type Admin struct {
 	Config
 	lock     sync.RWMutex
 	profiler profiler.Profiler
+
+	chains       chainTracker
+	snapshotLock sync.Mutex
 }
 
 // NewService returns a new admin API service.
@@ -69,13 +93,13 @@ func NewService(config Config) (http.Handler, error) {
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
-	return server, server.RegisterService(
-		&Admin{
-			Config:   config,
-			profiler: profiler.New(config.ProfileDir),
-		},
-		"admin",
-	)
+
+	admin := &Admin{
+		Config:   config,
+		profiler: profiler.New(config.ProfileDir),
+	}
+	config.ChainManager.AddRegistrant(&admin.chains)
+	return server, server.RegisterService(admin, "admin")
 }
 
 // StartCPUProfiler starts a cpu profile writing to the specified file
@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/snapshot"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// snapshotFileExtension is appended to the name of exported snapshots.
+const snapshotFileExtension = ".snapshot"
+
+var (
+	_ chains.Registrant = (*chainTracker)(nil)
+
+	errSnapshotsDisabled   = errors.New("database doesn't support snapshots")
+	errInvalidSnapshotName = errors.New("invalid snapshot name")
+)
+
+type trackedChain struct {
+	ctx *snow.ConsensusContext
+	vm  block.ChainVM
+}
+
+// chainTracker records the chains running on this node. The last accepted
+// blocks of the linear chains are included in snapshot manifests.
+type chainTracker struct {
+	lock     sync.Mutex
+	chainIDs set.Set[ids.ID]
+	chains   map[ids.ID]trackedChain
+}
+
+func (c *chainTracker) RegisterChain(_ string, ctx *snow.ConsensusContext, vm common.VM) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.chainIDs.Add(ctx.ChainID)
+
+	chainVM, ok := vm.(block.ChainVM)
+	if !ok {
+		return
+	}
+	if c.chains == nil {
+		c.chains = make(map[ids.ID]trackedChain)
+	}
+	c.chains[ctx.ChainID] = trackedChain{
+		ctx: ctx,
+		vm:  chainVM,
+	}
+}
+
+// trackedChainIDs returns the IDs of all the chains running on this node.
+func (c *chainTracker) trackedChainIDs() []ids.ID {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.chainIDs.List()
+}
+
+// lockChains grabs the context lock of every tracked chain. Because blocks are
+// only accepted while holding the context lock, this prevents the database
+// from being modified by consensus until the returned function is called.
+func (c *chainTracker) lockChains() ([]trackedChain, func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	chainIDs := make([]ids.ID, 0, len(c.chains))
+	for chainID := range c.chains {
+		chainIDs = append(chainIDs, chainID)
+	}
+	// Locks are always grabbed in the same order.
+	slices.SortFunc(chainIDs, ids.ID.Compare)
+
+	trackedChains := make([]trackedChain, len(chainIDs))
+	for i, chainID := range chainIDs {
+		chain := c.chains[chainID]
+		chain.ctx.Lock.Lock()
+		trackedChains[i] = chain
+	}
+	return trackedChains, func() {
+		for _, chain := range trackedChains {
+			chain.ctx.Lock.Unlock()
+		}
+	}
+}
+
+func lastAccepted(ctx context.Context, trackedChains []trackedChain) ([]snapshot.Chain, error) {
+	lastAccepted := make([]snapshot.Chain, len(trackedChains))
+	for i, chain := range trackedChains {
+		blkID, err := chain.vm.LastAccepted(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last accepted of %s: %w", chain.ctx.ChainID, err)
+		}
+		blk, err := chain.vm.GetBlock(ctx, blkID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %s of %s: %w", blkID, chain.ctx.ChainID, err)
+		}
+		lastAccepted[i] = snapshot.Chain{
+			ChainID: chain.ctx.ChainID,
+			BlockID: blkID,
+			Height:  blk.Height(),
+		}
+	}
+	return lastAccepted, nil
+}
+
+type ExportSnapshotArgs struct {
+	// Name of the snapshot file to create in the snapshot directory.
+	Name string `json:"name"`
+}
+
+type ExportSnapshotReply struct {
+	Path     string            `json:"path"`
+	Manifest snapshot.Manifest `json:"manifest"`
+	Summary  snapshot.Summary  `json:"summary"`
+}
+
+// ExportSnapshot writes a consistent, compressed, archive of the node's
+// database to the snapshot directory.
+func (a *Admin) ExportSnapshot(r *http.Request, args *ExportSnapshotArgs, reply *ExportSnapshotReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "exportSnapshot"),
+		logging.UserString("name", args.Name),
+	)
+
+	if a.Snapshotter == nil {
+		return errSnapshotsDisabled
+	}
+	if len(args.Name) == 0 || strings.ContainsAny(args.Name, `/\`) || args.Name == "." || args.Name == ".." {
+		return fmt.Errorf("%w: %q", errInvalidSnapshotName, args.Name)
+	}
+
+	// Only one snapshot may be exported at a time.
+	a.snapshotLock.Lock()
+	defer a.snapshotLock.Unlock()
+
+	if err := os.MkdirAll(a.SnapshotDir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	path := filepath.Join(a.SnapshotDir, args.Name+snapshotFileExtension)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	ctx := r.Context()
+	trackedChains, unlock := a.chains.lockChains()
+	lastAcceptedChains, err := lastAccepted(ctx, trackedChains)
+	if err != nil {
+		unlock()
+		return err
+	}
+	dbSnapshot, err := a.Snapshotter.NewSnapshot()
+	unlock()
+	if err != nil {
+		return err
+	}
+	defer dbSnapshot.Release()
+
+	manifest := &snapshot.Manifest{
+		NodeVersion: version.CurrentApp.String(),
+		NetworkID:   a.NetworkID,
+		GenesisHash: a.GenesisHash,
+		Timestamp:   time.Now().UTC(),
+		Chains:      lastAcceptedChains,
+	}
+
+	a.Log.Info("exporting database snapshot",
+		zap.String("path", path),
+		zap.Reflect("chains", lastAcceptedChains),
+	)
+
+	summary, err := snapshot.Export(file, dbSnapshot, manifest)
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	a.Log.Info("exported database snapshot",
+		zap.String("path", path),
+		zap.Uint64("numKeys", summary.NumKeys),
+		zap.Stringer("checksum", summary.Checksum),
+	)
+
+	reply.Path = path
+	reply.Manifest = *manifest
+	reply.Summary = *summary
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/snapshot"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestExportSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	require.NoError(db.Put([]byte("hello"), []byte("world")))
+
+	genesisHash := ids.GenerateTestID()
+	a := &Admin{Config: Config{
+		Log:         logging.NoLog{},
+		DB:          db,
+		Snapshotter: db,
+		SnapshotDir: t.TempDir(),
+		NetworkID:   constants.UnitTestID,
+		GenesisHash: genesisHash,
+	}}
+
+	r, err := http.NewRequest(http.MethodPost, "", nil)
+	require.NoError(err)
+
+	reply := &ExportSnapshotReply{}
+	require.NoError(a.ExportSnapshot(r, &ExportSnapshotArgs{Name: "test"}, reply))
+	require.Equal(constants.UnitTestID, reply.Manifest.NetworkID)
+	require.Equal(genesisHash, reply.Manifest.GenesisHash)
+	require.Equal(uint64(1), reply.Summary.NumKeys)
+
+	file, err := os.Open(reply.Path)
+	require.NoError(err)
+	defer file.Close()
+
+	manifest, err := snapshot.ReadManifest(file)
+	require.NoError(err)
+	require.Equal(reply.Manifest.GenesisHash, manifest.GenesisHash)
+
+	// Existing snapshots are never overwritten
+	err = a.ExportSnapshot(r, &ExportSnapshotArgs{Name: "test"}, &ExportSnapshotReply{})
+	require.ErrorIs(err, os.ErrExist)
+}
+
+func TestExportSnapshotInvalidName(t *testing.T) {
+	db := memdb.New()
+	a := &Admin{Config: Config{
+		Log:         logging.NoLog{},
+		DB:          db,
+		Snapshotter: db,
+		SnapshotDir: t.TempDir(),
+	}}
+
+	for _, name := range []string{"", ".", "..", "../escape", `a\b`} {
+		err := a.ExportSnapshot(nil, &ExportSnapshotArgs{Name: name}, &ExportSnapshotReply{})
+		require.ErrorIs(t, err, errInvalidSnapshotName)
+	}
+}
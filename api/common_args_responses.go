@@ -158,3 +158,32 @@ type GetUTXOsReply struct {
 	// Encoding specifies the encoding format the UTXOs are returned in
 	Encoding formatting.Encoding `json:"encoding"`
 }
+
+// MempoolTx describes a transaction that is waiting in the mempool.
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Size of the transaction in bytes
+	Size avajson.Uint64 `json:"size"`
+	// Fee paid by the transaction in the fee asset
+	Fee avajson.Uint64 `json:"fee"`
+	// Age is the number of seconds since the transaction was added to the
+	// mempool.
+	Age avajson.Uint64 `json:"age"`
+}
+
+// DroppedTx describes a transaction that was recently dropped from the
+// mempool.
+type DroppedTx struct {
+	TxID ids.ID `json:"txID"`
+	// Reason the transaction was dropped
+	Reason string `json:"reason"`
+}
+
+// GetMempoolReply defines the GetMempool replies returned from the API
+type GetMempoolReply struct {
+	// Transactions in the mempool, from the oldest to the newest
+	Txs []MempoolTx `json:"txs"`
+	// Recently dropped transactions, from the least to the most recently
+	// dropped
+	Dropped []DroppedTx `json:"dropped"`
+}
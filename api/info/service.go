@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils"
@@ -45,6 +46,7 @@ type Info struct {
 	chainManager chains.Manager
 	vmManager    vms.Manager
 	benchlist    benchlist.Manager
+	timeouts     timeout.Manager
 }
 
 type Parameters struct {
@@ -66,6 +68,7 @@ func NewService(
 	myIP *utils.Atomic[netip.AddrPort],
 	network network.Network,
 	benchlist benchlist.Manager,
+	timeouts timeout.Manager,
 ) (http.Handler, error) {
 	server := rpc.NewServer()
 	codec := json.NewCodec()
@@ -81,6 +84,7 @@ func NewService(
 			myIP:         myIP,
 			networking:   network,
 			benchlist:    benchlist,
+			timeouts:     timeouts,
 		},
 		"info",
 	)
@@ -232,14 +236,9 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	peers := i.networking.PeerInfo(args.NodeIDs)
 	peerInfo := make([]Peer, len(peers))
 	for index, peer := range peers {
-		benchedIDs := i.benchlist.GetBenched(peer.ID)
-		benchedAliases := make([]string, len(benchedIDs))
-		for idx, id := range benchedIDs {
-			alias, err := i.chainManager.PrimaryAlias(id)
-			if err != nil {
-				return fmt.Errorf("failed to get primary alias for chain ID %s: %w", id, err)
-			}
-			benchedAliases[idx] = alias
+		benchedAliases, err := i.getBenched(peer.ID)
+		if err != nil {
+			return err
 		}
 		peerInfo[index] = Peer{
 			Info:    peer,
@@ -252,6 +251,70 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	return nil
 }
 
+// PeerStatsArgs are the arguments for calling PeerStats
+type PeerStatsArgs struct {
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+type PeerStats struct {
+	peer.Stats
+
+	Benched []string `json:"benched"`
+	// AverageResponseLatency is the moving average of the number of
+	// nanoseconds it took the peer to respond to our requests. It is only
+	// reported if the peer responded to a request recently.
+	AverageResponseLatency *json.Uint64 `json:"averageResponseLatency,omitempty"`
+}
+
+// PeerStatsReply are the results from calling PeerStats
+type PeerStatsReply struct {
+	// Number of elements in [Peers]
+	NumPeers json.Uint64 `json:"numPeers"`
+	// Each element is a peer
+	Peers []PeerStats `json:"peers"`
+}
+
+// PeerStats returns the message accounting of the current peers
+func (i *Info) PeerStats(_ *http.Request, args *PeerStatsArgs, reply *PeerStatsReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "peerStats"),
+	)
+
+	peers := i.networking.PeerStats(args.NodeIDs)
+	reply.Peers = make([]PeerStats, len(peers))
+	for index, peer := range peers {
+		benchedAliases, err := i.getBenched(peer.ID)
+		if err != nil {
+			return err
+		}
+		reply.Peers[index] = PeerStats{
+			Stats:   peer,
+			Benched: benchedAliases,
+		}
+		if latency, ok := i.timeouts.AverageLatency(peer.ID); ok {
+			latencyNanos := json.Uint64(latency.Nanoseconds())
+			reply.Peers[index].AverageResponseLatency = &latencyNanos
+		}
+	}
+	reply.NumPeers = json.Uint64(len(reply.Peers))
+	return nil
+}
+
+// getBenched returns the aliases of the chains that [nodeID] is benched on.
+func (i *Info) getBenched(nodeID ids.NodeID) ([]string, error) {
+	benchedIDs := i.benchlist.GetBenched(nodeID)
+	benchedAliases := make([]string, len(benchedIDs))
+	for idx, id := range benchedIDs {
+		alias, err := i.chainManager.PrimaryAlias(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get primary alias for chain ID %s: %w", id, err)
+		}
+		benchedAliases[idx] = alias
+	}
+	return benchedAliases, nil
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
@@ -0,0 +1,416 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	dto "github.com/prometheus/client_model/go"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+const (
+	otlpExportTimeout = 10 * time.Second
+	otlpHTTPPath      = "/v1/metrics"
+)
+
+var (
+	errUnknownExporterType = errors.New("unknown exporter type")
+	errUnexpectedStatus    = errors.New("unexpected status")
+)
+
+type OTLPConfig struct {
+	trace.ExporterConfig `json:"exporterConfig"`
+
+	// Used to flag if metrics should be pushed over OTLP
+	Enabled bool `json:"enabled"`
+
+	// How often the metrics are pushed
+	Interval time.Duration `json:"interval"`
+
+	AppName string `json:"appName"`
+	Version string `json:"version"`
+}
+
+// OTLPExporter periodically pushes the metrics of a gatherer to an OTLP
+// collector.
+//
+// Prometheus counters are exported as cumulative monotonic sums, gauges and
+// untyped metrics as gauges, histograms as explicit bucket histograms and
+// summaries as summaries. Metric labels are exported as attributes.
+type OTLPExporter struct {
+	log      logging.Logger
+	gatherer prometheus.Gatherer
+	config   OTLPConfig
+	client   otlpClient
+
+	// The cumulative metrics are reported as starting when the exporter was
+	// created.
+	startTime time.Time
+	resource  *resourcepb.Resource
+
+	closeOnce sync.Once
+	closer    chan struct{}
+	done      sync.WaitGroup
+}
+
+// NewOTLPExporter returns an exporter that pushes the metrics of [gatherer]
+// every [config.Interval] until it is closed.
+func NewOTLPExporter(
+	log logging.Logger,
+	gatherer prometheus.Gatherer,
+	config OTLPConfig,
+) (*OTLPExporter, error) {
+	client, err := newOTLPClient(config.ExporterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &OTLPExporter{
+		log:       log,
+		gatherer:  gatherer,
+		config:    config,
+		client:    client,
+		startTime: time.Now(),
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				newStringAttribute("service.name", config.AppName),
+				newStringAttribute("version", config.Version),
+			},
+		},
+		closer: make(chan struct{}),
+	}
+	e.done.Add(1)
+	go e.run()
+	return e, nil
+}
+
+func (e *OTLPExporter) run() {
+	defer e.done.Done()
+
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.export(); err != nil {
+				e.log.Warn("failed to export metrics",
+					zap.String("endpoint", e.config.Endpoint),
+					zap.Error(err),
+				)
+			}
+		case <-e.closer:
+			return
+		}
+	}
+}
+
+func (e *OTLPExporter) export() error {
+	// Gather returns partially filled metrics in the case of an error, so the
+	// gathered metrics are still exported.
+	metricFamilies, err := e.gatherer.Gather()
+	if err != nil {
+		e.log.Debug("failed to gather some metrics",
+			zap.Error(err),
+		)
+	}
+
+	request := &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{
+			{
+				Resource: e.resource,
+				ScopeMetrics: []*metricspb.ScopeMetrics{
+					{
+						Scope: &commonpb.InstrumentationScope{
+							Name:    e.config.AppName,
+							Version: e.config.Version,
+						},
+						Metrics: newOTLPMetrics(metricFamilies, e.startTime, time.Now()),
+					},
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+	return e.client.Export(ctx, request)
+}
+
+// Close stops exporting metrics. Metrics are not exported when the exporter is
+// closed.
+func (e *OTLPExporter) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.closer)
+	})
+	e.done.Wait()
+	return e.client.Close()
+}
+
+// newOTLPMetrics converts the gathered Prometheus metrics into OTLP metrics.
+// Cumulative metrics are reported as starting at [startTime].
+func newOTLPMetrics(metricFamilies []*dto.MetricFamily, startTime, now time.Time) []*metricspb.Metric {
+	var (
+		startTimeUnixNano = uint64(startTime.UnixNano())
+		timeUnixNano      = uint64(now.UnixNano())
+		metrics           = make([]*metricspb.Metric, 0, len(metricFamilies))
+	)
+	for _, metricFamily := range metricFamilies {
+		metric := &metricspb.Metric{
+			Name:        metricFamily.GetName(),
+			Description: metricFamily.GetHelp(),
+		}
+		switch metricFamily.GetType() {
+		case dto.MetricType_COUNTER:
+			dataPoints := make([]*metricspb.NumberDataPoint, len(metricFamily.Metric))
+			for i, m := range metricFamily.Metric {
+				dataPoints[i] = &metricspb.NumberDataPoint{
+					Attributes:        newAttributes(m.Label),
+					StartTimeUnixNano: startTimeUnixNano,
+					TimeUnixNano:      timeUnixNano,
+					Value: &metricspb.NumberDataPoint_AsDouble{
+						AsDouble: m.GetCounter().GetValue(),
+					},
+				}
+			}
+			metric.Data = &metricspb.Metric_Sum{
+				Sum: &metricspb.Sum{
+					DataPoints:             dataPoints,
+					AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+					IsMonotonic:            true,
+				},
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			dataPoints := make([]*metricspb.NumberDataPoint, len(metricFamily.Metric))
+			for i, m := range metricFamily.Metric {
+				value := m.GetGauge().GetValue()
+				if m.Untyped != nil {
+					value = m.GetUntyped().GetValue()
+				}
+				dataPoints[i] = &metricspb.NumberDataPoint{
+					Attributes:   newAttributes(m.Label),
+					TimeUnixNano: timeUnixNano,
+					Value: &metricspb.NumberDataPoint_AsDouble{
+						AsDouble: value,
+					},
+				}
+			}
+			metric.Data = &metricspb.Metric_Gauge{
+				Gauge: &metricspb.Gauge{
+					DataPoints: dataPoints,
+				},
+			}
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			dataPoints := make([]*metricspb.HistogramDataPoint, len(metricFamily.Metric))
+			for i, m := range metricFamily.Metric {
+				dataPoints[i] = newHistogramDataPoint(m, startTimeUnixNano, timeUnixNano)
+			}
+			metric.Data = &metricspb.Metric_Histogram{
+				Histogram: &metricspb.Histogram{
+					DataPoints:             dataPoints,
+					AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				},
+			}
+		case dto.MetricType_SUMMARY:
+			dataPoints := make([]*metricspb.SummaryDataPoint, len(metricFamily.Metric))
+			for i, m := range metricFamily.Metric {
+				summary := m.GetSummary()
+				quantiles := make([]*metricspb.SummaryDataPoint_ValueAtQuantile, len(summary.Quantile))
+				for j, quantile := range summary.Quantile {
+					quantiles[j] = &metricspb.SummaryDataPoint_ValueAtQuantile{
+						Quantile: quantile.GetQuantile(),
+						Value:    quantile.GetValue(),
+					}
+				}
+				dataPoints[i] = &metricspb.SummaryDataPoint{
+					Attributes:        newAttributes(m.Label),
+					StartTimeUnixNano: startTimeUnixNano,
+					TimeUnixNano:      timeUnixNano,
+					Count:             summary.GetSampleCount(),
+					Sum:               summary.GetSampleSum(),
+					QuantileValues:    quantiles,
+				}
+			}
+			metric.Data = &metricspb.Metric_Summary{
+				Summary: &metricspb.Summary{
+					DataPoints: dataPoints,
+				},
+			}
+		default:
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// newHistogramDataPoint converts the cumulative buckets of a Prometheus
+// histogram into the per-bucket counts used by OTLP.
+func newHistogramDataPoint(m *dto.Metric, startTimeUnixNano, timeUnixNano uint64) *metricspb.HistogramDataPoint {
+	var (
+		histogram      = m.GetHistogram()
+		sum            = histogram.GetSampleSum()
+		count          = histogram.GetSampleCount()
+		explicitBounds = make([]float64, 0, len(histogram.Bucket))
+		bucketCounts   = make([]uint64, 0, len(histogram.Bucket)+1)
+		previousCount  uint64
+	)
+	for _, bucket := range histogram.Bucket {
+		// The +Inf bucket is implicit in OTLP
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			break
+		}
+		explicitBounds = append(explicitBounds, bucket.GetUpperBound())
+		bucketCounts = append(bucketCounts, bucket.GetCumulativeCount()-previousCount)
+		previousCount = bucket.GetCumulativeCount()
+	}
+	bucketCounts = append(bucketCounts, count-previousCount)
+
+	return &metricspb.HistogramDataPoint{
+		Attributes:        newAttributes(m.Label),
+		StartTimeUnixNano: startTimeUnixNano,
+		TimeUnixNano:      timeUnixNano,
+		Count:             count,
+		Sum:               &sum,
+		BucketCounts:      bucketCounts,
+		ExplicitBounds:    explicitBounds,
+	}
+}
+
+func newAttributes(labels []*dto.LabelPair) []*commonpb.KeyValue {
+	attributes := make([]*commonpb.KeyValue, len(labels))
+	for i, label := range labels {
+		attributes[i] = newStringAttribute(label.GetName(), label.GetValue())
+	}
+	return attributes
+}
+
+func newStringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key: key,
+		Value: &commonpb.AnyValue{
+			Value: &commonpb.AnyValue_StringValue{
+				StringValue: value,
+			},
+		},
+	}
+}
+
+type otlpClient interface {
+	Export(ctx context.Context, request *colmetricspb.ExportMetricsServiceRequest) error
+	io.Closer
+}
+
+func newOTLPClient(config trace.ExporterConfig) (otlpClient, error) {
+	switch config.Type {
+	case trace.GRPC:
+		creds := insecure.NewCredentials()
+		if !config.Insecure {
+			creds = credentials.NewTLS(&tls.Config{
+				MinVersion: tls.VersionTLS12,
+			})
+		}
+		conn, err := grpc.Dial(config.Endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+		return &otlpGRPCClient{
+			conn:    conn,
+			client:  colmetricspb.NewMetricsServiceClient(conn),
+			headers: metadata.New(config.Headers),
+		}, nil
+	case trace.HTTP:
+		scheme := "https://"
+		if config.Insecure {
+			scheme = "http://"
+		}
+		return &otlpHTTPClient{
+			client:  &http.Client{},
+			url:     scheme + config.Endpoint + otlpHTTPPath,
+			headers: config.Headers,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownExporterType, config.Type)
+	}
+}
+
+type otlpGRPCClient struct {
+	conn    *grpc.ClientConn
+	client  colmetricspb.MetricsServiceClient
+	headers metadata.MD
+}
+
+func (c *otlpGRPCClient) Export(ctx context.Context, request *colmetricspb.ExportMetricsServiceRequest) error {
+	ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	_, err := c.client.Export(ctx, request)
+	return err
+}
+
+func (c *otlpGRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+type otlpHTTPClient struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func (c *otlpHTTPClient) Export(ctx context.Context, request *colmetricspb.ExportMetricsServiceRequest) error {
+	body, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range c.headers {
+		httpRequest.Header.Set(key, value)
+	}
+
+	response, err := c.client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s", errUnexpectedStatus, response.Status)
+	}
+	return nil
+}
+
+func (c *otlpHTTPClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
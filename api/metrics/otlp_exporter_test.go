@@ -0,0 +1,245 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+func newTestGatherer(t *testing.T) prometheus.Gatherer {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "counter",
+			Help: "counter help",
+		},
+		[]string{"kind"},
+	)
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "gauge",
+	})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "histogram",
+		Buckets: []float64{1, 10},
+	})
+	summary := prometheus.NewSummary(prometheus.SummaryOpts{
+		Name:       "summary",
+		Objectives: map[float64]float64{0.5: 0.05},
+	})
+	require.NoError(registry.Register(counter))
+	require.NoError(registry.Register(gauge))
+	require.NoError(registry.Register(histogram))
+	require.NoError(registry.Register(summary))
+
+	counter.WithLabelValues("a").Add(2)
+	gauge.Set(3)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+	histogram.Observe(50)
+	summary.Observe(4)
+
+	gatherer := NewLabelGatherer("chain")
+	require.NoError(gatherer.Register("C", registry))
+	return gatherer
+}
+
+func TestNewOTLPMetrics(t *testing.T) {
+	require := require.New(t)
+
+	metricFamilies, err := newTestGatherer(t).Gather()
+	require.NoError(err)
+
+	var (
+		startTime = time.Unix(1, 0)
+		now       = time.Unix(2, 0)
+	)
+	metrics := newOTLPMetrics(metricFamilies, startTime, now)
+	require.Len(metrics, 4)
+
+	byName := make(map[string]*metricspb.Metric)
+	for _, metric := range metrics {
+		byName[metric.Name] = metric
+	}
+
+	counter := byName["counter"]
+	require.Equal("counter help", counter.Description)
+	sum := counter.GetSum()
+	require.NotNil(sum)
+	require.True(sum.IsMonotonic)
+	require.Equal(metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.AggregationTemporality)
+	require.Len(sum.DataPoints, 1)
+	require.Equal(2.0, sum.DataPoints[0].GetAsDouble())
+	require.Equal(uint64(startTime.UnixNano()), sum.DataPoints[0].StartTimeUnixNano)
+	require.Equal(uint64(now.UnixNano()), sum.DataPoints[0].TimeUnixNano)
+	attributes := make(map[string]string)
+	for _, attribute := range sum.DataPoints[0].Attributes {
+		attributes[attribute.Key] = attribute.Value.GetStringValue()
+	}
+	require.Equal(map[string]string{"kind": "a", "chain": "C"}, attributes)
+
+	gauge := byName["gauge"].GetGauge()
+	require.NotNil(gauge)
+	require.Len(gauge.DataPoints, 1)
+	require.Equal(3.0, gauge.DataPoints[0].GetAsDouble())
+
+	histogram := byName["histogram"].GetHistogram()
+	require.NotNil(histogram)
+	require.Len(histogram.DataPoints, 1)
+	require.Equal(uint64(3), histogram.DataPoints[0].Count)
+	require.Equal(55.5, histogram.DataPoints[0].GetSum())
+	require.Equal([]float64{1, 10}, histogram.DataPoints[0].ExplicitBounds)
+	require.Equal([]uint64{1, 1, 1}, histogram.DataPoints[0].BucketCounts)
+
+	summary := byName["summary"].GetSummary()
+	require.NotNil(summary)
+	require.Len(summary.DataPoints, 1)
+	require.Equal(uint64(1), summary.DataPoints[0].Count)
+	require.Equal(4.0, summary.DataPoints[0].Sum)
+	require.Len(summary.DataPoints[0].QuantileValues, 1)
+	require.Equal(0.5, summary.DataPoints[0].QuantileValues[0].Quantile)
+}
+
+type testMetricsServer struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+
+	requests chan *colmetricspb.ExportMetricsServiceRequest
+	headers  chan metadata.MD
+}
+
+func (s *testMetricsServer) Export(ctx context.Context, request *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	select {
+	case s.requests <- request:
+		s.headers <- md
+	default:
+	}
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func TestOTLPExporterGRPC(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	metricsServer := &testMetricsServer{
+		requests: make(chan *colmetricspb.ExportMetricsServiceRequest, 1),
+		headers:  make(chan metadata.MD, 1),
+	}
+	server := grpc.NewServer()
+	colmetricspb.RegisterMetricsServiceServer(server, metricsServer)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	exporter, err := NewOTLPExporter(
+		logging.NoLog{},
+		newTestGatherer(t),
+		OTLPConfig{
+			ExporterConfig: trace.ExporterConfig{
+				Type:     trace.GRPC,
+				Endpoint: listener.Addr().String(),
+				Headers:  map[string]string{"key": "value"},
+				Insecure: true,
+			},
+			Enabled:  true,
+			Interval: time.Millisecond,
+			AppName:  "app",
+		},
+	)
+	require.NoError(err)
+
+	request := <-metricsServer.requests
+	require.Equal([]string{"value"}, (<-metricsServer.headers).Get("key"))
+	require.NoError(exporter.Close())
+
+	require.Len(request.ResourceMetrics, 1)
+	require.Len(request.ResourceMetrics[0].ScopeMetrics, 1)
+	require.Equal("app", request.ResourceMetrics[0].ScopeMetrics[0].Scope.Name)
+	require.Len(request.ResourceMetrics[0].ScopeMetrics[0].Metrics, 4)
+}
+
+func TestOTLPExporterHTTP(t *testing.T) {
+	require := require.New(t)
+
+	requests := make(chan *colmetricspb.ExportMetricsServiceRequest, 1)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpHTTPPath || r.Header.Get("key") != "value" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		request := &colmetricspb.ExportMetricsServiceRequest{}
+		if err := proto.Unmarshal(body, request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		select {
+		case requests <- request:
+		default:
+		}
+	}))
+	t.Cleanup(httpServer.Close)
+
+	exporter, err := NewOTLPExporter(
+		logging.NoLog{},
+		newTestGatherer(t),
+		OTLPConfig{
+			ExporterConfig: trace.ExporterConfig{
+				Type:     trace.HTTP,
+				Endpoint: strings.TrimPrefix(httpServer.URL, "http://"),
+				Headers:  map[string]string{"key": "value"},
+				Insecure: true,
+			},
+			Enabled:  true,
+			Interval: time.Millisecond,
+		},
+	)
+	require.NoError(err)
+
+	request := <-requests
+	require.NoError(exporter.Close())
+
+	require.Len(request.ResourceMetrics, 1)
+	require.Len(request.ResourceMetrics[0].ScopeMetrics, 1)
+	require.Len(request.ResourceMetrics[0].ScopeMetrics[0].Metrics, 4)
+}
+
+func TestOTLPExporterUnknownType(t *testing.T) {
+	_, err := NewOTLPExporter(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		OTLPConfig{
+			Enabled:  true,
+			Interval: time.Second,
+		},
+	)
+	require.ErrorIs(t, err, errUnknownExporterType)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/bootstrap/queue"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/state"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
@@ -189,6 +190,7 @@ type ManagerConfig struct {
 	TracingEnabled         bool
 	// Must not be used unless [TracingEnabled] is true as this may be nil.
 	Tracer                    trace.Tracer
+	AuditLogConfig            audit.Config
 	Log                       logging.Logger
 	LogFactory                logging.Factory
 	VMManager                 vms.Manager // Manage mappings from vm ID --> vm
@@ -243,6 +245,10 @@ type ManagerConfig struct {
 	ChainDataDir string
 
 	Subnets *Subnets
+
+	// Scores peers by how well they served our requests. Used to select the
+	// peers that bootstrapping requests are sent to.
+	PeerReputation *p2p.Reputation
 }
 
 type manager struct {
@@ -503,6 +509,11 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		return nil, err
 	}
 
+	var auditLog audit.Log = audit.NoLog{}
+	if m.AuditLogConfig.Enabled {
+		auditLog = audit.New(chainLog, m.AuditLogConfig, chainParams.ID)
+	}
+
 	ctx := &snow.ConsensusContext{
 		Context: &snow.Context{
 			NetworkID: m.NetworkID,
@@ -531,6 +542,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		BlockAcceptor:  m.BlockAcceptorGroup,
 		TxAcceptor:     m.TxAcceptorGroup,
 		VertexAcceptor: m.VertexAcceptorGroup,
+		AuditLog:       auditLog,
 	}
 
 	// Get a factory for the vm we want to use on our chain
@@ -625,6 +637,7 @@ func (m *manager) createAvalancheChain(
 	})
 
 	primaryAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
+	tracer := trace.WithChain(m.Tracer, primaryAlias)
 	meterDBReg, err := metrics.MakeAndRegister(
 		m.MeterDBMetrics,
 		primaryAlias,
@@ -678,7 +691,7 @@ func (m *manager) createAvalancheChain(
 	}
 
 	if m.TracingEnabled {
-		avalancheMessageSender = sender.Trace(avalancheMessageSender, m.Tracer)
+		avalancheMessageSender = sender.Trace(avalancheMessageSender, tracer)
 	}
 
 	// Passes messages from the snowman engines to the network
@@ -697,7 +710,7 @@ func (m *manager) createAvalancheChain(
 	}
 
 	if m.TracingEnabled {
-		snowmanMessageSender = sender.Trace(snowmanMessageSender, m.Tracer)
+		snowmanMessageSender = sender.Trace(snowmanMessageSender, tracer)
 	}
 
 	chainConfig, err := m.getChainConfig(ctx.ChainID)
@@ -718,7 +731,7 @@ func (m *manager) createAvalancheChain(
 		dagVM = metervm.NewVertexVM(dagVM, meterdagvmReg)
 	}
 	if m.TracingEnabled {
-		dagVM = tracedvm.NewVertexVM(dagVM, m.Tracer)
+		dagVM = tracedvm.NewVertexVM(dagVM, tracer)
 	}
 
 	// Handles serialization/deserialization of vertices and also the
@@ -776,7 +789,7 @@ func (m *manager) createAvalancheChain(
 
 	var vmWrappedInsideProposerVM block.ChainVM = untracedVMWrappedInsideProposerVM
 	if m.TracingEnabled {
-		vmWrappedInsideProposerVM = tracedvm.NewBlockVM(vmWrappedInsideProposerVM, primaryAlias, m.Tracer)
+		vmWrappedInsideProposerVM = tracedvm.NewBlockVM(vmWrappedInsideProposerVM, primaryAlias, tracer)
 	}
 
 	proposervmReg, err := metrics.MakeAndRegister(
@@ -815,7 +828,7 @@ func (m *manager) createAvalancheChain(
 		vmWrappingProposerVM = metervm.NewBlockVM(vmWrappingProposerVM, meterchainvmReg)
 	}
 	if m.TracingEnabled {
-		vmWrappingProposerVM = tracedvm.NewBlockVM(vmWrappingProposerVM, "proposervm", m.Tracer)
+		vmWrappingProposerVM = tracedvm.NewBlockVM(vmWrappingProposerVM, "proposervm", tracer)
 	}
 
 	// Note: linearizableVM is the VM that the Avalanche engines should be
@@ -874,6 +887,7 @@ func (m *manager) createAvalancheChain(
 		p2pReg,
 		set.Of(ctx.NodeID),
 		nil,
+		m.PeerReputation,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating peer tracker: %w", err)
@@ -923,7 +937,7 @@ func (m *manager) createAvalancheChain(
 
 	var snowmanConsensus smcon.Consensus = &smcon.Topological{}
 	if m.TracingEnabled {
-		snowmanConsensus = smcon.Trace(snowmanConsensus, m.Tracer)
+		snowmanConsensus = smcon.Trace(snowmanConsensus, tracer)
 	}
 
 	// Create engine, bootstrapper and state-syncer in this order,
@@ -945,7 +959,7 @@ func (m *manager) createAvalancheChain(
 	}
 
 	if m.TracingEnabled {
-		snowmanEngine = common.TraceEngine(snowmanEngine, m.Tracer)
+		snowmanEngine = common.TraceEngine(snowmanEngine, tracer)
 	}
 
 	// create bootstrap gear
@@ -974,7 +988,7 @@ func (m *manager) createAvalancheChain(
 	}
 
 	if m.TracingEnabled {
-		snowmanBootstrapper = common.TraceBootstrapableEngine(snowmanBootstrapper, m.Tracer)
+		snowmanBootstrapper = common.TraceBootstrapableEngine(snowmanBootstrapper, tracer)
 	}
 
 	avaGetHandler, err := avagetter.New(
@@ -992,7 +1006,7 @@ func (m *manager) createAvalancheChain(
 	// create engine gear
 	avalancheEngine := aveng.New(ctx, avaGetHandler, linearizableVM)
 	if m.TracingEnabled {
-		avalancheEngine = common.TraceEngine(avalancheEngine, m.Tracer)
+		avalancheEngine = common.TraceEngine(avalancheEngine, tracer)
 	}
 
 	// create bootstrap gear
@@ -1022,7 +1036,7 @@ func (m *manager) createAvalancheChain(
 	}
 
 	if m.TracingEnabled {
-		avalancheBootstrapper = common.TraceBootstrapableEngine(avalancheBootstrapper, m.Tracer)
+		avalancheBootstrapper = common.TraceBootstrapableEngine(avalancheBootstrapper, tracer)
 	}
 
 	h.SetEngineManager(&handler.EngineManager{
@@ -1070,6 +1084,7 @@ func (m *manager) createSnowmanChain(
 	})
 
 	primaryAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
+	tracer := trace.WithChain(m.Tracer, primaryAlias)
 	meterDBReg, err := metrics.MakeAndRegister(
 		m.MeterDBMetrics,
 		primaryAlias,
@@ -1103,7 +1118,7 @@ func (m *manager) createSnowmanChain(
 	}
 
 	if m.TracingEnabled {
-		messageSender = sender.Trace(messageSender, m.Tracer)
+		messageSender = sender.Trace(messageSender, tracer)
 	}
 
 	var (
@@ -1120,7 +1135,7 @@ func (m *manager) createSnowmanChain(
 		}
 
 		if m.TracingEnabled {
-			valState = validators.Trace(valState, "platformvm", m.Tracer)
+			valState = validators.Trace(valState, "platformvm", tracer)
 		}
 
 		// Notice that this context is left unlocked. This is because the
@@ -1176,7 +1191,7 @@ func (m *manager) createSnowmanChain(
 	)
 
 	if m.TracingEnabled {
-		vm = tracedvm.NewBlockVM(vm, primaryAlias, m.Tracer)
+		vm = tracedvm.NewBlockVM(vm, primaryAlias, tracer)
 	}
 
 	proposervmReg, err := metrics.MakeAndRegister(
@@ -1213,7 +1228,7 @@ func (m *manager) createSnowmanChain(
 		vm = metervm.NewBlockVM(vm, meterchainvmReg)
 	}
 	if m.TracingEnabled {
-		vm = tracedvm.NewBlockVM(vm, "proposervm", m.Tracer)
+		vm = tracedvm.NewBlockVM(vm, "proposervm", tracer)
 	}
 
 	// The channel through which a VM may send messages to the consensus engine
@@ -1273,6 +1288,7 @@ func (m *manager) createSnowmanChain(
 		p2pReg,
 		set.Of(ctx.NodeID),
 		nil,
+		m.PeerReputation,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating peer tracker: %w", err)
@@ -1322,7 +1338,7 @@ func (m *manager) createSnowmanChain(
 
 	var consensus smcon.Consensus = &smcon.Topological{}
 	if m.TracingEnabled {
-		consensus = smcon.Trace(consensus, m.Tracer)
+		consensus = smcon.Trace(consensus, tracer)
 	}
 
 	// Create engine, bootstrapper and state-syncer in this order,
@@ -1345,7 +1361,7 @@ func (m *manager) createSnowmanChain(
 	}
 
 	if m.TracingEnabled {
-		engine = common.TraceEngine(engine, m.Tracer)
+		engine = common.TraceEngine(engine, tracer)
 	}
 
 	// create bootstrap gear
@@ -1375,7 +1391,7 @@ func (m *manager) createSnowmanChain(
 	}
 
 	if m.TracingEnabled {
-		bootstrapper = common.TraceBootstrapableEngine(bootstrapper, m.Tracer)
+		bootstrapper = common.TraceBootstrapableEngine(bootstrapper, tracer)
 	}
 
 	// create state sync gear
@@ -1399,7 +1415,7 @@ func (m *manager) createSnowmanChain(
 	)
 
 	if m.TracingEnabled {
-		stateSyncer = common.TraceStateSyncer(stateSyncer, m.Tracer)
+		stateSyncer = common.TraceStateSyncer(stateSyncer, tracer)
 	}
 
 	h.SetEngineManager(&handler.EngineManager{
@@ -18,14 +18,17 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/capture"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -55,6 +58,9 @@ import (
 const (
 	chainConfigFileName  = "config"
 	chainUpgradeFileName = "upgrade"
+	traceDirName         = "traces"
+	auditLogDirName      = "audit"
+	captureDirName       = "capture"
 	subnetConfigFileExt  = ".json"
 
 	keystoreDeprecationMsg = "keystore API is deprecated"
@@ -84,6 +90,9 @@ var (
 	errStakingCertContentUnset                = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errMissingStakingSigningKeyFile           = errors.New("missing staking signing key file")
 	errTracingEndpointEmpty                   = fmt.Errorf("%s cannot be empty", TracingEndpointKey)
+	errMetricsExportEndpointEmpty             = fmt.Errorf("%s cannot be empty", MetricsExportEndpointKey)
+	errMetricsExportUnsupportedExporterType   = fmt.Errorf("%s must be %s or %s", MetricsExportExporterTypeKey, trace.GRPC, trace.HTTP)
+	errMetricsExportIntervalNonPositive       = fmt.Errorf("%s must be positive", MetricsExportIntervalKey)
 	errPluginDirNotADirectory                 = errors.New("plugin dir is not a directory")
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
@@ -181,6 +190,7 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexGRPCPort:        uint16(v.GetUint(IndexGRPCPortKey)),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
@@ -324,10 +334,14 @@ func getNetworkConfig(
 				},
 			},
 
-			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
-				AtLargeAllocSize:    v.GetUint64(OutboundThrottlerAtLargeAllocSizeKey),
-				VdrAllocSize:        v.GetUint64(OutboundThrottlerVdrAllocSizeKey),
-				NodeMaxAtLargeBytes: v.GetUint64(OutboundThrottlerNodeMaxAtLargeBytesKey),
+			OutboundMsgThrottlerConfig: throttling.OutboundMsgThrottlerConfig{
+				MsgByteThrottlerConfig: throttling.MsgByteThrottlerConfig{
+					AtLargeAllocSize:    v.GetUint64(OutboundThrottlerAtLargeAllocSizeKey),
+					VdrAllocSize:        v.GetUint64(OutboundThrottlerVdrAllocSizeKey),
+					NodeMaxAtLargeBytes: v.GetUint64(OutboundThrottlerNodeMaxAtLargeBytesKey),
+				},
+				NodeMaxAppBytes:    v.GetUint64(OutboundThrottlerNodeMaxAppBytesKey),
+				NodeMaxGossipBytes: v.GetUint64(OutboundThrottlerNodeMaxGossipBytesKey),
 			},
 		},
 
@@ -350,6 +364,8 @@ func getNetworkConfig(
 		},
 
 		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
+		PeerACLFile:   GetExpandedArg(v, NetworkPeerACLFileKey),
+		QUICEnabled:   v.GetBool(NetworkQUICEnabledKey),
 
 		TimeoutConfig: network.TimeoutConfig{
 			PingPongTimeout:      v.GetDuration(NetworkPingTimeoutKey),
@@ -382,6 +398,15 @@ func getNetworkConfig(
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
 	}
 
+	if v.GetBool(NetworkCaptureEnabledKey) {
+		config.CaptureConfig = capture.Config{
+			Enabled:   true,
+			Directory: filepath.Join(GetExpandedArg(v, LogsDirKey), captureDirName),
+			MaxSize:   int(v.GetUint(NetworkCaptureMaxSizeKey)),
+			MaxFiles:  int(v.GetUint(NetworkCaptureMaxFilesKey)),
+		}
+	}
+
 	switch {
 	case config.HealthConfig.MaxTimeSinceMsgSent < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkHealthMaxTimeSinceMsgSentKey)
@@ -486,6 +511,13 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		BootstrapMaxTimeGetAncestors:            v.GetDuration(BootstrapMaxTimeGetAncestorsKey),
 		BootstrapAncestorsMaxContainersSent:     int(v.GetUint(BootstrapAncestorsMaxContainersSentKey)),
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
+		BootstrapDNSSeeds:                       v.GetStringSlice(BootstrapDNSSeedsKey),
+		BootstrapDNSResolver:                    v.GetString(BootstrapDNSResolverKey),
+		BootstrapPeersFile:                      GetExpandedArg(v, BootstrapPeersFileKey),
+		BootstrapPeersRefreshFrequency:          v.GetDuration(BootstrapPeersRefreshFrequencyKey),
+	}
+	if config.BootstrapPeersRefreshFrequency <= 0 {
+		return node.BootstrapConfig{}, fmt.Errorf("%q must be positive", BootstrapPeersRefreshFrequencyKey)
 	}
 
 	// TODO: Add a "BootstrappersKey" flag to more clearly enforce ID and IP
@@ -899,7 +931,9 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 			GetExpandedArg(v, DBPathKey),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		Config:             configBytes,
+		SnapshotDir:        GetExpandedArg(v, DBSnapshotDirKey),
+		SnapshotImportFile: GetExpandedArg(v, DBSnapshotImportFileKey),
 	}, nil
 }
 
@@ -1210,17 +1244,27 @@ func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 		return trace.Config{}, err
 	}
 
-	endpoint := v.GetString(TracingEndpointKey)
-	if endpoint == "" {
-		return trace.Config{}, errTracingEndpointEmpty
+	var (
+		endpoint string
+		dir      string
+	)
+	if exporterType == trace.File {
+		// Traces are written next to the node's logs
+		dir = filepath.Join(GetExpandedArg(v, LogsDirKey), traceDirName)
+	} else {
+		endpoint = v.GetString(TracingEndpointKey)
+		if endpoint == "" {
+			return trace.Config{}, errTracingEndpointEmpty
+		}
 	}
 
 	return trace.Config{
 		ExporterConfig: trace.ExporterConfig{
-			Type:     exporterType,
-			Endpoint: endpoint,
-			Insecure: v.GetBool(TracingInsecureKey),
-			Headers:  v.GetStringMapString(TracingHeadersKey),
+			Type:      exporterType,
+			Endpoint:  endpoint,
+			Insecure:  v.GetBool(TracingInsecureKey),
+			Headers:   v.GetStringMapString(TracingHeadersKey),
+			Directory: dir,
 		},
 		Enabled:         true,
 		TraceSampleRate: v.GetFloat64(TracingSampleRateKey),
@@ -1229,6 +1273,47 @@ func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 	}, nil
 }
 
+func getMetricsExportConfig(v *viper.Viper) (metrics.OTLPConfig, error) {
+	enabled := v.GetBool(MetricsExportEnabledKey)
+	if !enabled {
+		return metrics.OTLPConfig{
+			Enabled: false,
+		}, nil
+	}
+
+	exporterTypeStr := v.GetString(MetricsExportExporterTypeKey)
+	exporterType, err := trace.ExporterTypeFromString(exporterTypeStr)
+	if err != nil {
+		return metrics.OTLPConfig{}, err
+	}
+	if exporterType != trace.GRPC && exporterType != trace.HTTP {
+		return metrics.OTLPConfig{}, fmt.Errorf("%w: %q", errMetricsExportUnsupportedExporterType, exporterTypeStr)
+	}
+
+	endpoint := v.GetString(MetricsExportEndpointKey)
+	if endpoint == "" {
+		return metrics.OTLPConfig{}, errMetricsExportEndpointEmpty
+	}
+
+	interval := v.GetDuration(MetricsExportIntervalKey)
+	if interval <= 0 {
+		return metrics.OTLPConfig{}, errMetricsExportIntervalNonPositive
+	}
+
+	return metrics.OTLPConfig{
+		ExporterConfig: trace.ExporterConfig{
+			Type:     exporterType,
+			Endpoint: endpoint,
+			Insecure: v.GetBool(MetricsExportInsecureKey),
+			Headers:  v.GetStringMapString(MetricsExportHeadersKey),
+		},
+		Enabled:  true,
+		Interval: interval,
+		AppName:  constants.AppName,
+		Version:  version.Current.String(),
+	}, nil
+}
+
 // Returns the path to the directory that contains VM binaries.
 func getPluginDir(v *viper.Viper) (string, error) {
 	pluginDir := GetExpandedString(v, v.GetString(PluginDirKey))
@@ -1268,6 +1353,15 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, fmt.Errorf("%q must be >= 0", ConsensusShutdownTimeoutKey)
 	}
 
+	if v.GetBool(ConsensusAuditLogEnabledKey) {
+		nodeConfig.ConsensusAuditLogConfig = audit.Config{
+			Enabled:   true,
+			Directory: filepath.Join(GetExpandedArg(v, LogsDirKey), auditLogDirName),
+			MaxSize:   int(v.GetUint(ConsensusAuditLogMaxSizeKey)),
+			MaxFiles:  int(v.GetUint(ConsensusAuditLogMaxFilesKey)),
+		}
+	}
+
 	// Gossiping
 	nodeConfig.FrontierPollFrequency = v.GetDuration(ConsensusFrontierPollFrequencyKey)
 	if nodeConfig.FrontierPollFrequency < 0 {
@@ -1460,6 +1554,11 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.MetricsExportConfig, err = getMetricsExportConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.ChainDataDir = GetExpandedArg(v, ChainDataDirKey)
 
 	nodeConfig.ProcessContextFilePath = GetExpandedArg(v, ProcessContextFileKey)
@@ -541,6 +541,39 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 	}
 }
 
+func TestGetMetricsExportConfig(t *testing.T) {
+	tests := []struct {
+		exporterType string
+		expectedErr  error
+	}{
+		{
+			exporterType: "grpc",
+			expectedErr:  nil,
+		},
+		{
+			exporterType: "http",
+			expectedErr:  nil,
+		},
+		{
+			exporterType: "file",
+			expectedErr:  errMetricsExportUnsupportedExporterType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.exporterType, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(MetricsExportEnabledKey, true)
+			v.Set(MetricsExportExporterTypeKey, test.exporterType)
+			v.Set(MetricsExportEndpointKey, "localhost:4317")
+
+			_, err := getMetricsExportConfig(v)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
 // setups config json file and writes content
 func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 	configFilePath := filepath.Join(rootPath, "config.json")
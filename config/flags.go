@@ -41,6 +41,7 @@ var (
 	// [defaultUnexpandedDataDir] will be expanded when reading the flags
 	defaultDataDir              = filepath.Join("$HOME", ".avalanchego")
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
+	defaultDBSnapshotDir        = filepath.Join(defaultUnexpandedDataDir, "snapshots")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
@@ -56,6 +57,7 @@ var (
 	defaultPluginDir            = filepath.Join(defaultUnexpandedDataDir, "plugins")
 	defaultChainDataDir         = filepath.Join(defaultUnexpandedDataDir, "chainData")
 	defaultProcessContextPath   = filepath.Join(defaultUnexpandedDataDir, DefaultProcessContextFilename)
+	defaultPeerACLFilePath      = filepath.Join(defaultConfigDir, "peer-acl.json")
 )
 
 func deprecateFlags(fs *pflag.FlagSet) error {
@@ -132,6 +134,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
+	fs.String(DBSnapshotDirKey, defaultDBSnapshotDir, "Directory that database snapshots are exported to")
+	fs.String(DBSnapshotImportFileKey, "", "Path to a database snapshot to import. The snapshot is only imported if the database hasn't been initialized")
 
 	// Logging
 	fs.String(LogsDirKey, defaultLogDir, "Logging directory for Avalanche")
@@ -192,6 +196,14 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
 
+	fs.Bool(NetworkCaptureEnabledKey, false, "If true, record every message sent to and received from peers so that it can be replayed. Should only be specified for debugging")
+	fs.Uint(NetworkCaptureMaxSizeKey, 256, "The maximum file size in megabytes of the message capture before it gets rotated")
+	fs.Uint(NetworkCaptureMaxFilesKey, 8, "The maximum number of old message capture files to retain. 0 means retain all old message capture files")
+
+	fs.String(NetworkPeerACLFileKey, defaultPeerACLFilePath, "Specifies a JSON file that persists the nodeIDs, IPs, and CIDRs that peers are allowed or denied by. The file is updated by the admin API")
+
+	fs.Bool(NetworkQUICEnabledKey, false, "If true, accept QUIC connections on the UDP port that matches the staking port, and connect to peers that support QUIC over QUIC")
+
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
 	fs.Duration(BenchlistDurationKey, constants.DefaultBenchlistDuration, "Max amount of time a peer is benchlisted after surpassing the threshold")
@@ -201,6 +213,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
 	fs.Duration(ConsensusShutdownTimeoutKey, constants.DefaultConsensusShutdownTimeout, "Timeout before killing an unresponsive chain")
 	fs.Duration(ConsensusFrontierPollFrequencyKey, constants.DefaultFrontierPollFrequency, "Frequency of polling for new consensus frontiers")
+	fs.Bool(ConsensusAuditLogEnabledKey, false, "If true, record the polls and decisions of every snowman chain in an audit log")
+	fs.Uint(ConsensusAuditLogMaxSizeKey, 64, "The maximum file size in megabytes of a chain's audit log before it gets rotated")
+	fs.Uint(ConsensusAuditLogMaxFilesKey, 8, "The maximum number of old audit log files to retain per chain. 0 means retain all old audit log files")
 
 	// Inbound Throttling
 	fs.Uint64(InboundThrottlerAtLargeAllocSizeKey, constants.DefaultInboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in inbound message throttler")
@@ -216,6 +231,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, constants.DefaultOutboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerVdrAllocSizeKey, constants.DefaultOutboundThrottlerVdrAllocSize, "Size, in bytes, of validator byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerNodeMaxAtLargeBytesKey, constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes, "Max number of bytes a node can take from the outbound message throttler's at-large allocation. Must be at least the max message size")
+	fs.Uint64(OutboundThrottlerNodeMaxAppBytesKey, constants.DefaultOutboundThrottlerNodeMaxAppBytes, "Max number of bytes of app request and response messages that can be waiting to be sent to a node. 0 means no limit. Should be at least the max message size")
+	fs.Uint64(OutboundThrottlerNodeMaxGossipBytesKey, constants.DefaultOutboundThrottlerNodeMaxGossipBytes, "Max number of bytes of app gossip messages that can be waiting to be sent to a node. 0 means no limit. Should be at least the max message size")
 
 	// HTTP APIs
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server. If the address is empty or a literal unspecified IP address, the server will bind on all available unicast and anycast IP addresses of the local system")
@@ -302,6 +319,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
 	fs.Uint(BootstrapAncestorsMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Ancestors message")
+	fs.StringSlice(BootstrapDNSSeedsKey, nil, "List of domain names whose TXT records contain additional bootstrap peers of the form nodeID@IP:port, separated by whitespace or commas. Example: NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg@127.0.0.1:9651")
+	fs.String(BootstrapDNSResolverKey, "", fmt.Sprintf("Address of the DNS server used to resolve %s. If empty, the resolver of the system is used. Example: 127.0.0.1:53", BootstrapDNSSeedsKey))
+	fs.String(BootstrapPeersFileKey, "", `Specifies a JSON file that contains additional bootstrap peers. The file is reloaded periodically so that bootstrap peers can be changed without restarting the node. Example: [{"id":"NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg","ip":"127.0.0.1:9651"}]`)
+	fs.Duration(BootstrapPeersRefreshFrequencyKey, time.Minute, fmt.Sprintf("Frequency at which %s are resolved and %s is reloaded", BootstrapDNSSeedsKey, BootstrapPeersFileKey))
 
 	// Consensus
 	fs.Int(SnowSampleSizeKey, snowball.DefaultParameters.K, "Number of nodes to query for each network poll")
@@ -326,6 +347,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.Uint(IndexGRPCPortKey, 0, "Port of the gRPC server of the index API, which listens on the HTTP host. If 0, the gRPC server is disabled. Ignored if index is disabled")
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -372,12 +394,20 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	// Opentelemetry tracing
 	fs.Bool(TracingEnabledKey, false, "If true, enable opentelemetry tracing")
-	fs.String(TracingExporterTypeKey, trace.GRPC.String(), fmt.Sprintf("Type of exporter to use for tracing. Options are [%s, %s]", trace.GRPC, trace.HTTP))
+	fs.String(TracingExporterTypeKey, trace.GRPC.String(), fmt.Sprintf("Type of exporter to use for tracing. Options are [%s, %s, %s]", trace.GRPC, trace.HTTP, trace.File))
 	fs.String(TracingEndpointKey, "localhost:4317", "The endpoint to send trace data to")
 	fs.Bool(TracingInsecureKey, true, "If true, don't use TLS when sending trace data")
 	fs.Float64(TracingSampleRateKey, 0.1, "The fraction of traces to sample. If >= 1, always sample. If <= 0, never sample")
 	fs.StringToString(TracingHeadersKey, map[string]string{}, "The headers to provide the trace indexer")
 
+	// Opentelemetry metrics
+	fs.Bool(MetricsExportEnabledKey, false, "If true, push metrics to an opentelemetry collector")
+	fs.String(MetricsExportExporterTypeKey, trace.GRPC.String(), fmt.Sprintf("Type of exporter to use for metrics. Options are [%s, %s]", trace.GRPC, trace.HTTP))
+	fs.String(MetricsExportEndpointKey, "localhost:4317", "The endpoint to send metrics to")
+	fs.Bool(MetricsExportInsecureKey, true, "If true, don't use TLS when sending metrics")
+	fs.StringToString(MetricsExportHeadersKey, map[string]string{}, "The headers to provide the metrics collector")
+	fs.Duration(MetricsExportIntervalKey, 10*time.Second, "How often to push metrics")
+
 	fs.String(ProcessContextFileKey, defaultProcessContextPath, "The path to write process context to (including PID, API URI, and staking address).")
 }
 
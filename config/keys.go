@@ -55,6 +55,8 @@ const (
 	DBPathKey                              = "db-dir"
 	DBConfigFileKey                        = "db-config-file"
 	DBConfigContentKey                     = "db-config-file-content"
+	DBSnapshotDirKey                       = "db-snapshot-dir"
+	DBSnapshotImportFileKey                = "db-snapshot-import-file"
 	PublicIPKey                            = "public-ip"
 	PublicIPResolutionFreqKey              = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey           = "public-ip-resolution-service"
@@ -119,6 +121,11 @@ const (
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
+	NetworkCaptureEnabledKey                           = "network-capture-enabled"
+	NetworkCaptureMaxSizeKey                           = "network-capture-max-size"
+	NetworkCaptureMaxFilesKey                          = "network-capture-max-files"
+	NetworkPeerACLFileKey                              = "network-peer-acl-file"
+	NetworkQUICEnabledKey                              = "network-quic-enabled"
 	NetworkInboundConnUpgradeThrottlerCooldownKey      = "network-inbound-connection-throttling-cooldown"
 	NetworkInboundThrottlerMaxConnsPerSecKey           = "network-inbound-connection-throttling-max-conns-per-sec"
 	NetworkOutboundConnectionThrottlingRpsKey          = "network-outbound-connection-throttling-rps"
@@ -154,11 +161,15 @@ const (
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
 	ConsensusAppConcurrencyKey                         = "consensus-app-concurrency"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
+	ConsensusAuditLogEnabledKey                        = "consensus-audit-log-enabled"
+	ConsensusAuditLogMaxSizeKey                        = "consensus-audit-log-max-size"
+	ConsensusAuditLogMaxFilesKey                       = "consensus-audit-log-max-files"
 	ConsensusFrontierPollFrequencyKey                  = "consensus-frontier-poll-frequency"
 	ProposerVMUseCurrentHeightKey                      = "proposervm-use-current-height"
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexGRPCPortKey                                   = "index-grpc-port"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
@@ -168,6 +179,10 @@ const (
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
+	BootstrapDNSSeedsKey                               = "bootstrap-dns-seeds"
+	BootstrapDNSResolverKey                            = "bootstrap-dns-resolver"
+	BootstrapPeersFileKey                              = "bootstrap-peers-file"
+	BootstrapPeersRefreshFrequencyKey                  = "bootstrap-peers-refresh-frequency"
 	ChainDataDirKey                                    = "chain-data-dir"
 	ChainConfigDirKey                                  = "chain-config-dir"
 	ChainConfigContentKey                              = "chain-config-content"
@@ -200,6 +215,8 @@ const (
 	OutboundThrottlerAtLargeAllocSizeKey               = "throttler-outbound-at-large-alloc-size"
 	OutboundThrottlerVdrAllocSizeKey                   = "throttler-outbound-validator-alloc-size"
 	OutboundThrottlerNodeMaxAtLargeBytesKey            = "throttler-outbound-node-max-at-large-bytes"
+	OutboundThrottlerNodeMaxAppBytesKey                = "throttler-outbound-node-max-app-bytes"
+	OutboundThrottlerNodeMaxGossipBytesKey             = "throttler-outbound-node-max-gossip-bytes"
 	UptimeMetricFreqKey                                = "uptime-metric-freq"
 	VMAliasesFileKey                                   = "vm-aliases-file"
 	VMAliasesContentKey                                = "vm-aliases-file-content"
@@ -211,5 +228,11 @@ const (
 	TracingSampleRateKey                               = "tracing-sample-rate"
 	TracingExporterTypeKey                             = "tracing-exporter-type"
 	TracingHeadersKey                                  = "tracing-headers"
+	MetricsExportEnabledKey                            = "metrics-export-enabled"
+	MetricsExportEndpointKey                           = "metrics-export-endpoint"
+	MetricsExportInsecureKey                           = "metrics-export-insecure"
+	MetricsExportExporterTypeKey                       = "metrics-export-exporter-type"
+	MetricsExportHeadersKey                            = "metrics-export-headers"
+	MetricsExportIntervalKey                           = "metrics-export-interval"
 	ProcessContextFileKey                              = "process-context-file"
 )
@@ -84,6 +84,34 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// Snapshot is a read-only, point-in-time view of a database. Writes to the
+// database after the snapshot was taken are not visible in the snapshot.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases the resources held by the snapshot. Release should
+	// always succeed and can be called multiple times without causing error.
+	//
+	// Iterators created from the snapshot must be released before the
+	// snapshot is released.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot returns a snapshot of the current state of the database.
+	NewSnapshot() (Snapshot, error)
+}
+
+// SizeEstimator wraps the EstimateSize method of a backing data store.
+type SizeEstimator interface {
+	// EstimateSize returns the approximate number of bytes that the keys in
+	// the range [start, limit) occupy on disk. Recently written keys may not
+	// be included in the estimate.
+	EstimateSize(start []byte, limit []byte) (uint64, error)
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
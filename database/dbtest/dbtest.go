@@ -1337,3 +1337,140 @@ func FuzzNewIteratorWithStartAndPrefix(f *testing.F, db database.Database) {
 		require.NoError(database.AtomicClear(db, db))
 	})
 }
+
+// SnapshotTests is a list of all database snapshot tests
+var SnapshotTests = map[string]func(t *testing.T, db SnapshotterDatabase){
+	"Snapshot":         TestSnapshot,
+	"SnapshotIterator": TestSnapshotIterator,
+	"SnapshotClosed":   TestSnapshotClosed,
+}
+
+// SnapshotterDatabase is a database that supports snapshots.
+type SnapshotterDatabase interface {
+	database.Database
+	database.Snapshotter
+}
+
+// TestSnapshot tests to make sure that a snapshot doesn't observe writes made
+// after the snapshot was taken.
+func TestSnapshot(t *testing.T, db SnapshotterDatabase) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	require.NoError(db.Put(key1, value1))
+
+	snapshot, err := db.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key1, value2))
+	require.NoError(db.Put(key2, value2))
+
+	value, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err := snapshot.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	_, err = snapshot.Get(key2)
+	require.Equal(database.ErrNotFound, err)
+
+	value, err = db.Get(key1)
+	require.NoError(err)
+	require.Equal(value2, value)
+}
+
+// TestSnapshotIterator tests to make sure that iterators created from a
+// snapshot only iterate over the contents of the database when the snapshot
+// was taken.
+func TestSnapshotIterator(t *testing.T, db SnapshotterDatabase) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot, err := db.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Delete(key1))
+	require.NoError(db.Put(key3, value3))
+
+	iterator := snapshot.NewIteratorWithPrefix([]byte("hello"))
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+}
+
+// TestSnapshotClosed tests to make sure that a snapshot can't be created from
+// a closed database and that releasing a snapshot multiple times doesn't
+// error.
+func TestSnapshotClosed(t *testing.T, db SnapshotterDatabase) {
+	require := require.New(t)
+
+	snapshot, err := db.NewSnapshot()
+	require.NoError(err)
+	snapshot.Release()
+	snapshot.Release()
+
+	require.NoError(db.Close())
+
+	_, err = db.NewSnapshot()
+	require.Equal(database.ErrClosed, err)
+}
+
+// SizeEstimatorDatabase is a database that can estimate its disk usage.
+type SizeEstimatorDatabase interface {
+	database.Database
+	database.SizeEstimator
+}
+
+// TestEstimateSize tests to make sure that the estimated size of a range only
+// includes the keys in the range once they have been compacted.
+func TestEstimateSize(t *testing.T, db SizeEstimatorDatabase) {
+	require := require.New(t)
+
+	const (
+		numKeys   = 256
+		valueSize = units.KiB
+	)
+	for i := 0; i < numKeys; i++ {
+		key := []byte{'a', byte(i)}
+		// Random values can't be compressed.
+		require.NoError(db.Put(key, utils.RandomBytes(valueSize)))
+	}
+	require.NoError(db.Compact(nil, nil))
+
+	size, err := db.EstimateSize([]byte{'a'}, []byte{'b'})
+	require.NoError(err)
+	require.GreaterOrEqual(size, uint64(numKeys*valueSize))
+
+	size, err = db.EstimateSize([]byte{'b'}, []byte{'c'})
+	require.NoError(err)
+	require.Zero(size)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package factory
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// Path returns the directory that the database engine [name] stores its files
+// in when the node's database directory is [path].
+func Path(name string, path string) (string, error) {
+	switch name {
+	case leveldb.Name:
+		// Prior to v1.10.15, the only on-disk database was leveldb, and its
+		// files went to [dbPath]/[networkID]/v1.4.5.
+		return filepath.Join(path, version.CurrentDatabase.String()), nil
+	case memdb.Name:
+		return "", nil
+	case pebbledb.Name:
+		return filepath.Join(path, "pebble"), nil
+	default:
+		return "", fmt.Errorf(
+			"db-type was %q but should have been one of {%s, %s, %s}",
+			name,
+			leveldb.Name,
+			memdb.Name,
+			pebbledb.Name,
+		)
+	}
+}
+
+// New opens the database engine [name] in the node's database directory
+// [path].
+func New(
+	name string,
+	path string,
+	config []byte,
+	log logging.Logger,
+	reg prometheus.Registerer,
+) (database.Database, error) {
+	dbPath, err := Path(name, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var db database.Database
+	switch name {
+	case leveldb.Name:
+		db, err = leveldb.New(dbPath, config, log, reg)
+	case memdb.Name:
+		db = memdb.New()
+	case pebbledb.Name:
+		db, err = pebbledb.New(dbPath, config, log, reg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create %s at %s: %w", name, dbPath, err)
+	}
+	return db, nil
+}
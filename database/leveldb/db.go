@@ -63,9 +63,11 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iter)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.SizeEstimator = (*Database)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iter)(nil)
 
 	ErrInvalidConfig = errors.New("invalid config")
 	ErrCouldNotOpen  = errors.New("could not open")
@@ -352,6 +354,14 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return updateError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+func (db *Database) EstimateSize(start []byte, limit []byte) (uint64, error) {
+	sizes, err := db.DB.SizeOf([]util.Range{{Start: start, Limit: limit}})
+	if err != nil {
+		return 0, updateError(err)
+	}
+	return uint64(sizes.Sum()), nil
+}
+
 func (db *Database) Close() error {
 	db.closed.Set(true)
 	db.closeOnce.Do(func() {
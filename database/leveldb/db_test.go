@@ -29,6 +29,20 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for name, test := range dbtest.SnapshotTests {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			db, err := New(folder, nil, logging.NoLog{}, prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			test(t, db.(*Database))
+
+			_ = db.Close()
+		})
+	}
+}
+
 func newDB(t testing.TB) database.Database {
 	folder := t.TempDir()
 	db, err := New(folder, nil, logging.NoLog{}, prometheus.NewRegistry())
@@ -36,6 +50,12 @@ func newDB(t testing.TB) database.Database {
 	return db
 }
 
+func TestEstimateSize(t *testing.T) {
+	db := newDB(t).(*Database)
+	dbtest.TestEstimateSize(t, db)
+	_ = db.Close()
+}
+
 func FuzzKeyValue(f *testing.F) {
 	db := newDB(f)
 	defer db.Close()
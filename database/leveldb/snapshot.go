@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package leveldb
+
+import (
+	"bytes"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var _ database.Snapshot = (*snapshot)(nil)
+
+type snapshot struct {
+	db *Database
+	*leveldb.Snapshot
+}
+
+// NewSnapshot returns a point-in-time view of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	s, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db:       db,
+		Snapshot: s,
+	}, nil
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.Snapshot.Has(key, nil)
+	return has, updateSnapshotError(err)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key, nil)
+	return value, updateSnapshotError(err)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(new(util.Range), nil),
+	}
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(&util.Range{Start: start}, nil),
+	}
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(util.BytesPrefix(prefix), nil),
+	}
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(iterRange, nil),
+	}
+}
+
+// updateSnapshotError additionally treats usage of a released snapshot as
+// usage of a closed database.
+func updateSnapshotError(err error) error {
+	if err == leveldb.ErrSnapshotReleased {
+		return database.ErrClosed
+	}
+	return updateError(err)
+}
@@ -5,6 +5,7 @@ package memdb
 
 import (
 	"context"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -21,9 +22,11 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Iterator    = (*iterator)(nil)
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -108,6 +111,21 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+// NewSnapshot copies the current contents of the database into a new snapshot.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	// Values are never modified in place, so copying the map is sufficient to
+	// isolate the snapshot from future writes.
+	return &snapshot{
+		Database: &Database{db: maps.Clone(db.db)},
+	}, nil
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{db: db}
 }
@@ -253,3 +271,11 @@ func (it *iterator) Release() {
 	it.keys = nil
 	it.values = nil
 }
+
+type snapshot struct {
+	*Database
+}
+
+func (s *snapshot) Release() {
+	_ = s.Database.Close()
+}
@@ -18,6 +18,14 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for name, test := range dbtest.SnapshotTests {
+		t.Run(name, func(t *testing.T) {
+			test(t, New())
+		})
+	}
+}
+
 func FuzzKeyValue(f *testing.F) {
 	dbtest.FuzzKeyValue(f, New())
 }
@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/factory"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/migrate"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+var (
+	errSameDBType   = errors.New("source and target db-type must differ")
+	errMemDBInvalid = fmt.Errorf("%s can not be migrated", memdb.Name)
+	errNotAnObject  = errors.New("config must be a JSON object")
+)
+
+// This migrates a node's database from one database engine to another.
+//
+// The node must not be running while the migration is in progress. If the
+// migration is interrupted, re-running the same command resumes it. Once the
+// migration has been validated, the db-type in the node's config file is
+// updated, if one was provided.
+func main() {
+	var (
+		dbDir          string
+		networkName    string
+		sourceDBType   string
+		targetDBType   string
+		sourceDBConfig string
+		targetDBConfig string
+		nodeConfigFile string
+		migrateConfig  = migrate.Config{
+			BatchSize: migrate.DefaultBatchSize,
+			PrefixLen: migrate.DefaultPrefixLen,
+		}
+	)
+	rootCmd := &cobra.Command{
+		Use:   "dbmigrate",
+		Short: "Migrate a node's database to a different database engine",
+		RunE: func(*cobra.Command, []string) error {
+			if sourceDBType == targetDBType {
+				return errSameDBType
+			}
+			if sourceDBType == memdb.Name || targetDBType == memdb.Name {
+				return errMemDBInvalid
+			}
+
+			log := logging.NewLogger(
+				"dbmigrate",
+				logging.NewWrappedCore(
+					logging.Info,
+					os.Stdout,
+					logging.Colors.ConsoleEncoder(),
+				),
+			)
+
+			dbPath := filepath.Join(os.ExpandEnv(dbDir), networkName)
+			src, err := openDB(sourceDBType, dbPath, sourceDBConfig, log)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := src.Close(); err != nil {
+					log.Error("failed to close source database", zap.Error(err))
+				}
+			}()
+
+			dst, err := openDB(targetDBType, dbPath, targetDBConfig, log)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := dst.Close(); err != nil {
+					log.Error("failed to close target database", zap.Error(err))
+				}
+			}()
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			if _, err := migrate.Migrate(ctx, log, src, dst, migrateConfig); err != nil {
+				return err
+			}
+
+			if len(nodeConfigFile) == 0 {
+				log.Info("migration complete; restart the node with the new db-type",
+					zap.String("db-type", targetDBType),
+				)
+				return nil
+			}
+			if err := setDBType(os.ExpandEnv(nodeConfigFile), targetDBType); err != nil {
+				return fmt.Errorf("failed to update node config: %w", err)
+			}
+			log.Info("migration complete; updated node config",
+				zap.String("configFile", nodeConfigFile),
+				zap.String("db-type", targetDBType),
+			)
+			return nil
+		},
+	}
+	flags := rootCmd.Flags()
+	flags.StringVar(&dbDir, config.DBPathKey, filepath.Join("$HOME", constants.AppName, "db"), "Path to the node's database directory")
+	flags.StringVar(&networkName, "network-name", constants.MainnetName, "Name of the network the database belongs to")
+	flags.StringVar(&sourceDBType, "source-db-type", leveldb.Name, "Database type to migrate from")
+	flags.StringVar(&targetDBType, "target-db-type", pebbledb.Name, "Database type to migrate to")
+	flags.StringVar(&sourceDBConfig, "source-db-config-file", "", "Path to the source database config file")
+	flags.StringVar(&targetDBConfig, "target-db-config-file", "", "Path to the target database config file")
+	flags.StringVar(&nodeConfigFile, config.ConfigFileKey, "", "Path to the node's config file. If provided, its db-type is updated once the migration completes")
+	flags.IntVar(&migrateConfig.BatchSize, "batch-size", migrateConfig.BatchSize, "Number of bytes to write per batch")
+	flags.IntVar(&migrateConfig.PrefixLen, "prefix-len", migrateConfig.PrefixLen, "Number of leading key bytes to group keys by when validating")
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "dbmigrate failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func openDB(name string, path string, configFile string, log logging.Logger) (database.Database, error) {
+	var configBytes []byte
+	if len(configFile) > 0 {
+		var err error
+		configBytes, err = os.ReadFile(os.ExpandEnv(configFile))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return factory.New(name, path, configBytes, log, prometheus.NewRegistry())
+}
+
+// setDBType sets the db-type of the config file at [path]. JSON config files
+// are edited in place so that the rest of the file is left untouched. Config
+// files of other types are rewritten with viper, which the node uses to load
+// them.
+func setDBType(path string, dbType string) error {
+	if filepath.Ext(path) != ".json" {
+		v := viper.New()
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return err
+		}
+		v.Set(config.DBTypeKey, dbType)
+		return v.WriteConfig()
+	}
+
+	configBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	configBytes, err = setJSONDBType(configBytes, dbType)
+	if err != nil {
+		return err
+	}
+	return perms.WriteFile(path, configBytes, perms.ReadWrite)
+}
+
+// setJSONDBType replaces the value of every top-level db-type key in
+// [configBytes], or adds the key if it is missing, without re-encoding any
+// other part of the config.
+func setJSONDBType(configBytes []byte, dbType string) ([]byte, error) {
+	dbTypeBytes, err := json.Marshal(dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(configBytes))
+	token, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, errNotAnObject
+	}
+	objectStart := d.InputOffset()
+
+	type span struct {
+		start int64
+		end   int64
+	}
+	var (
+		numKeys int
+		values  []span
+	)
+	for d.More() {
+		token, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := d.Decode(&value); err != nil {
+			return nil, err
+		}
+		numKeys++
+
+		if key, _ := token.(string); key == config.DBTypeKey {
+			end := d.InputOffset()
+			values = append(values, span{
+				start: end - int64(len(value)),
+				end:   end,
+			})
+		}
+	}
+
+	if len(values) == 0 {
+		field := fmt.Sprintf("\n  %q: %s", config.DBTypeKey, dbTypeBytes)
+		if numKeys > 0 {
+			field += ","
+		}
+		return bytes.Join([][]byte{
+			configBytes[:objectStart],
+			[]byte(field),
+			configBytes[objectStart:],
+		}, nil), nil
+	}
+
+	// Replace the values from last to first so that the earlier offsets
+	// remain valid.
+	for i := len(values) - 1; i >= 0; i-- {
+		value := values[i]
+		configBytes = bytes.Join([][]byte{
+			configBytes[:value.start],
+			dbTypeBytes,
+			configBytes[value.end:],
+		}, nil)
+	}
+	return configBytes, nil
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package migrate copies the contents of one database engine into another.
+//
+// The migration streams every key of the source database, in key order, into
+// the destination database in batches. After every batch a checkpoint is
+// written atomically with the data so that an interrupted migration can be
+// resumed without re-copying previously written keys. Once all keys have been
+// copied, the source and destination are compared by key count and checksum
+// per key prefix.
+package migrate
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	DefaultBatchSize = 4 * units.MiB
+	// DefaultPrefixLen groups keys by the prefixes generated by prefixdb.
+	DefaultPrefixLen = hashing.HashLen
+)
+
+var (
+	// checkpointKey is the key, in the destination database, that the
+	// migration progress is recorded under. It is removed once the migration
+	// completes.
+	checkpointKey = hashing.ComputeHash256([]byte("avalanchego database migration checkpoint"))
+
+	errInvalidCheckpoint = errors.New("invalid checkpoint")
+	errInvalidBatchSize  = errors.New("batch size must be positive")
+	errInvalidPrefixLen  = errors.New("prefix length must not be negative")
+)
+
+type Config struct {
+	// BatchSize is the number of bytes to buffer before writing a batch, and
+	// a checkpoint, to the destination database.
+	BatchSize int `json:"batchSize"`
+	// PrefixLen is the number of leading key bytes that keys are grouped by
+	// when validating the migration.
+	PrefixLen int `json:"prefixLen"`
+}
+
+func (c Config) Verify() error {
+	switch {
+	case c.BatchSize <= 0:
+		return errInvalidBatchSize
+	case c.PrefixLen < 0:
+		return errInvalidPrefixLen
+	default:
+		return nil
+	}
+}
+
+// Checkpoint records the progress of a migration.
+type Checkpoint struct {
+	// LastKey is the last key that was written to the destination database.
+	LastKey []byte
+	// NumKeys is the number of keys that have been written to the destination
+	// database.
+	NumKeys uint64
+}
+
+func (c *Checkpoint) Bytes() []byte {
+	b := make([]byte, database.Uint64Size+len(c.LastKey))
+	binary.BigEndian.PutUint64(b, c.NumKeys)
+	copy(b[database.Uint64Size:], c.LastKey)
+	return b
+}
+
+func parseCheckpoint(b []byte) (*Checkpoint, error) {
+	if len(b) < database.Uint64Size {
+		return nil, fmt.Errorf("%w: expected at least %d bytes but got %d",
+			errInvalidCheckpoint,
+			database.Uint64Size,
+			len(b),
+		)
+	}
+	return &Checkpoint{
+		LastKey: slices.Clone(b[database.Uint64Size:]),
+		NumKeys: binary.BigEndian.Uint64(b),
+	}, nil
+}
+
+// GetCheckpoint returns the checkpoint of an in-progress migration into [db].
+// If no migration is in progress, [database.ErrNotFound] is returned.
+func GetCheckpoint(db database.KeyValueReader) (*Checkpoint, error) {
+	b, err := db.Get(checkpointKey)
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckpoint(b)
+}
+
+// Migrate copies every key from [src] into [dst].
+//
+// If [dst] contains the checkpoint of a previous, incomplete, migration, the
+// migration resumes after the last checkpointed key. Neither database should
+// be modified by anything else while the migration is running.
+//
+// On success, the per-prefix statistics of the migrated keys are returned.
+func Migrate(
+	ctx context.Context,
+	log logging.Logger,
+	src database.Database,
+	dst database.Database,
+	config Config,
+) (Summary, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := GetCheckpoint(dst)
+	switch {
+	case err == database.ErrNotFound:
+		checkpoint = &Checkpoint{}
+		log.Info("starting database migration")
+	case err != nil:
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	default:
+		log.Info("resuming database migration",
+			zap.Binary("lastKey", checkpoint.LastKey),
+			zap.Uint64("numKeys", checkpoint.NumKeys),
+		)
+	}
+
+	if err := copyKeys(ctx, log, src, dst, checkpoint, config.BatchSize); err != nil {
+		return nil, err
+	}
+
+	log.Info("validating database migration",
+		zap.Uint64("numKeys", checkpoint.NumKeys),
+	)
+
+	summary, err := Validate(src, dst, config.PrefixLen)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dst.Delete(checkpointKey); err != nil {
+		return nil, fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+
+	log.Info("finished database migration",
+		zap.Uint64("numKeys", checkpoint.NumKeys),
+		zap.Int("numPrefixes", len(summary)),
+	)
+	return summary, nil
+}
+
+// copyKeys writes all the keys in [src] after [checkpoint] into [dst].
+// [checkpoint] is updated as batches are written.
+func copyKeys(
+	ctx context.Context,
+	log logging.Logger,
+	src database.Iteratee,
+	dst database.Batcher,
+	checkpoint *Checkpoint,
+	batchSize int,
+) error {
+	var it database.Iterator
+	if checkpoint.NumKeys == 0 {
+		it = src.NewIterator()
+	} else {
+		// The smallest key that is larger than LastKey is LastKey with a 0
+		// byte appended.
+		start := append(slices.Clone(checkpoint.LastKey), 0)
+		it = src.NewIteratorWithStart(start)
+	}
+	defer it.Release()
+
+	batch := dst.NewBatch()
+	writeBatch := func(lastKey []byte, numKeys uint64) error {
+		checkpoint.LastKey = slices.Clone(lastKey)
+		checkpoint.NumKeys = numKeys
+		if err := batch.Put(checkpointKey, checkpoint.Bytes()); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return fmt.Errorf("failed to write batch: %w", err)
+		}
+		batch.Reset()
+		return nil
+	}
+
+	var (
+		numKeys = checkpoint.NumKeys
+		lastKey = slices.Clone(checkpoint.LastKey)
+	)
+	for it.Next() {
+		key := it.Key()
+		if err := batch.Put(key, it.Value()); err != nil {
+			return err
+		}
+		numKeys++
+		// The iterator may reuse the key's memory, so it is copied.
+		lastKey = append(lastKey[:0], key...)
+
+		if batch.Size() < batchSize {
+			continue
+		}
+
+		if err := writeBatch(lastKey, numKeys); err != nil {
+			return err
+		}
+
+		log.Debug("wrote database migration batch",
+			zap.Binary("lastKey", lastKey),
+			zap.Uint64("numKeys", numKeys),
+		)
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("failed to iterate source database: %w", err)
+	}
+	return writeBatch(lastKey, numKeys)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestDB(t *testing.T, numKeys int) database.Database {
+	require := require.New(t)
+
+	db := memdb.New()
+	for _, prefix := range []string{"a", "b", "c"} {
+		pdb := prefixdb.New([]byte(prefix), db)
+		for i := 0; i < numKeys; i++ {
+			require.NoError(pdb.Put(
+				[]byte(fmt.Sprintf("key-%04d", i)),
+				[]byte(fmt.Sprintf("value-%04d", i)),
+			))
+		}
+	}
+	return db
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestDB(t, 100)
+	dst := memdb.New()
+
+	summary, err := Migrate(
+		context.Background(),
+		logging.NoLog{},
+		src,
+		dst,
+		Config{
+			BatchSize: 64,
+			PrefixLen: DefaultPrefixLen,
+		},
+	)
+	require.NoError(err)
+	require.Len(summary, 3)
+	for _, stats := range summary {
+		require.Equal(uint64(100), stats.NumKeys)
+	}
+
+	_, err = GetCheckpoint(dst)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	expectedSummary, err := Summarize(src, DefaultPrefixLen)
+	require.NoError(err)
+	dstSummary, err := Summarize(dst, DefaultPrefixLen)
+	require.NoError(err)
+	require.Equal(expectedSummary, dstSummary)
+}
+
+func TestMigrateResume(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestDB(t, 100)
+	dst := memdb.New()
+
+	// Cancelling the context causes the migration to stop after the first
+	// batch is written.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	config := Config{
+		BatchSize: 64,
+		PrefixLen: DefaultPrefixLen,
+	}
+	_, err := Migrate(ctx, logging.NoLog{}, src, dst, config)
+	require.ErrorIs(err, context.Canceled)
+
+	checkpoint, err := GetCheckpoint(dst)
+	require.NoError(err)
+	require.Positive(checkpoint.NumKeys)
+	require.Less(checkpoint.NumKeys, uint64(300))
+
+	summary, err := Migrate(context.Background(), logging.NoLog{}, src, dst, config)
+	require.NoError(err)
+	require.Len(summary, 3)
+
+	_, err = GetCheckpoint(dst)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestValidateMismatch(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestDB(t, 10)
+	dst := newTestDB(t, 10)
+	_, err := Validate(src, dst, DefaultPrefixLen)
+	require.NoError(err)
+
+	require.NoError(prefixdb.New([]byte("b"), dst).Put([]byte("key-0000"), []byte("modified")))
+	_, err = Validate(src, dst, DefaultPrefixLen)
+	require.ErrorIs(err, errMismatch)
+
+	require.NoError(dst.Put([]byte("extra"), nil))
+	_, err = Validate(src, dst, DefaultPrefixLen)
+	require.ErrorIs(err, errMismatch)
+}
+
+func TestCheckpointBytes(t *testing.T) {
+	require := require.New(t)
+
+	checkpoint := &Checkpoint{
+		LastKey: []byte("last key"),
+		NumKeys: 12345,
+	}
+	parsed, err := parseCheckpoint(checkpoint.Bytes())
+	require.NoError(err)
+	require.Equal(checkpoint, parsed)
+
+	_, err = parseCheckpoint([]byte{0x00})
+	require.ErrorIs(err, errInvalidCheckpoint)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+var errMismatch = errors.New("database contents mismatch")
+
+// PrefixStats summarizes the keys in a database that share a prefix.
+type PrefixStats struct {
+	NumKeys uint64 `json:"numKeys"`
+	// Size is the total number of bytes of all keys and values.
+	Size uint64 `json:"size"`
+	// Checksum is a hash over all key/value pairs, in key order.
+	Checksum ids.ID `json:"checksum"`
+}
+
+// Summary maps a key prefix to the statistics of the keys with that prefix.
+type Summary map[string]PrefixStats
+
+// Summarize calculates the statistics of every [prefixLen] byte prefix of the
+// keys in [db]. Keys shorter than [prefixLen] are grouped by the full key.
+//
+// Migration checkpoints are not included in the summary.
+func Summarize(db database.Iteratee, prefixLen int) (Summary, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		summary       = make(Summary)
+		currentPrefix []byte
+		currentStats  PrefixStats
+		hasher        hash.Hash
+		lenBytes      [database.Uint64Size]byte
+	)
+	finishPrefix := func() {
+		if hasher == nil {
+			return
+		}
+		copy(currentStats.Checksum[:], hasher.Sum(nil))
+		summary[string(currentPrefix)] = currentStats
+	}
+	for it.Next() {
+		key := it.Key()
+		if bytes.Equal(key, checkpointKey) {
+			continue
+		}
+
+		prefix := key[:min(len(key), prefixLen)]
+		if hasher == nil || !bytes.Equal(prefix, currentPrefix) {
+			finishPrefix()
+			currentPrefix = append(currentPrefix[:0], prefix...)
+			currentStats = PrefixStats{}
+			hasher = sha256.New()
+		}
+
+		value := it.Value()
+		currentStats.NumKeys++
+		currentStats.Size += uint64(len(key) + len(value))
+
+		// Length prefixing the key and value ensures that the checksum
+		// commits to the boundaries between them.
+		binary.BigEndian.PutUint64(lenBytes[:], uint64(len(key)))
+		_, _ = hasher.Write(lenBytes[:])
+		_, _ = hasher.Write(key)
+		binary.BigEndian.PutUint64(lenBytes[:], uint64(len(value)))
+		_, _ = hasher.Write(lenBytes[:])
+		_, _ = hasher.Write(value)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	finishPrefix()
+	return summary, nil
+}
+
+// Validate verifies that [src] and [dst] contain the same key/value pairs by
+// comparing their summaries.
+func Validate(src, dst database.Iteratee, prefixLen int) (Summary, error) {
+	srcSummary, err := Summarize(src, prefixLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize source database: %w", err)
+	}
+	dstSummary, err := Summarize(dst, prefixLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize destination database: %w", err)
+	}
+	if err := srcSummary.Compare(dstSummary); err != nil {
+		return nil, err
+	}
+	return srcSummary, nil
+}
+
+// Compare returns an error describing the first difference between [s] and
+// [other], if any.
+func (s Summary) Compare(other Summary) error {
+	for prefix, stats := range s {
+		otherStats, ok := other[prefix]
+		if !ok {
+			return fmt.Errorf("%w: prefix %s is missing",
+				errMismatch,
+				formatPrefix(prefix),
+			)
+		}
+		if stats != otherStats {
+			return fmt.Errorf("%w: prefix %s has %d keys with checksum %s but expected %d keys with checksum %s",
+				errMismatch,
+				formatPrefix(prefix),
+				otherStats.NumKeys,
+				otherStats.Checksum,
+				stats.NumKeys,
+				stats.Checksum,
+			)
+		}
+	}
+	for prefix := range other {
+		if _, ok := s[prefix]; !ok {
+			return fmt.Errorf("%w: prefix %s is unexpected",
+				errMismatch,
+				formatPrefix(prefix),
+			)
+		}
+	}
+	return nil
+}
+
+func formatPrefix(prefix string) string {
+	str, _ := formatting.Encode(formatting.HexNC, []byte(prefix))
+	return str
+}
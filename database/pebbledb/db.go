@@ -31,7 +31,9 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.SizeEstimator = (*Database)(nil)
 
 	errInvalidOperation = errors.New("invalid operation")
 
@@ -51,6 +53,7 @@ type Database struct {
 	pebbleDB      *pebble.DB
 	closed        bool
 	openIterators set.Set[*iter]
+	openSnapshots set.Set[*snapshot]
 }
 
 type Config struct {
@@ -93,6 +96,7 @@ func New(file string, configBytes []byte, log logging.Logger, _ prometheus.Regis
 	return &Database{
 		pebbleDB:      db,
 		openIterators: set.Set[*iter]{},
+		openSnapshots: set.Set[*snapshot]{},
 	}, err
 }
 
@@ -113,6 +117,11 @@ func (db *Database) Close() error {
 	}
 	db.openIterators.Clear()
 
+	for snapshot := range db.openSnapshots {
+		snapshot.release()
+	}
+	db.openSnapshots.Clear()
+
 	return updateError(db.pebbleDB.Close())
 }
 
@@ -218,6 +227,18 @@ func (db *Database) Compact(start []byte, end []byte) error {
 	return updateError(db.pebbleDB.Compact(start, end, true /* parallelize */))
 }
 
+func (db *Database) EstimateSize(start []byte, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return 0, database.ErrClosed
+	}
+
+	size, err := db.pebbleDB.EstimateDiskUsage(start, limit)
+	return size, updateError(err)
+}
+
 func (db *Database) NewIterator() database.Iterator {
 	return db.NewIteratorWithStartAndPrefix(nil, nil)
 }
@@ -31,6 +31,22 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for name, test := range dbtest.SnapshotTests {
+		t.Run(name, func(t *testing.T) {
+			db := newDB(t)
+			test(t, db)
+			_ = db.Close()
+		})
+	}
+}
+
+func TestEstimateSize(t *testing.T) {
+	db := newDB(t)
+	dbtest.TestEstimateSize(t, db)
+	_ = db.Close()
+}
+
 func FuzzKeyValue(f *testing.F) {
 	db := newDB(f)
 	dbtest.FuzzKeyValue(f, db)
@@ -0,0 +1,319 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package snapshot exports and imports point-in-time archives of a database.
+//
+// An archive is laid out as:
+//
+//	magic       [8]byte
+//	manifestLen uint32
+//	manifest    [manifestLen]byte (JSON)
+//	chunks      repeated {chunkLen uint32, chunk [chunkLen]byte}
+//	terminator  uint32 (0)
+//	numKeys     uint64
+//	checksum    [32]byte
+//
+// Every chunk is a zstd compressed sequence of length prefixed key/value
+// pairs. The checksum is the sha256 hash of all the uncompressed chunks.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// targetChunkSize is the number of uncompressed bytes after which a chunk
+	// is flushed.
+	targetChunkSize = 4 * units.MiB
+	// maxChunkSize is the maximum number of uncompressed bytes in a chunk.
+	maxChunkSize = 256 * units.MiB
+	// maxManifestSize is the maximum number of bytes in a manifest.
+	maxManifestSize = 16 * units.MiB
+
+	// importBatchSize is the number of bytes to buffer before writing a batch
+	// during an import.
+	importBatchSize = 4 * units.MiB
+)
+
+var (
+	magic = [8]byte{'a', 'v', 'a', 'x', 's', 'n', 'a', 'p'}
+
+	ErrInvalidManifest = errors.New("invalid manifest")
+
+	errInvalidMagic     = errors.New("invalid snapshot magic")
+	errTooLarge         = errors.New("too large")
+	errKeyCountMismatch = errors.New("key count mismatch")
+	errChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Chain records the last accepted block of a chain at the time the snapshot
+// was taken.
+type Chain struct {
+	ChainID ids.ID `json:"chainID"`
+	BlockID ids.ID `json:"blockID"`
+	Height  uint64 `json:"height"`
+}
+
+// Manifest describes the contents of a snapshot.
+type Manifest struct {
+	// NodeVersion is the version of the node that created the snapshot.
+	NodeVersion string `json:"nodeVersion"`
+	NetworkID   uint32 `json:"networkID"`
+	// GenesisHash is the hash of the genesis the database was initialized
+	// with.
+	GenesisHash ids.ID    `json:"genesisHash"`
+	Timestamp   time.Time `json:"timestamp"`
+	Chains      []Chain   `json:"chains"`
+}
+
+// Summary describes the key/value pairs contained in a snapshot.
+type Summary struct {
+	NumKeys  uint64 `json:"numKeys"`
+	Checksum ids.ID `json:"checksum"`
+}
+
+// Export writes [manifest] followed by every key/value pair in [db] to [w].
+//
+// To produce a consistent archive of a live database, [db] should be a
+// [database.Snapshot].
+func Export(w io.Writer, db database.Iteratee, manifest *Manifest) (*Summary, error) {
+	compressor, err := compression.NewZstdCompressor(maxChunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(magic[:]); err != nil {
+		return nil, err
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBytes(bw, manifestBytes); err != nil {
+		return nil, err
+	}
+
+	var (
+		hasher  = sha256.New()
+		chunk   []byte
+		numKeys uint64
+	)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		_, _ = hasher.Write(chunk)
+		compressed, err := compressor.Compress(chunk)
+		if err != nil {
+			return err
+		}
+		chunk = chunk[:0]
+		return writeBytes(bw, compressed)
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		chunk = appendBytes(chunk, it.Key())
+		chunk = appendBytes(chunk, it.Value())
+		numKeys++
+
+		if len(chunk) >= targetChunkSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		NumKeys: numKeys,
+	}
+	copy(summary.Checksum[:], hasher.Sum(nil))
+
+	// Write the terminator followed by the summary.
+	var trailer [wrappers.IntLen + database.Uint64Size + ids.IDLen]byte
+	binary.BigEndian.PutUint64(trailer[wrappers.IntLen:], summary.NumKeys)
+	copy(trailer[wrappers.IntLen+database.Uint64Size:], summary.Checksum[:])
+	if _, err := bw.Write(trailer[:]); err != nil {
+		return nil, err
+	}
+	return summary, bw.Flush()
+}
+
+// ReadManifest reads the manifest at the start of a snapshot from [r].
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	var readMagic [len(magic)]byte
+	if _, err := io.ReadFull(r, readMagic[:]); err != nil {
+		return nil, err
+	}
+	if readMagic != magic {
+		return nil, errInvalidMagic
+	}
+
+	manifestBytes, err := readBytes(r, maxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+	return manifest, nil
+}
+
+// Import reads a snapshot from [r] and writes its key/value pairs into [db].
+//
+// [verify] is called with the snapshot's manifest before any key/value pairs
+// are written. If [verify] returns an error, the import is aborted.
+//
+// If the snapshot is found to be corrupted after some keys have been written,
+// an error is returned and [db] will contain a subset of the snapshot.
+func Import(
+	r io.Reader,
+	db database.Batcher,
+	verify func(*Manifest) error,
+) (*Manifest, *Summary, error) {
+	compressor, err := compression.NewZstdCompressor(maxChunkSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	br := bufio.NewReader(r)
+	manifest, err := ReadManifest(br)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := verify(manifest); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	var (
+		hasher  = sha256.New()
+		batch   = db.NewBatch()
+		numKeys uint64
+	)
+	for {
+		compressed, err := readBytes(br, maxChunkSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(compressed) == 0 {
+			break
+		}
+
+		chunk, err := compressor.Decompress(compressed)
+		if err != nil {
+			return nil, nil, err
+		}
+		_, _ = hasher.Write(chunk)
+
+		chunkReader := bytes.NewReader(chunk)
+		for chunkReader.Len() > 0 {
+			key, err := readBytes(chunkReader, maxChunkSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			value, err := readBytes(chunkReader, maxChunkSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := batch.Put(key, value); err != nil {
+				return nil, nil, err
+			}
+			numKeys++
+		}
+
+		if batch.Size() < importBatchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return nil, nil, err
+		}
+		batch.Reset()
+	}
+
+	var trailer [database.Uint64Size + ids.IDLen]byte
+	if _, err := io.ReadFull(br, trailer[:]); err != nil {
+		return nil, nil, err
+	}
+	expected := &Summary{
+		NumKeys: binary.BigEndian.Uint64(trailer[:]),
+	}
+	copy(expected.Checksum[:], trailer[database.Uint64Size:])
+
+	summary := &Summary{
+		NumKeys: numKeys,
+	}
+	copy(summary.Checksum[:], hasher.Sum(nil))
+
+	switch {
+	case summary.NumKeys != expected.NumKeys:
+		return nil, nil, fmt.Errorf("%w: read %d but expected %d",
+			errKeyCountMismatch,
+			summary.NumKeys,
+			expected.NumKeys,
+		)
+	case summary.Checksum != expected.Checksum:
+		return nil, nil, fmt.Errorf("%w: calculated %s but expected %s",
+			errChecksumMismatch,
+			summary.Checksum,
+			expected.Checksum,
+		)
+	}
+
+	// Only write the final batch once the snapshot has been fully verified.
+	return manifest, summary, batch.Write()
+}
+
+func appendBytes(dst []byte, b []byte) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(b)))
+	return append(dst, b...)
+}
+
+func writeBytes(w io.Writer, b []byte) error {
+	var lenBytes [wrappers.IntLen]byte
+	binary.BigEndian.PutUint32(lenBytes[:], uint32(len(b)))
+	if _, err := w.Write(lenBytes[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func readBytes(r io.Reader, maxLen uint32) ([]byte, error) {
+	var lenBytes [wrappers.IntLen]byte
+	if _, err := io.ReadFull(r, lenBytes[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(lenBytes[:])
+	if length > maxLen {
+		return nil, fmt.Errorf("%w: %d > %d", errTooLarge, length, maxLen)
+	}
+	b := make([]byte, length)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var errTest = errors.New("non-nil error")
+
+func newTestManifest() *Manifest {
+	return &Manifest{
+		NodeVersion: "avalanchego/1.2.3",
+		NetworkID:   constants.UnitTestID,
+		GenesisHash: ids.GenerateTestID(),
+		Timestamp:   time.Unix(123, 0).UTC(),
+		Chains: []Chain{
+			{
+				ChainID: constants.PlatformChainID,
+				BlockID: ids.GenerateTestID(),
+				Height:  5,
+			},
+		},
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	for i := 0; i < 1000; i++ {
+		require.NoError(src.Put(
+			[]byte(fmt.Sprintf("key-%04d", i)),
+			bytes.Repeat([]byte{byte(i)}, i),
+		))
+	}
+	require.NoError(src.Put([]byte("empty"), []byte{}))
+
+	snapshot, err := src.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	manifest := newTestManifest()
+	archive := &bytes.Buffer{}
+	exportSummary, err := Export(archive, snapshot, manifest)
+	require.NoError(err)
+	require.Equal(uint64(1001), exportSummary.NumKeys)
+
+	readManifest, err := ReadManifest(bytes.NewReader(archive.Bytes()))
+	require.NoError(err)
+	require.Equal(manifest, readManifest)
+
+	dst := memdb.New()
+	importedManifest, importSummary, err := Import(
+		bytes.NewReader(archive.Bytes()),
+		dst,
+		func(m *Manifest) error {
+			require.Equal(manifest, m)
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.Equal(manifest, importedManifest)
+	require.Equal(exportSummary, importSummary)
+
+	it := src.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		value, err := dst.Get(it.Key())
+		require.NoError(err)
+		require.Equal(it.Value(), value)
+	}
+	require.NoError(it.Error())
+
+	srcCount, err := database.Count(src)
+	require.NoError(err)
+	dstCount, err := database.Count(dst)
+	require.NoError(err)
+	require.Equal(srcCount, dstCount)
+}
+
+func TestImportVerifyFails(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	require.NoError(src.Put([]byte("key"), []byte("value")))
+
+	archive := &bytes.Buffer{}
+	_, err := Export(archive, src, newTestManifest())
+	require.NoError(err)
+
+	dst := memdb.New()
+	_, _, err = Import(
+		archive,
+		dst,
+		func(*Manifest) error {
+			return errTest
+		},
+	)
+	require.ErrorIs(err, ErrInvalidManifest)
+	require.ErrorIs(err, errTest)
+	count, err := database.Count(dst)
+	require.NoError(err)
+	require.Zero(count)
+}
+
+func TestImportCorrupted(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	require.NoError(src.Put([]byte("key"), []byte("value")))
+
+	archive := &bytes.Buffer{}
+	_, err := Export(archive, src, newTestManifest())
+	require.NoError(err)
+
+	// Corrupt the checksum
+	archiveBytes := archive.Bytes()
+	archiveBytes[len(archiveBytes)-1]++
+
+	dst := memdb.New()
+	_, _, err = Import(
+		bytes.NewReader(archiveBytes),
+		dst,
+		func(*Manifest) error {
+			return nil
+		},
+	)
+	require.ErrorIs(err, errChecksumMismatch)
+	count, err := database.Count(dst)
+	require.NoError(err)
+	require.Zero(count)
+}
+
+func TestReadManifestInvalidMagic(t *testing.T) {
+	_, err := ReadManifest(bytes.NewReader(make([]byte, 64)))
+	require.ErrorIs(t, err, errInvalidMagic)
+}
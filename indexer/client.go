@@ -22,6 +22,13 @@ type Client interface {
 	// If [startIndex] > the last accepted index, returns an error (unless the above apply.)
 	// If we run out of transactions, returns the ones fetched before running out.
 	GetContainerRange(ctx context.Context, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, error)
+	// GetContainersSince returns up to [numToFetch] containers accepted after
+	// [cursor] and the cursor to provide to fetch the containers accepted
+	// after them. If [cursor] is empty, containers are returned starting from
+	// the first accepted container. If no container has been accepted after
+	// [cursor], the node waits for one to be accepted before responding. If
+	// none is accepted in time, no containers and [cursor] are returned.
+	GetContainersSince(ctx context.Context, cursor string, numToFetch int, options ...rpc.Option) ([]Container, string, error)
 	// Get a container by its index
 	GetContainerByIndex(ctx context.Context, index uint64, options ...rpc.Option) (Container, error)
 	// Get the most recently accepted container and its index
@@ -77,6 +84,32 @@ func (c *client) GetContainerRange(ctx context.Context, startIndex uint64, numTo
 	return response, nil
 }
 
+func (c *client) GetContainersSince(ctx context.Context, cursor string, numToFetch int, options ...rpc.Option) ([]Container, string, error) {
+	var fcs GetContainersSinceResponse
+	err := c.requester.SendRequest(ctx, "index.getContainersSince", &GetContainersSinceArgs{
+		Cursor:     cursor,
+		NumToFetch: json.Uint64(numToFetch),
+		Encoding:   formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	response := make([]Container, len(fcs.Containers))
+	for i, resp := range fcs.Containers {
+		containerBytes, err := formatting.Decode(resp.Encoding, resp.Bytes)
+		if err != nil {
+			return nil, "", fmt.Errorf("couldn't decode container %s: %w", resp.ID, err)
+		}
+		response[i] = Container{
+			ID:        resp.ID,
+			Timestamp: resp.Timestamp.Unix(),
+			Bytes:     containerBytes,
+		}
+	}
+	return response, fcs.Cursor, nil
+}
+
 func (c *client) GetContainerByIndex(ctx context.Context, index uint64, options ...rpc.Option) (Container, error) {
 	var fc FormattedContainer
 	err := c.requester.SendRequest(ctx, "index.getContainerByIndex", &GetContainerByIndexArgs{
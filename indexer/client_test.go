@@ -91,6 +91,37 @@ func TestIndexClient(t *testing.T) {
 		require.Equal(id, containers[0].ID)
 		require.Equal(bytes, containers[0].Bytes)
 	}
+	{
+		// Test GetContainersSince
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		nextCursor := cursor{
+			NextIndex:       1,
+			LastContainerID: id,
+		}
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getContainersSince",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetContainersSinceResponse)) = GetContainersSinceResponse{
+					Containers: []FormattedContainer{{
+						ID:    id,
+						Bytes: bytesStr,
+					}},
+					Cursor: nextCursor.String(),
+				}
+				return nil
+			},
+		}
+		containers, gotCursor, err := client.GetContainersSince(context.Background(), "", 10)
+		require.NoError(err)
+		require.Len(containers, 1)
+		require.Equal(id, containers[0].ID)
+		require.Equal(bytes, containers[0].Bytes)
+		require.Equal(nextCursor.String(), gotCursor)
+	}
 	{
 		// Test IsAccepted
 		client.requester = &mockClient{
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+var (
+	errInvalidCursor  = errors.New("invalid cursor")
+	errCursorMismatch = errors.New("cursor doesn't match the index")
+	errCursorAhead    = errors.New("cursor is ahead of the index")
+)
+
+// cursor is a position in an index. Because accepted containers are final, a
+// cursor always refers to the same position.
+//
+// The ID of the container preceding the position is included so that a cursor
+// is rejected by an index that accepted a different container at that
+// position, such as the index of another chain.
+type cursor struct {
+	// Index of the next container
+	NextIndex uint64 `serialize:"true"`
+	// ID of the container at [NextIndex]-1, or [ids.Empty] if [NextIndex] is 0
+	LastContainerID ids.ID `serialize:"true"`
+}
+
+// String returns the opaque representation of the cursor.
+func (c cursor) String() string {
+	bytes, err := Codec.Marshal(CodecVersion, c)
+	if err != nil {
+		// Marshalling a fixed size struct can't fail
+		panic(err)
+	}
+	str, err := formatting.Encode(formatting.Hex, bytes)
+	if err != nil {
+		panic(err)
+	}
+	return str
+}
+
+// parseCursor parses the opaque representation of a cursor. The empty string
+// is parsed as the cursor preceding the first container.
+func parseCursor(str string) (cursor, error) {
+	var c cursor
+	if str == "" {
+		return c, nil
+	}
+
+	bytes, err := formatting.Decode(formatting.Hex, str)
+	if err != nil {
+		return c, fmt.Errorf("%w: %w", errInvalidCursor, err)
+	}
+	if _, err := Codec.Unmarshal(bytes, &c); err != nil {
+		return c, fmt.Errorf("%w: %w", errInvalidCursor, err)
+	}
+	return c, nil
+}
+
+// next returns the cursor following [containers], which were fetched starting
+// at [c].
+func (c cursor) next(containers []Container) cursor {
+	if len(containers) == 0 {
+		return c
+	}
+	return cursor{
+		NextIndex:       c.NextIndex + uint64(len(containers)),
+		LastContainerID: containers[len(containers)-1].ID,
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	indexerpb "github.com/ava-labs/avalanchego/proto/pb/indexer"
+)
+
+var _ indexerpb.IndexerServer = (*grpcServer)(nil)
+
+// grpcServer serves the indices over gRPC.
+type grpcServer struct {
+	indexerpb.UnsafeIndexerServer
+	indexer *indexer
+}
+
+func (s *grpcServer) GetContainersSince(
+	ctx context.Context,
+	req *indexerpb.GetContainersSinceRequest,
+) (*indexerpb.GetContainersSinceResponse, error) {
+	index, err := s.indexer.getIndex(req.Chain, req.Index)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	c, err := parseCursor(req.Cursor)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	containers, next, err := index.GetContainersSince(ctx, c, req.NumToFetch)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &indexerpb.GetContainersSinceResponse{
+		Containers: make([]*indexerpb.Container, len(containers)),
+		Cursor:     next.String(),
+	}
+	for i, container := range containers {
+		resp.Containers[i] = newContainerResponse(container, c.NextIndex+uint64(i))
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) StreamContainers(
+	req *indexerpb.StreamContainersRequest,
+	stream indexerpb.Indexer_StreamContainersServer,
+) error {
+	index, err := s.indexer.getIndex(req.Chain, req.Index)
+	if err != nil {
+		return grpcError(err)
+	}
+	c, err := parseCursor(req.Cursor)
+	if err != nil {
+		return grpcError(err)
+	}
+
+	ctx := stream.Context()
+	for {
+		containers, next, err := index.GetContainersSince(ctx, c, MaxFetchedByRange)
+		if err != nil {
+			return grpcError(err)
+		}
+		if err := ctx.Err(); err != nil {
+			return grpcError(err)
+		}
+
+		// Send blocks until the client has capacity to receive the container,
+		// so containers are only read as fast as the client consumes them.
+		for i, container := range containers {
+			if err := stream.Send(newContainerResponse(container, c.NextIndex+uint64(i))); err != nil {
+				return err
+			}
+		}
+		c = next
+	}
+}
+
+// newContainerResponse returns the response for [container], which was
+// accepted at [index].
+func newContainerResponse(container Container, index uint64) *indexerpb.Container {
+	return &indexerpb.Container{
+		Id:        container.ID[:],
+		Bytes:     container.Bytes,
+		Timestamp: container.Timestamp,
+		Index:     index,
+		Cursor: cursor{
+			NextIndex:       index + 1,
+			LastContainerID: container.ID,
+		}.String(),
+	}
+}
+
+// grpcError returns [err] with the gRPC status code that describes it, so that
+// clients can distinguish between bad requests and server failures.
+func grpcError(err error) error {
+	var code codes.Code
+	switch {
+	case errors.Is(err, errUnknownChain), errors.Is(err, errUnknownIndex):
+		code = codes.NotFound
+	case errors.Is(err, errInvalidCursor):
+		code = codes.InvalidArgument
+	case errors.Is(err, errCursorMismatch), errors.Is(err, errCursorAhead):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	indexerpb "github.com/ava-labs/avalanchego/proto/pb/indexer"
+)
+
+func TestGRPCServer(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{})
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, 3)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+	}
+	for _, containerID := range containerIDs[:2] {
+		require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+	}
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	server := grpcutils.NewServer()
+	indexerpb.RegisterIndexerServer(server, &grpcServer{
+		indexer: &indexer{
+			blockIndices: map[ids.ID]*index{ctx.ChainID: idx},
+			chainIDs:     map[string]ids.ID{"C": ctx.ChainID},
+		},
+	})
+	go grpcutils.Serve(listener, server)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+	t.Cleanup(func() {
+		server.Stop()
+		_ = conn.Close()
+		_ = listener.Close()
+	})
+	client := indexerpb.NewIndexerClient(conn)
+
+	errTests := []struct {
+		name         string
+		req          *indexerpb.GetContainersSinceRequest
+		expectedCode codes.Code
+	}{
+		{
+			name: "unknown chain",
+			req: &indexerpb.GetContainersSinceRequest{
+				Chain: "X",
+				Index: "block",
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "unknown index",
+			req: &indexerpb.GetContainersSinceRequest{
+				Chain: "C",
+				Index: "tx",
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "invalid cursor",
+			req: &indexerpb.GetContainersSinceRequest{
+				Chain:  "C",
+				Index:  "block",
+				Cursor: "not a cursor",
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "cursor ahead",
+			req: &indexerpb.GetContainersSinceRequest{
+				Chain:  "C",
+				Index:  "block",
+				Cursor: cursor{NextIndex: 3}.String(),
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name: "cursor mismatch",
+			req: &indexerpb.GetContainersSinceRequest{
+				Chain: "C",
+				Index: "block",
+				Cursor: cursor{
+					NextIndex:       1,
+					LastContainerID: ids.GenerateTestID(),
+				}.String(),
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+	for _, test := range errTests {
+		test.req.NumToFetch = 1
+		_, err := client.GetContainersSince(context.Background(), test.req)
+		require.Equal(test.expectedCode, status.Code(err), test.name)
+	}
+
+	resp, err := client.GetContainersSince(context.Background(), &indexerpb.GetContainersSinceRequest{
+		Chain:      "C",
+		Index:      "block",
+		NumToFetch: 1,
+	})
+	require.NoError(err)
+	require.Len(resp.Containers, 1)
+	require.Equal(containerIDs[0][:], resp.Containers[0].Id)
+	require.Equal(resp.Cursor, resp.Containers[0].Cursor)
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.StreamContainers(streamCtx, &indexerpb.StreamContainersRequest{
+		Chain:  ctx.ChainID.String(),
+		Index:  "block",
+		Cursor: resp.Cursor,
+	})
+	require.NoError(err)
+
+	container, err := stream.Recv()
+	require.NoError(err)
+	require.Equal(containerIDs[1][:], container.Id)
+	require.Equal(uint64(1), container.Index)
+
+	// Containers accepted while streaming are sent once accepted.
+	require.NoError(idx.Accept(ctx, containerIDs[2], utils.RandomBytes(32)))
+	container, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(containerIDs[2][:], container.Id)
+	require.Equal(uint64(2), container.Index)
+}
@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -54,6 +55,8 @@ type index struct {
 	// Container ID --> Index
 	containerToIndex database.Database
 	log              logging.Logger
+	// Closed and replaced every time a container is accepted
+	accepted chan struct{}
 }
 
 // Create a new thread-safe index.
@@ -75,6 +78,7 @@ func newIndex(
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
 		log:              log,
+		accepted:         make(chan struct{}),
 	}
 
 	// Get next accepted index from db
@@ -158,7 +162,14 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	// Notify anyone waiting for a new container
+	close(i.accepted)
+	i.accepted = make(chan struct{})
+	return nil
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -237,6 +248,95 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 	return containers, nil
 }
 
+// getNextAcceptedIndex returns the index the next accepted container will be
+// indexed at.
+func (i *index) getNextAcceptedIndex() uint64 {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.nextAcceptedIndex
+}
+
+// getContainersFrom returns up to [numToFetch] containers starting at
+// [startIndex]. If no container has been accepted at [startIndex] yet, no
+// containers are returned. The returned channel is closed once a container is
+// accepted after the returned containers.
+func (i *index) getContainersFrom(startIndex, numToFetch uint64) ([]Container, <-chan struct{}, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	containers, err := i.getContainersFromLocked(startIndex, numToFetch)
+	return containers, i.accepted, err
+}
+
+// Assumes [i.lock] is held
+func (i *index) getContainersFromLocked(startIndex, numToFetch uint64) ([]Container, error) {
+	if startIndex >= i.nextAcceptedIndex {
+		return nil, nil
+	}
+
+	// [startIndex] < [i.nextAcceptedIndex] so this can't overflow.
+	lastIndex := min(startIndex+numToFetch, i.nextAcceptedIndex) - 1
+	containers := make([]Container, 0, lastIndex-startIndex+1)
+	for j := startIndex; j <= lastIndex; j++ {
+		container, err := i.getContainerByIndex(j)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get container at index %d: %w", j, err)
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// GetContainersSince returns up to [numToFetch] containers accepted after [c]
+// and the cursor following them. If no container has been accepted after [c],
+// blocks until one is accepted or [ctx] is done. If [ctx] is done first, no
+// containers and [c] are returned.
+func (i *index) GetContainersSince(ctx context.Context, c cursor, numToFetch uint64) ([]Container, cursor, error) {
+	if numToFetch == 0 || numToFetch > MaxFetchedByRange {
+		return nil, c, fmt.Errorf("%w but is %d", errNumToFetchInvalid, numToFetch)
+	}
+
+	for {
+		containers, accepted, err := i.getContainersSince(c, numToFetch)
+		if err != nil || len(containers) > 0 {
+			return containers, c.next(containers), err
+		}
+
+		select {
+		case <-accepted:
+		case <-ctx.Done():
+			return nil, c, nil
+		}
+	}
+}
+
+func (i *index) getContainersSince(c cursor, numToFetch uint64) ([]Container, <-chan struct{}, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if c.NextIndex > i.nextAcceptedIndex {
+		return nil, nil, fmt.Errorf("%w: %d > %d", errCursorAhead, c.NextIndex, i.nextAcceptedIndex)
+	}
+	if c.NextIndex > 0 {
+		lastContainer, err := i.getContainerByIndex(c.NextIndex - 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if lastContainer.ID != c.LastContainerID {
+			return nil, nil, fmt.Errorf("%w: expected %s at index %d but found %s",
+				errCursorMismatch,
+				c.LastContainerID,
+				c.NextIndex-1,
+				lastContainer.ID,
+			)
+		}
+	}
+
+	containers, err := i.getContainersFromLocked(c.NextIndex, numToFetch)
+	return containers, i.accepted, err
+}
+
 // Returns database.ErrNotFound if the container is not indexed as accepted
 func (i *index) GetIndex(id ids.ID) (uint64, error) {
 	i.lock.RLock()
@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -162,3 +163,69 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	require.Equal([]byte{1, 2, 3}, gotContainer.Bytes)
 }
+
+func TestGetContainersSince(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{})
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, 4)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+	}
+	for _, containerID := range containerIDs[:3] {
+		require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+	}
+
+	start, err := parseCursor("")
+	require.NoError(err)
+
+	containers, next, err := idx.GetContainersSince(context.Background(), start, 2)
+	require.NoError(err)
+	require.Len(containers, 2)
+	require.Equal(containerIDs[0], containers[0].ID)
+	require.Equal(containerIDs[1], containers[1].ID)
+
+	// The opaque representation of the cursor is stable
+	next, err = parseCursor(next.String())
+	require.NoError(err)
+	require.Equal(cursor{NextIndex: 2, LastContainerID: containerIDs[1]}, next)
+
+	containers, next, err = idx.GetContainersSince(context.Background(), next, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 1)
+	require.Equal(containerIDs[2], containers[0].ID)
+
+	// Without any new containers, the call returns once the context is done.
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	containers, sameCursor, err := idx.GetContainersSince(cancelledCtx, next, MaxFetchedByRange)
+	require.NoError(err)
+	require.Empty(containers)
+	require.Equal(next, sameCursor)
+
+	// Waiting calls return once a container is accepted.
+	go func() {
+		require.NoError(idx.Accept(ctx, containerIDs[3], utils.RandomBytes(32)))
+	}()
+	containers, _, err = idx.GetContainersSince(context.Background(), next, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 1)
+	require.Equal(containerIDs[3], containers[0].ID)
+
+	_, _, err = idx.GetContainersSince(context.Background(), cursor{NextIndex: 1}, MaxFetchedByRange)
+	require.ErrorIs(err, errCursorMismatch)
+
+	_, _, err = idx.GetContainersSince(context.Background(), cursor{NextIndex: 5}, MaxFetchedByRange)
+	require.ErrorIs(err, errCursorAhead)
+
+	_, _, err = idx.GetContainersSince(context.Background(), start, 0)
+	require.ErrorIs(err, errNumToFetchInvalid)
+
+	_, err = parseCursor("0x1234")
+	require.ErrorIs(err, errInvalidCursor)
+}
@@ -4,12 +4,14 @@
 package indexer
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"sync"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
@@ -20,11 +22,14 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	indexerpb "github.com/ava-labs/avalanchego/proto/pb/indexer"
 )
 
 const (
@@ -40,6 +45,8 @@ var (
 	_ Indexer = (*indexer)(nil)
 
 	hasRunKey = []byte{0x07}
+
+	errUnknownIndex = errors.New("unknown index")
 )
 
 // Config for an indexer
@@ -53,6 +60,10 @@ type Config struct {
 	VertexAcceptorGroup  snow.AcceptorGroup
 	APIServer            server.PathAdder
 	ShutdownF            func()
+	// If non-nil, changes to the validator sets are available to subscribe to
+	Validators validators.Manager
+	// If non-nil, the indices are served over gRPC by this server
+	GRPCServer grpc.ServiceRegistrar
 }
 
 // Indexer causes accepted containers for a given chain
@@ -78,10 +89,29 @@ func NewIndexer(config Config) (Indexer, error) {
 		txIndices:            map[ids.ID]*index{},
 		vtxIndices:           map[ids.ID]*index{},
 		blockIndices:         map[ids.ID]*index{},
+		chainIDs:             map[string]ids.ID{},
 		pathAdder:            config.APIServer,
 		shutdownF:            config.ShutdownF,
 	}
 
+	if config.IndexingEnabled {
+		var changes *validatorChanges
+		if config.Validators != nil {
+			changes = newValidatorChanges()
+			config.Validators.RegisterCallbackListener(changes)
+		}
+		indexer.subscriptions = newSubscriptionServer(config.Log, changes)
+		if err := config.APIServer.AddRoute(indexer.subscriptions, "index", "/events"); err != nil {
+			return nil, err
+		}
+
+		if config.GRPCServer != nil {
+			indexerpb.RegisterIndexerServer(config.GRPCServer, &grpcServer{
+				indexer: indexer,
+			})
+		}
+	}
+
 	hasRun, err := indexer.hasRun()
 	if err != nil {
 		return nil, err
@@ -119,6 +149,8 @@ type indexer struct {
 	vtxIndices map[ids.ID]*index
 	// Chain ID --> index of txs of that chain (if applicable)
 	txIndices map[ids.ID]*index
+	// Chain name --> ID of that chain, for every indexed chain
+	chainIDs map[string]ids.ID
 
 	// Notifies of newly accepted blocks
 	blockAcceptorGroup snow.AcceptorGroup
@@ -126,6 +158,9 @@ type indexer struct {
 	txAcceptorGroup snow.AcceptorGroup
 	// Notifies of newly accepted vertices
 	vertexAcceptorGroup snow.AcceptorGroup
+
+	// Serves subscriptions to the indices. nil if indexing is disabled.
+	subscriptions *subscriptionServer
 }
 
 // Assumes [ctx.Lock] is not held
@@ -258,6 +293,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		return
 	}
 	i.blockIndices[chainID] = index
+	i.chainIDs[chainName] = chainID
 
 	switch vm.(type) {
 	case vertex.DAGVM:
@@ -292,7 +328,9 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 			return
 		}
 		i.txIndices[chainID] = txIndex
+		i.subscriptions.addChain(chainName, chainID, index, txIndex)
 	case block.ChainVM:
+		i.subscriptions.addChain(chainName, chainID, index, nil)
 	default:
 		vmType := fmt.Sprintf("%T", vm)
 		i.log.Error("got unexpected vm type",
@@ -344,6 +382,40 @@ func (i *indexer) registerChainHelper(
 	return index, nil
 }
 
+// getIndex returns the index named [indexName] of the chain with the name or ID
+// [chain].
+func (i *indexer) getIndex(chain string, indexName string) (*index, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	chainID, ok := i.chainIDs[chain]
+	if !ok {
+		var err error
+		chainID, err = ids.FromString(chain)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", errUnknownChain, chain)
+		}
+	}
+
+	var indices map[ids.ID]*index
+	switch indexName {
+	case "block":
+		indices = i.blockIndices
+	case "vtx":
+		indices = i.vtxIndices
+	case "tx":
+		indices = i.txIndices
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownIndex, indexName)
+	}
+
+	index, ok := indices[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: chain %q doesn't have a %q index", errUnknownIndex, chain, indexName)
+	}
+	return index, nil
+}
+
 // Close this indexer. Stops indexing all chains.
 // Closes [i.db]. Assumes Close is only called after
 // the node is done making decisions.
@@ -361,6 +433,10 @@ func (i *indexer) close() error {
 	}
 	i.closed = true
 
+	if i.subscriptions != nil {
+		i.subscriptions.close()
+	}
+
 	errs := &wrappers.Errs{}
 	for chainID, txIndex := range i.txIndices {
 		errs.Add(
@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -14,6 +15,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils/json"
 )
 
+// Maximum amount of time a call to GetContainersSince waits for a container to
+// be accepted
+const maxGetContainersSinceWait = 10 * time.Second
+
 type service struct {
 	index *index
 }
@@ -111,6 +116,49 @@ func (s *service) GetContainerRange(_ *http.Request, args *GetContainerRangeArgs
 	return nil
 }
 
+type GetContainersSinceArgs struct {
+	Cursor     string              `json:"cursor"`
+	NumToFetch json.Uint64         `json:"numToFetch"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+type GetContainersSinceResponse struct {
+	Containers []FormattedContainer `json:"containers"`
+	Cursor     string               `json:"cursor"`
+}
+
+// GetContainersSince returns up to [numToFetch] containers accepted after
+// [cursor], along with the cursor to provide to fetch the containers accepted
+// after them. If [cursor] is empty, containers are returned starting from the
+// first accepted container.
+// If no container has been accepted after [cursor], waits for up to
+// [maxGetContainersSinceWait] for one to be accepted. If none is accepted, no
+// containers and [cursor] are returned.
+func (s *service) GetContainersSince(r *http.Request, args *GetContainersSinceArgs, reply *GetContainersSinceResponse) error {
+	c, err := parseCursor(args.Cursor)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), maxGetContainersSinceWait)
+	defer cancel()
+
+	containers, next, err := s.index.GetContainersSince(ctx, c, uint64(args.NumToFetch))
+	if err != nil {
+		return err
+	}
+
+	reply.Containers = make([]FormattedContainer, len(containers))
+	for i, container := range containers {
+		reply.Containers[i], err = newFormattedContainer(container, c.NextIndex+uint64(i), args.Encoding)
+		if err != nil {
+			return err
+		}
+	}
+	reply.Cursor = next.String()
+	return nil
+}
+
 type GetIndexArgs struct {
 	ID ids.ID `json:"id"`
 }
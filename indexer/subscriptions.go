@@ -0,0 +1,576 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	SubscribeAcceptedBlocksMethod   = "subscribeAcceptedBlocks"
+	SubscribeAcceptedTxsMethod      = "subscribeAcceptedTxs"
+	SubscribeValidatorChangesMethod = "subscribeValidatorChanges"
+	UnsubscribeMethod               = "unsubscribe"
+
+	// Maximum number of events that are read at a time for a subscription
+	subscriptionBatchSize = 64
+
+	// Maximum number of subscriptions a connection can have at once
+	maxSubscriptionsPerConn = 16
+
+	// Maximum number of messages waiting to be written to a connection. Once
+	// reached, subscriptions stop reading events until the client catches up.
+	maxPendingMessages = 1024
+
+	// Size of the ws read and write buffers
+	wsBufferSize = units.KiB
+
+	// Maximum message size allowed from a client
+	wsMaxMessageSize = units.KiB
+
+	// Time allowed to write a message to the client
+	wsWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the client
+	wsPongWait = 60 * time.Second
+
+	// Send pings to the client with this period. Must be less than pongWait.
+	wsPingPeriod = (wsPongWait * 9) / 10
+)
+
+var (
+	errUnknownMethod        = errors.New("unknown method")
+	errUnknownChain         = errors.New("unknown chain")
+	errUnknownSubscription  = errors.New("unknown subscription")
+	errTooManySubscriptions = fmt.Errorf("connections can have at most %d subscriptions", maxSubscriptionsPerConn)
+	errValidatorsNotTracked = errors.New("validator changes are not tracked")
+	errMissingEpoch         = errors.New("cursor provided without an epoch")
+
+	upgrader = websocket.Upgrader{
+		ReadBufferSize:  wsBufferSize,
+		WriteBufferSize: wsBufferSize,
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+)
+
+// SubscriptionRequest is sent by a client to manage its subscriptions.
+type SubscriptionRequest struct {
+	ID     avajson.Uint64  `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// SubscribeAcceptedArgs are the params of the [SubscribeAcceptedBlocksMethod]
+// and [SubscribeAcceptedTxsMethod] requests.
+type SubscribeAcceptedArgs struct {
+	// Chain is the alias or ID of the chain to subscribe to.
+	Chain string `json:"chain"`
+	// Cursor is the index of the first container to send. If nil, only
+	// containers accepted after subscribing are sent.
+	Cursor   *avajson.Uint64     `json:"cursor"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// SubscribeValidatorChangesArgs are the params of the
+// [SubscribeValidatorChangesMethod] request.
+type SubscribeValidatorChangesArgs struct {
+	// SubnetID is the subnet to send the changes of. If nil, the changes of
+	// every subnet are sent.
+	SubnetID *ids.ID `json:"subnetID"`
+	// Cursor is the number of the first change to send. If nil, only changes
+	// that happen after subscribing are sent.
+	Cursor *avajson.Uint64 `json:"cursor"`
+	// Epoch is the epoch that [Cursor] was returned in. Must be provided if
+	// [Cursor] is provided.
+	Epoch *avajson.Uint64 `json:"epoch"`
+}
+
+// UnsubscribeArgs are the params of the [UnsubscribeMethod] request.
+type UnsubscribeArgs struct {
+	Subscription avajson.Uint64 `json:"subscription"`
+}
+
+// SubscriptionResponse is sent in response to a [SubscriptionRequest].
+type SubscriptionResponse struct {
+	ID avajson.Uint64 `json:"id"`
+	// Subscription is the ID of the created subscription
+	Subscription avajson.Uint64 `json:"subscription,omitempty"`
+	// Cursor is the cursor the created subscription starts at
+	Cursor *avajson.Uint64 `json:"cursor,omitempty"`
+	// Epoch is the epoch of the cursors of a validator changes subscription
+	Epoch *avajson.Uint64 `json:"epoch,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// SubscriptionEvent is sent for every event of a subscription.
+type SubscriptionEvent struct {
+	Subscription avajson.Uint64 `json:"subscription"`
+	// Cursor is the cursor to subscribe with to resume after this event
+	Cursor avajson.Uint64 `json:"cursor"`
+	// Epoch is the epoch to subscribe with, along with [Cursor], to resume
+	// after a validator change
+	Epoch           *avajson.Uint64     `json:"epoch,omitempty"`
+	Container       *FormattedContainer `json:"container,omitempty"`
+	ValidatorChange *ValidatorChange    `json:"validatorChange,omitempty"`
+	// Error is set if the subscription was terminated
+	Error string `json:"error,omitempty"`
+}
+
+// fetchFunc returns the events starting at [cursor] and the cursor that
+// follows them. The returned channel is closed once more events may be
+// available.
+type fetchFunc func(cursor uint64) ([]*SubscriptionEvent, uint64, <-chan struct{}, error)
+
+// subscriptionServer serves websocket subscriptions to accepted containers and
+// validator changes.
+type subscriptionServer struct {
+	log logging.Logger
+	// nil if validator changes are not tracked
+	validatorChanges *validatorChanges
+
+	lock   sync.RWMutex
+	closed bool
+	// Chain alias or ID --> index of blocks of that chain
+	blockIndices map[string]*index
+	// Chain alias or ID --> index of txs of that chain
+	txIndices map[string]*index
+	conns     set.Set[*subscriptionConn]
+}
+
+func newSubscriptionServer(log logging.Logger, validatorChanges *validatorChanges) *subscriptionServer {
+	return &subscriptionServer{
+		log:              log,
+		validatorChanges: validatorChanges,
+		blockIndices:     make(map[string]*index),
+		txIndices:        make(map[string]*index),
+	}
+}
+
+// addChain makes the indices of the chain [chainID] available to subscribe
+// to. [txIndex] may be nil.
+func (s *subscriptionServer) addChain(chainName string, chainID ids.ID, blockIndex *index, txIndex *index) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, name := range []string{chainName, chainID.String()} {
+		s.blockIndices[name] = blockIndex
+		if txIndex != nil {
+			s.txIndices[name] = txIndex
+		}
+	}
+}
+
+func (s *subscriptionServer) getIndex(indices map[string]*index, chain string) (*index, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	index, ok := indices[chain]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownChain, chain)
+	}
+	return index, nil
+}
+
+func (s *subscriptionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wsConn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn := &subscriptionConn{
+		server:        s,
+		conn:          wsConn,
+		ctx:           ctx,
+		cancel:        cancel,
+		send:          make(chan interface{}, maxPendingMessages),
+		subscriptions: make(map[uint64]context.CancelFunc),
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		cancel()
+		_ = wsConn.Close()
+		return
+	}
+	s.conns.Add(conn)
+
+	go conn.writePump()
+	go conn.readPump()
+}
+
+func (s *subscriptionServer) removeConnection(conn *subscriptionConn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.conns.Remove(conn)
+}
+
+// close terminates all the connections. Connections that are opened after
+// close is called are closed immediately.
+func (s *subscriptionServer) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	for conn := range s.conns {
+		conn.cancel()
+	}
+}
+
+// subscriptionConn is a websocket connection with a set of subscriptions.
+type subscriptionConn struct {
+	server *subscriptionServer
+	conn   *websocket.Conn
+
+	// Cancelled when the connection is closed
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Buffered channel of outbound messages
+	send chan interface{}
+
+	lock               sync.Mutex
+	lastSubscriptionID uint64
+	// Subscription ID --> cancels the subscription
+	subscriptions map[uint64]context.CancelFunc
+}
+
+// readPump reads the requests of the client until the connection is closed.
+//
+// There is at most one reader on a connection because all reads are performed
+// by this goroutine.
+func (c *subscriptionConn) readPump() {
+	defer func() {
+		c.cancel()
+		c.server.removeConnection(c)
+
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := c.conn.SetReadDeadline(time.Now().Add(wsPongWait)); err != nil {
+		return
+	}
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var request SubscriptionRequest
+		if err := c.conn.ReadJSON(&request); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.server.log.Debug("unexpected close in websockets",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+
+		response, start := c.handleRequest(&request)
+		if !c.write(response) {
+			return
+		}
+		if start != nil {
+			// The subscription is started after the response is queued so
+			// that the response is sent before any of its events.
+			start()
+		}
+	}
+}
+
+// writePump writes the outbound messages to the client until the connection is
+// closed.
+//
+// There is at most one writer on a connection because all writes are performed
+// by this goroutine.
+func (c *subscriptionConn) writePump() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		c.cancel()
+		ticker.Stop()
+
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message := <-c.send:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+			if err := c.conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-c.ctx.Done():
+			_ = c.conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+				time.Now().Add(wsWriteWait),
+			)
+			return
+		}
+	}
+}
+
+// write queues [message] to be sent to the client. Blocks until the message is
+// queued or the connection is closed. Returns false if the connection is
+// closed.
+func (c *subscriptionConn) write(message interface{}) bool {
+	select {
+	case c.send <- message:
+		return true
+	case <-c.ctx.Done():
+		return false
+	}
+}
+
+// handleRequest returns the response to [request]. If a subscription was
+// created, the returned function starts it.
+func (c *subscriptionConn) handleRequest(request *SubscriptionRequest) (*SubscriptionResponse, func()) {
+	response := &SubscriptionResponse{
+		ID: request.ID,
+	}
+
+	var (
+		cursor uint64
+		fetch  fetchFunc
+		err    error
+	)
+	switch request.Method {
+	case SubscribeAcceptedBlocksMethod:
+		cursor, fetch, err = c.subscribeAccepted(c.server.blockIndices, request.Params)
+	case SubscribeAcceptedTxsMethod:
+		cursor, fetch, err = c.subscribeAccepted(c.server.txIndices, request.Params)
+	case SubscribeValidatorChangesMethod:
+		cursor, fetch, err = c.subscribeValidatorChanges(request.Params)
+		if err == nil {
+			epoch := avajson.Uint64(c.server.validatorChanges.epoch)
+			response.Epoch = &epoch
+		}
+	case UnsubscribeMethod:
+		if err := c.unsubscribe(request.Params); err != nil {
+			response.Error = err.Error()
+		}
+		return response, nil
+	default:
+		err = fmt.Errorf("%w: %q", errUnknownMethod, request.Method)
+	}
+	if err != nil {
+		response.Error = err.Error()
+		return response, nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.subscriptions) >= maxSubscriptionsPerConn {
+		response.Error = errTooManySubscriptions.Error()
+		return response, nil
+	}
+
+	c.lastSubscriptionID++
+	subscriptionID := c.lastSubscriptionID
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.subscriptions[subscriptionID] = cancel
+
+	startCursor := avajson.Uint64(cursor)
+	response.Subscription = avajson.Uint64(subscriptionID)
+	response.Cursor = &startCursor
+	return response, func() {
+		go c.runSubscription(ctx, subscriptionID, cursor, fetch)
+	}
+}
+
+func (c *subscriptionConn) subscribeAccepted(indices map[string]*index, params []byte) (uint64, fetchFunc, error) {
+	var args SubscribeAcceptedArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return 0, nil, err
+	}
+	index, err := c.server.getIndex(indices, args.Chain)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var cursor uint64
+	if args.Cursor != nil {
+		cursor = uint64(*args.Cursor)
+	} else {
+		cursor = index.getNextAcceptedIndex()
+	}
+
+	return cursor, func(cursor uint64) ([]*SubscriptionEvent, uint64, <-chan struct{}, error) {
+		containers, accepted, err := index.getContainersFrom(cursor, subscriptionBatchSize)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		events := make([]*SubscriptionEvent, len(containers))
+		for i, container := range containers {
+			containerIndex := cursor + uint64(i)
+			formattedContainer, err := newFormattedContainer(container, containerIndex, args.Encoding)
+			if err != nil {
+				return nil, 0, nil, err
+			}
+			events[i] = &SubscriptionEvent{
+				Cursor:    avajson.Uint64(containerIndex + 1),
+				Container: &formattedContainer,
+			}
+		}
+		return events, cursor + uint64(len(containers)), accepted, nil
+	}, nil
+}
+
+func (c *subscriptionConn) subscribeValidatorChanges(params []byte) (uint64, fetchFunc, error) {
+	var args SubscribeValidatorChangesArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return 0, nil, err
+	}
+	changes := c.server.validatorChanges
+	if changes == nil {
+		return 0, nil, errValidatorsNotTracked
+	}
+
+	var cursor uint64
+	if args.Cursor != nil {
+		if args.Epoch == nil {
+			return 0, nil, errMissingEpoch
+		}
+		if err := changes.verifyEpoch(uint64(*args.Epoch)); err != nil {
+			return 0, nil, err
+		}
+		cursor = uint64(*args.Cursor)
+	} else {
+		cursor = changes.next()
+	}
+
+	epoch := avajson.Uint64(changes.epoch)
+	return cursor, func(cursor uint64) ([]*SubscriptionEvent, uint64, <-chan struct{}, error) {
+		validatorChanges, changed, err := changes.getChangesFrom(cursor, subscriptionBatchSize)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		events := make([]*SubscriptionEvent, 0, len(validatorChanges))
+		for i := range validatorChanges {
+			change := &validatorChanges[i]
+			if args.SubnetID != nil && change.SubnetID != *args.SubnetID {
+				continue
+			}
+			events = append(events, &SubscriptionEvent{
+				Cursor:          avajson.Uint64(cursor + uint64(i) + 1),
+				Epoch:           &epoch,
+				ValidatorChange: change,
+			})
+		}
+		return events, cursor + uint64(len(validatorChanges)), changed, nil
+	}, nil
+}
+
+func (c *subscriptionConn) unsubscribe(params []byte) error {
+	var args UnsubscribeArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	subscriptionID := uint64(args.Subscription)
+	cancel, ok := c.subscriptions[subscriptionID]
+	if !ok {
+		return fmt.Errorf("%w: %d", errUnknownSubscription, subscriptionID)
+	}
+	cancel()
+	delete(c.subscriptions, subscriptionID)
+	return nil
+}
+
+// runSubscription sends the events returned by [fetch], starting at [cursor],
+// until [ctx] is cancelled or fetching fails.
+func (c *subscriptionConn) runSubscription(ctx context.Context, subscriptionID uint64, cursor uint64, fetch fetchFunc) {
+	defer func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if cancel, ok := c.subscriptions[subscriptionID]; ok {
+			cancel()
+			delete(c.subscriptions, subscriptionID)
+		}
+	}()
+
+	for {
+		events, nextCursor, wait, err := fetch(cursor)
+		if err != nil {
+			c.server.log.Debug("terminating subscription",
+				zap.Uint64("subscriptionID", subscriptionID),
+				zap.Uint64("cursor", cursor),
+				zap.Error(err),
+			)
+			c.write(&SubscriptionEvent{
+				Subscription: avajson.Uint64(subscriptionID),
+				Cursor:       avajson.Uint64(cursor),
+				Error:        err.Error(),
+			})
+			return
+		}
+
+		for _, event := range events {
+			event.Subscription = avajson.Uint64(subscriptionID)
+			select {
+			case c.send <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if nextCursor != cursor {
+			// There may be more events available already.
+			cursor = nextCursor
+			continue
+		}
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
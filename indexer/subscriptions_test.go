@@ -0,0 +1,217 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+func newTestSubscriptionConn(t *testing.T, s *subscriptionServer) *websocket.Conn {
+	httpServer := httptest.NewServer(s)
+	t.Cleanup(httpServer.Close)
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func subscribe(t *testing.T, conn *websocket.Conn, id uint64, method string, params interface{}) *SubscriptionResponse {
+	require := require.New(t)
+
+	paramsBytes, err := json.Marshal(params)
+	require.NoError(err)
+	require.NoError(conn.WriteJSON(&SubscriptionRequest{
+		ID:     avajson.Uint64(id),
+		Method: method,
+		Params: paramsBytes,
+	}))
+
+	var response SubscriptionResponse
+	require.NoError(conn.ReadJSON(&response))
+	require.Equal(avajson.Uint64(id), response.ID)
+	return &response
+}
+
+func TestSubscribeAcceptedBlocks(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{})
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, 4)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+	}
+	for _, containerID := range containerIDs[:3] {
+		require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+	}
+
+	s := newSubscriptionServer(logging.NoLog{}, nil)
+	s.addChain("C", ctx.ChainID, idx, nil)
+	conn := newTestSubscriptionConn(t, s)
+
+	response := subscribe(t, conn, 1, SubscribeAcceptedBlocksMethod, &SubscribeAcceptedArgs{
+		Chain: "X",
+	})
+	require.Contains(response.Error, errUnknownChain.Error())
+
+	response = subscribe(t, conn, 2, SubscribeAcceptedTxsMethod, &SubscribeAcceptedArgs{
+		Chain: "C",
+	})
+	require.Contains(response.Error, errUnknownChain.Error())
+
+	cursor := avajson.Uint64(1)
+	response = subscribe(t, conn, 3, SubscribeAcceptedBlocksMethod, &SubscribeAcceptedArgs{
+		Chain:  ctx.ChainID.String(),
+		Cursor: &cursor,
+	})
+	require.Empty(response.Error)
+	require.Equal(avajson.Uint64(1), response.Subscription)
+	require.Equal(cursor, *response.Cursor)
+
+	// Containers accepted before subscribing are sent starting at the cursor
+	// and containers accepted after subscribing are sent once accepted.
+	require.NoError(idx.Accept(ctx, containerIDs[3], utils.RandomBytes(32)))
+	for i := 1; i < len(containerIDs); i++ {
+		var event SubscriptionEvent
+		require.NoError(conn.ReadJSON(&event))
+		require.Equal(response.Subscription, event.Subscription)
+		require.Equal(avajson.Uint64(i+1), event.Cursor)
+		require.NotNil(event.Container)
+		require.Equal(containerIDs[i], event.Container.ID)
+		require.Equal(avajson.Uint64(i), event.Container.Index)
+	}
+
+	response = subscribe(t, conn, 4, UnsubscribeMethod, &UnsubscribeArgs{
+		Subscription: response.Subscription,
+	})
+	require.Empty(response.Error)
+
+	response = subscribe(t, conn, 5, UnsubscribeMethod, &UnsubscribeArgs{
+		Subscription: 1,
+	})
+	require.Contains(response.Error, errUnknownSubscription.Error())
+}
+
+func TestSubscribeValidatorChanges(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		changes  = newValidatorChanges()
+		subnetID = ids.GenerateTestID()
+		nodeID0  = ids.GenerateTestNodeID()
+		nodeID1  = ids.GenerateTestNodeID()
+	)
+	changes.OnValidatorAdded(constants.PrimaryNetworkID, nodeID0, nil, ids.GenerateTestID(), 1)
+	changes.OnValidatorAdded(subnetID, nodeID0, nil, ids.GenerateTestID(), 1)
+
+	s := newSubscriptionServer(logging.NoLog{}, changes)
+	conn := newTestSubscriptionConn(t, s)
+
+	var (
+		cursor = avajson.Uint64(0)
+		epoch  = avajson.Uint64(changes.epoch)
+	)
+	response := subscribe(t, conn, 1, SubscribeValidatorChangesMethod, &SubscribeValidatorChangesArgs{
+		SubnetID: &subnetID,
+		Cursor:   &cursor,
+		Epoch:    &epoch,
+	})
+	require.Empty(response.Error)
+	require.Equal(&epoch, response.Epoch)
+
+	changes.OnValidatorWeightChanged(subnetID, nodeID0, 1, 2)
+	changes.OnValidatorAdded(constants.PrimaryNetworkID, nodeID1, nil, ids.GenerateTestID(), 1)
+	changes.OnValidatorRemoved(subnetID, nodeID0, 2)
+
+	expectedChanges := []struct {
+		cursor avajson.Uint64
+		typ    string
+	}{
+		{cursor: 2, typ: ValidatorAdded},
+		{cursor: 3, typ: ValidatorWeightChanged},
+		{cursor: 5, typ: ValidatorRemoved},
+	}
+	for _, expected := range expectedChanges {
+		var event SubscriptionEvent
+		require.NoError(conn.ReadJSON(&event))
+		require.Equal(expected.cursor, event.Cursor)
+		require.Equal(&epoch, event.Epoch)
+		require.NotNil(event.ValidatorChange)
+		require.Equal(expected.typ, event.ValidatorChange.Type)
+		require.Equal(subnetID, event.ValidatorChange.SubnetID)
+		require.Equal(nodeID0, event.ValidatorChange.NodeID)
+	}
+}
+
+func TestSubscribeValidatorChangesStaleCursor(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		changes = newValidatorChanges()
+		s       = newSubscriptionServer(logging.NoLog{}, changes)
+		conn    = newTestSubscriptionConn(t, s)
+		cursor  = avajson.Uint64(0)
+	)
+
+	response := subscribe(t, conn, 1, SubscribeValidatorChangesMethod, &SubscribeValidatorChangesArgs{
+		Cursor: &cursor,
+	})
+	require.Contains(response.Error, errMissingEpoch.Error())
+
+	// A cursor from a previous run of the node has a different epoch.
+	staleEpoch := avajson.Uint64(changes.epoch - 1)
+	response = subscribe(t, conn, 2, SubscribeValidatorChangesMethod, &SubscribeValidatorChangesArgs{
+		Cursor: &cursor,
+		Epoch:  &staleEpoch,
+	})
+	require.Contains(response.Error, errCursorStale.Error())
+}
+
+func TestValidatorChangesPruning(t *testing.T) {
+	require := require.New(t)
+
+	changes := newValidatorChanges()
+	for i := 0; i < maxValidatorChanges+1; i++ {
+		changes.OnValidatorAdded(constants.PrimaryNetworkID, ids.GenerateTestNodeID(), nil, ids.Empty, 1)
+	}
+	require.Equal(uint64(maxValidatorChanges+1), changes.next())
+
+	_, _, err := changes.getChangesFrom(0, 1)
+	require.ErrorIs(err, errCursorPruned)
+
+	_, _, err = changes.getChangesFrom(maxValidatorChanges+2, 1)
+	require.ErrorIs(err, errCursorUnknown)
+
+	got, _, err := changes.getChangesFrom(1, 2)
+	require.NoError(err)
+	require.Len(got, 2)
+
+	got, _, err = changes.getChangesFrom(maxValidatorChanges+1, 1)
+	require.NoError(err)
+	require.Empty(got)
+}
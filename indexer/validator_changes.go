@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	ValidatorAdded         = "added"
+	ValidatorRemoved       = "removed"
+	ValidatorWeightChanged = "weightChanged"
+
+	// Maximum number of validator changes that are kept in memory
+	maxValidatorChanges = 8192
+)
+
+var (
+	_ validators.ManagerCallbackListener = (*validatorChanges)(nil)
+
+	errCursorPruned  = errors.New("cursor has been pruned")
+	errCursorUnknown = errors.New("cursor is unknown")
+	errCursorStale   = errors.New("cursor is from a previous run of the node")
+)
+
+// ValidatorChange is a change to the validator set of a subnet.
+type ValidatorChange struct {
+	Type           string      `json:"type"`
+	SubnetID       ids.ID      `json:"subnetID"`
+	NodeID         ids.NodeID  `json:"nodeID"`
+	TxID           ids.ID      `json:"txID"`
+	Weight         json.Uint64 `json:"weight"`
+	PreviousWeight json.Uint64 `json:"previousWeight"`
+	Timestamp      time.Time   `json:"timestamp"`
+}
+
+// validatorChanges keeps the most recent changes to the validator sets tracked
+// by this node. Each change is numbered in the order it happened, starting
+// from the validators that existed when the listener was registered.
+//
+// Changes are only kept in memory, so numbers are reset when the node
+// restarts. Cursors are therefore only valid for the epoch they were created
+// in, which is unique to every run of the node.
+type validatorChanges struct {
+	clock mockable.Clock
+	epoch uint64
+
+	lock sync.RWMutex
+	// The number of the first change in [changes]
+	firstChange uint64
+	changes     []ValidatorChange
+	// Closed and replaced every time a change is added
+	changed chan struct{}
+}
+
+func newValidatorChanges() *validatorChanges {
+	v := &validatorChanges{
+		changed: make(chan struct{}),
+	}
+	v.epoch = uint64(v.clock.Time().UnixNano())
+	return v
+}
+
+func (v *validatorChanges) OnValidatorAdded(subnetID ids.ID, nodeID ids.NodeID, _ *bls.PublicKey, txID ids.ID, weight uint64) {
+	v.add(ValidatorChange{
+		Type:     ValidatorAdded,
+		SubnetID: subnetID,
+		NodeID:   nodeID,
+		TxID:     txID,
+		Weight:   json.Uint64(weight),
+	})
+}
+
+func (v *validatorChanges) OnValidatorRemoved(subnetID ids.ID, nodeID ids.NodeID, weight uint64) {
+	v.add(ValidatorChange{
+		Type:           ValidatorRemoved,
+		SubnetID:       subnetID,
+		NodeID:         nodeID,
+		PreviousWeight: json.Uint64(weight),
+	})
+}
+
+func (v *validatorChanges) OnValidatorWeightChanged(subnetID ids.ID, nodeID ids.NodeID, oldWeight, newWeight uint64) {
+	v.add(ValidatorChange{
+		Type:           ValidatorWeightChanged,
+		SubnetID:       subnetID,
+		NodeID:         nodeID,
+		Weight:         json.Uint64(newWeight),
+		PreviousWeight: json.Uint64(oldWeight),
+	})
+}
+
+func (v *validatorChanges) add(change ValidatorChange) {
+	change.Timestamp = v.clock.Time()
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if len(v.changes) == maxValidatorChanges {
+		v.changes[0] = ValidatorChange{} // Allow the change to be GCed
+		v.changes = v.changes[1:]
+		v.firstChange++
+	}
+	v.changes = append(v.changes, change)
+
+	close(v.changed)
+	v.changed = make(chan struct{})
+}
+
+// next returns the number of the next change.
+func (v *validatorChanges) next() uint64 {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	return v.firstChange + uint64(len(v.changes))
+}
+
+// verifyEpoch returns an error if a cursor created in [epoch] can't be used.
+func (v *validatorChanges) verifyEpoch(epoch uint64) error {
+	if epoch != v.epoch {
+		return fmt.Errorf("%w: epoch %d != %d", errCursorStale, epoch, v.epoch)
+	}
+	return nil
+}
+
+// getChangesFrom returns up to [numToFetch] changes starting at change number
+// [start]. The returned channel is closed once a change is added after the
+// returned changes.
+func (v *validatorChanges) getChangesFrom(start, numToFetch uint64) ([]ValidatorChange, <-chan struct{}, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	next := v.firstChange + uint64(len(v.changes))
+	switch {
+	case start < v.firstChange:
+		return nil, nil, fmt.Errorf("%w: %d < %d", errCursorPruned, start, v.firstChange)
+	case start > next:
+		return nil, nil, fmt.Errorf("%w: %d > %d", errCursorUnknown, start, next)
+	}
+
+	offset := start - v.firstChange
+	end := min(offset+numToFetch, uint64(len(v.changes)))
+	changes := make([]ValidatorChange, end-offset)
+	copy(changes, v.changes[offset:end])
+	return changes, v.changed, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+// Lane is the priority class of an outbound message. Messages in lower lanes
+// are sent before messages in higher lanes.
+type Lane uint8
+
+const (
+	HandshakeLane Lane = iota
+	ConsensusLane
+	AppLane
+	GossipLane
+
+	NumLanes = int(GossipLane) + 1
+)
+
+func (l Lane) String() string {
+	switch l {
+	case HandshakeLane:
+		return "handshake"
+	case ConsensusLane:
+		return "consensus"
+	case AppLane:
+		return "app"
+	case GossipLane:
+		return "gossip"
+	default:
+		return "unknown"
+	}
+}
+
+// GetLane returns the lane that messages with [op] are sent in.
+func GetLane(op Op) Lane {
+	switch op {
+	case PingOp, PongOp, HandshakeOp, GetPeerListOp, PeerListOp:
+		return HandshakeLane
+	case AppRequestOp, AppResponseOp, AppErrorOp:
+		return AppLane
+	case AppGossipOp:
+		return GossipLane
+	default:
+		return ConsensusLane
+	}
+}
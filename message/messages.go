@@ -105,6 +105,9 @@ type OutboundMessage interface {
 	BypassThrottling() bool
 	// Op returns the op that describes this message type
 	Op() Op
+	// ChainID returns the chain this message is destined for, or [ids.Empty]
+	// if this message isn't destined for a chain
+	ChainID() ids.ID
 	// Bytes returns the bytes that will be sent
 	Bytes() []byte
 	// BytesSavedCompression returns the number of bytes that this message saved
@@ -115,6 +118,7 @@ type OutboundMessage interface {
 type outboundMessage struct {
 	bypassThrottling      bool
 	op                    Op
+	chainID               ids.ID
 	bytes                 []byte
 	bytesSavedCompression int
 }
@@ -127,6 +131,10 @@ func (m *outboundMessage) Op() Op {
 	return m.op
 }
 
+func (m *outboundMessage) ChainID() ids.ID {
+	return m.chainID
+}
+
 func (m *outboundMessage) Bytes() []byte {
 	return m.bytes
 }
@@ -300,9 +308,16 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression
 		return nil, err
 	}
 
+	// Network messages, such as pings, aren't destined for a chain.
+	var chainID ids.ID
+	if msg, err := Unwrap(m); err == nil {
+		chainID, _ = GetChainID(msg)
+	}
+
 	return &outboundMessage{
 		bypassThrottling:      bypassThrottling,
 		op:                    op,
+		chainID:               chainID,
 		bytes:                 b,
 		bytesSavedCompression: saved,
 	}, nil
@@ -12,6 +12,7 @@ package message
 import (
 	reflect "reflect"
 
+	ids "github.com/ava-labs/avalanchego/ids"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -80,6 +81,20 @@ func (mr *MockOutboundMessageMockRecorder) BytesSavedCompression() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesSavedCompression", reflect.TypeOf((*MockOutboundMessage)(nil).BytesSavedCompression))
 }
 
+// ChainID mocks base method.
+func (m *MockOutboundMessage) ChainID() ids.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainID")
+	ret0, _ := ret[0].(ids.ID)
+	return ret0
+}
+
+// ChainID indicates an expected call of ChainID.
+func (mr *MockOutboundMessageMockRecorder) ChainID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockOutboundMessage)(nil).ChainID))
+}
+
 // Op mocks base method.
 func (m *MockOutboundMessage) Op() Op {
 	m.ctrl.T.Helper()
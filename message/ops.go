@@ -174,6 +174,17 @@ var (
 		CrossChainAppResponseOp,
 	}
 
+	RequestToResponseOps = map[Op]Op{
+		GetStateSummaryFrontierOp: StateSummaryFrontierOp,
+		GetAcceptedStateSummaryOp: AcceptedStateSummaryOp,
+		GetAcceptedFrontierOp:     AcceptedFrontierOp,
+		GetAcceptedOp:             AcceptedOp,
+		GetAncestorsOp:            AncestorsOp,
+		GetOp:                     PutOp,
+		PushQueryOp:               ChitsOp,
+		PullQueryOp:               ChitsOp,
+		AppRequestOp:              AppResponseOp,
+	}
 	FailedToResponseOps = map[Op]Op{
 		GetStateSummaryFrontierFailedOp: StateSummaryFrontierOp,
 		GetAcceptedStateSummaryFailedOp: AcceptedStateSummaryOp,
@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// List is a set of peers, identified by their nodeID, their IP, or the range
+// of IPs they are in.
+type List struct {
+	NodeIDs []ids.NodeID   `json:"nodeIDs"`
+	IPs     []netip.Addr   `json:"ips"`
+	CIDRs   []netip.Prefix `json:"cidrs"`
+}
+
+// Config is the persisted content of an ACL.
+type Config struct {
+	Allow List `json:"allow"`
+	Deny  List `json:"deny"`
+}
+
+// ACL decides which peers this node is allowed to connect to.
+//
+// A peer is denied if its nodeID or its IP matches an entry of the deny list.
+// Otherwise, if the allow list contains any nodeIDs, the peer must have one of
+// them. Similarly, if the allow list contains any IPs or CIDRs, the IP of the
+// peer must match one of them.
+//
+// A nil ACL allows every peer.
+//
+// ACL is safe for concurrent use.
+type ACL struct {
+	path string
+
+	lock      sync.RWMutex
+	config    Config
+	allow     matcher
+	deny      matcher
+	listeners []func()
+}
+
+// New returns an ACL that is persisted to [path]. If [path] exists, the ACL is
+// initialized from it. If [path] is empty, the ACL is only kept in memory.
+func New(path string) (*ACL, error) {
+	a := &ACL{
+		path: path,
+	}
+	if len(path) == 0 {
+		return a, nil
+	}
+
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(bytes, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	a.set(config)
+	return a, nil
+}
+
+// Config returns the current allow and deny lists.
+func (a *ACL) Config() Config {
+	if a == nil {
+		return Config{}
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.config
+}
+
+// Set replaces the allow and deny lists, persists them, and then notifies the
+// registered listeners.
+func (a *ACL) Set(config Config) error {
+	a.lock.Lock()
+	if len(a.path) != 0 {
+		bytes, err := json.MarshalIndent(config, "", "\t")
+		if err != nil {
+			a.lock.Unlock()
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(a.path), perms.ReadWriteExecute); err != nil {
+			a.lock.Unlock()
+			return err
+		}
+		if err := perms.WriteFile(a.path, bytes, perms.ReadWrite); err != nil {
+			a.lock.Unlock()
+			return err
+		}
+	}
+	a.set(config)
+	listeners := a.listeners
+	a.lock.Unlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+	return nil
+}
+
+// RegisterListener registers [listener] to be called after the ACL is
+// changed.
+func (a *ACL) RegisterListener(listener func()) {
+	if a == nil {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.listeners = append(a.listeners, listener)
+}
+
+// AllowNodeID returns true if connections to [nodeID] are permitted by the
+// nodeID entries of the ACL.
+func (a *ACL) AllowNodeID(nodeID ids.NodeID) bool {
+	if a == nil {
+		return true
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.allowNodeID(nodeID)
+}
+
+// AllowIP returns true if connections to [ip] are permitted by the IP and CIDR
+// entries of the ACL. An invalid [ip] is only permitted if the allow list
+// doesn't contain any IPs or CIDRs.
+func (a *ACL) AllowIP(ip netip.Addr) bool {
+	if a == nil {
+		return true
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.allowIP(ip)
+}
+
+// Allow returns true if connections to [nodeID] at [ip] are permitted.
+func (a *ACL) Allow(nodeID ids.NodeID, ip netip.Addr) bool {
+	if a == nil {
+		return true
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.allowNodeID(nodeID) && a.allowIP(ip)
+}
+
+func (a *ACL) allowNodeID(nodeID ids.NodeID) bool {
+	if a.deny.nodeIDs.Contains(nodeID) {
+		return false
+	}
+	return a.allow.nodeIDs.Len() == 0 || a.allow.nodeIDs.Contains(nodeID)
+}
+
+func (a *ACL) allowIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if a.deny.containsIP(ip) {
+		return false
+	}
+	return !a.allow.hasIPs() || a.allow.containsIP(ip)
+}
+
+// set assumes the write lock is held or that [a] isn't shared yet.
+func (a *ACL) set(config Config) {
+	a.config = config
+	a.allow = newMatcher(config.Allow)
+	a.deny = newMatcher(config.Deny)
+}
+
+type matcher struct {
+	nodeIDs set.Set[ids.NodeID]
+	ips     set.Set[netip.Addr]
+	cidrs   []netip.Prefix
+}
+
+func newMatcher(list List) matcher {
+	m := matcher{
+		nodeIDs: set.Of(list.NodeIDs...),
+		ips:     set.NewSet[netip.Addr](len(list.IPs)),
+		cidrs:   make([]netip.Prefix, len(list.CIDRs)),
+	}
+	for _, ip := range list.IPs {
+		m.ips.Add(ip.Unmap())
+	}
+	for i, cidr := range list.CIDRs {
+		m.cidrs[i] = cidr.Masked()
+	}
+	return m
+}
+
+func (m *matcher) hasIPs() bool {
+	return m.ips.Len() != 0 || len(m.cidrs) != 0
+}
+
+func (m *matcher) containsIP(ip netip.Addr) bool {
+	if !ip.IsValid() {
+		return false
+	}
+	if m.ips.Contains(ip) {
+		return true
+	}
+	for _, cidr := range m.cidrs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
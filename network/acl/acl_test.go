@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acl
+
+import (
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestACLAllow(t *testing.T) {
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		ip0     = netip.MustParseAddr("1.2.3.4")
+		ip1     = netip.MustParseAddr("1.2.3.5")
+		ip2     = netip.MustParseAddr("10.0.0.1")
+		ip6     = netip.MustParseAddr("2001:db8::1")
+	)
+	tests := []struct {
+		name    string
+		config  Config
+		nodeID  ids.NodeID
+		ip      netip.Addr
+		allowed bool
+	}{
+		{
+			name:    "empty",
+			nodeID:  nodeID0,
+			ip:      ip0,
+			allowed: true,
+		},
+		{
+			name: "denied nodeID",
+			config: Config{
+				Deny: List{NodeIDs: []ids.NodeID{nodeID0}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip0,
+			allowed: false,
+		},
+		{
+			name: "denied IP",
+			config: Config{
+				Deny: List{IPs: []netip.Addr{ip0}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip0,
+			allowed: false,
+		},
+		{
+			name: "denied IPv4-mapped IP",
+			config: Config{
+				Deny: List{IPs: []netip.Addr{ip0}},
+			},
+			nodeID:  nodeID0,
+			ip:      netip.AddrFrom16(ip0.As16()),
+			allowed: false,
+		},
+		{
+			name: "denied CIDR",
+			config: Config{
+				Deny: List{CIDRs: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip2,
+			allowed: false,
+		},
+		{
+			name: "denied IPv6 CIDR",
+			config: Config{
+				Deny: List{CIDRs: []netip.Prefix{netip.MustParsePrefix("2001:db8::/32")}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip6,
+			allowed: false,
+		},
+		{
+			name: "not denied",
+			config: Config{
+				Deny: List{
+					NodeIDs: []ids.NodeID{nodeID1},
+					IPs:     []netip.Addr{ip1},
+					CIDRs:   []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+				},
+			},
+			nodeID:  nodeID0,
+			ip:      ip0,
+			allowed: true,
+		},
+		{
+			name: "allowed nodeID",
+			config: Config{
+				Allow: List{NodeIDs: []ids.NodeID{nodeID0}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip0,
+			allowed: true,
+		},
+		{
+			name: "not allowed nodeID",
+			config: Config{
+				Allow: List{NodeIDs: []ids.NodeID{nodeID1}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip0,
+			allowed: false,
+		},
+		{
+			name: "allowed CIDR",
+			config: Config{
+				Allow: List{CIDRs: []netip.Prefix{netip.MustParsePrefix("1.2.3.0/24")}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip1,
+			allowed: true,
+		},
+		{
+			name: "not allowed IP",
+			config: Config{
+				Allow: List{IPs: []netip.Addr{ip0}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip1,
+			allowed: false,
+		},
+		{
+			name: "invalid IP with allowed IPs",
+			config: Config{
+				Allow: List{IPs: []netip.Addr{ip0}},
+			},
+			nodeID:  nodeID0,
+			allowed: false,
+		},
+		{
+			name: "invalid IP with denied IPs",
+			config: Config{
+				Deny: List{IPs: []netip.Addr{ip0}},
+			},
+			nodeID:  nodeID0,
+			allowed: true,
+		},
+		{
+			name: "deny overrides allow",
+			config: Config{
+				Allow: List{NodeIDs: []ids.NodeID{nodeID0}},
+				Deny:  List{IPs: []netip.Addr{ip0}},
+			},
+			nodeID:  nodeID0,
+			ip:      ip0,
+			allowed: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			a, err := New("")
+			require.NoError(err)
+			require.NoError(a.Set(test.config))
+			require.Equal(test.allowed, a.Allow(test.nodeID, test.ip))
+		})
+	}
+}
+
+func TestNilACL(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		a      *ACL
+		nodeID = ids.GenerateTestNodeID()
+		ip     = netip.MustParseAddr("1.2.3.4")
+	)
+	require.True(a.AllowNodeID(nodeID))
+	require.True(a.AllowIP(ip))
+	require.True(a.Allow(nodeID, ip))
+	require.Equal(Config{}, a.Config())
+	a.RegisterListener(func() {})
+}
+
+func TestACLPersistence(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "configs", "peer-acl.json")
+	a, err := New(path)
+	require.NoError(err)
+	require.Equal(Config{}, a.Config())
+
+	var numUpdates int
+	a.RegisterListener(func() {
+		numUpdates++
+	})
+
+	config := Config{
+		Allow: List{
+			IPs: []netip.Addr{netip.MustParseAddr("1.2.3.4")},
+		},
+		Deny: List{
+			NodeIDs: []ids.NodeID{ids.GenerateTestNodeID()},
+			CIDRs:   []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+		},
+	}
+	require.NoError(a.Set(config))
+	require.Equal(1, numUpdates)
+	require.Equal(config, a.Config())
+
+	a, err = New(path)
+	require.NoError(err)
+	require.Equal(config, a.Config())
+	require.False(a.AllowNodeID(config.Deny.NodeIDs[0]))
+	require.True(a.AllowIP(config.Allow.IPs[0]))
+	require.False(a.AllowIP(netip.MustParseAddr("10.1.2.3")))
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+const (
+	// FileName is the name of the file that messages are captured to. Rotated
+	// files are named after it, with the time of the rotation.
+	FileName = "capture.bin"
+
+	// direction + op + nodeID + chainID + timestamp + length
+	frameHeaderLen = 1 + 1 + ids.NodeIDLen + ids.IDLen + 8 + 4
+)
+
+const (
+	Inbound Direction = iota
+	Outbound
+)
+
+var (
+	errUnknownDirection = errors.New("unknown direction")
+	errFrameTooLarge    = errors.New("frame too large")
+)
+
+// Direction is whether a captured message was received from or sent to a
+// peer.
+type Direction byte
+
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "inbound"
+	case Outbound:
+		return "outbound"
+	default:
+		return "unknown"
+	}
+}
+
+// Frame is a single message that was received from or sent to a peer.
+type Frame struct {
+	Direction Direction
+	Op        message.Op
+	// NodeID is the peer the message was received from or sent to
+	NodeID ids.NodeID
+	// ChainID is the chain the message is destined for, or [ids.Empty] if the
+	// message isn't destined for a chain
+	ChainID ids.ID
+	Time    time.Time
+	// Bytes is the message, exactly as it was sent over the wire
+	Bytes []byte
+}
+
+func (f *Frame) marshal() []byte {
+	bytes := make([]byte, frameHeaderLen+len(f.Bytes))
+	bytes[0] = byte(f.Direction)
+	bytes[1] = byte(f.Op)
+	offset := 2
+	offset += copy(bytes[offset:], f.NodeID[:])
+	offset += copy(bytes[offset:], f.ChainID[:])
+	binary.BigEndian.PutUint64(bytes[offset:], uint64(f.Time.UnixNano()))
+	offset += 8
+	binary.BigEndian.PutUint32(bytes[offset:], uint32(len(f.Bytes)))
+	offset += 4
+	copy(bytes[offset:], f.Bytes)
+	return bytes
+}
+
+// Reader reads the frames of a capture file.
+type Reader struct {
+	reader *bufio.Reader
+	header [frameHeaderLen]byte
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		reader: bufio.NewReader(r),
+	}
+}
+
+// Read returns the next frame. [io.EOF] is returned once all the frames were
+// read. [io.ErrUnexpectedEOF] is returned if the last frame was only partially
+// written, which happens if the node was killed while capturing.
+func (r *Reader) Read() (*Frame, error) {
+	if _, err := io.ReadFull(r.reader, r.header[:]); err != nil {
+		return nil, err
+	}
+
+	f := &Frame{
+		Direction: Direction(r.header[0]),
+		Op:        message.Op(r.header[1]),
+	}
+	if f.Direction != Inbound && f.Direction != Outbound {
+		return nil, fmt.Errorf("%w: %d", errUnknownDirection, f.Direction)
+	}
+	offset := 2
+	offset += copy(f.NodeID[:], r.header[offset:])
+	offset += copy(f.ChainID[:], r.header[offset:])
+	f.Time = time.Unix(0, int64(binary.BigEndian.Uint64(r.header[offset:])))
+	offset += 8
+	length := binary.BigEndian.Uint32(r.header[offset:])
+	if length > constants.DefaultMaxMessageSize {
+		return nil, fmt.Errorf("%w: %d > %d", errFrameTooLarge, length, constants.DefaultMaxMessageSize)
+	}
+
+	f.Bytes = make([]byte, length)
+	if _, err := io.ReadFull(r.reader, f.Bytes); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// Files returns the capture files in [dir], from oldest to newest.
+func Files(dir string) ([]string, error) {
+	ext := filepath.Ext(FileName)
+	name := strings.TrimSuffix(FileName, ext)
+
+	// Rotated files are named after the time they were rotated, so they sort
+	// from oldest to newest. The current file is the newest.
+	files, err := filepath.Glob(filepath.Join(dir, name+"-*"+ext))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	currentFile := filepath.Join(dir, FileName)
+	if _, err := os.Stat(currentFile); err == nil {
+		files = append(files, currentFile)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,301 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newCreator(t *testing.T) message.Creator {
+	creator, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		compression.TypeZstd,
+		10*time.Second,
+	)
+	require.NoError(t, err)
+	return creator
+}
+
+// capture writes a capture in [dir] containing an inbound ping, an inbound
+// push query, an outbound chits and an inbound app gossip.
+func capture(t *testing.T, dir string, creator message.Creator, nodeID ids.NodeID, chainID ids.ID) {
+	require := require.New(t)
+
+	w := NewWriter(logging.NoLog{}, Config{
+		Enabled:   true,
+		Directory: dir,
+		MaxSize:   1,
+		MaxFiles:  1,
+	})
+	w.clock.Set(time.Unix(1, 0))
+
+	inbound := func(outMsg message.OutboundMessage, err error) {
+		require.NoError(err)
+		msg, err := creator.Parse(outMsg.Bytes(), nodeID, func() {})
+		require.NoError(err)
+		w.Inbound(nodeID, msg, outMsg.Bytes())
+	}
+	inbound(creator.Ping(100, nil))
+	inbound(creator.PushQuery(chainID, 1, time.Second, []byte("block"), 2))
+
+	chits, err := creator.Chits(chainID, 1, ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID())
+	require.NoError(err)
+	w.Outbound(nodeID, chits)
+
+	inbound(creator.AppGossip(chainID, []byte("gossip")))
+	require.NoError(w.Close())
+}
+
+func TestWriterReader(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir     = t.TempDir()
+		creator = newCreator(t)
+		nodeID  = ids.GenerateTestNodeID()
+		chainID = ids.GenerateTestID()
+	)
+	capture(t, dir, creator, nodeID, chainID)
+
+	files, err := Files(dir)
+	require.NoError(err)
+	require.Equal([]string{filepath.Join(dir, FileName)}, files)
+
+	captureBytes, err := os.ReadFile(files[0])
+	require.NoError(err)
+
+	r := NewReader(bytes.NewReader(captureBytes))
+	expected := []struct {
+		direction Direction
+		op        message.Op
+		chainID   ids.ID
+	}{
+		{Inbound, message.PingOp, ids.Empty},
+		{Inbound, message.PushQueryOp, chainID},
+		{Outbound, message.ChitsOp, chainID},
+		{Inbound, message.AppGossipOp, chainID},
+	}
+	var lastFrameLen int
+	for _, e := range expected {
+		frame, err := r.Read()
+		require.NoError(err)
+		require.Equal(e.direction, frame.Direction)
+		require.Equal(e.op, frame.Op)
+		require.Equal(e.chainID, frame.ChainID)
+		require.Equal(nodeID, frame.NodeID)
+		require.Equal(time.Unix(1, 0), frame.Time)
+
+		// The captured bytes can be parsed back into the original message
+		msg, err := creator.Parse(frame.Bytes, frame.NodeID, func() {})
+		require.NoError(err)
+		require.Equal(e.op, msg.Op())
+		lastFrameLen = frameHeaderLen + len(frame.Bytes)
+	}
+	_, err = r.Read()
+	require.ErrorIs(err, io.EOF)
+
+	// A partially written frame is reported as unexpected
+	truncated := captureBytes[:len(captureBytes)-lastFrameLen/2]
+	r = NewReader(bytes.NewReader(truncated))
+	for range expected[:len(expected)-1] {
+		_, err := r.Read()
+		require.NoError(err)
+	}
+	_, err = r.Read()
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
+func TestFiles(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	names := []string{
+		FileName,
+		"capture-2024-06-02T00-00-00.000.bin",
+		"capture-2024-06-01T00-00-00.000.bin",
+		"other.bin",
+	}
+	for _, name := range names {
+		require.NoError(os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	files, err := Files(dir)
+	require.NoError(err)
+	require.Equal([]string{
+		filepath.Join(dir, names[2]),
+		filepath.Join(dir, names[1]),
+		filepath.Join(dir, names[0]),
+	}, files)
+
+	files, err = Files(t.TempDir())
+	require.NoError(err)
+	require.Empty(files)
+}
+
+// testRouter handles every message with [handleInbound] and registers every
+// request with [registerRequest].
+type testRouter struct {
+	router.Router
+	handleInbound   func(context.Context, message.InboundMessage)
+	registerRequest func(nodeID ids.NodeID, requestID uint32, op message.Op)
+}
+
+func (r *testRouter) HandleInbound(ctx context.Context, msg message.InboundMessage) {
+	r.handleInbound(ctx, msg)
+}
+
+func (r *testRouter) RegisterRequest(
+	_ context.Context,
+	nodeID ids.NodeID,
+	_ ids.ID,
+	_ ids.ID,
+	requestID uint32,
+	op message.Op,
+	_ message.InboundMessage,
+	_ p2p.EngineType,
+) {
+	if r.registerRequest != nil {
+		r.registerRequest(nodeID, requestID, op)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir     = t.TempDir()
+		creator = newCreator(t)
+		nodeID  = ids.GenerateTestNodeID()
+		chainID = ids.GenerateTestID()
+	)
+	capture(t, dir, creator, nodeID, chainID)
+
+	f, err := os.Open(filepath.Join(dir, FileName))
+	require.NoError(err)
+	defer f.Close()
+
+	var ops []message.Op
+	replayer := NewReplayer(&testRouter{
+		handleInbound: func(_ context.Context, msg message.InboundMessage) {
+			require.Equal(nodeID, msg.NodeID())
+			ops = append(ops, msg.Op())
+
+			// Messages may finish being handled asynchronously
+			go msg.OnFinishedHandling()
+		},
+	}, creator)
+
+	numReplayed, err := replayer.Replay(context.Background(), NewReader(f))
+	require.NoError(err)
+	require.Equal(2, numReplayed)
+	require.Equal([]message.Op{message.PushQueryOp, message.AppGossipOp}, ops)
+}
+
+func TestReplayRemapsRequestIDs(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir     = t.TempDir()
+		creator = newCreator(t)
+		nodeID  = ids.GenerateTestNodeID()
+		chainID = ids.GenerateTestID()
+		w       = NewWriter(logging.NoLog{}, Config{
+			Directory: dir,
+			MaxSize:   1,
+		})
+	)
+
+	// The captured node sent two queries with request IDs 10 and 11 and
+	// received the responses in reverse order. It also received a response
+	// to a request that it never sent.
+	for _, requestID := range []uint32{10, 11} {
+		query, err := creator.PullQuery(chainID, requestID, time.Second, ids.GenerateTestID(), 1)
+		require.NoError(err)
+		w.Outbound(nodeID, query)
+	}
+	for _, requestID := range []uint32{11, 10, 12} {
+		chits, err := creator.Chits(chainID, requestID, ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID())
+		require.NoError(err)
+		msg, err := creator.Parse(chits.Bytes(), nodeID, func() {})
+		require.NoError(err)
+		w.Inbound(nodeID, msg, chits.Bytes())
+	}
+	require.NoError(w.Close())
+
+	f, err := os.Open(filepath.Join(dir, FileName))
+	require.NoError(err)
+	defer f.Close()
+
+	var requestIDs []uint32
+	replayer := NewReplayer(&testRouter{
+		handleInbound: func(_ context.Context, msg message.InboundMessage) {
+			requestID, ok := message.GetRequestID(msg.Message())
+			require.True(ok)
+			requestIDs = append(requestIDs, requestID)
+			msg.OnFinishedHandling()
+		},
+	}, creator)
+
+	// The replaying node sends the same queries with different request IDs.
+	for _, requestID := range []uint32{1, 2} {
+		replayer.RegisterRequest(
+			context.Background(),
+			nodeID,
+			chainID,
+			chainID,
+			requestID,
+			message.ChitsOp,
+			nil,
+			p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		)
+	}
+
+	numReplayed, err := replayer.Replay(context.Background(), NewReader(f))
+	require.NoError(err)
+	require.Equal(2, numReplayed)
+	require.Equal([]uint32{2, 1}, requestIDs)
+}
+
+func TestReplayCancelled(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir     = t.TempDir()
+		creator = newCreator(t)
+	)
+	capture(t, dir, creator, ids.GenerateTestNodeID(), ids.GenerateTestID())
+
+	f, err := os.Open(filepath.Join(dir, FileName))
+	require.NoError(err)
+	defer f.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	replayer := NewReplayer(&testRouter{
+		handleInbound: func(context.Context, message.InboundMessage) {
+			// The message is never finished being handled
+			cancel()
+		},
+	}, creator)
+
+	numReplayed, err := replayer.Replay(ctx, NewReader(f))
+	require.ErrorIs(err, context.Canceled)
+	require.Zero(numReplayed)
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+)
+
+var _ router.Router = (*Replayer)(nil)
+
+// requestKey identifies the requests to a peer for a chain that are answered
+// with the same response op.
+type requestKey struct {
+	nodeID  ids.NodeID
+	chainID ids.ID
+	op      message.Op
+}
+
+type capturedRequest struct {
+	requestKey
+	requestID uint32
+}
+
+// Replayer replays captured messages through a router, such as a
+// [router.ChainRouter].
+//
+// Responses are only handled by a [router.ChainRouter] if a matching request
+// is outstanding, but the replaying node issues its own request IDs. To
+// remap the request IDs of the captured responses, the Replayer must be used
+// as the router of the chains that the messages are replayed to, so that it
+// is notified of the requests they register. The nth request that was
+// captured to be sent to a peer for a chain is matched with the nth request
+// that the replaying node registers to the same peer for the same chain.
+// Responses to captured requests that weren't matched are dropped.
+type Replayer struct {
+	router.Router
+	parser message.InboundMsgBuilder
+
+	lock sync.Mutex
+	// Request IDs that were captured but not registered yet
+	capturedRequestIDs map[requestKey][]uint32
+	// Request IDs that were registered but not captured yet
+	registeredRequestIDs map[requestKey][]uint32
+	// Captured request -> registered request ID
+	requestIDs map[capturedRequest]uint32
+}
+
+// NewReplayer returns a Replayer that replays messages through
+// [chainRouter]. [parser] is used to parse the captured messages.
+func NewReplayer(chainRouter router.Router, parser message.InboundMsgBuilder) *Replayer {
+	return &Replayer{
+		Router:               chainRouter,
+		parser:               parser,
+		capturedRequestIDs:   make(map[requestKey][]uint32),
+		registeredRequestIDs: make(map[requestKey][]uint32),
+		requestIDs:           make(map[capturedRequest]uint32),
+	}
+}
+
+func (r *Replayer) RegisterRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestingChainID ids.ID,
+	respondingChainID ids.ID,
+	requestID uint32,
+	op message.Op,
+	failedMsg message.InboundMessage,
+	engineType p2p.EngineType,
+) {
+	r.Router.RegisterRequest(
+		ctx,
+		nodeID,
+		requestingChainID,
+		respondingChainID,
+		requestID,
+		op,
+		failedMsg,
+		engineType,
+	)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := requestKey{
+		nodeID:  nodeID,
+		chainID: respondingChainID,
+		op:      op,
+	}
+	r.registeredRequestIDs[key] = append(r.registeredRequestIDs[key], requestID)
+	r.match(key)
+}
+
+// Replay feeds the inbound messages of [reader] to the router, in the order
+// they were captured. Outbound requests are only used to remap the request
+// IDs of their responses. Other outbound messages and messages that are
+// handled by the network layer, such as handshakes, are skipped.
+//
+// Every message is fully handled before the next message is replayed, so that
+// replaying the same capture against the same state is deterministic as long
+// as the replaying node only sends requests while handling messages.
+//
+// Returns the number of messages that were replayed.
+func (r *Replayer) Replay(ctx context.Context, reader *Reader) (int, error) {
+	var numReplayed int
+	for {
+		frame, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return numReplayed, nil
+		}
+		if err != nil {
+			return numReplayed, err
+		}
+		if slices.Contains(message.HandshakeOps, frame.Op) {
+			continue
+		}
+
+		handled := make(chan struct{})
+		msg, err := r.parser.Parse(frame.Bytes, frame.NodeID, sync.OnceFunc(func() {
+			close(handled)
+		}))
+		if err != nil {
+			return numReplayed, fmt.Errorf("failed to parse %s %s message from %s: %w", frame.Direction, frame.Op, frame.NodeID, err)
+		}
+
+		if frame.Direction == Outbound {
+			r.captureRequest(frame, msg)
+			continue
+		}
+		if !r.remapResponse(frame, msg) {
+			continue
+		}
+
+		r.Router.HandleInbound(ctx, msg)
+		select {
+		case <-handled:
+		case <-ctx.Done():
+			return numReplayed, ctx.Err()
+		}
+		numReplayed++
+	}
+}
+
+// captureRequest records the request ID of [msg] if it is a request.
+func (r *Replayer) captureRequest(frame *Frame, msg message.InboundMessage) {
+	responseOp, ok := message.RequestToResponseOps[frame.Op]
+	if !ok {
+		return
+	}
+	requestID, ok := message.GetRequestID(msg.Message())
+	if !ok {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := requestKey{
+		nodeID:  frame.NodeID,
+		chainID: frame.ChainID,
+		op:      responseOp,
+	}
+	r.capturedRequestIDs[key] = append(r.capturedRequestIDs[key], requestID)
+	r.match(key)
+}
+
+// remapResponse replaces the request ID of [msg] with the ID of the matching
+// registered request if it is a response. Returns false if [msg] is a response
+// to a request that wasn't matched, in which case it should be dropped.
+func (r *Replayer) remapResponse(frame *Frame, msg message.InboundMessage) bool {
+	if !slices.Contains(message.ConsensusResponseOps, frame.Op) {
+		return true
+	}
+	requestID, ok := message.GetRequestID(msg.Message())
+	if !ok {
+		return false
+	}
+
+	responseOp := frame.Op
+	if op, ok := message.FailedToResponseOps[responseOp]; ok {
+		responseOp = op
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	request := capturedRequest{
+		requestKey: requestKey{
+			nodeID:  frame.NodeID,
+			chainID: frame.ChainID,
+			op:      responseOp,
+		},
+		requestID: requestID,
+	}
+	registeredRequestID, ok := r.requestIDs[request]
+	if !ok {
+		return false
+	}
+	delete(r.requestIDs, request)
+	return setRequestID(msg.Message(), registeredRequestID)
+}
+
+// match pairs the captured and registered requests of [key] in the order they
+// were captured and registered.
+//
+// Assumes [r.lock] is held.
+func (r *Replayer) match(key requestKey) {
+	var (
+		captured   = r.capturedRequestIDs[key]
+		registered = r.registeredRequestIDs[key]
+		numMatched = min(len(captured), len(registered))
+	)
+	for i := 0; i < numMatched; i++ {
+		r.requestIDs[capturedRequest{
+			requestKey: key,
+			requestID:  captured[i],
+		}] = registered[i]
+	}
+
+	if len(captured) == numMatched {
+		delete(r.capturedRequestIDs, key)
+	} else {
+		r.capturedRequestIDs[key] = captured[numMatched:]
+	}
+	if len(registered) == numMatched {
+		delete(r.registeredRequestIDs, key)
+	} else {
+		r.registeredRequestIDs[key] = registered[numMatched:]
+	}
+}
+
+// setRequestID sets the request ID of the response [m]. Returns false if [m]
+// isn't a response.
+func setRequestID(m any, requestID uint32) bool {
+	switch msg := m.(type) {
+	case *p2p.StateSummaryFrontier:
+		msg.RequestId = requestID
+	case *p2p.AcceptedStateSummary:
+		msg.RequestId = requestID
+	case *p2p.AcceptedFrontier:
+		msg.RequestId = requestID
+	case *p2p.Accepted:
+		msg.RequestId = requestID
+	case *p2p.Ancestors:
+		msg.RequestId = requestID
+	case *p2p.Put:
+		msg.RequestId = requestID
+	case *p2p.Chits:
+		msg.RequestId = requestID
+	case *p2p.AppResponse:
+		msg.RequestId = requestID
+	case *p2p.AppError:
+		msg.RequestId = requestID
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"io"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var _ Recorder = (*Writer)(nil)
+
+type Config struct {
+	// Used to flag if peer messages should be captured
+	Enabled bool `json:"enabled"`
+
+	// Directory the capture files are written to
+	Directory string `json:"directory"`
+
+	// The maximum size in megabytes of a capture file before it is rotated
+	MaxSize int `json:"maxSize"`
+
+	// The maximum number of rotated capture files to retain
+	MaxFiles int `json:"maxFiles"`
+}
+
+// Recorder is notified of every message that is received from or sent to a
+// peer.
+type Recorder interface {
+	// Inbound is called with every message that was parsed from [msgBytes],
+	// which were received from [nodeID].
+	Inbound(nodeID ids.NodeID, msg message.InboundMessage, msgBytes []byte)
+
+	// Outbound is called with every message that was sent to [nodeID].
+	Outbound(nodeID ids.NodeID, msg message.OutboundMessage)
+}
+
+// Writer writes the captured messages to rotating files.
+type Writer struct {
+	log   logging.Logger
+	clock mockable.Clock
+
+	lock   sync.Mutex
+	writer io.WriteCloser
+}
+
+// NewWriter returns a Writer that captures messages to the directory in
+// [config].
+func NewWriter(log logging.Logger, config Config) *Writer {
+	return &Writer{
+		log: log,
+		writer: &lumberjack.Logger{
+			Filename:   filepath.Join(config.Directory, FileName),
+			MaxSize:    config.MaxSize,
+			MaxBackups: config.MaxFiles,
+		},
+	}
+}
+
+func (w *Writer) Inbound(nodeID ids.NodeID, msg message.InboundMessage, msgBytes []byte) {
+	// Network messages, such as pings, aren't destined for a chain.
+	chainID, _ := message.GetChainID(msg.Message())
+	w.write(&Frame{
+		Direction: Inbound,
+		Op:        msg.Op(),
+		NodeID:    nodeID,
+		ChainID:   chainID,
+		Bytes:     msgBytes,
+	})
+}
+
+func (w *Writer) Outbound(nodeID ids.NodeID, msg message.OutboundMessage) {
+	w.write(&Frame{
+		Direction: Outbound,
+		Op:        msg.Op(),
+		NodeID:    nodeID,
+		ChainID:   msg.ChainID(),
+		Bytes:     msg.Bytes(),
+	})
+}
+
+// write never returns an error so that a failure to capture a message doesn't
+// impact the peer.
+func (w *Writer) write(frame *Frame) {
+	frame.Time = w.clock.Time()
+	bytes := frame.marshal()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if _, err := w.writer.Write(bytes); err != nil {
+		w.log.Warn("failed to capture message",
+			zap.Stringer("direction", frame.Direction),
+			zap.Stringer("messageOp", frame.Op),
+			zap.Stringer("nodeID", frame.NodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (w *Writer) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.writer.Close()
+}
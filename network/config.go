@@ -10,7 +10,10 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/acl"
+	"github.com/ava-labs/avalanchego/network/capture"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/quic"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -92,7 +95,7 @@ type DelayConfig struct {
 type ThrottlerConfig struct {
 	InboundConnUpgradeThrottlerConfig throttling.InboundConnUpgradeThrottlerConfig `json:"inboundConnUpgradeThrottlerConfig"`
 	InboundMsgThrottlerConfig         throttling.InboundMsgThrottlerConfig         `json:"inboundMsgThrottlerConfig"`
-	OutboundMsgThrottlerConfig        throttling.MsgByteThrottlerConfig            `json:"outboundMsgThrottlerConfig"`
+	OutboundMsgThrottlerConfig        throttling.OutboundMsgThrottlerConfig        `json:"outboundMsgThrottlerConfig"`
 	MaxInboundConnsPerSec             float64                                      `json:"maxInboundConnsPerSec"`
 }
 
@@ -111,6 +114,25 @@ type Config struct {
 
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
+	// CaptureConfig configures recording every message sent to and received
+	// from peers, so that the messages can be replayed.
+	CaptureConfig capture.Config `json:"captureConfig"`
+
+	// PeerACLFile is the file that the peer allow and deny lists are
+	// persisted to.
+	PeerACLFile string `json:"peerACLFile"`
+	// PeerACL decides which peers may be connected to. If nil, all peers are
+	// allowed.
+	PeerACL *acl.ACL `json:"-"`
+
+	// QUICEnabled enables accepting and dialing QUIC connections on the UDP
+	// port that matches the staking port.
+	QUICEnabled bool `json:"quicEnabled"`
+	// QUICTransport accepts and dials QUIC connections. Peers that advertise
+	// QUIC support are dialed over QUIC, falling back to TCP if the QUIC
+	// connection fails. If nil, only TCP is used.
+	QUICTransport *quic.Transport `json:"-"`
+
 	MyNodeID           ids.NodeID                    `json:"myNodeID"`
 	MyIPPort           *utils.Atomic[netip.AddrPort] `json:"myIP"`
 	NetworkID          uint32                        `json:"networkID"`
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+const nodeIDIPSeparator = "@"
+
+var errMissingSeparator = fmt.Errorf("expected a nodeID and an IP separated by %q", nodeIDIPSeparator)
+
+// ParseBootstrapper parses a bootstrapper of the form nodeID@IP:port. For
+// example:
+//
+//	NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg@127.0.0.1:9651
+func ParseBootstrapper(s string) (genesis.Bootstrapper, error) {
+	nodeIDStr, ipStr, ok := strings.Cut(strings.TrimSpace(s), nodeIDIPSeparator)
+	if !ok {
+		return genesis.Bootstrapper{}, fmt.Errorf("%w: %q", errMissingSeparator, s)
+	}
+	nodeID, err := ids.NodeIDFromString(nodeIDStr)
+	if err != nil {
+		return genesis.Bootstrapper{}, fmt.Errorf("couldn't parse bootstrapper id %q: %w", nodeIDStr, err)
+	}
+	ip, err := ips.ParseAddrPort(ipStr)
+	if err != nil {
+		return genesis.Bootstrapper{}, fmt.Errorf("couldn't parse bootstrapper ip %q: %w", ipStr, err)
+	}
+	return genesis.Bootstrapper{
+		ID: nodeID,
+		IP: ip,
+	}, nil
+}
+
+// ReadBootstrappersFile reads the bootstrappers in [path]. The file uses the
+// same format as the default bootstrappers of a network:
+//
+//	[
+//		{
+//			"id": "NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg",
+//			"ip": "127.0.0.1:9651"
+//		}
+//	]
+//
+// A missing file contains no bootstrappers.
+func ReadBootstrappersFile(path string) ([]genesis.Bootstrapper, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var bootstrappers []genesis.Bootstrapper
+	if err := json.Unmarshal(bytes, &bootstrappers); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return bootstrappers, nil
+}
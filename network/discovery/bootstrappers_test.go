@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package discovery
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	testNodeID0 = ids.BuildTestNodeID([]byte{0})
+	testNodeID1 = ids.BuildTestNodeID([]byte{1})
+
+	testBootstrapper0 = genesis.Bootstrapper{
+		ID: testNodeID0,
+		IP: netip.MustParseAddrPort("127.0.0.1:9651"),
+	}
+	testBootstrapper1 = genesis.Bootstrapper{
+		ID: testNodeID1,
+		IP: netip.MustParseAddrPort("[::1]:9653"),
+	}
+)
+
+func TestParseBootstrapper(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    genesis.Bootstrapper
+		expectedErr error
+	}{
+		{
+			name:     "ipv4",
+			input:    testNodeID0.String() + "@127.0.0.1:9651",
+			expected: testBootstrapper0,
+		},
+		{
+			name:     "ipv6",
+			input:    " " + testNodeID1.String() + "@[::1]:9653 ",
+			expected: testBootstrapper1,
+		},
+		{
+			name:        "missing separator",
+			input:       testNodeID0.String(),
+			expectedErr: errMissingSeparator,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			bootstrapper, err := ParseBootstrapper(test.input)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, bootstrapper)
+		})
+	}
+}
+
+func TestReadBootstrappersFile(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "bootstrappers.json")
+	bootstrappers, err := ReadBootstrappersFile(path)
+	require.NoError(err)
+	require.Empty(bootstrappers)
+
+	writeBootstrappersFile(t, path, testBootstrapper0, testBootstrapper1)
+	bootstrappers, err = ReadBootstrappersFile(path)
+	require.NoError(err)
+	require.Equal([]genesis.Bootstrapper{testBootstrapper0, testBootstrapper1}, bootstrappers)
+}
+
+func writeBootstrappersFile(t *testing.T, path string, bootstrappers ...genesis.Bootstrapper) {
+	bytes := []byte("[")
+	for i, bootstrapper := range bootstrappers {
+		if i > 0 {
+			bytes = append(bytes, ',')
+		}
+		bytes = append(bytes, `{"id":"`+bootstrapper.ID.String()+`","ip":"`+bootstrapper.IP.String()+`"}`...)
+	}
+	bytes = append(bytes, ']')
+	require.NoError(t, os.WriteFile(path, bytes, 0o600))
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/genesis"
+)
+
+var _ Resolver = (*net.Resolver)(nil)
+
+// Resolver looks up the TXT records of DNS seeds.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NewResolver returns a Resolver that sends its queries to the DNS server at
+// [address]. If [address] is empty, the resolver of the system is used.
+func NewResolver(address string) Resolver {
+	if len(address) == 0 {
+		return net.DefaultResolver
+	}
+
+	dialer := net.Dialer{}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// LookupSeed returns the bootstrappers in the TXT records of [seed]. Every
+// record may contain multiple bootstrappers of the form nodeID@IP:port,
+// separated by whitespace or commas.
+func LookupSeed(ctx context.Context, resolver Resolver, seed string) ([]genesis.Bootstrapper, error) {
+	records, err := resolver.LookupTXT(ctx, seed)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't lookup DNS seed %s: %w", seed, err)
+	}
+
+	var bootstrappers []genesis.Bootstrapper
+	for _, record := range records {
+		entries := strings.FieldsFunc(record, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		for _, entry := range entries {
+			bootstrapper, err := ParseBootstrapper(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid record of DNS seed %s: %w", seed, err)
+			}
+			bootstrappers = append(bootstrappers, bootstrapper)
+		}
+	}
+	return bootstrappers, nil
+}
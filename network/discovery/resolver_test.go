@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/ava-labs/avalanchego/genesis"
+)
+
+var (
+	_ Resolver = testResolver(nil)
+
+	errUnknownSeed = errors.New("unknown seed")
+)
+
+// testResolver maps seeds to their TXT records.
+type testResolver map[string][]string
+
+func (r testResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, errUnknownSeed
+	}
+	return records, nil
+}
+
+func TestLookupSeed(t *testing.T) {
+	tests := []struct {
+		name        string
+		records     []string
+		expected    []genesis.Bootstrapper
+		expectedErr error
+	}{
+		{
+			name: "no records",
+		},
+		{
+			name: "one bootstrapper per record",
+			records: []string{
+				testNodeID0.String() + "@127.0.0.1:9651",
+				testNodeID1.String() + "@[::1]:9653",
+			},
+			expected: []genesis.Bootstrapper{testBootstrapper0, testBootstrapper1},
+		},
+		{
+			name: "multiple bootstrappers per record",
+			records: []string{
+				testNodeID0.String() + "@127.0.0.1:9651, " + testNodeID1.String() + "@[::1]:9653",
+			},
+			expected: []genesis.Bootstrapper{testBootstrapper0, testBootstrapper1},
+		},
+		{
+			name: "invalid record",
+			records: []string{
+				testNodeID0.String() + "@127.0.0.1:9651",
+				testNodeID1.String(),
+			},
+			expectedErr: errMissingSeparator,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			resolver := testResolver{
+				"seed.example.com": test.records,
+			}
+			bootstrappers, err := LookupSeed(context.Background(), resolver, "seed.example.com")
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, bootstrappers)
+		})
+	}
+}
+
+// Tests that the resolver can be pointed at a local DNS server.
+func TestNewResolver(t *testing.T) {
+	require := require.New(t)
+
+	record := testNodeID0.String() + "@127.0.0.1:9651"
+	address := serveTXT(t, "seed.example.com.", record)
+
+	bootstrappers, err := LookupSeed(context.Background(), NewResolver(address), "seed.example.com")
+	require.NoError(err)
+	require.Equal([]genesis.Bootstrapper{testBootstrapper0}, bootstrappers)
+}
+
+// serveTXT starts a DNS server that answers TXT queries for [name] with
+// [record] and returns its address.
+func serveTXT(t *testing.T, name string, record string) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var request dnsmessage.Message
+			if err := request.Unpack(buf[:n]); err != nil || len(request.Questions) != 1 {
+				continue
+			}
+			question := request.Questions[0]
+
+			response := dnsmessage.Message{
+				Header: dnsmessage.Header{
+					ID:            request.ID,
+					Response:      true,
+					Authoritative: true,
+				},
+				Questions: request.Questions,
+			}
+			switch {
+			case question.Name.String() != name:
+				response.RCode = dnsmessage.RCodeNameError
+			case question.Type == dnsmessage.TypeTXT:
+				response.Answers = []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{
+						Name:  question.Name,
+						Type:  dnsmessage.TypeTXT,
+						Class: dnsmessage.ClassINET,
+					},
+					Body: &dnsmessage.TXTResource{
+						TXT: []string{record},
+					},
+				}}
+			}
+
+			responseBytes, err := response.Pack()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(responseBytes, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
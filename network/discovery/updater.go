@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// RefreshTimeout is the maximum amount of time that refreshing the
+// bootstrappers of a Source may take.
+const RefreshTimeout = 10 * time.Second
+
+// Source discovers bootstrappers from the TXT records of DNS seeds and from a
+// bootstrappers file.
+type Source struct {
+	resolver Resolver
+	dnsSeeds []string
+	file     string
+}
+
+// NewSource returns a Source that resolves [dnsSeeds] with [resolver] and
+// reads [file]. If [file] is empty, no file is read.
+func NewSource(resolver Resolver, dnsSeeds []string, file string) *Source {
+	return &Source{
+		resolver: resolver,
+		dnsSeeds: dnsSeeds,
+		file:     file,
+	}
+}
+
+// Bootstrappers returns the bootstrappers of every DNS seed and of the
+// bootstrappers file. If some of them can't be read, the bootstrappers of the
+// others are returned along with the error.
+func (s *Source) Bootstrappers(ctx context.Context) ([]genesis.Bootstrapper, error) {
+	var (
+		bootstrappers []genesis.Bootstrapper
+		errs          []error
+	)
+	for _, seed := range s.dnsSeeds {
+		seedBootstrappers, err := LookupSeed(ctx, s.resolver, seed)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		bootstrappers = append(bootstrappers, seedBootstrappers...)
+	}
+	if len(s.file) != 0 {
+		fileBootstrappers, err := ReadBootstrappersFile(s.file)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		bootstrappers = append(bootstrappers, fileBootstrappers...)
+	}
+	return bootstrappers, errors.Join(errs...)
+}
+
+// OnChange is called with the bootstrappers that were added to and removed
+// from a Source. A bootstrapper whose IP changed is both removed, with its
+// previous IP, and added, with its new IP.
+type OnChange func(added []genesis.Bootstrapper, removed []genesis.Bootstrapper)
+
+// Updater periodically refreshes the bootstrappers of a Source.
+// Dispatch() and Stop() should only be called once.
+type Updater struct {
+	source     *Source
+	updateFreq time.Duration
+	onChange   OnChange
+	// current maps the nodeID of every bootstrapper reported by the last
+	// refresh to its IP.
+	current map[ids.NodeID]netip.AddrPort
+
+	// Cancelling causes Dispatch() to eventually return.
+	rootCtx       context.Context
+	rootCtxCancel context.CancelFunc
+	// Closed when Dispatch() has returned.
+	doneChan chan struct{}
+}
+
+// NewUpdater returns an Updater that refreshes [source] every [updateFreq] and
+// reports the changes since [initial] to [onChange].
+func NewUpdater(
+	source *Source,
+	initial []genesis.Bootstrapper,
+	updateFreq time.Duration,
+	onChange OnChange,
+) *Updater {
+	current := make(map[ids.NodeID]netip.AddrPort, len(initial))
+	for _, bootstrapper := range initial {
+		current[bootstrapper.ID] = bootstrapper.IP
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Updater{
+		source:        source,
+		updateFreq:    updateFreq,
+		onChange:      onChange,
+		current:       current,
+		rootCtx:       ctx,
+		rootCtxCancel: cancel,
+		doneChan:      make(chan struct{}),
+	}
+}
+
+// Dispatch refreshes the bootstrappers every [updateFreq] until Stop() is
+// called. Should be called in a goroutine.
+func (u *Updater) Dispatch(log logging.Logger) {
+	ticker := time.NewTicker(u.updateFreq)
+	defer func() {
+		ticker.Stop()
+		close(u.doneChan)
+	}()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(u.rootCtx, RefreshTimeout)
+			err := u.refresh(ctx)
+			cancel()
+			if err != nil {
+				log.Warn("couldn't refresh all bootstrappers",
+					zap.Error(err),
+				)
+			}
+		case <-u.rootCtx.Done():
+			return
+		}
+	}
+}
+
+// Stop refreshing the bootstrappers.
+func (u *Updater) Stop() {
+	// Cause Dispatch() to return and cancel the in-flight refresh.
+	u.rootCtxCancel()
+	// Wait until Dispatch() has returned.
+	<-u.doneChan
+}
+
+// refresh reports the bootstrappers that changed since the last refresh. If
+// the source failed, bootstrappers are only added or updated, never removed,
+// as they may be missing because of the failure.
+func (u *Updater) refresh(ctx context.Context) error {
+	bootstrappers, err := u.source.Bootstrappers(ctx)
+	latest := make(map[ids.NodeID]netip.AddrPort, len(bootstrappers))
+	for _, bootstrapper := range bootstrappers {
+		latest[bootstrapper.ID] = bootstrapper.IP
+	}
+
+	var removed []genesis.Bootstrapper
+	for nodeID, ip := range u.current {
+		latestIP, ok := latest[nodeID]
+		if ok && latestIP == ip {
+			continue
+		}
+		if !ok && err != nil {
+			latest[nodeID] = ip
+			continue
+		}
+		removed = append(removed, genesis.Bootstrapper{
+			ID: nodeID,
+			IP: ip,
+		})
+	}
+
+	var added []genesis.Bootstrapper
+	for nodeID, ip := range latest {
+		if currentIP, ok := u.current[nodeID]; ok && currentIP == ip {
+			continue
+		}
+		added = append(added, genesis.Bootstrapper{
+			ID: nodeID,
+			IP: ip,
+		})
+	}
+
+	u.current = latest
+	if len(added) != 0 || len(removed) != 0 {
+		u.onChange(added, removed)
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package discovery
+
+import (
+	"context"
+	"net/netip"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/genesis"
+)
+
+func TestSourceBootstrappers(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "bootstrappers.json")
+	writeBootstrappersFile(t, path, testBootstrapper1)
+
+	resolver := testResolver{
+		"seed.example.com": {testNodeID0.String() + "@127.0.0.1:9651"},
+	}
+	source := NewSource(resolver, []string{"seed.example.com", "unknown.example.com"}, path)
+
+	// The bootstrappers of the seed that could be resolved and of the file are
+	// returned along with the error of the other seed.
+	bootstrappers, err := source.Bootstrappers(context.Background())
+	require.ErrorIs(err, errUnknownSeed)
+	require.Equal([]genesis.Bootstrapper{testBootstrapper0, testBootstrapper1}, bootstrappers)
+}
+
+func TestUpdaterRefresh(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "bootstrappers.json")
+	writeBootstrappersFile(t, path, testBootstrapper0)
+
+	resolver := testResolver{
+		"seed.example.com": nil,
+	}
+	source := NewSource(resolver, []string{"seed.example.com"}, path)
+
+	var added, removed []genesis.Bootstrapper
+	onChange := func(a []genesis.Bootstrapper, r []genesis.Bootstrapper) {
+		added = a
+		removed = r
+	}
+	updater := NewUpdater(source, []genesis.Bootstrapper{testBootstrapper0}, time.Minute, onChange)
+
+	// Nothing changed
+	require.NoError(updater.refresh(ctx))
+	require.Empty(added)
+	require.Empty(removed)
+
+	// The seed is resolved again
+	resolver["seed.example.com"] = []string{testNodeID1.String() + "@[::1]:9653"}
+	require.NoError(updater.refresh(ctx))
+	require.Equal([]genesis.Bootstrapper{testBootstrapper1}, added)
+	require.Empty(removed)
+
+	// A failing seed doesn't remove its bootstrappers
+	added, removed = nil, nil
+	delete(resolver, "seed.example.com")
+	require.ErrorIs(updater.refresh(ctx), errUnknownSeed)
+	require.Empty(added)
+	require.Empty(removed)
+
+	// Bootstrappers are removed once every source succeeds
+	resolver["seed.example.com"] = nil
+	require.NoError(updater.refresh(ctx))
+	require.Empty(added)
+	require.Equal([]genesis.Bootstrapper{testBootstrapper1}, removed)
+
+	// The file is reloaded and a bootstrapper whose IP changed is removed and
+	// added
+	added, removed = nil, nil
+	movedBootstrapper0 := genesis.Bootstrapper{
+		ID: testNodeID0,
+		IP: netip.MustParseAddrPort("127.0.0.1:9652"),
+	}
+	writeBootstrappersFile(t, path, movedBootstrapper0)
+	require.NoError(updater.refresh(ctx))
+	require.Equal([]genesis.Bootstrapper{movedBootstrapper0}, added)
+	require.Equal([]genesis.Bootstrapper{testBootstrapper0}, removed)
+}
@@ -154,6 +154,29 @@ func (i *ipTracker) ManuallyGossip(nodeID ids.NodeID) {
 	i.manuallyGossipable.Add(nodeID)
 }
 
+// ManuallyUntrack reverts a previous call to ManuallyTrack. The connection to
+// the provided nodeID remains desired if it was manually gossiped or if it is
+// a validator.
+func (i *ipTracker) ManuallyUntrack(nodeID ids.NodeID) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.manuallyGossipable.Contains(nodeID) {
+		return
+	}
+
+	i.manuallyTracked.Remove(nodeID)
+
+	// Validators are always gossipable.
+	if i.gossipableIDs.Contains(nodeID) {
+		return
+	}
+
+	i.trackedIDs.Remove(nodeID)
+	delete(i.mostRecentTrackedIPs, nodeID)
+	i.numTrackedIPs.Set(float64(len(i.mostRecentTrackedIPs)))
+}
+
 // WantsConnection returns true if any of the following conditions are met:
 //  1. The node has been manually tracked.
 //  2. The node has been manually gossiped.
@@ -219,6 +219,77 @@ func TestIPTracker_ManuallyGossip(t *testing.T) {
 	}
 }
 
+func TestIPTracker_ManuallyUntrack(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialState  *ipTracker
+		nodeID        ids.NodeID
+		expectedState *ipTracker
+	}{
+		{
+			name: "connected non-validator",
+			initialState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.Connected(ip)
+				return tracker
+			}(),
+			nodeID: ip.NodeID,
+			expectedState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.Connected(ip)
+				tracker.manuallyTracked.Remove(ip.NodeID)
+				tracker.trackedIDs.Remove(ip.NodeID)
+				delete(tracker.mostRecentTrackedIPs, ip.NodeID)
+				return tracker
+			}(),
+		},
+		{
+			name: "validator",
+			initialState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.OnValidatorAdded(ip.NodeID, nil, ids.Empty, 0)
+				tracker.Connected(ip)
+				return tracker
+			}(),
+			nodeID: ip.NodeID,
+			expectedState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.OnValidatorAdded(ip.NodeID, nil, ids.Empty, 0)
+				tracker.Connected(ip)
+				tracker.manuallyTracked.Remove(ip.NodeID)
+				return tracker
+			}(),
+		},
+		{
+			name: "manually gossiped",
+			initialState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyGossip(ip.NodeID)
+				tracker.Connected(ip)
+				return tracker
+			}(),
+			nodeID: ip.NodeID,
+			expectedState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyGossip(ip.NodeID)
+				tracker.Connected(ip)
+				return tracker
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.initialState.ManuallyUntrack(test.nodeID)
+			requireEqual(t, test.expectedState, test.initialState)
+			requireMetricsConsistent(t, test.initialState)
+		})
+	}
+}
+
 func TestIPTracker_AddIP(t *testing.T) {
 	newerIP := newerTestIP(ip)
 	tests := []struct {
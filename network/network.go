@@ -23,8 +23,11 @@ import (
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/acl"
+	"github.com/ava-labs/avalanchego/network/capture"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/quic"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -80,11 +83,22 @@ type Network interface {
 	// connect to this ID.
 	ManuallyTrack(nodeID ids.NodeID, ip netip.AddrPort)
 
+	// Stop attempting to connect to this ID if it was manually tracked and a
+	// connection to it isn't otherwise desired. Existing connections aren't
+	// closed.
+	ManuallyUntrack(nodeID ids.NodeID)
+
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
 	// info about all peers that have finished the handshake. Otherwise, returns
 	// info about the peers in [nodeIDs] that have finished the handshake.
 	PeerInfo(nodeIDs []ids.NodeID) []peer.Info
 
+	// PeerStats returns the message accounting of peers. If [nodeIDs] is
+	// empty, returns the accounting of all peers that have finished the
+	// handshake. Otherwise, returns the accounting of the peers in [nodeIDs]
+	// that have finished the handshake.
+	PeerStats(nodeIDs []ids.NodeID) []peer.Stats
+
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
@@ -131,6 +145,14 @@ type network struct {
 	serverUpgrader peer.Upgrader
 	// Does TLS handshakes for outbound connections
 	clientUpgrader peer.Upgrader
+	// Accepts and dials QUIC connections, if enabled
+	quicTransport *quic.Transport
+	// Authenticates QUIC connections
+	quicUpgrader peer.Upgrader
+	// Captures peer messages, if enabled
+	captureWriter *capture.Writer
+	// Decides which peers may be connected to
+	peerACL *acl.ACL
 
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
@@ -152,6 +174,9 @@ type network struct {
 	connectingPeers peer.Set
 	connectedPeers  peer.Set
 	closing         bool
+	// quicPeers contains the peers that advertised QUIC support during their
+	// most recent handshake. They are dialed over QUIC when reconnecting.
+	quicPeers set.Set[ids.NodeID]
 
 	// router is notified about all peer [Connected] and [Disconnected] events
 	// as well as all non-handshake peer messages.
@@ -272,23 +297,40 @@ func NewNetwork(
 		MaxClockDifference:   config.MaxClockDifference,
 		SupportedACPs:        config.SupportedACPs.List(),
 		ObjectedACPs:         config.ObjectedACPs.List(),
+		QUICEnabled:          config.QUICTransport != nil,
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
 	}
 
+	peerACL := config.PeerACL
+	if peerACL == nil {
+		// An ACL that isn't persisted can't fail to be created.
+		peerACL, _ = acl.New("")
+	}
+
 	onCloseCtx, cancel := context.WithCancel(context.Background())
+	var captureWriter *capture.Writer
+	if config.CaptureConfig.Enabled {
+		captureWriter = capture.NewWriter(log, config.CaptureConfig)
+		peerConfig.Capture = captureWriter
+	}
+
 	n := &network{
 		config:               config,
 		peerConfig:           peerConfig,
+		captureWriter:        captureWriter,
+		peerACL:              peerACL,
 		metrics:              metrics,
 		outboundMsgThrottler: outboundMsgThrottler,
 
-		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
+		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig, peerACL),
 		listener:                    listener,
 		dialer:                      dialer,
-		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
-		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
+		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected, peerACL),
+		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected, peerACL),
+		quicTransport:               config.QUICTransport,
+		quicUpgrader:                peer.NewQUICUpgrader(metrics.tlsConnRejected, peerACL),
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -306,6 +348,7 @@ func NewNetwork(
 		router:          router,
 	}
 	n.peerConfig.Network = n
+	peerACL.RegisterListener(n.disconnectDeniedPeers)
 	return n, nil
 }
 
@@ -443,6 +486,11 @@ func (n *network) Connected(nodeID ids.NodeID) {
 		tracked.stopTracking()
 		delete(n.trackedIPs, nodeID)
 	}
+	if peer.SupportsQUIC() {
+		n.quicPeers.Add(nodeID)
+	} else {
+		n.quicPeers.Remove(nodeID)
+	}
 	n.connectingPeers.Remove(nodeID)
 	n.connectedPeers.Add(peer)
 	n.peersLock.Unlock()
@@ -527,6 +575,9 @@ func (n *network) Peers(except ids.NodeID, knownPeers *bloom.ReadFilter, salt []
 func (n *network) Dispatch() error {
 	go n.runTimers() // Periodically perform operations
 	go n.inboundConnUpgradeThrottler.Dispatch()
+	if n.quicTransport != nil {
+		go n.dispatchQUIC()
+	}
 	for { // Continuously accept new connections
 		if n.onCloseCtx.Err() != nil {
 			break
@@ -544,45 +595,11 @@ func (n *network) Dispatch() error {
 
 		// Note: listener.Accept is rate limited outside of this package, so a
 		// peer can not just arbitrarily spin up goroutines here.
-		go func() {
-			// Note: Calling [RemoteAddr] with the Proxy protocol enabled may
-			// block for up to ProxyReadHeaderTimeout. Therefore, we ensure to
-			// call this function inside the go-routine, rather than the main
-			// accept loop.
-			remoteAddr := conn.RemoteAddr().String()
-			ip, err := ips.ParseAddrPort(remoteAddr)
-			if err != nil {
-				n.peerConfig.Log.Error("failed to parse remote address",
-					zap.String("peerIP", remoteAddr),
-					zap.Error(err),
-				)
-				_ = conn.Close()
-				return
-			}
-
-			if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
-				n.peerConfig.Log.Debug("failed to upgrade connection",
-					zap.String("reason", "rate-limiting"),
-					zap.Stringer("peerIP", ip),
-				)
-				n.metrics.inboundConnRateLimited.Inc()
-				_ = conn.Close()
-				return
-			}
-			n.metrics.inboundConnAllowed.Inc()
-
-			n.peerConfig.Log.Verbo("starting to upgrade connection",
-				zap.String("direction", "inbound"),
-				zap.Stringer("peerIP", ip),
-			)
-
-			if err := n.upgrade(conn, n.serverUpgrader); err != nil {
-				n.peerConfig.Log.Verbo("failed to upgrade connection",
-					zap.String("direction", "inbound"),
-					zap.Error(err),
-				)
-			}
-		}()
+		//
+		// Note: Calling [RemoteAddr] with the Proxy protocol enabled may block
+		// for up to ProxyReadHeaderTimeout. Therefore, we ensure to call this
+		// function inside the go-routine, rather than the main accept loop.
+		go n.upgradeInbound(conn, n.serverUpgrader)
 	}
 	n.inboundConnUpgradeThrottler.Stop()
 	n.StartClose()
@@ -596,9 +613,64 @@ func (n *network) Dispatch() error {
 	for _, peer := range append(connecting, connected...) {
 		errs.Add(peer.AwaitClosed(context.TODO()))
 	}
+	if n.captureWriter != nil {
+		// All peers have closed, so no more messages will be captured.
+		errs.Add(n.captureWriter.Close())
+	}
 	return errs.Err
 }
 
+// dispatchQUIC accepts QUIC connections until the network is closed.
+func (n *network) dispatchQUIC() {
+	for {
+		conn, err := n.quicTransport.Accept(n.onCloseCtx)
+		if err != nil {
+			n.peerConfig.Log.Debug("stopped accepting QUIC connections", zap.Error(err))
+			return
+		}
+
+		go n.upgradeInbound(conn, n.quicUpgrader)
+	}
+}
+
+// upgradeInbound upgrades the inbound connection [conn] with [upgrader], unless
+// the connection is rate-limited or denied by the peer ACL.
+func (n *network) upgradeInbound(conn net.Conn, upgrader peer.Upgrader) {
+	remoteAddr := conn.RemoteAddr().String()
+	ip, err := ips.ParseAddrPort(remoteAddr)
+	if err != nil {
+		n.peerConfig.Log.Error("failed to parse remote address",
+			zap.String("peerIP", remoteAddr),
+			zap.Error(err),
+		)
+		_ = conn.Close()
+		return
+	}
+
+	if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
+		n.peerConfig.Log.Debug("failed to upgrade connection",
+			zap.String("reason", "rate-limiting or denied by the peer ACL"),
+			zap.Stringer("peerIP", ip),
+		)
+		n.metrics.inboundConnRateLimited.Inc()
+		_ = conn.Close()
+		return
+	}
+	n.metrics.inboundConnAllowed.Inc()
+
+	n.peerConfig.Log.Verbo("starting to upgrade connection",
+		zap.String("direction", "inbound"),
+		zap.Stringer("peerIP", ip),
+	)
+
+	if err := n.upgrade(conn, upgrader); err != nil {
+		n.peerConfig.Log.Verbo("failed to upgrade connection",
+			zap.String("direction", "inbound"),
+			zap.Error(err),
+		)
+	}
+}
+
 func (n *network) ManuallyTrack(nodeID ids.NodeID, ip netip.AddrPort) {
 	n.ipTracker.ManuallyTrack(nodeID)
 
@@ -621,6 +693,21 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip netip.AddrPort) {
 	}
 }
 
+func (n *network) ManuallyUntrack(nodeID ids.NodeID) {
+	n.ipTracker.ManuallyUntrack(nodeID)
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	if n.ipTracker.WantsConnection(nodeID) {
+		return
+	}
+	if tracked, ok := n.trackedIPs[nodeID]; ok {
+		tracked.stopTracking()
+		delete(n.trackedIPs, nodeID)
+	}
+}
+
 func (n *network) track(ip *ips.ClaimedIPPort) error {
 	// To avoid signature verification when the IP isn't needed, we
 	// optimistically filter out IPs. This can result in us not tracking an IP
@@ -906,7 +993,20 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
-			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
+			// Similarly, we skip all attempts to initiate a connection to a
+			// peer that is denied by the peer ACL. The ACL may be changed at
+			// runtime, so the loop continues.
+			if !n.peerACL.Allow(nodeID, ip.ip.Addr()) {
+				n.peerConfig.Log.Verbo("skipping connection dial",
+					zap.String("reason", "denied by the peer ACL"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
+			conn, upgrader, err := n.dialPeer(nodeID, ip.ip)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to reach peer, attempting again",
@@ -923,7 +1023,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				zap.Stringer("peerIP", ip.ip),
 			)
 
-			err = n.upgrade(conn, n.clientUpgrader)
+			err = n.upgrade(conn, upgrader)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
@@ -938,6 +1038,62 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 	}()
 }
 
+// dialPeer connects to [nodeID] at [ip]. If both this node and the peer
+// support QUIC, the connection is made over QUIC. Otherwise, or if the QUIC
+// connection fails, the connection is made over TCP. Returns the connection and
+// the upgrader that must be used to upgrade it.
+func (n *network) dialPeer(nodeID ids.NodeID, ip netip.AddrPort) (net.Conn, peer.Upgrader, error) {
+	if n.quicTransport != nil {
+		n.peersLock.RLock()
+		supportsQUIC := n.quicPeers.Contains(nodeID)
+		n.peersLock.RUnlock()
+
+		if supportsQUIC {
+			// Like the TCP dialer, a zero timeout means no timeout.
+			ctx, cancel := n.onCloseCtx, context.CancelFunc(func() {})
+			if timeout := n.config.DialerConfig.ConnectionTimeout; timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+			}
+			conn, err := n.quicTransport.Dial(ctx, ip)
+			cancel()
+			if err == nil {
+				return conn, n.quicUpgrader, nil
+			}
+
+			n.peerConfig.Log.Verbo("failed to reach peer over QUIC, falling back to TCP",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("peerIP", ip),
+				zap.Error(err),
+			)
+		}
+	}
+
+	conn, err := n.dialer.Dial(n.onCloseCtx, ip)
+	return conn, n.clientUpgrader, err
+}
+
+// disconnectDeniedPeers starts closing the connections to all the connecting
+// and connected peers that are denied by the peer ACL.
+func (n *network) disconnectDeniedPeers() {
+	n.peersLock.RLock()
+	connecting := n.connectingPeers.Sample(n.connectingPeers.Len(), peer.NoPrecondition)
+	connected := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
+	n.peersLock.RUnlock()
+
+	for _, p := range append(connecting, connected...) {
+		if n.peerACL.Allow(p.ID(), p.RemoteIP().Addr()) {
+			continue
+		}
+
+		n.peerConfig.Log.Info("disconnecting from peer",
+			zap.String("reason", "denied by the peer ACL"),
+			zap.Stringer("nodeID", p.ID()),
+			zap.Stringer("peerIP", p.RemoteIP()),
+		)
+		p.StartClose()
+	}
+}
+
 // upgrade the provided connection, which may be an inbound connection or an
 // outbound connection, with the provided [upgrader].
 //
@@ -1032,12 +1188,28 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		zap.Stringer("nodeID", nodeID),
 	)
 
+	// QUIC connections exchange app messages over a separate stream.
+	var (
+		appConn         net.Conn
+		appMessageQueue peer.MessageQueue
+	)
+	if quicConn, ok := tlsConn.(*quic.Conn); ok {
+		appConn = quicConn.AppStream()
+		appMessageQueue = peer.NewThrottledMessageQueue(
+			n.peerConfig.Metrics,
+			nodeID,
+			n.peerConfig.Log,
+			n.outboundMsgThrottler,
+		)
+	}
+
 	// peer.Start requires there is only ever one peer instance running with the
 	// same [peerConfig.InboundMsgThrottler]. This is guaranteed by the above
 	// de-duplications for [connectingPeers] and [connectedPeers].
 	peer := peer.Start(
 		n.peerConfig,
 		tlsConn,
+		appConn,
 		cert,
 		nodeID,
 		peer.NewThrottledMessageQueue(
@@ -1046,6 +1218,7 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 			n.peerConfig.Log,
 			n.outboundMsgThrottler,
 		),
+		appMessageQueue,
 	)
 	n.connectingPeers.Add(peer)
 	n.peersLock.Unlock()
@@ -1062,6 +1235,16 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) PeerStats(nodeIDs []ids.NodeID) []peer.Stats {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	if len(nodeIDs) == 0 {
+		return n.connectedPeers.AllStats()
+	}
+	return n.connectedPeers.Stats(nodeIDs)
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
@@ -1071,6 +1254,13 @@ func (n *network) StartClose() {
 				zap.Error(err),
 			)
 		}
+		if n.quicTransport != nil {
+			if err := n.quicTransport.Close(); err != nil {
+				n.peerConfig.Log.Debug("closing the QUIC transport",
+					zap.Error(err),
+				)
+			}
+		}
 
 		n.peersLock.Lock()
 		defer n.peersLock.Unlock()
@@ -6,6 +6,8 @@ package network
 import (
 	"context"
 	"crypto"
+	"crypto/tls"
+	"net"
 	"net/netip"
 	"sync"
 	"testing"
@@ -16,8 +18,10 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/acl"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/quic"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -85,10 +89,12 @@ var (
 				MaxRecheckDelay: 50 * time.Millisecond,
 			},
 		},
-		OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
-			VdrAllocSize:        1 * units.GiB,
-			AtLargeAllocSize:    1 * units.GiB,
-			NodeMaxAtLargeBytes: constants.DefaultMaxMessageSize,
+		OutboundMsgThrottlerConfig: throttling.OutboundMsgThrottlerConfig{
+			MsgByteThrottlerConfig: throttling.MsgByteThrottlerConfig{
+				VdrAllocSize:        1 * units.GiB,
+				AtLargeAllocSize:    1 * units.GiB,
+				NodeMaxAtLargeBytes: constants.DefaultMaxMessageSize,
+			},
 		},
 		MaxInboundConnsPerSec: 100,
 	}
@@ -753,3 +759,132 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestPeerACLDisconnectsDeniedPeers(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil, nil})
+
+	net0 := networks[0]
+	require.NoError(net0.peerACL.Set(acl.Config{
+		Deny: acl.List{
+			NodeIDs: []ids.NodeID{nodeIDs[1]},
+		},
+	}))
+
+	isConnected := func(nodeID ids.NodeID) bool {
+		net0.peersLock.RLock()
+		defer net0.peersLock.RUnlock()
+
+		_, connected := net0.connectedPeers.GetByID(nodeID)
+		return connected
+	}
+	require.Eventually(
+		func() bool {
+			return !isConnected(nodeIDs[1])
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+	require.True(isConnected(nodeIDs[2]))
+
+	// The denied peer should not be able to reconnect.
+	time.Sleep(100 * time.Millisecond)
+	require.False(isConnected(nodeIDs[1]))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func newQUICTransport(t *testing.T, tlsConfig *tls.Config) *quic.Transport {
+	require := require.New(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+
+	transport, err := quic.NewTransport(conn, tlsConfig, time.Second, defaultThrottlerConfig.MaxInboundConnsPerSec)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+	return transport
+}
+
+func TestDialPeerQUIC(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 1)
+
+	config := configs[0]
+	config.Beacons = validators.NewManager()
+	config.Validators = validators.NewManager()
+	require.NoError(config.Validators.AddStaker(constants.PrimaryNetworkID, nodeIDs[0], nil, ids.GenerateTestID(), 1))
+	config.QUICTransport = newQUICTransport(t, config.TLSConfig)
+
+	net, err := NewNetwork(
+		config,
+		upgrade.InitiallyActiveTime,
+		newMessageCreator(t),
+		prometheus.NewRegistry(),
+		logging.NoLog{},
+		listeners[0],
+		dialer,
+		&testHandler{},
+	)
+	require.NoError(err)
+	network := net.(*network)
+	defer network.StartClose()
+
+	var (
+		peerNodeID    = ids.GenerateTestNodeID()
+		peerTransport = newQUICTransport(t, config.TLSConfig)
+	)
+	peerIP, err := netip.ParseAddrPort(peerTransport.Addr().String())
+	require.NoError(err)
+	peerListener := newTestListener(peerIP)
+	dialer.AddListener(peerIP, peerListener)
+	defer peerListener.Close()
+	go func() {
+		for {
+			conn, err := peerListener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	// Peers that haven't advertised QUIC support are dialed over TCP.
+	conn, upgrader, err := network.dialPeer(peerNodeID, peerIP)
+	require.NoError(err)
+	require.IsType(&testConn{}, conn)
+	require.Equal(network.clientUpgrader, upgrader)
+	require.NoError(conn.Close())
+
+	network.peersLock.Lock()
+	network.quicPeers.Add(peerNodeID)
+	network.peersLock.Unlock()
+
+	conn, upgrader, err = network.dialPeer(peerNodeID, peerIP)
+	require.NoError(err)
+	require.IsType(&quic.Conn{}, conn)
+	require.Equal(network.quicUpgrader, upgrader)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	peerConn, err := peerTransport.Accept(ctx)
+	require.NoError(err)
+	require.NoError(peerConn.Close())
+	require.NoError(conn.Close())
+
+	// If the QUIC connection can't be established, the peer is dialed over
+	// TCP.
+	require.NoError(peerTransport.Close())
+	conn, upgrader, err = network.dialPeer(peerNodeID, peerIP)
+	require.NoError(err)
+	require.IsType(&testConn{}, conn)
+	require.Equal(network.clientUpgrader, upgrader)
+	require.NoError(conn.Close())
+}
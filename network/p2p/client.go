@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -97,6 +98,13 @@ func (c *Client) AppRequest(
 			)
 		}
 
+		// The outcome of each request is recorded separately, so that the
+		// latency of a request is measured from when it was sent.
+		callback := onResponse
+		if reputation := c.options.reputation; reputation != nil {
+			callback = reputationCallback(reputation, onResponse)
+		}
+
 		if err := c.sender.SendAppRequest(
 			ctxWithoutCancel,
 			set.Of(nodeID),
@@ -114,7 +122,7 @@ func (c *Client) AppRequest(
 
 		c.router.pendingAppRequests[requestID] = pendingAppRequest{
 			handlerID: c.handlerIDStr,
-			callback:  onResponse,
+			callback:  callback,
 		}
 		c.router.requestID += 2
 	}
@@ -122,6 +130,22 @@ func (c *Client) AppRequest(
 	return nil
 }
 
+// reputationCallback wraps [onResponse] to record the latency and failures of
+// a request in [reputation]. Errors returned by the peer's handler are not
+// considered failures of the peer.
+func reputationCallback(reputation *Reputation, onResponse AppResponseCallback) AppResponseCallback {
+	start := time.Now()
+	return func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		switch {
+		case err == nil:
+			reputation.RegisterResponse(nodeID, time.Since(start))
+		case errors.Is(err, common.ErrTimeout):
+			reputation.RegisterFailure(nodeID)
+		}
+		onResponse(ctx, nodeID, responseBytes, err)
+	}
+}
+
 // AppGossip sends a gossip message to a random set of peers.
 func (c *Client) AppGossip(
 	ctx context.Context,
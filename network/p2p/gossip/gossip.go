@@ -28,11 +28,12 @@ const (
 	sentIO     = "sent"
 	receivedIO = "received"
 
-	typeLabel  = "type"
-	pushType   = "push"
-	pullType   = "pull"
-	unsentType = "unsent"
-	sentType   = "sent"
+	typeLabel     = "type"
+	pushType      = "push"
+	pullType      = "pull"
+	reconcileType = "reconcile"
+	unsentType    = "unsent"
+	sentType      = "sent"
 
 	defaultGossipableCount = 64
 )
@@ -40,6 +41,7 @@ const (
 var (
 	_ Gossiper = (*ValidatorGossiper)(nil)
 	_ Gossiper = (*PullGossiper[*testTx])(nil)
+	_ Gossiper = (*ReconciliationGossiper[*testTx])(nil)
 	_ Gossiper = (*NoOpGossiper)(nil)
 
 	_ Set[*testTx] = (*FullSet[*testTx])(nil)
@@ -61,7 +63,21 @@ var (
 		ioLabel:   receivedIO,
 		typeLabel: pullType,
 	}
-	typeLabels   = []string{typeLabel}
+	sentReconcileLabels = prometheus.Labels{
+		ioLabel:   sentIO,
+		typeLabel: reconcileType,
+	}
+	receivedReconcileLabels = prometheus.Labels{
+		ioLabel:   receivedIO,
+		typeLabel: reconcileType,
+	}
+	typeLabels = []string{typeLabel}
+	pullLabels = prometheus.Labels{
+		typeLabel: pullType,
+	}
+	reconcileLabels = prometheus.Labels{
+		typeLabel: reconcileType,
+	}
 	unsentLabels = prometheus.Labels{
 		typeLabel: unsentType,
 	}
@@ -97,6 +113,8 @@ type ValidatorGossiper struct {
 type Metrics struct {
 	count                   *prometheus.CounterVec
 	bytes                   *prometheus.CounterVec
+	requestBytes            *prometheus.CounterVec
+	reconciliationFailures  prometheus.Counter
 	tracking                *prometheus.GaugeVec
 	trackingLifetimeAverage prometheus.Gauge
 	topValidators           *prometheus.GaugeVec
@@ -124,6 +142,19 @@ func NewMetrics(
 			},
 			ioTypeLabels,
 		),
+		requestBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "gossip_request_bytes",
+				Help:      "amount of pull requests sent to discover missing gossip (bytes)",
+			},
+			typeLabels,
+		),
+		reconciliationFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "gossip_reconciliation_failures",
+			Help:      "number of set reconciliations that failed because the difference between the sets was too large (n)",
+		}),
 		tracking: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -149,6 +180,8 @@ func NewMetrics(
 	err := errors.Join(
 		metrics.Register(m.count),
 		metrics.Register(m.bytes),
+		metrics.Register(m.requestBytes),
+		metrics.Register(m.reconciliationFailures),
 		metrics.Register(m.tracking),
 		metrics.Register(m.trackingLifetimeAverage),
 		metrics.Register(m.topValidators),
@@ -172,6 +205,16 @@ func (m *Metrics) observeMessage(labels prometheus.Labels, count int, bytes int)
 	return nil
 }
 
+func (m *Metrics) observeRequest(labels prometheus.Labels, bytes int) error {
+	bytesMetric, err := m.requestBytes.GetMetricWith(labels)
+	if err != nil {
+		return fmt.Errorf("failed to get request bytes metric: %w", err)
+	}
+
+	bytesMetric.Add(float64(bytes))
+	return nil
+}
+
 func (v ValidatorGossiper) Gossip(ctx context.Context) error {
 	if !v.Validators.Has(ctx, v.NodeID) {
 		return nil
@@ -215,7 +258,13 @@ func (p *PullGossiper[_]) Gossip(ctx context.Context) error {
 
 	for i := 0; i < p.pollSize; i++ {
 		err := p.client.AppRequestAny(ctx, msgBytes, p.handleResponse)
-		if err != nil && !errors.Is(err, p2p.ErrNoPeers) {
+		if errors.Is(err, p2p.ErrNoPeers) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := p.metrics.observeRequest(pullLabels, len(msgBytes)); err != nil {
 			return err
 		}
 	}
@@ -244,13 +293,30 @@ func (p *PullGossiper[_]) handleResponse(
 		return
 	}
 
+	receivedBytes := addGossip(p.log, p.marshaller, p.set, nodeID, gossip)
+	if err := p.metrics.observeMessage(receivedPullLabels, len(gossip), receivedBytes); err != nil {
+		p.log.Error("failed to update metrics",
+			zap.Error(err),
+		)
+	}
+}
+
+// addGossip adds the gossipables in [gossip] received from [nodeID] to [set]
+// and returns the number of bytes received.
+func addGossip[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	nodeID ids.NodeID,
+	gossip [][]byte,
+) int {
 	receivedBytes := 0
 	for _, bytes := range gossip {
 		receivedBytes += len(bytes)
 
-		gossipable, err := p.marshaller.UnmarshalGossip(bytes)
+		gossipable, err := marshaller.UnmarshalGossip(bytes)
 		if err != nil {
-			p.log.Debug(
+			log.Debug(
 				"failed to unmarshal gossip",
 				zap.Stringer("nodeID", nodeID),
 				zap.Error(err),
@@ -259,13 +325,13 @@ func (p *PullGossiper[_]) handleResponse(
 		}
 
 		gossipID := gossipable.GossipID()
-		p.log.Debug(
+		log.Debug(
 			"received gossip",
 			zap.Stringer("nodeID", nodeID),
 			zap.Stringer("id", gossipID),
 		)
-		if err := p.set.Add(gossipable); err != nil {
-			p.log.Debug(
+		if err := set.Add(gossipable); err != nil {
+			log.Debug(
 				"failed to add gossip to the known set",
 				zap.Stringer("nodeID", nodeID),
 				zap.Stringer("id", gossipID),
@@ -274,12 +340,7 @@ func (p *PullGossiper[_]) handleResponse(
 			continue
 		}
 	}
-
-	if err := p.metrics.observeMessage(receivedPullLabels, len(gossip), receivedBytes); err != nil {
-		p.log.Error("failed to update metrics",
-			zap.Error(err),
-		)
-	}
+	return receivedBytes
 }
 
 // NewPushGossiper returns an instance of PushGossiper
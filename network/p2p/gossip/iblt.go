@@ -0,0 +1,199 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bloom"
+)
+
+const (
+	// ibltNumHashes is the number of cells every ID is added to. The cells
+	// of an IBLT are split into [ibltNumHashes] partitions so that the cells
+	// of an ID are always distinct.
+	ibltNumHashes = 3
+	// ibltCellSize is the serialized size of a cell:
+	//
+	//	[count int32][id sum 32 bytes][hash sum uint64]
+	ibltCellSize = 4 + ids.IDLen + 8
+)
+
+var (
+	errInvalidNumCells = fmt.Errorf("number of cells must be a non-zero multiple of %d", ibltNumHashes)
+	errMismatchedIBLT  = errors.New("mismatched iblt")
+)
+
+// iblt is an invertible bloom lookup table of IDs. Subtracting the IBLT of a
+// set from the IBLT of another set results in an IBLT of the symmetric
+// difference of the sets, which can be decoded as long as the difference is
+// small compared to the number of cells, regardless of the size of the sets.
+type iblt struct {
+	salt  ids.ID
+	cells []ibltCell
+}
+
+type ibltCell struct {
+	count   int32
+	idSum   ids.ID
+	hashSum uint64
+}
+
+func newIBLT(numCells int, salt ids.ID) (*iblt, error) {
+	if numCells <= 0 || numCells%ibltNumHashes != 0 {
+		return nil, errInvalidNumCells
+	}
+	return &iblt{
+		salt:  salt,
+		cells: make([]ibltCell, numCells),
+	}, nil
+}
+
+func parseIBLT(bytes []byte, salt ids.ID) (*iblt, error) {
+	if len(bytes)%ibltCellSize != 0 {
+		return nil, errInvalidNumCells
+	}
+	t, err := newIBLT(len(bytes)/ibltCellSize, salt)
+	if err != nil {
+		return nil, err
+	}
+	for i := range t.cells {
+		cell := &t.cells[i]
+		cell.count = int32(binary.BigEndian.Uint32(bytes))
+		copy(cell.idSum[:], bytes[4:])
+		cell.hashSum = binary.BigEndian.Uint64(bytes[4+ids.IDLen:])
+		bytes = bytes[ibltCellSize:]
+	}
+	return t, nil
+}
+
+func (t *iblt) Add(id ids.ID) {
+	t.update(id, t.hash(id), 1)
+}
+
+// Subtract removes the IDs of [other] from [t]. IDs that are only in [other]
+// end up with a negative count.
+func (t *iblt) Subtract(other *iblt) error {
+	if t.salt != other.salt || len(t.cells) != len(other.cells) {
+		return errMismatchedIBLT
+	}
+	for i := range t.cells {
+		cell := &t.cells[i]
+		otherCell := &other.cells[i]
+		cell.count -= otherCell.count
+		xor(&cell.idSum, &otherCell.idSum)
+		cell.hashSum ^= otherCell.hashSum
+	}
+	return nil
+}
+
+// Decode returns the IDs with a positive count and the IDs with a negative
+// count. Returns false if [t] contains too many IDs to be decoded. [t] is
+// emptied by a successful decode.
+func (t *iblt) Decode() ([]ids.ID, []ids.ID, bool) {
+	var (
+		positive []ids.ID
+		negative []ids.ID
+		pure     = make([]int, 0, len(t.cells))
+	)
+	for i := range t.cells {
+		if t.isPure(i) {
+			pure = append(pure, i)
+		}
+	}
+	for len(pure) > 0 {
+		i := pure[len(pure)-1]
+		pure = pure[:len(pure)-1]
+		// Removing other IDs may have changed this cell since it was pure
+		if !t.isPure(i) {
+			continue
+		}
+
+		cell := t.cells[i]
+		if cell.count == 1 {
+			positive = append(positive, cell.idSum)
+		} else {
+			negative = append(negative, cell.idSum)
+		}
+		for _, j := range t.update(cell.idSum, cell.hashSum, -cell.count) {
+			if t.isPure(j) {
+				pure = append(pure, j)
+			}
+		}
+	}
+
+	for _, cell := range t.cells {
+		if cell != (ibltCell{}) {
+			return nil, nil, false
+		}
+	}
+	return positive, negative, true
+}
+
+func (t *iblt) Marshal() []byte {
+	bytes := make([]byte, len(t.cells)*ibltCellSize)
+	b := bytes
+	for _, cell := range t.cells {
+		binary.BigEndian.PutUint32(b, uint32(cell.count))
+		copy(b[4:], cell.idSum[:])
+		binary.BigEndian.PutUint64(b[4+ids.IDLen:], cell.hashSum)
+		b = b[ibltCellSize:]
+	}
+	return bytes
+}
+
+// update adds [count] to the cells of [id] and returns the indices of the
+// cells.
+func (t *iblt) update(id ids.ID, hash uint64, count int32) [ibltNumHashes]int {
+	indices := t.indices(hash)
+	for _, i := range indices {
+		cell := &t.cells[i]
+		cell.count += count
+		xor(&cell.idSum, &id)
+		cell.hashSum ^= hash
+	}
+	return indices
+}
+
+func (t *iblt) indices(hash uint64) [ibltNumHashes]int {
+	var (
+		indices       [ibltNumHashes]int
+		partitionSize = uint64(len(t.cells) / ibltNumHashes)
+	)
+	for i := range indices {
+		index := splitMix64(hash+uint64(i)) % partitionSize
+		indices[i] = i*int(partitionSize) + int(index)
+	}
+	return indices
+}
+
+// isPure returns true if cell [i] contains exactly one ID.
+func (t *iblt) isPure(i int) bool {
+	cell := &t.cells[i]
+	return (cell.count == 1 || cell.count == -1) && t.hash(cell.idSum) == cell.hashSum
+}
+
+func (t *iblt) hash(id ids.ID) uint64 {
+	return bloom.Hash(id[:], t.salt[:])
+}
+
+func xor(dst, src *ids.ID) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// splitMix64 is the finalizer of the SplitMix64 generator, which is used to
+// derive independent cell indices from a single hash.
+func splitMix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
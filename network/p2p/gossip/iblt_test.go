@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestIBLTDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		numCells int
+		shared   int
+		onlyA    int
+		onlyB    int
+	}{
+		{
+			name:     "identical sets",
+			numCells: 30,
+			shared:   1000,
+		},
+		{
+			name:     "small difference",
+			numCells: 30,
+			shared:   1000,
+			onlyA:    5,
+			onlyB:    3,
+		},
+		{
+			name:     "disjoint sets",
+			numCells: 60,
+			onlyA:    10,
+			onlyB:    10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			a, err := newIBLT(tt.numCells, ids.Empty)
+			require.NoError(err)
+			b, err := newIBLT(tt.numCells, ids.Empty)
+			require.NoError(err)
+
+			for i := 0; i < tt.shared; i++ {
+				id := newIBLTTestID(0, i)
+				a.Add(id)
+				b.Add(id)
+			}
+			expectedA := make([]ids.ID, tt.onlyA)
+			for i := range expectedA {
+				expectedA[i] = newIBLTTestID(1, i)
+				a.Add(expectedA[i])
+			}
+			expectedB := make([]ids.ID, tt.onlyB)
+			for i := range expectedB {
+				expectedB[i] = newIBLTTestID(2, i)
+				b.Add(expectedB[i])
+			}
+
+			require.NoError(a.Subtract(b))
+			positive, negative, ok := a.Decode()
+			require.True(ok)
+			require.ElementsMatch(expectedA, positive)
+			require.ElementsMatch(expectedB, negative)
+		})
+	}
+}
+
+func TestIBLTDecodeTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	a, err := newIBLT(6, ids.Empty)
+	require.NoError(err)
+	for i := 0; i < 100; i++ {
+		a.Add(newIBLTTestID(0, i))
+	}
+
+	_, _, ok := a.Decode()
+	require.False(ok)
+}
+
+func TestIBLTSubtractMismatched(t *testing.T) {
+	require := require.New(t)
+
+	a, err := newIBLT(6, ids.ID{1})
+	require.NoError(err)
+	b, err := newIBLT(6, ids.ID{2})
+	require.NoError(err)
+	c, err := newIBLT(9, ids.ID{1})
+	require.NoError(err)
+
+	require.ErrorIs(a.Subtract(b), errMismatchedIBLT)
+	require.ErrorIs(a.Subtract(c), errMismatchedIBLT)
+}
+
+func TestIBLTMarshal(t *testing.T) {
+	require := require.New(t)
+
+	salt := ids.GenerateTestID()
+	a, err := newIBLT(12, salt)
+	require.NoError(err)
+	for i := 0; i < 3; i++ {
+		a.Add(ids.GenerateTestID())
+	}
+
+	b, err := parseIBLT(a.Marshal(), salt)
+	require.NoError(err)
+	require.Equal(a, b)
+
+	_, err = parseIBLT(a.Marshal()[1:], salt)
+	require.ErrorIs(err, errInvalidNumCells)
+}
+
+func TestNewIBLTInvalidNumCells(t *testing.T) {
+	for _, numCells := range []int{-3, 0, 4} {
+		_, err := newIBLT(numCells, ids.Empty)
+		require.ErrorIs(t, err, errInvalidNumCells)
+	}
+}
+
+// newIBLTTestID returns a deterministic ID so that decoding, which can fail
+// with a small probability, is reproducible.
+func newIBLTTestID(set int, i int) ids.ID {
+	return ids.ID{byte(set), byte(i), byte(i >> 8)}
+}
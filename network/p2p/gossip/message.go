@@ -57,3 +57,24 @@ func ParseAppGossip(bytes []byte) ([][]byte, error) {
 	err := proto.Unmarshal(bytes, msg)
 	return msg.Gossip, err
 }
+
+// marshalReconciliationRequest marshals a set reconciliation request. The
+// request reuses the format of pull gossip requests, with the invertible bloom
+// lookup table of the requester in place of the bloom filter.
+func marshalReconciliationRequest(t *iblt) ([]byte, error) {
+	return MarshalAppRequest(t.Marshal(), t.salt[:])
+}
+
+func parseReconciliationRequest(bytes []byte) (*iblt, error) {
+	request := &sdk.PullGossipRequest{}
+	if err := proto.Unmarshal(bytes, request); err != nil {
+		return nil, err
+	}
+
+	salt, err := ids.ToID(request.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIBLT(request.Filter, salt)
+}
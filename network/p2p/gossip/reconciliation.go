@@ -0,0 +1,348 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	// reconciliationShrinkFactor is how many times larger than the number of
+	// received gossipables the IBLT must be for a response to count towards
+	// shrinking it.
+	reconciliationShrinkFactor = 8
+	// reconciliationShrinkResponses is the number of consecutive responses
+	// that must be much smaller than the IBLT for it to be shrunk. This keeps
+	// the size from oscillating when the difference between the sets is close
+	// to the decoding threshold.
+	reconciliationShrinkResponses = 4
+	// reconciliationMaxFailures is the number of consecutive failures with the
+	// maximum number of cells after which the fallback gossiper is used.
+	reconciliationMaxFailures = 3
+	// reconciliationFallbackRounds is the number of gossip rounds that use the
+	// fallback gossiper before set reconciliation is attempted again.
+	reconciliationFallbackRounds = 10
+)
+
+var (
+	_ p2p.Handler = (*ReconciliationHandler[*testTx])(nil)
+
+	// ErrReconciliationFailed should be used to indicate that a set
+	// reconciliation request failed due to the difference between the sets
+	// being too large to be decoded
+	ErrReconciliationFailed = &common.AppError{
+		Code:    -9,
+		Message: "set reconciliation failed",
+	}
+
+	ErrInvalidReconciliationCells = errors.New("iblt cells must be a non-zero multiple of 3 with min cells <= max cells")
+)
+
+// NewReconciliationGossiper returns a gossiper that pulls the gossipables of
+// peers that are missing from [set] using set reconciliation. The requests
+// contain an invertible bloom lookup table (IBLT) of [set] with between
+// [minCells] and [maxCells] cells, whose size only depends on the difference
+// between [set] and the sets of peers.
+//
+// The number of cells must be a multiple of 3. If peers repeatedly fail to
+// decode IBLTs with [maxCells] cells, gossip is pulled with [fallback], such as
+// a PullGossiper, for a number of rounds instead. [fallback] may be nil, in
+// which case set reconciliation is always used.
+func NewReconciliationGossiper[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	client *p2p.Client,
+	metrics Metrics,
+	pollSize int,
+	minCells int,
+	maxCells int,
+	fallback Gossiper,
+) (*ReconciliationGossiper[T], error) {
+	if minCells <= 0 || minCells%ibltNumHashes != 0 || maxCells%ibltNumHashes != 0 || minCells > maxCells {
+		return nil, ErrInvalidReconciliationCells
+	}
+
+	return &ReconciliationGossiper[T]{
+		log:        log,
+		marshaller: marshaller,
+		set:        set,
+		client:     client,
+		metrics:    metrics,
+		pollSize:   pollSize,
+		minCells:   minCells,
+		maxCells:   maxCells,
+		fallback:   fallback,
+		numCells:   minCells,
+	}, nil
+}
+
+// ReconciliationGossiper is an alternative to PullGossiper whose requests
+// scale with the difference between the sets of the peers rather than with
+// their size.
+type ReconciliationGossiper[T Gossipable] struct {
+	log        logging.Logger
+	marshaller Marshaller[T]
+	set        Set[T]
+	client     *p2p.Client
+	metrics    Metrics
+	pollSize   int
+	minCells   int
+	maxCells   int
+	fallback   Gossiper
+
+	lock sync.Mutex
+	// numCells is doubled when peers fail to decode our IBLT and halved when
+	// it has been much larger than needed for several responses.
+	numCells int
+	// shrinkResponses is the number of consecutive responses that were much
+	// smaller than the IBLT.
+	shrinkResponses int
+	// failures is the number of consecutive failures with [maxCells] cells.
+	failures int
+	// fallbackRounds is the number of remaining rounds that use [fallback].
+	fallbackRounds int
+}
+
+func (r *ReconciliationGossiper[T]) Gossip(ctx context.Context) error {
+	r.lock.Lock()
+	numCells := r.numCells
+	useFallback := r.fallbackRounds > 0
+	if useFallback {
+		r.fallbackRounds--
+	}
+	r.lock.Unlock()
+
+	if useFallback {
+		return r.fallback.Gossip(ctx)
+	}
+
+	var salt ids.ID
+	if _, err := rand.Read(salt[:]); err != nil {
+		return err
+	}
+	t, err := newIBLT(numCells, salt)
+	if err != nil {
+		return err
+	}
+	r.set.Iterate(func(gossipable T) bool {
+		t.Add(gossipable.GossipID())
+		return true
+	})
+
+	msgBytes, err := marshalReconciliationRequest(t)
+	if err != nil {
+		return err
+	}
+
+	onResponse := func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		r.handleResponse(ctx, nodeID, numCells, responseBytes, err)
+	}
+	for i := 0; i < r.pollSize; i++ {
+		err := r.client.AppRequestAny(ctx, msgBytes, onResponse)
+		if errors.Is(err, p2p.ErrNoPeers) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.metrics.observeRequest(reconcileLabels, len(msgBytes)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconciliationGossiper[T]) handleResponse(
+	_ context.Context,
+	nodeID ids.NodeID,
+	numCells int,
+	responseBytes []byte,
+	err error,
+) {
+	if errors.Is(err, ErrReconciliationFailed) {
+		r.log.Debug("peer failed to reconcile sets",
+			zap.Stringer("nodeID", nodeID),
+			zap.Int("numCells", numCells),
+		)
+		r.metrics.reconciliationFailures.Inc()
+		r.onFailure(numCells)
+		return
+	}
+	if err != nil {
+		r.log.Debug(
+			"failed gossip request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	gossip, err := ParseAppResponse(responseBytes)
+	if err != nil {
+		r.log.Debug("failed to unmarshal gossip response", zap.Error(err))
+		return
+	}
+
+	receivedBytes := addGossip(r.log, r.marshaller, r.set, nodeID, gossip)
+	if err := r.metrics.observeMessage(receivedReconcileLabels, len(gossip), receivedBytes); err != nil {
+		r.log.Error("failed to update metrics",
+			zap.Error(err),
+		)
+	}
+
+	r.onSuccess(numCells, len(gossip))
+}
+
+// onFailure grows the IBLT after a peer failed to decode an IBLT with
+// [numCells] cells. Falls back to [fallback] if the IBLT can't grow anymore.
+func (r *ReconciliationGossiper[T]) onFailure(numCells int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.shrinkResponses = 0
+	if numCells < r.maxCells {
+		r.resize(2 * numCells)
+		return
+	}
+
+	r.failures++
+	if r.failures < reconciliationMaxFailures || r.fallback == nil {
+		return
+	}
+
+	r.log.Debug("falling back after failing to reconcile sets",
+		zap.Int("numCells", numCells),
+		zap.Int("failures", r.failures),
+	)
+	r.failures = 0
+	r.fallbackRounds = reconciliationFallbackRounds
+}
+
+// onSuccess shrinks the IBLT if it has been much larger than needed for
+// several consecutive responses.
+func (r *ReconciliationGossiper[T]) onSuccess(numCells int, numGossip int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.failures = 0
+	if reconciliationShrinkFactor*numGossip >= numCells {
+		r.shrinkResponses = 0
+		return
+	}
+
+	r.shrinkResponses++
+	if r.shrinkResponses < reconciliationShrinkResponses {
+		return
+	}
+
+	r.shrinkResponses = 0
+	r.resize(numCells / 2)
+}
+
+// resize sets the number of cells of the next IBLTs to the multiple of
+// [ibltNumHashes] closest to [numCells] in [minCells, maxCells].
+//
+// Assumes [lock] is held.
+func (r *ReconciliationGossiper[T]) resize(numCells int) {
+	numCells -= numCells % ibltNumHashes
+	r.numCells = min(max(numCells, r.minCells), r.maxCells)
+}
+
+// NewReconciliationHandler returns a handler that serves the requests of
+// ReconciliationGossipers. Requests with more than [maxCells] cells are
+// dropped.
+func NewReconciliationHandler[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	metrics Metrics,
+	targetResponseSize int,
+	maxCells int,
+) *ReconciliationHandler[T] {
+	return &ReconciliationHandler[T]{
+		Handler:  *NewHandler(log, marshaller, set, metrics, targetResponseSize),
+		maxCells: maxCells,
+	}
+}
+
+// ReconciliationHandler handles push gossip like Handler but serves set
+// reconciliation requests instead of bloom filter requests.
+type ReconciliationHandler[T Gossipable] struct {
+	Handler[T]
+	maxCells int
+}
+
+func (r *ReconciliationHandler[T]) AppRequest(_ context.Context, nodeID ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	peerIBLT, err := parseReconciliationRequest(requestBytes)
+	if err != nil || len(peerIBLT.cells) > r.maxCells {
+		return nil, p2p.ErrUnexpected
+	}
+
+	t, err := newIBLT(len(peerIBLT.cells), peerIBLT.salt)
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+	r.set.Iterate(func(gossipable T) bool {
+		t.Add(gossipable.GossipID())
+		return true
+	})
+	if err := t.Subtract(peerIBLT); err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	// The gossipables that only we have are the ones the peer is missing
+	missingIDs, _, ok := t.Decode()
+	if !ok {
+		r.log.Debug("failed to reconcile sets",
+			zap.Stringer("nodeID", nodeID),
+			zap.Int("numCells", len(peerIBLT.cells)),
+		)
+		return nil, ErrReconciliationFailed
+	}
+
+	missing := set.Of(missingIDs...)
+	responseSize := 0
+	gossipBytes := make([][]byte, 0, len(missingIDs))
+	r.set.Iterate(func(gossipable T) bool {
+		if !missing.Contains(gossipable.GossipID()) {
+			return true
+		}
+
+		var bytes []byte
+		bytes, err = r.marshaller.MarshalGossip(gossipable)
+		if err != nil {
+			return false
+		}
+
+		gossipBytes = append(gossipBytes, bytes)
+		responseSize += len(bytes)
+		return responseSize <= r.targetResponseSize
+	})
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	if err := r.metrics.observeMessage(sentReconcileLabels, len(gossipBytes), responseSize); err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	response, err := MarshalAppResponse(gossipBytes)
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+	return response, nil
+}
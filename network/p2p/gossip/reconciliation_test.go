@@ -0,0 +1,323 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+type reconciliationTest struct {
+	requestSender  *enginetest.SenderStub
+	requestNetwork *p2p.Network
+	requestSet     *testSet
+	gossiper       *ReconciliationGossiper[*testTx]
+
+	responseSender  *enginetest.SenderStub
+	responseNetwork *p2p.Network
+}
+
+func newReconciliationTest(
+	t *testing.T,
+	targetResponseSize int,
+	minCells int,
+	maxCells int,
+	requester []*testTx,
+	responder []*testTx,
+	fallback Gossiper,
+) *reconciliationTest {
+	require := require.New(t)
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+	marshaller := testMarshaller{}
+
+	responseSender := &enginetest.SenderStub{
+		SentAppResponse: make(chan []byte, 1),
+		SentAppError:    make(chan *common.AppError, 1),
+	}
+	responseNetwork, err := p2p.NewNetwork(logging.NoLog{}, responseSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	responseBloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 1000, 0.01, 0.05)
+	require.NoError(err)
+	responseSet := &testSet{
+		txs:   make(map[ids.ID]*testTx),
+		bloom: responseBloom,
+	}
+	for _, item := range responder {
+		require.NoError(responseSet.Add(item))
+	}
+
+	handler := NewReconciliationHandler[*testTx](
+		logging.NoLog{},
+		marshaller,
+		responseSet,
+		metrics,
+		targetResponseSize,
+		maxCells,
+	)
+	require.NoError(responseNetwork.AddHandler(0x0, handler))
+
+	requestSender := &enginetest.SenderStub{
+		SentAppRequest: make(chan []byte, 1),
+	}
+	requestNetwork, err := p2p.NewNetwork(logging.NoLog{}, requestSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(requestNetwork.Connected(context.Background(), ids.EmptyNodeID, nil))
+
+	requestBloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 1000, 0.01, 0.05)
+	require.NoError(err)
+	requestSet := &testSet{
+		txs:   make(map[ids.ID]*testTx),
+		bloom: requestBloom,
+	}
+	for _, item := range requester {
+		require.NoError(requestSet.Add(item))
+	}
+
+	gossiper, err := NewReconciliationGossiper[*testTx](
+		logging.NoLog{},
+		marshaller,
+		requestSet,
+		requestNetwork.NewClient(0x0),
+		metrics,
+		1,
+		minCells,
+		maxCells,
+		fallback,
+	)
+	require.NoError(err)
+
+	return &reconciliationTest{
+		requestSender:   requestSender,
+		requestNetwork:  requestNetwork,
+		requestSet:      requestSet,
+		gossiper:        gossiper,
+		responseSender:  responseSender,
+		responseNetwork: responseNetwork,
+	}
+}
+
+func TestReconciliationGossiperGossip(t *testing.T) {
+	tests := []struct {
+		name                   string
+		targetResponseSize     int
+		requester              []*testTx // what we have
+		responder              []*testTx // what the peer we're requesting gossip from has
+		expectedPossibleValues []*testTx // possible values we can have
+		expectedLen            int
+	}{
+		{
+			name: "no gossip - no one knows anything",
+		},
+		{
+			name:                   "no gossip - requester knows more than responder",
+			targetResponseSize:     1024,
+			requester:              []*testTx{{id: ids.ID{0}}},
+			expectedPossibleValues: []*testTx{{id: ids.ID{0}}},
+			expectedLen:            1,
+		},
+		{
+			name:                   "no gossip - requester knows everything responder knows",
+			targetResponseSize:     1024,
+			requester:              []*testTx{{id: ids.ID{0}}},
+			responder:              []*testTx{{id: ids.ID{0}}},
+			expectedPossibleValues: []*testTx{{id: ids.ID{0}}},
+			expectedLen:            1,
+		},
+		{
+			name:                   "gossip - requester knows nothing",
+			targetResponseSize:     1024,
+			responder:              []*testTx{{id: ids.ID{0}}},
+			expectedPossibleValues: []*testTx{{id: ids.ID{0}}},
+			expectedLen:            1,
+		},
+		{
+			name:                   "gossip - requester knows less than responder",
+			targetResponseSize:     1024,
+			requester:              []*testTx{{id: ids.ID{0}}},
+			responder:              []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}},
+			expectedPossibleValues: []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}},
+			expectedLen:            2,
+		},
+		{
+			name:                   "gossip - target response size exceeded",
+			targetResponseSize:     32,
+			responder:              []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}, {id: ids.ID{2}}},
+			expectedPossibleValues: []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}, {id: ids.ID{2}}},
+			expectedLen:            2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			test := newReconciliationTest(t, tt.targetResponseSize, 60, 60, tt.requester, tt.responder, nil)
+			received := set.Set[*testTx]{}
+			test.requestSet.onAdd = func(tx *testTx) {
+				received.Add(tx)
+			}
+
+			require.NoError(test.gossiper.Gossip(ctx))
+			require.NoError(test.responseNetwork.AppRequest(ctx, ids.EmptyNodeID, 1, time.Time{}, <-test.requestSender.SentAppRequest))
+			require.NoError(test.requestNetwork.AppResponse(ctx, ids.EmptyNodeID, 1, <-test.responseSender.SentAppResponse))
+
+			require.Len(test.requestSet.txs, tt.expectedLen)
+			require.Subset(tt.expectedPossibleValues, maps.Values(test.requestSet.txs))
+
+			// we should not receive anything that we already had before we
+			// requested the gossip
+			for _, tx := range tt.requester {
+				require.NotContains(received, tx)
+			}
+		})
+	}
+}
+
+func TestReconciliationGossiperResize(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	responder := make([]*testTx, 100)
+	for i := range responder {
+		responder[i] = &testTx{id: ids.GenerateTestID()}
+	}
+	test := newReconciliationTest(t, 1024, 3, 12, nil, responder, nil)
+
+	// The difference can't be decoded from any allowed number of cells, so
+	// the IBLT grows until it reaches the maximum size
+	requestID := uint32(1)
+	for _, expectedNumCells := range []int{6, 12, 12} {
+		require.NoError(test.gossiper.Gossip(ctx))
+		require.NoError(test.responseNetwork.AppRequest(ctx, ids.EmptyNodeID, requestID, time.Time{}, <-test.requestSender.SentAppRequest))
+
+		appErr := <-test.responseSender.SentAppError
+		require.ErrorIs(appErr, ErrReconciliationFailed)
+		require.NoError(test.requestNetwork.AppRequestFailed(ctx, ids.EmptyNodeID, requestID, appErr))
+		require.Equal(expectedNumCells, test.gossiper.numCells)
+		requestID += 2
+	}
+}
+
+func TestReconciliationGossiperShrink(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	test := newReconciliationTest(t, 1024, 3, 12, nil, nil, nil)
+	test.gossiper.numCells = 12
+
+	// The IBLT is only shrunk after several consecutive responses that are
+	// much smaller than it
+	requestID := uint32(1)
+	for i := 0; i < 2*reconciliationShrinkResponses-1; i++ {
+		expectedNumCells := 12
+		if i >= reconciliationShrinkResponses-1 {
+			expectedNumCells = 6
+		}
+
+		require.NoError(test.gossiper.Gossip(ctx))
+		require.NoError(test.responseNetwork.AppRequest(ctx, ids.EmptyNodeID, requestID, time.Time{}, <-test.requestSender.SentAppRequest))
+		require.NoError(test.requestNetwork.AppResponse(ctx, ids.EmptyNodeID, requestID, <-test.responseSender.SentAppResponse))
+		require.Equal(expectedNumCells, test.gossiper.numCells)
+		requestID += 2
+	}
+}
+
+func TestReconciliationGossiperFallback(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	responder := make([]*testTx, 100)
+	for i := range responder {
+		responder[i] = &testTx{id: ids.GenerateTestID()}
+	}
+	fallbackRounds := 0
+	fallback := &TestGossiper{
+		GossipF: func(context.Context) error {
+			fallbackRounds++
+			return nil
+		},
+	}
+	test := newReconciliationTest(t, 1024, 12, 12, nil, responder, fallback)
+
+	// Peers repeatedly fail to decode the largest IBLT
+	requestID := uint32(1)
+	for i := 0; i < reconciliationMaxFailures; i++ {
+		require.NoError(test.gossiper.Gossip(ctx))
+		require.NoError(test.responseNetwork.AppRequest(ctx, ids.EmptyNodeID, requestID, time.Time{}, <-test.requestSender.SentAppRequest))
+
+		appErr := <-test.responseSender.SentAppError
+		require.ErrorIs(appErr, ErrReconciliationFailed)
+		require.NoError(test.requestNetwork.AppRequestFailed(ctx, ids.EmptyNodeID, requestID, appErr))
+		requestID += 2
+	}
+
+	// The following rounds use the fallback gossiper
+	for i := 0; i < reconciliationFallbackRounds; i++ {
+		require.NoError(test.gossiper.Gossip(ctx))
+		require.Empty(test.requestSender.SentAppRequest)
+	}
+	require.Equal(reconciliationFallbackRounds, fallbackRounds)
+
+	// Set reconciliation is attempted again afterwards
+	require.NoError(test.gossiper.Gossip(ctx))
+	require.Len(test.requestSender.SentAppRequest, 1)
+	require.Equal(reconciliationFallbackRounds, fallbackRounds)
+}
+
+func TestNewReconciliationGossiperInvalidCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		minCells int
+		maxCells int
+	}{
+		{
+			name:     "zero cells",
+			minCells: 0,
+			maxCells: 3,
+		},
+		{
+			name:     "not a multiple of the number of hashes",
+			minCells: 4,
+			maxCells: 12,
+		},
+		{
+			name:     "min cells greater than max cells",
+			minCells: 6,
+			maxCells: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReconciliationGossiper[*testTx](
+				logging.NoLog{},
+				testMarshaller{},
+				nil,
+				nil,
+				Metrics{},
+				1,
+				tt.minCells,
+				tt.maxCells,
+				nil,
+			)
+			require.ErrorIs(t, err, ErrInvalidReconciliationCells)
+		})
+	}
+}
@@ -49,10 +49,21 @@ func WithValidatorSampling(validators *Validators) ClientOption {
 	})
 }
 
+// WithReputation configures Client to record the latency and failures of its
+// requests in [reputation] and Client.AppRequestAny to prefer nodes with a
+// good reputation.
+func WithReputation(reputation *Reputation) ClientOption {
+	return clientOptionFunc(func(options *clientOptions) {
+		options.reputation = reputation
+	})
+}
+
 // clientOptions holds client-configurable values
 type clientOptions struct {
 	// nodeSampler is used to select nodes to route Client.AppRequestAny to
 	nodeSampler NodeSampler
+	// reputation is updated with the outcome of requests, if non-nil
+	reputation *Reputation
 }
 
 // NewNetwork returns an instance of Network
@@ -165,6 +176,12 @@ func (n *Network) NewClient(handlerID uint64, options ...ClientOption) *Client {
 	for _, option := range options {
 		option.apply(client.options)
 	}
+	if client.options.reputation != nil {
+		client.options.nodeSampler = &reputationSampler{
+			sampler:    client.options.nodeSampler,
+			reputation: client.options.reputation,
+		}
+	}
 
 	return client
 }
@@ -38,6 +38,10 @@ const (
 // Tracks the bandwidth of responses coming from peers,
 // preferring to contact peers with known good bandwidth, connecting
 // to new peers with an exponentially decaying probability.
+//
+// If a Reputation is provided, the outcome of requests is recorded in it and
+// peers that are selected without considering their bandwidth are preferred
+// based on their reputation.
 type PeerTracker struct {
 	// Lock to protect concurrent access to the peer tracker
 	lock sync.RWMutex
@@ -57,12 +61,16 @@ type PeerTracker struct {
 	bandwidthHeap heap.Map[ids.NodeID, safemath.Averager]
 	// Average bandwidth is only used for metrics.
 	averageBandwidth safemath.Averager
+	// Time that each outstanding request was sent to each tracked peer, keyed
+	// by request ID.
+	requestTimes map[ids.NodeID]map[uint32]time.Time
 
 	// The below fields are assumed to be constant and are not protected by the
 	// lock.
 	log          logging.Logger
 	ignoredNodes set.Set[ids.NodeID]
 	minVersion   *version.Application
+	reputation   *Reputation
 	metrics      peerTrackerMetrics
 }
 
@@ -78,6 +86,7 @@ func NewPeerTracker(
 	registerer prometheus.Registerer,
 	ignoredNodes set.Set[ids.NodeID],
 	minVersion *version.Application,
+	reputation *Reputation,
 ) (*PeerTracker, error) {
 	t := &PeerTracker{
 		peerBandwidth: make(map[ids.NodeID]safemath.Averager),
@@ -85,9 +94,11 @@ func NewPeerTracker(
 			return a.Read() > b.Read()
 		}),
 		averageBandwidth: safemath.NewAverager(0, bandwidthHalflife, time.Now()),
+		requestTimes:     make(map[ids.NodeID]map[uint32]time.Time),
 		log:              log,
 		ignoredNodes:     ignoredNodes,
 		minVersion:       minVersion,
+		reputation:       reputation,
 		metrics: peerTrackerMetrics{
 			numTrackedPeers: prometheus.NewGauge(
 				prometheus.GaugeOpts{
@@ -166,7 +177,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 	defer p.lock.RUnlock()
 
 	if p.shouldSelectUntrackedPeer() {
-		if nodeID, ok := p.untrackedPeers.Peek(); ok {
+		if nodeID, ok := p.peek(p.untrackedPeers); ok {
 			p.log.Debug("selecting peer",
 				zap.String("reason", "untracked"),
 				zap.Stringer("nodeID", nodeID),
@@ -188,7 +199,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 			return nodeID, true
 		}
 	} else {
-		if nodeID, ok := p.responsivePeers.Peek(); ok {
+		if nodeID, ok := p.peek(p.responsivePeers); ok {
 			p.log.Debug("selecting peer",
 				zap.String("reason", "responsive"),
 				zap.Stringer("nodeID", nodeID),
@@ -210,10 +221,26 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 	return ids.EmptyNodeID, false
 }
 
-// Record that we sent a request to [nodeID].
+// peek returns a peer from [peers]. If a reputation was provided, peers with a
+// better reputation are more likely to be returned.
+//
+// Assumes the read lock is held.
+func (p *PeerTracker) peek(peers set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	if p.reputation == nil {
+		return peers.Peek()
+	}
+
+	selected := p.reputation.Select(peers.List(), 1)
+	if len(selected) == 0 {
+		return ids.EmptyNodeID, false
+	}
+	return selected[0], true
+}
+
+// Record that we sent request [requestID] to [nodeID].
 //
 // Removes the peer's bandwidth averager from the bandwidth heap.
-func (p *PeerTracker) RegisterRequest(nodeID ids.NodeID) {
+func (p *PeerTracker) RegisterRequest(nodeID ids.NodeID, requestID uint32) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -221,27 +248,89 @@ func (p *PeerTracker) RegisterRequest(nodeID ids.NodeID) {
 	p.trackedPeers.Add(nodeID)
 	p.bandwidthHeap.Remove(nodeID)
 
+	requestTimes, ok := p.requestTimes[nodeID]
+	if !ok {
+		requestTimes = make(map[uint32]time.Time)
+		p.requestTimes[nodeID] = requestTimes
+	}
+	requestTimes[requestID] = time.Now()
+
 	p.metrics.numTrackedPeers.Set(float64(p.trackedPeers.Len()))
 }
 
-// Record that we observed that [nodeID]'s bandwidth is [bandwidth].
+// Record that [nodeID] responded to request [requestID] and that we observed
+// that [nodeID]'s bandwidth is [bandwidth].
 //
 // Adds the peer's bandwidth averager to the bandwidth heap.
-func (p *PeerTracker) RegisterResponse(nodeID ids.NodeID, bandwidth float64) {
+func (p *PeerTracker) RegisterResponse(nodeID ids.NodeID, requestID uint32, bandwidth float64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	requestTime, ok := p.removeRequest(nodeID, requestID)
+	if ok && p.reputation != nil {
+		p.reputation.RegisterResponse(nodeID, time.Since(requestTime))
+	}
 	p.updateBandwidth(nodeID, bandwidth, true)
 }
 
-// Record that a request failed to [nodeID].
+// Record that request [requestID] to [nodeID] failed.
 //
 // Adds the peer's bandwidth averager to the bandwidth heap.
-func (p *PeerTracker) RegisterFailure(nodeID ids.NodeID) {
+func (p *PeerTracker) RegisterFailure(nodeID ids.NodeID, requestID uint32) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, ok := p.removeRequest(nodeID, requestID)
+	if ok && p.reputation != nil {
+		p.reputation.RegisterFailure(nodeID)
+	}
 	p.updateBandwidth(nodeID, 0, false)
 }
 
-func (p *PeerTracker) updateBandwidth(nodeID ids.NodeID, bandwidth float64, responsive bool) {
+// Record that the last response of [nodeID], which was registered with
+// RegisterResponse, was invalid.
+//
+// The bandwidth of the response was already observed by RegisterResponse, so
+// only the responsiveness of the peer is updated.
+func (p *PeerTracker) RegisterInvalidResponse(nodeID ids.NodeID) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
+	if !p.trackedPeers.Contains(nodeID) {
+		// we're not tracking this peer, nothing to do here
+		p.log.Debug("registering invalid response for untracked peer",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return
+	}
+
+	if p.reputation != nil {
+		p.reputation.RegisterInvalidResponse(nodeID)
+	}
+	p.responsivePeers.Remove(nodeID)
+	p.metrics.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
+}
+
+// removeRequest removes request [requestID] to [nodeID] and returns the time
+// it was sent, if it is outstanding.
+//
+// Assumes the write lock is held.
+func (p *PeerTracker) removeRequest(nodeID ids.NodeID, requestID uint32) (time.Time, bool) {
+	requestTimes := p.requestTimes[nodeID]
+	requestTime, ok := requestTimes[requestID]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	delete(requestTimes, requestID)
+	if len(requestTimes) == 0 {
+		delete(p.requestTimes, nodeID)
+	}
+	return requestTime, true
+}
+
+// Assumes the write lock is held.
+func (p *PeerTracker) updateBandwidth(nodeID ids.NodeID, bandwidth float64, responsive bool) {
 	if !p.trackedPeers.Contains(nodeID) {
 		// we're not tracking this peer, nothing to do here
 		p.log.Debug("tracking bandwidth for untracked peer",
@@ -302,6 +391,7 @@ func (p *PeerTracker) Disconnected(nodeID ids.NodeID) {
 	p.trackedPeers.Remove(nodeID)
 	p.responsivePeers.Remove(nodeID)
 	delete(p.peerBandwidth, nodeID)
+	delete(p.requestTimes, nodeID)
 	p.bandwidthHeap.Remove(nodeID)
 
 	p.metrics.numTrackedPeers.Set(float64(p.trackedPeers.Len()))
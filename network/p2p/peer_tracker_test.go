@@ -22,6 +22,7 @@ func TestPeerTracker(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		nil,
 	)
 	require.NoError(err)
 
@@ -52,17 +53,17 @@ func TestPeerTracker(t *testing.T) {
 		require.Falsef(exists, "expected connecting to a new peer, but got the same peer twice: peer %s iteration %d", peer, i)
 		responsivePeers[peer] = true
 
-		p.RegisterRequest(peer) // mark the peer as having a message sent to it
+		p.RegisterRequest(peer, 0) // mark the peer as having a message sent to it
 	}
 
 	// Mark some peers as responsive and others as not responsive
 	i := 0
 	for peer := range responsivePeers {
 		if i < desiredMinResponsivePeers {
-			p.RegisterResponse(peer, 10)
+			p.RegisterResponse(peer, 0, 10)
 		} else {
 			responsivePeers[peer] = false // remember which peers were not responsive
-			p.RegisterFailure(peer)
+			p.RegisterFailure(peer, 0)
 		}
 		i++
 	}
@@ -77,11 +78,11 @@ func TestPeerTracker(t *testing.T) {
 		responsive, ok := responsivePeers[peer]
 		if ok {
 			require.Truef(responsive, "expected connecting to a responsive peer, but got a peer that was not responsive: peer %s iteration %d", peer, i)
-			p.RegisterResponse(peer, 10)
+			p.RegisterResponse(peer, 0, 10)
 		} else {
 			responsivePeers[peer] = false // remember that we connected to this peer
-			p.RegisterRequest(peer)       // mark the peer as having a message sent to it
-			p.RegisterFailure(peer)       // mark the peer as non-responsive
+			p.RegisterRequest(peer, 0)    // mark the peer as having a message sent to it
+			p.RegisterFailure(peer, 0)    // mark the peer as non-responsive
 		}
 	}
 
@@ -102,3 +103,34 @@ func TestPeerTracker(t *testing.T) {
 	require.True(ok)
 	require.Falsef(responsive, "expected connecting to a non-responsive peer, but got a peer that was responsive: peer %s", peer)
 }
+
+func TestPeerTrackerInvalidResponse(t *testing.T) {
+	require := require.New(t)
+	p, err := NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		nil,
+		nil,
+	)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	p.Connected(nodeID, &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	})
+
+	p.RegisterRequest(nodeID, 0)
+	p.RegisterResponse(nodeID, 0, 10)
+	require.True(p.responsivePeers.Contains(nodeID))
+	averageBandwidth := p.averageBandwidth.Read()
+
+	// The invalid response doesn't add another bandwidth observation
+	p.RegisterInvalidResponse(nodeID)
+	require.False(p.responsivePeers.Contains(nodeID))
+	require.Equal(10.0, p.peerBandwidth[nodeID].Read())
+	require.Equal(averageBandwidth, p.averageBandwidth.Read())
+}
@@ -0,0 +1,383 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	// reputationObservationWeight is the weight of the latest observation in
+	// the moving averages of a peer's reputation.
+	reputationObservationWeight = 0.1
+	// reputationLatencyTarget is the response latency that halves the latency
+	// component of a peer's score.
+	reputationLatencyTarget = time.Second
+	// unknownLatencyScore is the latency component of the score of a peer
+	// that never responded to us.
+	unknownLatencyScore = 0.5
+	// unknownPeerScore is the score of a peer that we have no information
+	// about.
+	unknownPeerScore = unknownLatencyScore
+	// reputationTTL is how long the reputation of a peer is kept after the
+	// last time we sent it a request.
+	reputationTTL = 14 * 24 * time.Hour
+	// reputationFlushFrequency is how often reputations are persisted.
+	reputationFlushFrequency = time.Minute
+	// reputationSampleFactor is the number of candidates, per requested node,
+	// that are sampled from the underlying NodeSampler before weighting them
+	// by reputation.
+	reputationSampleFactor = 4
+)
+
+var _ NodeSampler = (*reputationSampler)(nil)
+
+// Reputation scores peers by how well they served our requests. A score
+// combines the latency of a peer's responses, the fraction of requests it
+// failed to respond to, the fraction of its responses that were invalid, and
+// the fraction of time it was connected to us since we first sent it a
+// request. Only the time that this node was running is taken into account.
+//
+// Only the reputations of peers that we sent requests to are kept, and they
+// are pruned once we haven't sent requests to the peer for [reputationTTL].
+// Reputations are periodically persisted to a database so that they survive
+// restarts.
+//
+// Reputation is safe for concurrent use.
+type Reputation struct {
+	log   logging.Logger
+	db    database.Database
+	clock mockable.Clock
+
+	// Closed when Stop is called.
+	stop chan struct{}
+	// Closed when Dispatch has returned.
+	done chan struct{}
+
+	lock  sync.RWMutex
+	peers map[ids.NodeID]*peerReputation
+	// connected maps each connected peer to the time it connected.
+	connected map[ids.NodeID]time.Time
+}
+
+type peerReputation struct {
+	// Moving average of the response latency. Zero if the peer never
+	// responded to us.
+	Latency time.Duration `json:"latency"`
+	// Moving average of 1 for each response and 0 for each failed request.
+	Responsiveness float64 `json:"responsiveness"`
+	// Moving average of 1 for each invalid response and 0 for each valid
+	// response.
+	Invalidity float64 `json:"invalidity"`
+	// Updated is the last time the outcome of a request was recorded.
+	Updated time.Time `json:"updated"`
+	// Observed is the amount of time this node was running since the
+	// reputation was created, up to [observedSince].
+	Observed time.Duration `json:"observed"`
+	// Uptime is the amount of time we were connected to the peer while this
+	// node was running, up to [observedSince].
+	Uptime time.Duration `json:"uptime"`
+
+	// observedSince is the time up to which [Observed] and [Uptime] are
+	// accounted for. It is reset when the reputation is loaded, so that the
+	// time this node wasn't running isn't accounted for.
+	observedSince time.Time
+}
+
+// NewReputation returns a Reputation that is persisted to [db] and
+// initialized from its content.
+func NewReputation(log logging.Logger, db database.Database) (*Reputation, error) {
+	return newReputation(log, db, mockable.Clock{})
+}
+
+func newReputation(log logging.Logger, db database.Database, clock mockable.Clock) (*Reputation, error) {
+	r := &Reputation{
+		log:       log,
+		db:        db,
+		clock:     clock,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		peers:     make(map[ids.NodeID]*peerReputation),
+		connected: make(map[ids.NodeID]time.Time),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	now := r.clock.Time()
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		peer := &peerReputation{}
+		if err := json.Unmarshal(it.Value(), peer); err != nil {
+			return nil, err
+		}
+		peer.observedSince = now
+		r.peers[nodeID] = peer
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Dispatch periodically persists the reputations until Stop is called. Should
+// be called in a goroutine.
+func (r *Reputation) Dispatch() {
+	ticker := time.NewTicker(reputationFlushFrequency)
+	defer func() {
+		ticker.Stop()
+		close(r.done)
+	}()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Flush(); err != nil {
+				r.log.Warn("failed to persist peer reputations",
+					zap.Error(err),
+				)
+			}
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// Stop waits for Dispatch to return and persists the reputations one last
+// time. Must only be called once, after Dispatch was called.
+func (r *Reputation) Stop() error {
+	close(r.stop)
+	<-r.done
+	return r.Flush()
+}
+
+// Flush persists the reputations in a single batch. Reputations that weren't
+// updated for [reputationTTL] are deleted.
+func (r *Reputation) Flush() error {
+	batch := r.db.NewBatch()
+	if err := r.prepareFlush(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (r *Reputation) prepareFlush(batch database.Batch) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Time()
+	for nodeID, peer := range r.peers {
+		if now.Sub(peer.Updated) > reputationTTL {
+			delete(r.peers, nodeID)
+			if err := batch.Delete(nodeID.Bytes()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		r.updateUptime(nodeID, peer, now)
+		peerBytes, err := json.Marshal(peer)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(nodeID.Bytes(), peerBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Connected should be called when [nodeID] connects to this node.
+func (r *Reputation) Connected(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.connected[nodeID] = r.clock.Time()
+}
+
+// Disconnected should be called when [nodeID] disconnects from this node.
+func (r *Reputation) Disconnected(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if peer, ok := r.peers[nodeID]; ok {
+		r.updateUptime(nodeID, peer, r.clock.Time())
+	}
+	delete(r.connected, nodeID)
+}
+
+// RegisterResponse records that [nodeID] responded to a request after
+// [latency].
+func (r *Reputation) RegisterResponse(nodeID ids.NodeID, latency time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	peer := r.getOrCreate(nodeID)
+	if peer.Latency == 0 {
+		peer.Latency = latency
+	} else {
+		peer.Latency = time.Duration(observe(float64(peer.Latency), float64(latency)))
+	}
+	peer.Responsiveness = observe(peer.Responsiveness, 1)
+	peer.Invalidity = observe(peer.Invalidity, 0)
+}
+
+// RegisterFailure records that [nodeID] failed to respond to a request.
+func (r *Reputation) RegisterFailure(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	peer := r.getOrCreate(nodeID)
+	peer.Responsiveness = observe(peer.Responsiveness, 0)
+}
+
+// RegisterInvalidResponse records that the last response of [nodeID], which
+// was recorded with RegisterResponse, was invalid.
+func (r *Reputation) RegisterInvalidResponse(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// RegisterResponse observed the response as valid, so replacing that
+	// observation with an invalid one increases the average by the weight of
+	// an observation.
+	peer := r.getOrCreate(nodeID)
+	peer.Invalidity = math.Min(peer.Invalidity+reputationObservationWeight, 1)
+}
+
+// Score returns the score of [nodeID] in [0, 1]. Higher is better.
+func (r *Reputation) Score(nodeID ids.NodeID) float64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.score(nodeID)
+}
+
+// Select returns up to [limit] of [nodeIDs]. Nodes are sampled without
+// replacement with a probability proportional to their score.
+func (r *Reputation) Select(nodeIDs []ids.NodeID, limit int) []ids.NodeID {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	// Weighted sampling without replacement using the algorithm of
+	// Efraimidis and Spirakis: the nodes with the largest u^(1/score) are
+	// selected, where u is uniformly sampled from (0, 1).
+	type candidate struct {
+		nodeID ids.NodeID
+		key    float64
+	}
+	candidates := make([]candidate, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		score := math.Max(r.score(nodeID), math.SmallestNonzeroFloat64)
+		candidates[i] = candidate{
+			nodeID: nodeID,
+			key:    math.Pow(rand.Float64(), 1/score), // #nosec G404
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].key > candidates[j].key
+	})
+
+	limit = min(limit, len(candidates))
+	selected := make([]ids.NodeID, limit)
+	for i := range selected {
+		selected[i] = candidates[i].nodeID
+	}
+	return selected
+}
+
+// score assumes the read lock is held.
+func (r *Reputation) score(nodeID ids.NodeID) float64 {
+	peer, ok := r.peers[nodeID]
+	if !ok {
+		return unknownPeerScore
+	}
+
+	latencyScore := unknownLatencyScore
+	if peer.Latency > 0 {
+		latencyScore = float64(reputationLatencyTarget) / float64(reputationLatencyTarget+peer.Latency)
+	}
+
+	uptimeScore := 1.0
+	now := r.clock.Time()
+	if observed := peer.Observed + now.Sub(peer.observedSince); observed > 0 {
+		uptime := r.uptime(nodeID, peer, now)
+		uptimeScore = math.Min(float64(uptime)/float64(observed), 1)
+	}
+
+	return peer.Responsiveness * (1 - peer.Invalidity) * latencyScore * uptimeScore
+}
+
+// uptime returns the amount of time we were connected to [nodeID] while this
+// node was running, up to [now].
+//
+// Assumes the read lock is held.
+func (r *Reputation) uptime(nodeID ids.NodeID, peer *peerReputation, now time.Time) time.Duration {
+	uptime := peer.Uptime
+	if connectedAt, ok := r.connected[nodeID]; ok {
+		if connectedAt.Before(peer.observedSince) {
+			connectedAt = peer.observedSince
+		}
+		uptime += now.Sub(connectedAt)
+	}
+	return uptime
+}
+
+// updateUptime accounts for the time up to [now] in [peer].
+//
+// Assumes the write lock is held.
+func (r *Reputation) updateUptime(nodeID ids.NodeID, peer *peerReputation, now time.Time) {
+	peer.Uptime = r.uptime(nodeID, peer, now)
+	peer.Observed += now.Sub(peer.observedSince)
+	peer.observedSince = now
+}
+
+// getOrCreate records that the outcome of a request to [nodeID] is being
+// updated and returns its reputation.
+//
+// Assumes the write lock is held.
+func (r *Reputation) getOrCreate(nodeID ids.NodeID) *peerReputation {
+	now := r.clock.Time()
+	peer, ok := r.peers[nodeID]
+	if !ok {
+		peer = &peerReputation{
+			Responsiveness: 1,
+			observedSince:  now,
+		}
+		r.peers[nodeID] = peer
+	}
+	peer.Updated = now
+	return peer
+}
+
+// reputationSampler samples nodes from a NodeSampler and prefers the nodes
+// with the best reputation.
+type reputationSampler struct {
+	sampler    NodeSampler
+	reputation *Reputation
+}
+
+func (s *reputationSampler) Sample(ctx context.Context, limit int) []ids.NodeID {
+	candidates := s.sampler.Sample(ctx, reputationSampleFactor*limit)
+	return s.reputation.Select(candidates, limit)
+}
+
+func observe(average, value float64) float64 {
+	return (1-reputationObservationWeight)*average + reputationObservationWeight*value
+}
@@ -0,0 +1,314 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestReputationScore(t *testing.T) {
+	require := require.New(t)
+
+	r, err := NewReputation(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+
+	// Uptime isn't accounted for if no time passes
+	r.clock.Set(time.Unix(1000, 0))
+
+	var (
+		fast    = ids.GenerateTestNodeID()
+		slow    = ids.GenerateTestNodeID()
+		failing = ids.GenerateTestNodeID()
+		invalid = ids.GenerateTestNodeID()
+		unknown = ids.GenerateTestNodeID()
+	)
+	for i := 0; i < 10; i++ {
+		r.RegisterResponse(fast, 10*time.Millisecond)
+		r.RegisterResponse(slow, 5*time.Second)
+		r.RegisterResponse(failing, 10*time.Millisecond)
+		r.RegisterFailure(failing)
+		r.RegisterResponse(invalid, 10*time.Millisecond)
+		r.RegisterInvalidResponse(invalid)
+	}
+
+	require.Equal(unknownPeerScore, r.Score(unknown))
+	require.Greater(r.Score(fast), r.Score(unknown))
+	require.Greater(r.Score(unknown), r.Score(slow))
+	require.Greater(r.Score(fast), r.Score(failing))
+	require.Greater(r.Score(fast), r.Score(invalid))
+}
+
+func TestReputationUptime(t *testing.T) {
+	require := require.New(t)
+
+	r, err := NewReputation(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+
+	now := time.Unix(1000, 0)
+	r.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	r.RegisterResponse(nodeID, time.Second)
+	initialScore := r.Score(nodeID)
+
+	r.Connected(nodeID)
+	r.clock.Set(now.Add(time.Minute))
+	require.Equal(initialScore, r.Score(nodeID))
+
+	// The peer was only connected for half of the time we have known it
+	r.Disconnected(nodeID)
+	r.clock.Set(now.Add(2 * time.Minute))
+	require.InDelta(initialScore/2, r.Score(nodeID), 1e-9)
+}
+
+func TestReputationPersistence(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	r, err := NewReputation(logging.NoLog{}, db)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	r.RegisterResponse(nodeID, 100*time.Millisecond)
+	r.RegisterFailure(nodeID)
+	r.RegisterInvalidResponse(nodeID)
+	score := r.Score(nodeID)
+
+	// Reputations are only persisted when they are flushed
+	numRows, err := database.Count(db)
+	require.NoError(err)
+	require.Zero(numRows)
+
+	require.NoError(r.Flush())
+	r, err = NewReputation(logging.NoLog{}, db)
+	require.NoError(err)
+	require.InDelta(score, r.Score(nodeID), 1e-9)
+}
+
+func TestReputationUptimeExcludesDowntime(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	now := time.Unix(1000, 0)
+	clock := mockable.Clock{}
+	clock.Set(now)
+	r, err := newReputation(logging.NoLog{}, db, clock)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	r.RegisterResponse(nodeID, time.Second)
+	initialScore := r.Score(nodeID)
+
+	r.Connected(nodeID)
+	r.clock.Set(now.Add(time.Minute))
+	require.NoError(r.Flush())
+
+	// The time this node wasn't running isn't accounted for
+	now = now.Add(time.Hour)
+	clock.Set(now)
+	r, err = newReputation(logging.NoLog{}, db, clock)
+	require.NoError(err)
+	require.Equal(initialScore, r.Score(nodeID))
+
+	// The peer was only connected for half of the time this node was running
+	r.clock.Set(now.Add(time.Minute))
+	require.InDelta(initialScore/2, r.Score(nodeID), 1e-9)
+}
+
+func TestReputationPrune(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	r, err := NewReputation(logging.NoLog{}, db)
+	require.NoError(err)
+
+	now := time.Unix(1000, 0)
+	r.clock.Set(now)
+
+	// Only peers that were sent requests have a reputation
+	connected := ids.GenerateTestNodeID()
+	r.Connected(connected)
+
+	stale := ids.GenerateTestNodeID()
+	r.RegisterResponse(stale, time.Second)
+
+	r.clock.Set(now.Add(reputationTTL))
+	active := ids.GenerateTestNodeID()
+	r.RegisterResponse(active, time.Second)
+	require.NoError(r.Flush())
+	require.Len(r.peers, 2)
+
+	r.clock.Set(now.Add(reputationTTL + time.Second))
+	require.NoError(r.Flush())
+	require.Len(r.peers, 1)
+	require.Contains(r.peers, active)
+
+	r, err = NewReputation(logging.NoLog{}, db)
+	require.NoError(err)
+	require.Len(r.peers, 1)
+	require.Contains(r.peers, active)
+}
+
+func TestReputationSelect(t *testing.T) {
+	require := require.New(t)
+
+	r, err := NewReputation(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+
+	// A peer that was never connected since we first sent it a request has a
+	// score of 0
+	now := time.Unix(1000, 0)
+	r.clock.Set(now)
+	bad := ids.GenerateTestNodeID()
+	r.RegisterResponse(bad, time.Second)
+	r.Connected(bad)
+	r.Disconnected(bad)
+	r.clock.Set(now.Add(time.Minute))
+	require.Zero(r.Score(bad))
+
+	good := ids.GenerateTestNodeID()
+	for i := 0; i < 10; i++ {
+		require.Equal([]ids.NodeID{good}, r.Select([]ids.NodeID{bad, good}, 1))
+	}
+	require.ElementsMatch([]ids.NodeID{bad, good}, r.Select([]ids.NodeID{bad, good}, 3))
+	require.Empty(r.Select(nil, 1))
+}
+
+func TestPeerTrackerReputation(t *testing.T) {
+	require := require.New(t)
+
+	r, err := NewReputation(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+	p, err := NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		nil,
+		r,
+	)
+	require.NoError(err)
+
+	bad := ids.GenerateTestNodeID()
+	good := ids.GenerateTestNodeID()
+	p.Connected(bad, version.CurrentApp)
+	p.Connected(good, version.CurrentApp)
+
+	// Reputations of untracked peers, such as the ones persisted before a
+	// restart, are used to select peers.
+	p.RegisterRequest(bad, 0)
+	p.RegisterResponse(bad, 0, 0)
+	for i := 0; i < 100; i++ {
+		p.RegisterInvalidResponse(bad)
+	}
+	p.Disconnected(bad)
+	p.Connected(bad, version.CurrentApp)
+	require.Less(r.Score(bad), 0.01)
+
+	for i := 0; i < 10; i++ {
+		nodeID, ok := p.SelectPeer()
+		require.True(ok)
+		require.Equal(good, nodeID)
+	}
+}
+
+func TestPeerTrackerReputationLatency(t *testing.T) {
+	require := require.New(t)
+
+	r, err := NewReputation(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+	p, err := NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		nil,
+		r,
+	)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	p.Connected(nodeID, version.CurrentApp)
+
+	// The latency of each response is measured from the request it responds
+	// to, even if other requests were sent to the peer afterwards.
+	p.RegisterRequest(nodeID, 1)
+	time.Sleep(50 * time.Millisecond)
+	p.RegisterRequest(nodeID, 2)
+	p.RegisterResponse(nodeID, 1, 10)
+	require.GreaterOrEqual(r.peers[nodeID].Latency, 50*time.Millisecond)
+
+	p.RegisterResponse(nodeID, 2, 10)
+	require.Empty(p.requestTimes)
+
+	// Responses to unknown requests don't affect the latency
+	latency := r.peers[nodeID].Latency
+	p.RegisterResponse(nodeID, 3, 10)
+	require.Equal(latency, r.peers[nodeID].Latency)
+}
+
+func TestClientReputation(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	r, err := NewReputation(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+	r.clock.Set(time.Unix(1000, 0))
+
+	sender := enginetest.SenderStub{
+		SentAppRequest: make(chan []byte, 3),
+	}
+	network, err := NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	client := network.NewClient(handlerID, WithReputation(r))
+
+	var (
+		slow    = ids.GenerateTestNodeID()
+		fast    = ids.GenerateTestNodeID()
+		failing = ids.GenerateTestNodeID()
+	)
+	onResponse := func(context.Context, ids.NodeID, []byte, error) {}
+
+	// The latency of each request is measured from when it was sent
+	require.NoError(client.AppRequest(ctx, set.Of(slow), []byte("request"), onResponse))
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(client.AppRequest(ctx, set.Of(fast), []byte("request"), onResponse))
+	require.NoError(client.AppRequest(ctx, set.Of(failing), []byte("request"), onResponse))
+
+	require.NoError(network.AppResponse(ctx, slow, 1, []byte("response")))
+	require.NoError(network.AppResponse(ctx, fast, 3, []byte("response")))
+	require.NoError(network.AppRequestFailed(ctx, failing, 5, common.ErrTimeout))
+
+	require.GreaterOrEqual(r.peers[slow].Latency, 50*time.Millisecond)
+	require.Less(r.peers[fast].Latency, r.peers[slow].Latency)
+	require.Less(r.peers[failing].Responsiveness, 1.0)
+
+	// AppRequestAny prefers nodes with a good reputation
+	r.Connected(fast)
+	r.Connected(slow)
+	r.Disconnected(slow)
+	r.clock.Set(r.clock.Time().Add(time.Minute))
+	require.Zero(r.Score(slow))
+
+	require.NoError(network.Connected(ctx, slow, version.CurrentApp))
+	require.NoError(network.Connected(ctx, fast, version.CurrentApp))
+	for i := 0; i < 10; i++ {
+		require.Equal([]ids.NodeID{fast}, client.options.nodeSampler.Sample(ctx, 1))
+	}
+}
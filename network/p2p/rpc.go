@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+const (
+	rpcKindCall byte = iota
+	rpcKindNext
+)
+
+const (
+	rpcFlagMore byte = 1 << iota
+	rpcFlagEmpty
+)
+
+var (
+	_ Codec[proto.Message] = ProtoCodec[proto.Message]{}
+
+	// ErrUnknownMethod should be used to indicate that a request failed due to
+	// it not matching a registered method
+	ErrUnknownMethod = &common.AppError{
+		Code:    -5,
+		Message: "unknown method",
+	}
+	// ErrInvalidRequest should be used to indicate that a request failed due
+	// to it being malformed
+	ErrInvalidRequest = &common.AppError{
+		Code:    -6,
+		Message: "invalid request",
+	}
+	// ErrDeadlineExceeded should be used to indicate that a request failed due
+	// to it not being handled before its deadline
+	ErrDeadlineExceeded = &common.AppError{
+		Code:    -7,
+		Message: "deadline exceeded",
+	}
+	// ErrUnknownStream should be used to indicate that a request failed due to
+	// it referencing a stream that doesn't exist or that has expired
+	ErrUnknownStream = &common.AppError{
+		Code:    -8,
+		Message: "unknown stream",
+	}
+
+	errTruncatedRPCMessage = errors.New("truncated rpc message")
+	errUnknownRPCKind      = errors.New("unknown rpc message kind")
+)
+
+// Codec serializes the requests or responses of a Method.
+type Codec[T any] interface {
+	Marshal(T) ([]byte, error)
+	Unmarshal([]byte) (T, error)
+}
+
+// ProtoCodec is a Codec for protobuf messages.
+type ProtoCodec[T proto.Message] struct{}
+
+func (ProtoCodec[T]) Marshal(msg T) ([]byte, error) {
+	return proto.Marshal(msg)
+}
+
+func (ProtoCodec[T]) Unmarshal(bytes []byte) (T, error) {
+	var zero T
+	msg := zero.ProtoReflect().New().Interface().(T)
+	return msg, proto.Unmarshal(bytes, msg)
+}
+
+// Method describes a typed request/response method. The same Method should be
+// used by the TypedClient issuing requests and the RPCHandler serving them.
+type Method[Req, Resp any] struct {
+	// ID uniquely identifies the method within an RPCHandler
+	ID uint64
+	// Name is used to label the metrics of the method
+	Name          string
+	RequestCodec  Codec[Req]
+	ResponseCodec Codec[Resp]
+	// Timeout is the maximum amount of time the client waits for a response.
+	// If zero, only the deadline of the request context is used.
+	Timeout time.Duration
+}
+
+// rpcRequest is the wire format of a request:
+//
+//	[kind byte][method uvarint][timeout ms uvarint][stream id uvarint][payload]
+//
+// where the stream id is only present for rpcKindNext requests. The timeout is
+// rounded up to the next millisecond and zero means that no timeout was set.
+type rpcRequest struct {
+	kind     byte
+	method   uint64
+	timeout  time.Duration
+	streamID uint64
+	payload  []byte
+}
+
+func (r *rpcRequest) bytes() []byte {
+	b := make([]byte, 0, 1+3*binary.MaxVarintLen64+len(r.payload))
+	b = append(b, r.kind)
+	b = binary.AppendUvarint(b, r.method)
+	b = binary.AppendUvarint(b, uint64((r.timeout+time.Millisecond-1)/time.Millisecond))
+	if r.kind == rpcKindNext {
+		b = binary.AppendUvarint(b, r.streamID)
+	}
+	return append(b, r.payload...)
+}
+
+func parseRPCRequest(b []byte) (*rpcRequest, error) {
+	if len(b) == 0 {
+		return nil, errTruncatedRPCMessage
+	}
+	r := &rpcRequest{
+		kind: b[0],
+	}
+	if r.kind != rpcKindCall && r.kind != rpcKindNext {
+		return nil, errUnknownRPCKind
+	}
+	b = b[1:]
+
+	var (
+		timeoutMS uint64
+		err       error
+	)
+	if r.method, b, err = readUvarint(b); err != nil {
+		return nil, err
+	}
+	if timeoutMS, b, err = readUvarint(b); err != nil {
+		return nil, err
+	}
+	r.timeout = time.Duration(timeoutMS) * time.Millisecond
+	if r.kind == rpcKindNext {
+		if r.streamID, b, err = readUvarint(b); err != nil {
+			return nil, err
+		}
+	}
+	r.payload = b
+	return r, nil
+}
+
+// rpcResponse is the wire format of a response:
+//
+//	[flags byte][stream id uvarint][payload]
+//
+// where the stream id is only present if more parts of the response remain.
+// An empty stream is sent as a single response without a payload.
+type rpcResponse struct {
+	hasMore  bool
+	empty    bool
+	streamID uint64
+	payload  []byte
+}
+
+func (r *rpcResponse) bytes() []byte {
+	var flags byte
+	if r.hasMore {
+		flags |= rpcFlagMore
+	}
+	if r.empty {
+		flags |= rpcFlagEmpty
+	}
+
+	b := make([]byte, 0, 1+binary.MaxVarintLen64+len(r.payload))
+	b = append(b, flags)
+	if r.hasMore {
+		b = binary.AppendUvarint(b, r.streamID)
+	}
+	return append(b, r.payload...)
+}
+
+func parseRPCResponse(b []byte) (*rpcResponse, error) {
+	if len(b) == 0 {
+		return nil, errTruncatedRPCMessage
+	}
+	r := &rpcResponse{
+		hasMore: b[0]&rpcFlagMore != 0,
+		empty:   b[0]&rpcFlagEmpty != 0,
+	}
+	b = b[1:]
+	if r.hasMore {
+		var err error
+		if r.streamID, b, err = readUvarint(b); err != nil {
+			return nil, err
+		}
+	}
+	r.payload = b
+	return r, nil
+}
+
+func readUvarint(b []byte) (uint64, []byte, error) {
+	v, n := binary.Uvarint(b)
+	if n <= 0 {
+		return 0, nil, errTruncatedRPCMessage
+	}
+	return v, b[n:], nil
+}
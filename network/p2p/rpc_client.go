@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// TypedResponseCallback is called upon receiving the response to a request
+// issued by TypedClient.
+// Callers should check [err] to see whether the request failed or not.
+type TypedResponseCallback[Resp any] func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	resp Resp,
+	err error,
+)
+
+// StreamPartCallback is called upon receiving a part of a streamed response.
+type StreamPartCallback[Resp any] func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	resp Resp,
+)
+
+// StreamDoneCallback is called once a streamed response has been fully
+// received or has failed.
+// Callers should check [err] to see whether the stream failed or not.
+type StreamDoneCallback func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	err error,
+)
+
+// TypedClient issues requests for a Method to the RPCHandler of a peer.
+//
+// If the Method has a timeout, or the request context has a deadline, the
+// callbacks may be called with ErrDeadlineExceeded from a separate goroutine
+// than the one handling responses.
+type TypedClient[Req, Resp any] struct {
+	client *Client
+	method Method[Req, Resp]
+}
+
+// NewTypedClient returns a TypedClient that issues requests for [method]
+// using [client]. [client] must be for the handler ID that the RPCHandler
+// serving [method] was added with.
+func NewTypedClient[Req, Resp any](client *Client, method Method[Req, Resp]) *TypedClient[Req, Resp] {
+	return &TypedClient[Req, Resp]{
+		client: client,
+		method: method,
+	}
+}
+
+// RequestAny issues a request to an arbitrary node decided by the Client.
+func (c *TypedClient[Req, Resp]) RequestAny(
+	ctx context.Context,
+	req Req,
+	onResponse TypedResponseCallback[Resp],
+) error {
+	sampled := c.client.options.nodeSampler.Sample(ctx, 1)
+	if len(sampled) != 1 {
+		return ErrNoPeers
+	}
+	return c.Request(ctx, sampled[0], req, onResponse)
+}
+
+// Request issues [req] to [nodeID]. [onResponse] is invoked exactly once upon
+// an error or a response.
+func (c *TypedClient[Req, Resp]) Request(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	req Req,
+	onResponse TypedResponseCallback[Resp],
+) error {
+	return c.call(ctx, nodeID, req, func(ctx context.Context, nodeID ids.NodeID, response *rpcResponse, err error) {
+		var resp Resp
+		if err == nil {
+			resp, err = c.method.ResponseCodec.Unmarshal(response.payload)
+		}
+		onResponse(ctx, nodeID, resp, err)
+	})
+}
+
+// Stream issues [req] to [nodeID] and fetches every part of the response.
+// [onPart] is invoked for each part in order. [onDone] is invoked exactly once
+// after the last part was received or upon an error.
+func (c *TypedClient[Req, Resp]) Stream(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	req Req,
+	onPart StreamPartCallback[Resp],
+	onDone StreamDoneCallback,
+) error {
+	var onResponse rpcResponseCallback
+	onResponse = func(callbackCtx context.Context, nodeID ids.NodeID, response *rpcResponse, err error) {
+		if err != nil {
+			onDone(callbackCtx, nodeID, err)
+			return
+		}
+
+		if !response.empty {
+			resp, err := c.method.ResponseCodec.Unmarshal(response.payload)
+			if err != nil {
+				onDone(callbackCtx, nodeID, err)
+				return
+			}
+			onPart(callbackCtx, nodeID, resp)
+		}
+
+		if !response.hasMore {
+			onDone(callbackCtx, nodeID, nil)
+			return
+		}
+
+		timeout, err := c.timeout(ctx)
+		if err != nil {
+			onDone(callbackCtx, nodeID, err)
+			return
+		}
+		next := rpcRequest{
+			kind:     rpcKindNext,
+			method:   c.method.ID,
+			timeout:  timeout,
+			streamID: response.streamID,
+		}
+		if err := c.issue(ctx, nodeID, next.bytes(), timeout, onResponse); err != nil {
+			onDone(callbackCtx, nodeID, err)
+		}
+	}
+	return c.call(ctx, nodeID, req, onResponse)
+}
+
+type rpcResponseCallback func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	response *rpcResponse,
+	err error,
+)
+
+func (c *TypedClient[Req, Resp]) call(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	req Req,
+	onResponse rpcResponseCallback,
+) error {
+	timeout, err := c.timeout(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := c.method.RequestCodec.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	request := rpcRequest{
+		kind:    rpcKindCall,
+		method:  c.method.ID,
+		timeout: timeout,
+		payload: payload,
+	}
+	return c.issue(ctx, nodeID, request.bytes(), timeout, onResponse)
+}
+
+// issue sends [requestBytes] to [nodeID]. If [timeout] is non-zero and no
+// response is received within [timeout], [onResponse] is called with
+// ErrDeadlineExceeded and the late response is dropped.
+func (c *TypedClient[Req, Resp]) issue(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestBytes []byte,
+	timeout time.Duration,
+	onResponse rpcResponseCallback,
+) error {
+	var (
+		done  atomic.Bool
+		timer atomic.Pointer[time.Timer]
+	)
+	err := c.client.AppRequest(ctx, set.Of(nodeID), requestBytes, func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		if !done.CompareAndSwap(false, true) {
+			return
+		}
+		if t := timer.Load(); t != nil {
+			t.Stop()
+		}
+
+		if err != nil {
+			onResponse(ctx, nodeID, nil, err)
+			return
+		}
+		response, err := parseRPCResponse(responseBytes)
+		onResponse(ctx, nodeID, response, err)
+	})
+	if err != nil || timeout == 0 {
+		return err
+	}
+
+	timer.Store(time.AfterFunc(timeout, func() {
+		if done.CompareAndSwap(false, true) {
+			onResponse(context.WithoutCancel(ctx), nodeID, nil, ErrDeadlineExceeded)
+		}
+	}))
+	return nil
+}
+
+// timeout returns the time the client waits for a response, which is the
+// earliest of the timeout of the method and the deadline of [ctx].
+func (c *TypedClient[Req, Resp]) timeout(ctx context.Context) (time.Duration, error) {
+	timeout := c.method.Timeout
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout, nil
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, context.DeadlineExceeded
+	}
+	if timeout == 0 || remaining < timeout {
+		timeout = remaining
+	}
+	return timeout, nil
+}
@@ -0,0 +1,600 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// streamTTL is how long the remaining parts of a streamed response are
+	// kept for the requester to fetch them.
+	streamTTL = 30 * time.Second
+	// maxStreamsPerNode is the maximum number of streamed responses that are
+	// kept for a single requester at once.
+	maxStreamsPerNode = 8
+	// maxStreamBytesPerNode is the maximum number of bytes of streamed
+	// responses that are buffered for a single requester at once.
+	maxStreamBytesPerNode = 2 * units.MiB
+
+	methodLabel = "method"
+)
+
+var (
+	_ Handler = (*RPCHandler)(nil)
+
+	ErrExistingMethod = errors.New("existing method")
+)
+
+// TypedHandler is the server-side logic of a Method.
+type TypedHandler[Req, Resp any] interface {
+	// Handle returns the response to [req]. If an *common.AppError is
+	// returned, it is sent to the requester as-is.
+	Handle(ctx context.Context, nodeID ids.NodeID, req Req) (Resp, error)
+}
+
+// StreamHandler is the server-side logic of a Method whose response is sent in
+// multiple parts.
+type StreamHandler[Req, Resp any] interface {
+	// HandleStream returns an iterator over the parts of the response to
+	// [req], in the order they should be received by the requester.
+	HandleStream(ctx context.Context, nodeID ids.NodeID, req Req) (ResponseIterator[Resp], error)
+}
+
+// ResponseIterator produces the parts of a streamed response as they are
+// requested, so that the full response is never held in memory.
+type ResponseIterator[Resp any] interface {
+	// Next returns the next part of the response, or false if there are no
+	// more parts. [ctx] expires along with the request that the part is
+	// fetched for.
+	Next(ctx context.Context) (Resp, bool, error)
+	// Release is called once no more parts will be fetched.
+	Release()
+}
+
+type rpcMethod struct {
+	name   string
+	handle func(ctx context.Context, nodeID ids.NodeID, payload []byte) (partIterator, error)
+}
+
+// partIterator produces the marshalled parts of a response.
+type partIterator interface {
+	next(ctx context.Context) ([]byte, bool, error)
+	release()
+}
+
+// singlePart is the partIterator of a response that has exactly one part.
+type singlePart struct {
+	part []byte
+	done bool
+}
+
+func (s *singlePart) next(context.Context) ([]byte, bool, error) {
+	if s.done {
+		return nil, false, nil
+	}
+	s.done = true
+	return s.part, true, nil
+}
+
+func (*singlePart) release() {}
+
+// marshaledParts is the partIterator of a StreamHandler's response.
+type marshaledParts[Resp any] struct {
+	it    ResponseIterator[Resp]
+	codec Codec[Resp]
+}
+
+func (m *marshaledParts[Resp]) next(ctx context.Context) ([]byte, bool, error) {
+	resp, ok, err := m.it.Next(ctx)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	part, err := m.codec.Marshal(resp)
+	if err != nil {
+		return nil, false, err
+	}
+	return part, true, nil
+}
+
+func (m *marshaledParts[Resp]) release() {
+	m.it.Release()
+}
+
+type streamKey struct {
+	nodeID   ids.NodeID
+	streamID uint64
+}
+
+type stream struct {
+	method uint64
+	parts  partIterator
+	// next is the part that is sent in response to the next request for the
+	// stream. It is fetched ahead of time so that the requester can be told
+	// whether there are more parts.
+	next   []byte
+	expiry time.Time
+}
+
+type rpcMetrics struct {
+	count    *prometheus.CounterVec
+	time     *prometheus.GaugeVec
+	failures *prometheus.CounterVec
+}
+
+// RPCHandler is a Handler that dispatches requests issued by TypedClients to
+// the TypedHandler or StreamHandler registered for their Method.
+type RPCHandler struct {
+	log     logging.Logger
+	metrics rpcMetrics
+
+	lock    sync.RWMutex
+	methods map[uint64]*rpcMethod
+	// streams contains the streams that are waiting for the requester to
+	// fetch their next part. Streams whose next part is being fetched are
+	// removed until the part has been fetched.
+	streams map[streamKey]*stream
+	// nodeStreams is the number of streams of each requester, including the
+	// streams whose next part is being fetched.
+	nodeStreams map[ids.NodeID]int
+	// nodeBytes is the number of bytes buffered in the streams of each
+	// requester.
+	nodeBytes    map[ids.NodeID]int
+	nextStreamID uint64
+}
+
+func NewRPCHandler(
+	log logging.Logger,
+	registerer prometheus.Registerer,
+	namespace string,
+) (*RPCHandler, error) {
+	h := &RPCHandler{
+		log: log,
+		metrics: rpcMetrics{
+			count: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Name:      "rpc_count",
+					Help:      "rpc request count (n)",
+				},
+				[]string{methodLabel},
+			),
+			time: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Name:      "rpc_time",
+					Help:      "rpc request handling time (ns)",
+				},
+				[]string{methodLabel},
+			),
+			failures: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Name:      "rpc_failures",
+					Help:      "rpc request failure count (n)",
+				},
+				[]string{methodLabel},
+			),
+		},
+		methods:     make(map[uint64]*rpcMethod),
+		streams:     make(map[streamKey]*stream),
+		nodeStreams: make(map[ids.NodeID]int),
+		nodeBytes:   make(map[ids.NodeID]int),
+	}
+
+	err := errors.Join(
+		registerer.Register(h.metrics.count),
+		registerer.Register(h.metrics.time),
+		registerer.Register(h.metrics.failures),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// RegisterMethod registers [handler] to serve requests for [method].
+func RegisterMethod[Req, Resp any](
+	h *RPCHandler,
+	method Method[Req, Resp],
+	handler TypedHandler[Req, Resp],
+) error {
+	return h.register(method.ID, method.Name, func(ctx context.Context, nodeID ids.NodeID, payload []byte) (partIterator, error) {
+		req, err := method.RequestCodec.Unmarshal(payload)
+		if err != nil {
+			return nil, ErrInvalidRequest
+		}
+
+		resp, err := handler.Handle(ctx, nodeID, req)
+		if err != nil {
+			return nil, err
+		}
+
+		respBytes, err := method.ResponseCodec.Marshal(resp)
+		if err != nil {
+			return nil, err
+		}
+		return &singlePart{part: respBytes}, nil
+	})
+}
+
+// RegisterStreamMethod registers [handler] to serve streamed requests for
+// [method].
+func RegisterStreamMethod[Req, Resp any](
+	h *RPCHandler,
+	method Method[Req, Resp],
+	handler StreamHandler[Req, Resp],
+) error {
+	return h.register(method.ID, method.Name, func(ctx context.Context, nodeID ids.NodeID, payload []byte) (partIterator, error) {
+		req, err := method.RequestCodec.Unmarshal(payload)
+		if err != nil {
+			return nil, ErrInvalidRequest
+		}
+
+		it, err := handler.HandleStream(ctx, nodeID, req)
+		if err != nil {
+			return nil, err
+		}
+		return &marshaledParts[Resp]{
+			it:    it,
+			codec: method.ResponseCodec,
+		}, nil
+	})
+}
+
+func (h *RPCHandler) register(
+	methodID uint64,
+	name string,
+	handle func(context.Context, ids.NodeID, []byte) (partIterator, error),
+) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, ok := h.methods[methodID]; ok {
+		return fmt.Errorf("failed to register method id %d: %w", methodID, ErrExistingMethod)
+	}
+
+	h.methods[methodID] = &rpcMethod{
+		name:   name,
+		handle: handle,
+	}
+	return nil
+}
+
+func (*RPCHandler) AppGossip(context.Context, ids.NodeID, []byte) {}
+
+// AppRequest handles a request issued by a TypedClient. The handler of the
+// method is given a context that expires at the earliest of [deadline] and the
+// timeout of the requester.
+func (h *RPCHandler) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	deadline time.Time,
+	requestBytes []byte,
+) ([]byte, *common.AppError) {
+	start := time.Now()
+	req, err := parseRPCRequest(requestBytes)
+	if err != nil {
+		h.log.Debug("failed to parse rpc request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return nil, ErrInvalidRequest
+	}
+
+	h.lock.RLock()
+	method, ok := h.methods[req.method]
+	h.lock.RUnlock()
+	if !ok {
+		h.log.Debug("received rpc request for unknown method",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("method", req.method),
+		)
+		return nil, ErrUnknownMethod
+	}
+
+	response, err := h.handle(ctx, nodeID, deadline, req, method)
+	h.observe(method.name, start, err)
+	if err != nil {
+		return nil, h.toAppError(nodeID, method.name, err)
+	}
+	return response, nil
+}
+
+func (h *RPCHandler) handle(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	deadline time.Time,
+	req *rpcRequest,
+	method *rpcMethod,
+) ([]byte, error) {
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+	if req.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.timeout)
+		defer cancel()
+	}
+
+	if req.kind == rpcKindNext {
+		return h.next(ctx, nodeID, req)
+	}
+
+	parts, err := method.handle(ctx, nodeID, req.payload)
+	if err != nil {
+		return nil, err
+	}
+
+	part, ok, err := parts.next(ctx)
+	if err != nil {
+		parts.release()
+		return nil, err
+	}
+	if !ok {
+		parts.release()
+		response := rpcResponse{
+			empty: true,
+		}
+		return response.bytes(), nil
+	}
+
+	nextPart, hasMore, err := fetchNext(ctx, parts)
+	if err != nil {
+		return nil, err
+	}
+	if !hasMore {
+		response := rpcResponse{
+			payload: part,
+		}
+		return response.bytes(), nil
+	}
+
+	streamID, err := h.addStream(nodeID, &stream{
+		method: req.method,
+		parts:  parts,
+		next:   nextPart,
+	})
+	if err != nil {
+		parts.release()
+		return nil, err
+	}
+	response := rpcResponse{
+		hasMore:  true,
+		streamID: streamID,
+		payload:  part,
+	}
+	return response.bytes(), nil
+}
+
+// next returns the next part of the stream referenced by [req].
+func (h *RPCHandler) next(ctx context.Context, nodeID ids.NodeID, req *rpcRequest) ([]byte, error) {
+	key := streamKey{
+		nodeID:   nodeID,
+		streamID: req.streamID,
+	}
+	s, err := h.takeStream(key, req.method)
+	if err != nil {
+		return nil, err
+	}
+
+	response := rpcResponse{
+		payload: s.next,
+	}
+	nextPart, hasMore, err := fetchNext(ctx, s.parts)
+	if err != nil || !hasMore {
+		h.removeStream(nodeID)
+		if err != nil {
+			return nil, err
+		}
+		return response.bytes(), nil
+	}
+
+	s.next = nextPart
+	if err := h.returnStream(key, s); err != nil {
+		s.parts.release()
+		return nil, err
+	}
+	response.hasMore = true
+	response.streamID = req.streamID
+	return response.bytes(), nil
+}
+
+// fetchNext returns the next part of [parts], if there is one. [parts] is
+// released if there are no more parts or the requester has given up on the
+// response.
+func fetchNext(ctx context.Context, parts partIterator) ([]byte, bool, error) {
+	part, ok, err := parts.next(ctx)
+	if err == nil {
+		// The requester has already given up on the response
+		err = ctx.Err()
+	}
+	if err != nil || !ok {
+		parts.release()
+		return nil, false, err
+	}
+	return part, true, nil
+}
+
+// addStream stores [s] until [nodeID] fetches its next part.
+func (h *RPCHandler) addStream(nodeID ids.NodeID, s *stream) (uint64, error) {
+	expired := h.removeExpiredStreams()
+	defer releaseStreams(expired)
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.nodeStreams[nodeID] >= maxStreamsPerNode {
+		return 0, ErrThrottled
+	}
+	if h.nodeBytes[nodeID]+len(s.next) > maxStreamBytesPerNode {
+		return 0, ErrThrottled
+	}
+
+	streamID := h.nextStreamID
+	h.nextStreamID++
+	s.expiry = time.Now().Add(streamTTL)
+	h.streams[streamKey{nodeID: nodeID, streamID: streamID}] = s
+	h.nodeStreams[nodeID]++
+	h.nodeBytes[nodeID] += len(s.next)
+	return streamID, nil
+}
+
+// takeStream removes the stream referenced by [key] while its next part is
+// being fetched, so that the part can't be fetched more than once. If the
+// stream has expired, it is released instead.
+func (h *RPCHandler) takeStream(key streamKey, methodID uint64) (*stream, error) {
+	h.lock.Lock()
+	s, ok := h.streams[key]
+	if !ok || s.method != methodID {
+		h.lock.Unlock()
+		return nil, ErrUnknownStream
+	}
+
+	delete(h.streams, key)
+	h.removeBytes(key.nodeID, len(s.next))
+	if !time.Now().After(s.expiry) {
+		h.lock.Unlock()
+		return s, nil
+	}
+
+	h.removeNodeStream(key.nodeID)
+	h.lock.Unlock()
+
+	releaseStreams([]*stream{s})
+	return nil, ErrUnknownStream
+}
+
+// returnStream stores [s] again after its next part has been fetched.
+func (h *RPCHandler) returnStream(key streamKey, s *stream) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.nodeBytes[key.nodeID]+len(s.next) > maxStreamBytesPerNode {
+		h.removeNodeStream(key.nodeID)
+		return ErrThrottled
+	}
+
+	s.expiry = time.Now().Add(streamTTL)
+	h.streams[key] = s
+	h.nodeBytes[key.nodeID] += len(s.next)
+	return nil
+}
+
+// removeStream removes a stream of [nodeID] that was taken by takeStream and
+// won't be returned.
+func (h *RPCHandler) removeStream(nodeID ids.NodeID) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.removeNodeStream(nodeID)
+}
+
+// ExpireStreams releases the streams whose requesters stopped fetching them
+// every [frequency] until [ctx] is cancelled. Otherwise, expired streams are
+// only released when a stream is created or when they are fetched.
+func (h *RPCHandler) ExpireStreams(ctx context.Context, frequency time.Duration) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			releaseStreams(h.removeExpiredStreams())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// removeExpiredStreams removes and returns the streams that have expired.
+func (h *RPCHandler) removeExpiredStreams() []*stream {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	var (
+		now     = time.Now()
+		expired []*stream
+	)
+	for key, s := range h.streams {
+		if now.After(s.expiry) {
+			delete(h.streams, key)
+			h.removeBytes(key.nodeID, len(s.next))
+			h.removeNodeStream(key.nodeID)
+			expired = append(expired, s)
+		}
+	}
+	return expired
+}
+
+// releaseStreams releases [streams] without holding the lock, as releasing a
+// stream calls into its handler.
+func releaseStreams(streams []*stream) {
+	for _, s := range streams {
+		s.parts.release()
+	}
+}
+
+// removeNodeStream assumes the write lock is held.
+func (h *RPCHandler) removeNodeStream(nodeID ids.NodeID) {
+	h.nodeStreams[nodeID]--
+	if h.nodeStreams[nodeID] == 0 {
+		delete(h.nodeStreams, nodeID)
+	}
+}
+
+// removeBytes assumes the write lock is held.
+func (h *RPCHandler) removeBytes(nodeID ids.NodeID, numBytes int) {
+	h.nodeBytes[nodeID] -= numBytes
+	if h.nodeBytes[nodeID] == 0 {
+		delete(h.nodeBytes, nodeID)
+	}
+}
+
+func (h *RPCHandler) observe(method string, start time.Time, err error) {
+	labels := prometheus.Labels{
+		methodLabel: method,
+	}
+	h.metrics.count.With(labels).Inc()
+	h.metrics.time.With(labels).Add(float64(time.Since(start)))
+	if err != nil {
+		h.metrics.failures.With(labels).Inc()
+	}
+}
+
+// toAppError maps [err] to the error that is sent to the requester.
+func (h *RPCHandler) toAppError(nodeID ids.NodeID, method string, err error) *common.AppError {
+	var appErr *common.AppError
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrDeadlineExceeded
+	default:
+		h.log.Debug("failed to handle rpc request",
+			zap.Stringer("nodeID", nodeID),
+			zap.String("method", method),
+			zap.Error(err),
+		)
+		return ErrUnexpected
+	}
+}
+
+func (*RPCHandler) CrossChainAppRequest(context.Context, ids.ID, time.Time, []byte) ([]byte, error) {
+	return nil, nil
+}
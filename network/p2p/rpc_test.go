@@ -0,0 +1,540 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const rpcHandlerID = 1
+
+var (
+	_ TypedHandler[*sdk.SignatureRequest, *sdk.SignatureResponse]  = (*testTypedHandler)(nil)
+	_ StreamHandler[*sdk.SignatureRequest, *sdk.SignatureResponse] = (*testStreamHandler)(nil)
+
+	testMethod = Method[*sdk.SignatureRequest, *sdk.SignatureResponse]{
+		ID:            1,
+		Name:          "test",
+		RequestCodec:  ProtoCodec[*sdk.SignatureRequest]{},
+		ResponseCodec: ProtoCodec[*sdk.SignatureResponse]{},
+	}
+	testStreamMethod = Method[*sdk.SignatureRequest, *sdk.SignatureResponse]{
+		ID:            2,
+		Name:          "test_stream",
+		RequestCodec:  ProtoCodec[*sdk.SignatureRequest]{},
+		ResponseCodec: ProtoCodec[*sdk.SignatureResponse]{},
+	}
+)
+
+type testTypedHandler struct {
+	handleF func(context.Context, ids.NodeID, *sdk.SignatureRequest) (*sdk.SignatureResponse, error)
+}
+
+func (t *testTypedHandler) Handle(ctx context.Context, nodeID ids.NodeID, req *sdk.SignatureRequest) (*sdk.SignatureResponse, error) {
+	return t.handleF(ctx, nodeID, req)
+}
+
+// testStreamHandler responds with one part per byte of the request message.
+// Each part repeats its byte [partSize] times.
+type testStreamHandler struct {
+	partSize  int
+	iterators []*testResponseIterator
+}
+
+func (t *testStreamHandler) HandleStream(_ context.Context, _ ids.NodeID, req *sdk.SignatureRequest) (ResponseIterator[*sdk.SignatureResponse], error) {
+	it := &testResponseIterator{
+		message:  req.Message,
+		partSize: max(t.partSize, 1),
+	}
+	t.iterators = append(t.iterators, it)
+	return it, nil
+}
+
+type testResponseIterator struct {
+	message  []byte
+	partSize int
+	released bool
+}
+
+func (t *testResponseIterator) Next(context.Context) (*sdk.SignatureResponse, bool, error) {
+	if len(t.message) == 0 {
+		return nil, false, nil
+	}
+	resp := &sdk.SignatureResponse{
+		Signature: bytes.Repeat(t.message[:1], t.partSize),
+	}
+	t.message = t.message[1:]
+	return resp, true, nil
+}
+
+func (t *testResponseIterator) Release() {
+	t.released = true
+}
+
+// newRPCTestClient returns a Client that is connected to a server with
+// [handler]. Messages are delivered asynchronously, as they would be over the
+// network.
+func newRPCTestClient(t *testing.T, handler Handler) (*Client, ids.NodeID) {
+	require := require.New(t)
+
+	var (
+		clientNodeID  = ids.GenerateTestNodeID()
+		serverNodeID  = ids.GenerateTestNodeID()
+		clientNetwork *Network
+		serverNetwork *Network
+	)
+
+	clientSender := &enginetest.Sender{
+		SendAppRequestF: func(ctx context.Context, _ set.Set[ids.NodeID], requestID uint32, request []byte) error {
+			go func() {
+				require.NoError(serverNetwork.AppRequest(ctx, clientNodeID, requestID, time.Now().Add(time.Minute), request))
+			}()
+			return nil
+		},
+	}
+	serverSender := &enginetest.Sender{
+		SendAppResponseF: func(ctx context.Context, _ ids.NodeID, requestID uint32, response []byte) error {
+			go func() {
+				require.NoError(clientNetwork.AppResponse(ctx, serverNodeID, requestID, response))
+			}()
+			return nil
+		},
+		SendAppErrorF: func(ctx context.Context, _ ids.NodeID, requestID uint32, code int32, message string) error {
+			go func() {
+				require.NoError(clientNetwork.AppRequestFailed(ctx, serverNodeID, requestID, &common.AppError{
+					Code:    code,
+					Message: message,
+				}))
+			}()
+			return nil
+		},
+	}
+
+	var err error
+	clientNetwork, err = NewNetwork(logging.NoLog{}, clientSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	serverNetwork, err = NewNetwork(logging.NoLog{}, serverSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(serverNetwork.AddHandler(rpcHandlerID, handler))
+	return clientNetwork.NewClient(rpcHandlerID), serverNodeID
+}
+
+func TestRPCRequest(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		method  uint64
+		handleF func(context.Context, ids.NodeID, *sdk.SignatureRequest) (*sdk.SignatureResponse, error)
+		want    *sdk.SignatureResponse
+		wantErr error
+	}{
+		{
+			name:   "response",
+			method: testMethod.ID,
+			handleF: func(_ context.Context, _ ids.NodeID, req *sdk.SignatureRequest) (*sdk.SignatureResponse, error) {
+				return &sdk.SignatureResponse{
+					Signature: req.Message,
+				}, nil
+			},
+			want: &sdk.SignatureResponse{
+				Signature: []byte("message"),
+			},
+		},
+		{
+			name:   "app error",
+			method: testMethod.ID,
+			handleF: func(context.Context, ids.NodeID, *sdk.SignatureRequest) (*sdk.SignatureResponse, error) {
+				return nil, errFoo
+			},
+			wantErr: errFoo,
+		},
+		{
+			name:   "deadline exceeded",
+			method: testMethod.ID,
+			handleF: func(context.Context, ids.NodeID, *sdk.SignatureRequest) (*sdk.SignatureResponse, error) {
+				return nil, context.DeadlineExceeded
+			},
+			wantErr: ErrDeadlineExceeded,
+		},
+		{
+			name:   "unexpected error",
+			method: testMethod.ID,
+			handleF: func(context.Context, ids.NodeID, *sdk.SignatureRequest) (*sdk.SignatureResponse, error) {
+				return nil, errFailed
+			},
+			wantErr: ErrUnexpected,
+		},
+		{
+			name:    "unknown method",
+			method:  testMethod.ID + 100,
+			wantErr: ErrUnknownMethod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler, err := NewRPCHandler(logging.NoLog{}, prometheus.NewRegistry(), "")
+			require.NoError(err)
+			require.NoError(RegisterMethod(handler, testMethod, &testTypedHandler{
+				handleF: tt.handleF,
+			}))
+			client, serverNodeID := newRPCTestClient(t, handler)
+
+			method := testMethod
+			method.ID = tt.method
+			typedClient := NewTypedClient(client, method)
+
+			done := make(chan struct{})
+			require.NoError(typedClient.Request(
+				context.Background(),
+				serverNodeID,
+				&sdk.SignatureRequest{
+					Message: []byte("message"),
+				},
+				func(_ context.Context, nodeID ids.NodeID, resp *sdk.SignatureResponse, err error) {
+					defer close(done)
+
+					require.Equal(serverNodeID, nodeID)
+					require.ErrorIs(err, tt.wantErr)
+					if tt.wantErr == nil {
+						require.Equal(tt.want.Signature, resp.Signature)
+					}
+				},
+			))
+			<-done
+		})
+	}
+}
+
+func TestRPCRegisterExistingMethod(t *testing.T) {
+	require := require.New(t)
+
+	handler, err := NewRPCHandler(logging.NoLog{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(RegisterStreamMethod(handler, testMethod, &testStreamHandler{}))
+	err = RegisterMethod(handler, testMethod, &testTypedHandler{})
+	require.ErrorIs(err, ErrExistingMethod)
+}
+
+func TestRPCTimeout(t *testing.T) {
+	require := require.New(t)
+
+	handled := make(chan bool, 1)
+	handler, err := NewRPCHandler(logging.NoLog{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(RegisterMethod(handler, testMethod, &testTypedHandler{
+		handleF: func(ctx context.Context, _ ids.NodeID, _ *sdk.SignatureRequest) (*sdk.SignatureResponse, error) {
+			// The timeout of the client is propagated to the server
+			deadline, ok := ctx.Deadline()
+			handled <- ok && time.Until(deadline) <= time.Second
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}))
+	client, serverNodeID := newRPCTestClient(t, handler)
+
+	method := testMethod
+	method.Timeout = time.Minute
+	typedClient := NewTypedClient(client, method)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 2)
+	require.NoError(typedClient.Request(
+		ctx,
+		serverNodeID,
+		&sdk.SignatureRequest{},
+		func(_ context.Context, _ ids.NodeID, _ *sdk.SignatureResponse, err error) {
+			done <- err
+		},
+	))
+	require.True(<-handled)
+	require.ErrorIs(<-done, ErrDeadlineExceeded)
+
+	// Requests can't be issued once the deadline has passed
+	err = typedClient.Request(
+		ctx,
+		serverNodeID,
+		&sdk.SignatureRequest{},
+		func(context.Context, ids.NodeID, *sdk.SignatureResponse, error) {},
+	)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestRPCStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		message []byte
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "single part",
+			message: []byte{1},
+		},
+		{
+			name:    "multiple parts",
+			message: []byte{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler, err := NewRPCHandler(logging.NoLog{}, prometheus.NewRegistry(), "")
+			require.NoError(err)
+			streamHandler := &testStreamHandler{}
+			require.NoError(RegisterStreamMethod(handler, testStreamMethod, streamHandler))
+			client, serverNodeID := newRPCTestClient(t, handler)
+			typedClient := NewTypedClient(client, testStreamMethod)
+
+			var got []byte
+			done := make(chan error, 1)
+			require.NoError(typedClient.Stream(
+				context.Background(),
+				serverNodeID,
+				&sdk.SignatureRequest{
+					Message: tt.message,
+				},
+				func(_ context.Context, _ ids.NodeID, resp *sdk.SignatureResponse) {
+					got = append(got, resp.Signature...)
+				},
+				func(_ context.Context, _ ids.NodeID, err error) {
+					done <- err
+				},
+			))
+			require.NoError(<-done)
+			require.Equal(tt.message, got)
+
+			// Fully consumed streams are released
+			handler.lock.RLock()
+			require.Empty(handler.streams)
+			require.Empty(handler.nodeStreams)
+			require.Empty(handler.nodeBytes)
+			handler.lock.RUnlock()
+			require.Len(streamHandler.iterators, 1)
+			require.True(streamHandler.iterators[0].released)
+		})
+	}
+}
+
+func TestRPCHandlerStreams(t *testing.T) {
+	require := require.New(t)
+
+	handler, err := NewRPCHandler(logging.NoLog{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(RegisterStreamMethod(handler, testStreamMethod, &testStreamHandler{}))
+
+	nodeID := ids.GenerateTestNodeID()
+	request := rpcRequest{
+		kind:   rpcKindCall,
+		method: testStreamMethod.ID,
+	}
+	request.payload, err = testStreamMethod.RequestCodec.Marshal(&sdk.SignatureRequest{
+		Message: []byte{1, 2},
+	})
+	require.NoError(err)
+
+	// A node can only hold a limited number of streams at once
+	for i := 0; i < maxStreamsPerNode; i++ {
+		_, appErr := handler.AppRequest(context.Background(), nodeID, time.Time{}, request.bytes())
+		require.Nil(appErr)
+	}
+	_, appErr := handler.AppRequest(context.Background(), nodeID, time.Time{}, request.bytes())
+	require.ErrorIs(appErr, ErrThrottled)
+
+	// Streams can only be fetched by the node they were created for
+	next := rpcRequest{
+		kind:     rpcKindNext,
+		method:   testStreamMethod.ID,
+		streamID: 0,
+	}
+	_, appErr = handler.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, next.bytes())
+	require.ErrorIs(appErr, ErrUnknownStream)
+
+	responseBytes, appErr := handler.AppRequest(context.Background(), nodeID, time.Time{}, next.bytes())
+	require.Nil(appErr)
+	response, err := parseRPCResponse(responseBytes)
+	require.NoError(err)
+	require.False(response.hasMore)
+
+	// Consumed streams can't be fetched again
+	_, appErr = handler.AppRequest(context.Background(), nodeID, time.Time{}, next.bytes())
+	require.ErrorIs(appErr, ErrUnknownStream)
+
+	// Expired streams are released
+	for _, s := range handler.streams {
+		s.expiry = time.Now().Add(-time.Second)
+	}
+	_, appErr = handler.AppRequest(context.Background(), nodeID, time.Time{}, request.bytes())
+	require.Nil(appErr)
+	require.Len(handler.streams, 1)
+
+	_, appErr = handler.AppRequest(context.Background(), nodeID, time.Time{}, []byte{})
+	require.ErrorIs(appErr, ErrInvalidRequest)
+}
+
+func TestRPCHandlerExpiredStreams(t *testing.T) {
+	require := require.New(t)
+
+	handler, err := NewRPCHandler(logging.NoLog{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	streamHandler := &testStreamHandler{}
+	require.NoError(RegisterStreamMethod(handler, testStreamMethod, streamHandler))
+
+	nodeID := ids.GenerateTestNodeID()
+	request := rpcRequest{
+		kind:   rpcKindCall,
+		method: testStreamMethod.ID,
+	}
+	request.payload, err = testStreamMethod.RequestCodec.Marshal(&sdk.SignatureRequest{
+		Message: []byte{1, 2},
+	})
+	require.NoError(err)
+
+	newExpiredStream := func() uint64 {
+		responseBytes, appErr := handler.AppRequest(context.Background(), nodeID, time.Time{}, request.bytes())
+		require.Nil(appErr)
+		response, err := parseRPCResponse(responseBytes)
+		require.NoError(err)
+		require.True(response.hasMore)
+
+		for _, s := range handler.streams {
+			s.expiry = time.Now().Add(-time.Second)
+		}
+		return response.streamID
+	}
+
+	// Fetching an expired stream releases it
+	next := rpcRequest{
+		kind:     rpcKindNext,
+		method:   testStreamMethod.ID,
+		streamID: newExpiredStream(),
+	}
+	_, appErr := handler.AppRequest(context.Background(), nodeID, time.Time{}, next.bytes())
+	require.ErrorIs(appErr, ErrUnknownStream)
+	require.True(streamHandler.iterators[0].released)
+	require.Empty(handler.streams)
+	require.Empty(handler.nodeStreams)
+	require.Empty(handler.nodeBytes)
+
+	// Expired streams are released periodically
+	newExpiredStream()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	handler.ExpireStreams(ctx, time.Millisecond)
+	require.True(streamHandler.iterators[1].released)
+	require.Empty(handler.streams)
+	require.Empty(handler.nodeStreams)
+	require.Empty(handler.nodeBytes)
+}
+
+func TestRPCHandlerStreamsIncrementally(t *testing.T) {
+	require := require.New(t)
+
+	handler, err := NewRPCHandler(logging.NoLog{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	streamHandler := &testStreamHandler{}
+	require.NoError(RegisterStreamMethod(handler, testStreamMethod, streamHandler))
+
+	nodeID := ids.GenerateTestNodeID()
+	request := rpcRequest{
+		kind:   rpcKindCall,
+		method: testStreamMethod.ID,
+	}
+	request.payload, err = testStreamMethod.RequestCodec.Marshal(&sdk.SignatureRequest{
+		Message: []byte{1, 2, 3, 4},
+	})
+	require.NoError(err)
+
+	responseBytes, appErr := handler.AppRequest(context.Background(), nodeID, time.Time{}, request.bytes())
+	require.Nil(appErr)
+	response, err := parseRPCResponse(responseBytes)
+	require.NoError(err)
+	require.True(response.hasMore)
+
+	// Only the part after the sent part is produced ahead of time
+	require.Len(streamHandler.iterators, 1)
+	it := streamHandler.iterators[0]
+	require.Equal([]byte{3, 4}, it.message)
+
+	next := rpcRequest{
+		kind:     rpcKindNext,
+		method:   testStreamMethod.ID,
+		streamID: response.streamID,
+	}
+	for _, remaining := range [][]byte{{4}, {}} {
+		responseBytes, appErr = handler.AppRequest(context.Background(), nodeID, time.Time{}, next.bytes())
+		require.Nil(appErr)
+		response, err = parseRPCResponse(responseBytes)
+		require.NoError(err)
+		require.True(response.hasMore)
+		require.Equal(remaining, it.message)
+		require.False(it.released)
+	}
+
+	responseBytes, appErr = handler.AppRequest(context.Background(), nodeID, time.Time{}, next.bytes())
+	require.Nil(appErr)
+	response, err = parseRPCResponse(responseBytes)
+	require.NoError(err)
+	require.False(response.hasMore)
+	require.True(it.released)
+}
+
+func TestRPCHandlerStreamBytesLimit(t *testing.T) {
+	require := require.New(t)
+
+	handler, err := NewRPCHandler(logging.NoLog{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	streamHandler := &testStreamHandler{
+		partSize: maxStreamBytesPerNode / 2,
+	}
+	require.NoError(RegisterStreamMethod(handler, testStreamMethod, streamHandler))
+
+	nodeID := ids.GenerateTestNodeID()
+	request := rpcRequest{
+		kind:   rpcKindCall,
+		method: testStreamMethod.ID,
+	}
+	request.payload, err = testStreamMethod.RequestCodec.Marshal(&sdk.SignatureRequest{
+		Message: []byte{1, 2},
+	})
+	require.NoError(err)
+
+	// Each stream buffers a single part, which is larger than half of the
+	// limit.
+	_, appErr := handler.AppRequest(context.Background(), nodeID, time.Time{}, request.bytes())
+	require.Nil(appErr)
+	_, appErr = handler.AppRequest(context.Background(), nodeID, time.Time{}, request.bytes())
+	require.ErrorIs(appErr, ErrThrottled)
+
+	// The throttled stream is released
+	require.Len(streamHandler.iterators, 2)
+	require.False(streamHandler.iterators[0].released)
+	require.True(streamHandler.iterators[1].released)
+
+	// Other nodes have their own limit
+	_, appErr = handler.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, request.bytes())
+	require.Nil(appErr)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/capture"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -45,6 +46,10 @@ type Config struct {
 	SupportedACPs []uint32
 	ObjectedACPs  []uint32
 
+	// QUICEnabled is advertised in the Handshake message to let peers know
+	// that this node accepts QUIC connections.
+	QUICEnabled bool
+
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	LastSent, LastReceived int64
@@ -57,4 +62,8 @@ type Config struct {
 
 	// Signs my IP so I can send my signed IP address in the Handshake message
 	IPSigner *IPSigner
+
+	// Records every message sent to and received from this peer. If nil,
+	// messages aren't captured.
+	Capture capture.Recorder
 }
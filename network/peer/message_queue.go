@@ -5,7 +5,9 @@ package peer
 
 import (
 	"context"
+	"math"
 	"sync"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 
@@ -14,15 +16,31 @@ import (
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
-const initialQueueSize = 64
+const (
+	initialQueueSize = 64
+
+	// laneQuantum is the number of bytes that a lane with a weight of 1 may
+	// send per round of draining.
+	laneQuantum = 64 * units.KiB
+)
 
 var (
 	_ MessageQueue = (*throttledMessageQueue)(nil)
 	_ MessageQueue = (*blockingMessageQueue)(nil)
 )
 
+// laneWeights are the relative number of bytes that each lane may send when
+// multiple lanes have messages waiting to be sent. The handshake lane is always
+// drained first, so it doesn't have a weight.
+var laneWeights = [message.NumLanes]uint64{
+	message.ConsensusLane: 8,
+	message.AppLane:       4,
+	message.GossipLane:    1,
+}
+
 type SendFailedCallback interface {
 	SendFailed(message.OutboundMessage)
 }
@@ -50,6 +68,10 @@ type MessageQueue interface {
 	// Close empties the queue and prevents further messages from being pushed
 	// onto it. After calling close once, future calls to close will do nothing.
 	Close()
+
+	// NumThrottled returns the number of messages that were dropped because of
+	// outbound message throttling.
+	NumThrottled() uint64
 }
 
 type throttledMessageQueue struct {
@@ -59,6 +81,9 @@ type throttledMessageQueue struct {
 	log                  logging.Logger
 	outboundMsgThrottler throttling.OutboundMsgThrottler
 
+	// numThrottled is the number of messages dropped by [outboundMsgThrottler]
+	numThrottled atomic.Uint64
+
 	// Signalled when a message is added to the queue and when Close() is
 	// called.
 	cond *sync.Cond
@@ -67,9 +92,20 @@ type throttledMessageQueue struct {
 	// [cond.L] must be held while accessing [closed].
 	closed bool
 
-	// queue of the messages
-	// [cond.L] must be held while accessing [queue].
-	queue buffer.Deque[message.OutboundMessage]
+	// lanes of the messages, indexed by their [message.Lane]
+	// [cond.L] must be held while accessing [lanes].
+	lanes [message.NumLanes]buffer.Deque[message.OutboundMessage]
+
+	// deficits are the number of bytes that each lane may send before other
+	// lanes are given a turn. This implements deficit round robin draining of
+	// the lanes, so that large gossip messages can't delay consensus messages
+	// and gossip isn't starved.
+	// [cond.L] must be held while accessing [deficits].
+	deficits [message.NumLanes]uint64
+
+	// numQueued is the number of messages in all lanes.
+	// [cond.L] must be held while accessing [numQueued].
+	numQueued int
 }
 
 func NewThrottledMessageQueue(
@@ -78,14 +114,17 @@ func NewThrottledMessageQueue(
 	log logging.Logger,
 	outboundMsgThrottler throttling.OutboundMsgThrottler,
 ) MessageQueue {
-	return &throttledMessageQueue{
+	q := &throttledMessageQueue{
 		onFailed:             onFailed,
 		id:                   id,
 		log:                  log,
 		outboundMsgThrottler: outboundMsgThrottler,
 		cond:                 sync.NewCond(&sync.Mutex{}),
-		queue:                buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize),
 	}
+	for i := range q.lanes {
+		q.lanes[i] = buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize)
+	}
+	return q
 }
 
 func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
@@ -108,6 +147,7 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 			zap.Stringer("messageOp", msg.Op()),
 			zap.Stringer("nodeID", q.id),
 		)
+		q.numThrottled.Add(1)
 		q.onFailed.SendFailed(msg)
 		return false
 	}
@@ -131,7 +171,8 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 		return false
 	}
 
-	q.queue.PushRight(msg)
+	q.lanes[message.GetLane(msg.Op())].PushRight(msg)
+	q.numQueued++
 	q.cond.Signal()
 	return true
 }
@@ -144,7 +185,7 @@ func (q *throttledMessageQueue) Pop() (message.OutboundMessage, bool) {
 		if q.closed {
 			return nil, false
 		}
-		if q.queue.Len() > 0 {
+		if q.numQueued > 0 {
 			// There is a message
 			break
 		}
@@ -159,7 +200,7 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
 
-	if q.closed || q.queue.Len() == 0 {
+	if q.closed || q.numQueued == 0 {
 		// There isn't a message
 		return nil, false
 	}
@@ -167,13 +208,66 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	return q.pop(), true
 }
 
+// pop assumes that [cond.L] is held and that there is at least one message.
 func (q *throttledMessageQueue) pop() message.OutboundMessage {
-	msg, _ := q.queue.PopLeft()
+	msg := q.popLane()
+	q.numQueued--
 
 	q.outboundMsgThrottler.Release(msg, q.id)
 	return msg
 }
 
+func (q *throttledMessageQueue) popLane() message.OutboundMessage {
+	// Handshake messages are always sent first, as the peer can't make
+	// progress without them.
+	if msg, ok := q.lanes[message.HandshakeLane].PopLeft(); ok {
+		return msg
+	}
+
+	for {
+		// Send the next message of the highest priority lane that has enough
+		// bytes remaining in its deficit.
+		for lane := message.ConsensusLane; int(lane) < message.NumLanes; lane++ {
+			msg, ok := q.lanes[lane].PeekLeft()
+			if !ok {
+				continue
+			}
+
+			size := uint64(len(msg.Bytes()))
+			if size > q.deficits[lane] {
+				continue
+			}
+
+			_, _ = q.lanes[lane].PopLeft()
+			q.deficits[lane] -= size
+			if q.lanes[lane].Len() == 0 {
+				// Idle lanes don't accumulate a deficit.
+				q.deficits[lane] = 0
+			}
+			return msg
+		}
+
+		// No lane can send its next message, so skip ahead to the first round
+		// in which a lane can.
+		rounds := uint64(math.MaxUint64)
+		for lane := message.ConsensusLane; int(lane) < message.NumLanes; lane++ {
+			msg, ok := q.lanes[lane].PeekLeft()
+			if !ok {
+				continue
+			}
+
+			quantum := laneWeights[lane] * laneQuantum
+			needed := uint64(len(msg.Bytes())) - q.deficits[lane]
+			rounds = min(rounds, (needed+quantum-1)/quantum)
+		}
+		for lane := message.ConsensusLane; int(lane) < message.NumLanes; lane++ {
+			if q.lanes[lane].Len() != 0 {
+				q.deficits[lane] += rounds * laneWeights[lane] * laneQuantum
+			}
+		}
+	}
+}
+
 func (q *throttledMessageQueue) Close() {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
@@ -184,16 +278,23 @@ func (q *throttledMessageQueue) Close() {
 
 	q.closed = true
 
-	for q.queue.Len() > 0 {
-		msg, _ := q.queue.PopLeft()
-		q.outboundMsgThrottler.Release(msg, q.id)
-		q.onFailed.SendFailed(msg)
+	for i, lane := range q.lanes {
+		for lane.Len() > 0 {
+			msg, _ := lane.PopLeft()
+			q.outboundMsgThrottler.Release(msg, q.id)
+			q.onFailed.SendFailed(msg)
+		}
+		q.lanes[i] = nil
 	}
-	q.queue = nil
+	q.numQueued = 0
 
 	q.cond.Broadcast()
 }
 
+func (q *throttledMessageQueue) NumThrottled() uint64 {
+	return q.numThrottled.Load()
+}
+
 type blockingMessageQueue struct {
 	onFailed SendFailedCallback
 	log      logging.Logger
@@ -302,3 +403,9 @@ func (q *blockingMessageQueue) Close() {
 		}
 	})
 }
+
+// NumThrottled always returns 0, as the blocking queue doesn't throttle
+// messages.
+func (*blockingMessageQueue) NumThrottled() uint64 {
+	return 0
+}
@@ -11,8 +11,11 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 func TestMessageQueue(t *testing.T) {
@@ -87,3 +90,97 @@ func TestMessageQueue(t *testing.T) {
 	_, ok = q.Pop()
 	require.False(ok)
 }
+
+func TestThrottledMessageQueueLanes(t *testing.T) {
+	require := require.New(t)
+
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(message.OutboundMessage) {
+			require.FailNow("unexpected send failure")
+		}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	mc := newMessageCreator(t)
+	chainID := ids.GenerateTestID()
+	gossipMsgs := make([]message.OutboundMessage, 3)
+	for i := range gossipMsgs {
+		// Random bytes aren't compressed, so the message is larger than the
+		// quantum of the gossip lane.
+		msg, err := mc.AppGossip(chainID, utils.RandomBytes(100*units.KiB))
+		require.NoError(err)
+		gossipMsgs[i] = msg
+	}
+	chitsMsg, err := mc.Chits(chainID, 1, ids.Empty, ids.Empty, ids.Empty)
+	require.NoError(err)
+	appMsg, err := mc.AppResponse(chainID, 1, utils.RandomBytes(100*units.KiB))
+	require.NoError(err)
+	pingMsg, err := mc.Ping(0, nil)
+	require.NoError(err)
+
+	ctx := context.Background()
+	for _, msg := range gossipMsgs {
+		require.True(q.Push(ctx, msg))
+	}
+	require.True(q.Push(ctx, appMsg))
+	require.True(q.Push(ctx, chitsMsg))
+	require.True(q.Push(ctx, pingMsg))
+
+	// Higher priority lanes are drained first, but the gossip lane still gets
+	// a share of the bytes before all higher priority messages are sent.
+	expectedMsgs := []message.OutboundMessage{
+		pingMsg,
+		chitsMsg,
+		appMsg,
+		gossipMsgs[0],
+		gossipMsgs[1],
+		gossipMsgs[2],
+	}
+	for _, expectedMsg := range expectedMsgs {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		require.Equal(expectedMsg.Op(), msg.Op())
+		require.Equal(expectedMsg, msg)
+	}
+
+	_, ok := q.PopNow()
+	require.False(ok)
+	q.Close()
+}
+
+func TestThrottledMessageQueueGossipNotStarved(t *testing.T) {
+	require := require.New(t)
+
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(message.OutboundMessage) {}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	mc := newMessageCreator(t)
+	chainID := ids.GenerateTestID()
+	gossipMsg, err := mc.AppGossip(chainID, utils.RandomBytes(units.KiB))
+	require.NoError(err)
+	require.True(q.Push(context.Background(), gossipMsg))
+
+	// Even if consensus messages keep being sent, the gossip message is
+	// eventually sent.
+	var numConsensusMsgs int
+	for {
+		chitsMsg, err := mc.Chits(chainID, 1, ids.Empty, ids.Empty, ids.Empty)
+		require.NoError(err)
+		require.True(q.Push(context.Background(), chitsMsg))
+
+		msg, ok := q.PopNow()
+		require.True(ok)
+		if msg.Op() == message.AppGossipOp {
+			break
+		}
+		numConsensusMsgs++
+	}
+	require.Less(numConsensusMsgs, 10)
+	q.Close()
+}
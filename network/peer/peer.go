@@ -50,6 +50,15 @@ const (
 var (
 	errClosed = errors.New("closed")
 
+	// appOps are the ops of the messages that are exchanged over the app
+	// stream of the connection, if it has one.
+	appOps = set.Of(
+		message.AppRequestOp,
+		message.AppResponseOp,
+		message.AppErrorOp,
+		message.AppGossipOp,
+	)
+
 	_ Peer = (*peer)(nil)
 )
 
@@ -63,6 +72,10 @@ type Peer interface {
 	// authenticate their messages.
 	Cert() *staking.Certificate
 
+	// RemoteIP returns the address of the connection to the remote peer. If
+	// the address isn't an IP, the returned AddrPort is invalid.
+	RemoteIP() netip.AddrPort
+
 	// LastSent returns the last time a message was sent to the peer.
 	LastSent() time.Time
 
@@ -82,6 +95,9 @@ type Peer interface {
 	// called after [Ready] returns true.
 	Info() Info
 
+	// Stats returns the message accounting of this peer.
+	Stats() Stats
+
 	// IP returns the claimed IP and signature provided by this peer during the
 	// handshake. It should only be called after [Ready] returns true.
 	IP() *SignedIP
@@ -99,6 +115,11 @@ type Peer interface {
 	// [Ready] returns true.
 	ObservedUptime(subnetID ids.ID) (uint32, bool)
 
+	// SupportsQUIC returns true if the peer advertised in the Handshake message
+	// that it accepts QUIC connections. It should only be called after [Ready]
+	// returns true.
+	SupportsQUIC() bool
+
 	// Send attempts to send [msg] to the peer. The peer takes ownership of
 	// [msg] for reference counting. This returns false if the message is
 	// guaranteed not to be delivered to the peer.
@@ -127,6 +148,9 @@ type peer struct {
 
 	// the connection object that is used to read/write messages from
 	conn net.Conn
+	// appConn, if non-nil, is used to read/write app messages instead of
+	// [conn], so that app messages aren't blocked behind consensus messages.
+	appConn net.Conn
 
 	// [cert] is this peer's certificate, specifically the leaf of the
 	// certificate chain they provided.
@@ -137,6 +161,17 @@ type peer struct {
 
 	// queue of messages to send to this peer.
 	messageQueue MessageQueue
+	// queue of app messages to send to this peer over [appConn]. Only set if
+	// [appConn] is set.
+	appMessageQueue MessageQueue
+
+	// acquireLock is held while acquiring from the inbound message throttler,
+	// because the throttler must not be acquired from concurrently for the
+	// same peer, but messages are read from [conn] and [appConn] concurrently.
+	acquireLock sync.Mutex
+
+	// stats tracks the messages exchanged with this peer.
+	stats *statsTracker
 
 	// ip is the claimed IP the peer gave us in the Handshake message.
 	ip *SignedIP
@@ -149,6 +184,9 @@ type peer struct {
 	// options of ACPs provided in the Handshake message.
 	supportedACPs set.Set[uint32]
 	objectedACPs  set.Set[uint32]
+	// supportsQUIC is true if the peer advertised QUIC support in the
+	// Handshake message.
+	supportsQUIC bool
 
 	// txIDOfVerifiedBLSKey is the txID that added the BLS key that was most
 	// recently verified to have signed the IP.
@@ -200,14 +238,20 @@ type peer struct {
 
 // Start a new peer instance.
 //
+// If [appConn] is non-nil, app messages are queued in [appMessageQueue] and
+// exchanged over [appConn], rather than over [conn]. Otherwise,
+// [appMessageQueue] is ignored.
+//
 // Invariant: There must only be one peer running at a time with a reference to
 // the same [config.InboundMsgThrottler].
 func Start(
 	config *Config,
 	conn net.Conn,
+	appConn net.Conn,
 	cert *staking.Certificate,
 	id ids.NodeID,
 	messageQueue MessageQueue,
+	appMessageQueue MessageQueue,
 ) Peer {
 	onClosingCtx, onClosingCtxCancel := context.WithCancel(context.Background())
 	p := &peer{
@@ -216,6 +260,7 @@ func Start(
 		cert:               cert,
 		id:                 id,
 		messageQueue:       messageQueue,
+		stats:              newStatsTracker(),
 		onFinishHandshake:  make(chan struct{}),
 		numExecuting:       3,
 		onClosingCtx:       onClosingCtx,
@@ -224,10 +269,23 @@ func Start(
 		observedUptimes:    make(map[ids.ID]uint32),
 		getPeerListChan:    make(chan struct{}, 1),
 	}
+	if appConn != nil {
+		p.appConn = appConn
+		p.appMessageQueue = appMessageQueue
+		p.numExecuting += 2
+	}
 
-	go p.readMessages()
+	// Track this node with the inbound message throttler until all the
+	// goroutines of this peer have exited.
+	p.InboundMsgThrottler.AddNode(p.id)
+
+	go p.readMessages(p.conn, false)
 	go p.writeMessages()
 	go p.sendNetworkMessages()
+	if p.appConn != nil {
+		go p.readMessages(p.appConn, true)
+		go p.writeAppMessages()
+	}
 
 	return p
 }
@@ -240,6 +298,11 @@ func (p *peer) Cert() *staking.Certificate {
 	return p.cert
 }
 
+func (p *peer) RemoteIP() netip.AddrPort {
+	ip, _ := ips.ParseAddrPort(p.conn.RemoteAddr().String())
+	return ip
+}
+
 func (p *peer) LastSent() time.Time {
 	return time.Unix(
 		atomic.LoadInt64(&p.lastSent),
@@ -284,9 +347,8 @@ func (p *peer) Info() Info {
 		primaryUptime = 0
 	}
 
-	ip, _ := ips.ParseAddrPort(p.conn.RemoteAddr().String())
 	return Info{
-		IP:                    ip,
+		IP:                    p.RemoteIP(),
 		PublicIP:              p.ip.AddrPort,
 		ID:                    p.id,
 		Version:               p.version.String(),
@@ -300,6 +362,14 @@ func (p *peer) Info() Info {
 	}
 }
 
+func (p *peer) Stats() Stats {
+	numThrottled := p.messageQueue.NumThrottled()
+	if p.appMessageQueue != nil {
+		numThrottled += p.appMessageQueue.NumThrottled()
+	}
+	return p.stats.Stats(p.id, numThrottled)
+}
+
 func (p *peer) IP() *SignedIP {
 	return p.ip
 }
@@ -320,7 +390,14 @@ func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 	return uptime, exist
 }
 
+func (p *peer) SupportsQUIC() bool {
+	return p.supportsQUIC
+}
+
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
+	if p.appMessageQueue != nil && appOps.Contains(msg.Op()) {
+		return p.appMessageQueue.Push(ctx, msg)
+	}
 	return p.messageQueue.Push(ctx, msg)
 }
 
@@ -339,6 +416,15 @@ func (p *peer) StartClose() {
 				zap.Error(err),
 			)
 		}
+		if p.appConn != nil {
+			if err := p.appConn.Close(); err != nil {
+				p.Log.Debug("failed to close app connection",
+					zap.Stringer("nodeID", p.id),
+					zap.Error(err),
+				)
+			}
+			p.appMessageQueue.Close()
+		}
 
 		p.messageQueue.Close()
 		p.onClosingCtxCancel()
@@ -370,27 +456,26 @@ func (p *peer) close() {
 		return
 	}
 
+	p.InboundMsgThrottler.RemoveNode(p.id)
 	p.Network.Disconnected(p.id)
 	close(p.onClosed)
 }
 
-// Read and handle messages from this peer.
+// Read and handle messages from this peer over [conn]. If [appOnly] is true,
+// the peer is disconnected if it sends any message other than an app message.
 // When this method returns, the connection is closed.
-func (p *peer) readMessages() {
-	// Track this node with the inbound message throttler.
-	p.InboundMsgThrottler.AddNode(p.id)
+func (p *peer) readMessages(conn net.Conn, appOnly bool) {
 	defer func() {
-		p.InboundMsgThrottler.RemoveNode(p.id)
 		p.StartClose()
 		p.close()
 	}()
 
 	// Continuously read and handle messages from this peer.
-	reader := bufio.NewReaderSize(p.conn, p.Config.ReadBufferSize)
+	reader := bufio.NewReaderSize(conn, p.Config.ReadBufferSize)
 	msgLenBytes := make([]byte, wrappers.IntLen)
 	for {
 		// Time out and close connection if we can't read the message length
-		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
+		if err := conn.SetReadDeadline(p.nextTimeout()); err != nil {
 			p.Log.Verbo(failedToSetDeadlineLog,
 				zap.Stringer("nodeID", p.id),
 				zap.String("direction", "read"),
@@ -426,16 +511,21 @@ func (p *peer) readMessages() {
 		// throttler metrics to verify that there is no leak.
 		//
 		// Invariant: There must only be one call to Acquire at any given time
-		// with the same nodeID. In this package, only this goroutine ever
-		// performs Acquire. Additionally, we ensure that this goroutine has
-		// exited before calling [Network.Disconnected] to guarantee that there
-		// can't be multiple instances of this goroutine running over different
-		// peer instances.
+		// with the same nodeID. In this package, only the goroutines reading
+		// messages perform Acquire, and they hold [p.acquireLock] while doing
+		// so. Additionally, we ensure that these goroutines have exited before
+		// calling [Network.Disconnected] to guarantee that there can't be
+		// multiple instances of these goroutines running over different peer
+		// instances.
+		p.acquireLock.Lock()
+		acquireStart := p.Clock.Time()
 		onFinishedHandling := p.InboundMsgThrottler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
 		)
+		p.stats.InboundThrottled(p.Clock.Time().Sub(acquireStart))
+		p.acquireLock.Unlock()
 
 		// If the peer is shutting down, there's no need to read the message.
 		if err := p.onClosingCtx.Err(); err != nil {
@@ -444,7 +534,7 @@ func (p *peer) readMessages() {
 		}
 
 		// Time out and close connection if we can't read message
-		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
+		if err := conn.SetReadDeadline(p.nextTimeout()); err != nil {
 			p.Log.Verbo(failedToSetDeadlineLog,
 				zap.Stringer("nodeID", p.id),
 				zap.String("direction", "read"),
@@ -495,9 +585,24 @@ func (p *peer) readMessages() {
 			continue
 		}
 
+		if appOnly && !appOps.Contains(msg.Op()) {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.String("reason", "non-app message on the app stream"),
+			)
+			msg.OnFinishedHandling()
+			p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+			return
+		}
+
 		now := p.Clock.Time()
 		p.storeLastReceived(now)
 		p.Metrics.Received(msg, msgLen)
+		p.stats.Received(msg.Op(), msgLen)
+		if p.Capture != nil {
+			p.Capture.Inbound(p.id, msg, msgBytes)
+		}
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -550,6 +655,7 @@ func (p *peer) writeMessages() {
 		p.ObjectedACPs,
 		knownPeersFilter,
 		knownPeersSalt,
+		p.QUICEnabled,
 	)
 	if err != nil {
 		p.Log.Error(failedToCreateMessageLog,
@@ -560,12 +666,37 @@ func (p *peer) writeMessages() {
 		return
 	}
 
-	p.writeMessage(writer, msg)
+	p.writeMessage(p.conn, writer, msg)
+	p.writeQueue(p.conn, writer, p.messageQueue)
+}
+
+// writeAppMessages writes the app messages of this peer over [p.appConn].
+func (p *peer) writeAppMessages() {
+	defer func() {
+		p.StartClose()
+		p.close()
+	}()
+
+	// The peer drops app messages until it has finished the handshake, so app
+	// messages are only sent after the Handshake and PeerList messages were
+	// sent over [p.conn].
+	select {
+	case <-p.onFinishHandshake:
+	case <-p.onClosingCtx.Done():
+		return
+	}
+
+	writer := bufio.NewWriterSize(p.appConn, p.Config.WriteBufferSize)
+	p.writeQueue(p.appConn, writer, p.appMessageQueue)
+}
 
+// writeQueue writes the messages of [queue] to [conn] until the peer is
+// closing.
+func (p *peer) writeQueue(conn net.Conn, writer *bufio.Writer, queue MessageQueue) {
 	for {
-		msg, ok := p.messageQueue.PopNow()
+		msg, ok := queue.PopNow()
 		if ok {
-			p.writeMessage(writer, msg)
+			p.writeMessage(conn, writer, msg)
 			continue
 		}
 
@@ -579,24 +710,24 @@ func (p *peer) writeMessages() {
 			return
 		}
 
-		msg, ok = p.messageQueue.Pop()
+		msg, ok = queue.Pop()
 		if !ok {
 			// This peer is closing
 			return
 		}
 
-		p.writeMessage(writer, msg)
+		p.writeMessage(conn, writer, msg)
 	}
 }
 
-func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
+func (p *peer) writeMessage(conn net.Conn, writer io.Writer, msg message.OutboundMessage) {
 	msgBytes := msg.Bytes()
 	p.Log.Verbo("sending message",
 		zap.Stringer("nodeID", p.id),
 		zap.Binary("messageBytes", msgBytes),
 	)
 
-	if err := p.conn.SetWriteDeadline(p.nextTimeout()); err != nil {
+	if err := conn.SetWriteDeadline(p.nextTimeout()); err != nil {
 		p.Log.Verbo(failedToSetDeadlineLog,
 			zap.Stringer("nodeID", p.id),
 			zap.String("direction", "write"),
@@ -628,6 +759,10 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	now := p.Clock.Time()
 	p.storeLastSent(now)
 	p.Metrics.Sent(msg)
+	p.stats.Sent(msg.Op(), len(msgBytes))
+	if p.Capture != nil {
+		p.Capture.Outbound(p.id, msg)
+	}
 }
 
 func (p *peer) sendNetworkMessages() {
@@ -1004,6 +1139,8 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		return
 	}
 
+	p.supportsQUIC = msg.SupportsQuic
+
 	var (
 		knownPeers = bloom.EmptyFilter
 		salt       []byte
@@ -8,6 +8,7 @@ import (
 	"crypto"
 	"net"
 	"net/netip"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math/meter"
 	"github.com/ava-labs/avalanchego/utils/resource"
@@ -132,10 +134,15 @@ func newRawTestPeer(t *testing.T, config Config) *rawTestPeer {
 }
 
 func startTestPeer(self *rawTestPeer, peer *rawTestPeer, conn net.Conn) *testPeer {
+	return startTestPeerWithAppConn(self, peer, conn, nil)
+}
+
+func startTestPeerWithAppConn(self *rawTestPeer, peer *rawTestPeer, conn net.Conn, appConn net.Conn) *testPeer {
 	return &testPeer{
 		Peer: Start(
 			self.config,
 			conn,
+			appConn,
 			peer.cert,
 			peer.nodeID,
 			NewThrottledMessageQueue(
@@ -144,6 +151,12 @@ func startTestPeer(self *rawTestPeer, peer *rawTestPeer, conn net.Conn) *testPee
 				logging.NoLog{},
 				throttling.NewNoOutboundThrottler(),
 			),
+			NewThrottledMessageQueue(
+				self.config.Metrics,
+				peer.nodeID,
+				logging.NoLog{},
+				throttling.NewNoOutboundThrottler(),
+			),
 		),
 		inboundMsgChan: self.inboundMsgChan,
 	}
@@ -211,6 +224,125 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+// countingConn counts the bytes read from the connection.
+type countingConn struct {
+	net.Conn
+	read atomic.Int64
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.read.Add(int64(n))
+	return n, err
+}
+
+func TestSendAppStream(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+	sharedConfig.QUICEnabled = true
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	conn0, conn1 := net.Pipe()
+	appConn0, appConn1 := net.Pipe()
+	countingAppConn1 := &countingConn{Conn: appConn1}
+	peer0 := startTestPeerWithAppConn(rawPeer0, rawPeer1, conn0, appConn0)
+	peer1 := startTestPeerWithAppConn(rawPeer1, rawPeer0, conn1, countingAppConn1)
+	awaitReady(t, peer0, peer1)
+
+	require.True(peer0.SupportsQUIC())
+	require.True(peer1.SupportsQUIC())
+
+	// The handshake is exchanged over the consensus connection
+	require.Zero(countingAppConn1.read.Load())
+
+	outboundGossipMsg, err := sharedConfig.MessageCreator.AppGossip(ids.Empty, []byte("gossip"))
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), outboundGossipMsg))
+
+	inboundGossipMsg := <-peer1.inboundMsgChan
+	require.Equal(message.AppGossipOp, inboundGossipMsg.Op())
+	require.Positive(countingAppConn1.read.Load())
+
+	outboundGetMsg, err := sharedConfig.MessageCreator.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), outboundGetMsg))
+
+	inboundGetMsg := <-peer1.inboundMsgChan
+	require.Equal(message.GetOp, inboundGetMsg.Op())
+
+	// Closing the peer closes both connections
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestNonAppMessageOnAppStreamDisconnects(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	conn0, conn1 := net.Pipe()
+	appConn0, appConn1 := net.Pipe()
+	defer func() {
+		_ = conn0.Close()
+		_ = appConn0.Close()
+	}()
+	peer1 := startTestPeerWithAppConn(rawPeer1, rawPeer0, conn1, appConn1)
+
+	// Send a consensus message over the app connection
+	getMsg, err := sharedConfig.MessageCreator.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	msgBytes := getMsg.Bytes()
+	msgLenBytes, err := writeMsgLen(uint32(len(msgBytes)), constants.DefaultMaxMessageSize)
+	require.NoError(err)
+	go func() {
+		_, _ = appConn0.Write(append(msgLenBytes[:], msgBytes...))
+	}()
+
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestStats(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+
+	outboundGetMsg, err := sharedConfig.MessageCreator.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+
+	require.True(peer0.Send(context.Background(), outboundGetMsg))
+	<-peer1.inboundMsgChan
+
+	getOp := message.GetOp.String()
+	getBytes := json.Uint64(len(outboundGetMsg.Bytes()))
+
+	// The message is recorded as sent before it is delivered
+	sent := peer0.Stats().Sent.Ops[getOp]
+	require.Equal(OpStats{Messages: 1, Bytes: getBytes}, sent)
+
+	stats := peer1.Stats()
+	require.Equal(rawPeer0.nodeID, stats.ID)
+	require.Equal(OpStats{Messages: 1, Bytes: getBytes}, stats.Received.Ops[getOp])
+	require.GreaterOrEqual(stats.Received.Messages, json.Uint64(1))
+	require.Zero(stats.OutboundThrottled)
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
 func TestPingUptimes(t *testing.T) {
 	trackedSubnetID := ids.GenerateTestID()
 	untrackedSubnetID := ids.GenerateTestID()
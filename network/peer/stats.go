@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// Stats describes the messages that were exchanged with a peer since the
+// connection was established.
+type Stats struct {
+	ID       ids.NodeID   `json:"nodeID"`
+	Sent     MessageStats `json:"sent"`
+	Received MessageStats `json:"received"`
+
+	// OutboundThrottled is the number of messages to the peer that were
+	// dropped by the outbound message throttler.
+	OutboundThrottled json.Uint64 `json:"outboundThrottled"`
+
+	// InboundThrottled is the total number of nanoseconds that reading
+	// messages from the peer was blocked by the inbound message throttler.
+	// Inbound messages are delayed, rather than dropped, by the throttler.
+	InboundThrottled json.Uint64 `json:"inboundThrottled"`
+}
+
+type MessageStats struct {
+	Messages json.Uint64 `json:"messages"`
+	Bytes    json.Uint64 `json:"bytes"`
+	// Ops breaks down the messages by their op
+	Ops map[string]OpStats `json:"ops"`
+}
+
+type OpStats struct {
+	Messages json.Uint64 `json:"messages"`
+	Bytes    json.Uint64 `json:"bytes"`
+}
+
+// statsTracker accumulates the [Stats] of a peer.
+type statsTracker struct {
+	lock             sync.Mutex
+	sent             map[message.Op]OpStats
+	received         map[message.Op]OpStats
+	inboundThrottled time.Duration
+}
+
+func newStatsTracker() *statsTracker {
+	return &statsTracker{
+		sent:     make(map[message.Op]OpStats),
+		received: make(map[message.Op]OpStats),
+	}
+}
+
+func (s *statsTracker) Sent(op message.Op, numBytes int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	addMessage(s.sent, op, numBytes)
+}
+
+func (s *statsTracker) Received(op message.Op, numBytes uint32) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	addMessage(s.received, op, int(numBytes))
+}
+
+func (s *statsTracker) InboundThrottled(duration time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.inboundThrottled += duration
+}
+
+func (s *statsTracker) Stats(nodeID ids.NodeID, outboundThrottled uint64) Stats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return Stats{
+		ID:                nodeID,
+		Sent:              newMessageStats(s.sent),
+		Received:          newMessageStats(s.received),
+		OutboundThrottled: json.Uint64(outboundThrottled),
+		InboundThrottled:  json.Uint64(s.inboundThrottled.Nanoseconds()),
+	}
+}
+
+func addMessage(ops map[message.Op]OpStats, op message.Op, numBytes int) {
+	stats := ops[op]
+	stats.Messages++
+	stats.Bytes += json.Uint64(numBytes)
+	ops[op] = stats
+}
+
+func newMessageStats(ops map[message.Op]OpStats) MessageStats {
+	stats := MessageStats{
+		Ops: make(map[string]OpStats, len(ops)),
+	}
+	for op, opStats := range ops {
+		stats.Messages += opStats.Messages
+		stats.Bytes += opStats.Bytes
+		stats.Ops[op.String()] = opStats
+	}
+	return stats
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/acl"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -64,10 +65,16 @@ func StartTestPeer(
 		return nil, err
 	}
 
+	peerACL, err := acl.New("")
+	if err != nil {
+		return nil, err
+	}
+
 	tlsConfg := TLSConfig(*tlsCert, nil)
 	clientUpgrader := NewTLSClientUpgrader(
 		tlsConfg,
 		prometheus.NewCounter(prometheus.CounterOpts{}),
+		peerACL,
 	)
 
 	peerID, conn, cert, err := clientUpgrader.Upgrade(conn)
@@ -134,6 +141,7 @@ func StartTestPeer(
 			),
 		},
 		conn,
+		nil,
 		cert,
 		peerID,
 		NewBlockingMessageQueue(
@@ -141,6 +149,7 @@ func StartTestPeer(
 			logging.NoLog{},
 			maxMessageToSend,
 		),
+		nil,
 	)
 	return peer, peer.AwaitReady(ctx)
 }
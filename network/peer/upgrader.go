@@ -7,18 +7,28 @@ import (
 	"crypto/tls"
 	"errors"
 	"net"
+	"net/netip"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/acl"
+	"github.com/ava-labs/avalanchego/network/quic"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
 var (
 	errNoCert = errors.New("tls handshake finished with no peer certificate")
+	errDenied = errors.New("peer is denied by the peer ACL")
+	errNoQUIC = errors.New("connection isn't a QUIC connection")
 
 	_ Upgrader = (*tlsServerUpgrader)(nil)
 	_ Upgrader = (*tlsClientUpgrader)(nil)
+	_ Upgrader = (*quicUpgrader)(nil)
+
+	_ tlsConn = (*tls.Conn)(nil)
+	_ tlsConn = (*quic.Conn)(nil)
 )
 
 type Upgrader interface {
@@ -26,39 +36,73 @@ type Upgrader interface {
 	Upgrade(net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error)
 }
 
+// tlsConn is a connection that is authenticated by a TLS handshake.
+type tlsConn interface {
+	net.Conn
+	Handshake() error
+	ConnectionState() tls.ConnectionState
+}
+
 type tlsServerUpgrader struct {
 	config       *tls.Config
 	invalidCerts prometheus.Counter
+	peerACL      *acl.ACL
 }
 
-func NewTLSServerUpgrader(config *tls.Config, invalidCerts prometheus.Counter) Upgrader {
+func NewTLSServerUpgrader(config *tls.Config, invalidCerts prometheus.Counter, peerACL *acl.ACL) Upgrader {
 	return &tlsServerUpgrader{
 		config:       config,
 		invalidCerts: invalidCerts,
+		peerACL:      peerACL,
 	}
 }
 
 func (t *tlsServerUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
-	return connToIDAndCert(tls.Server(conn, t.config), t.invalidCerts)
+	return connToIDAndCert(tls.Server(conn, t.config), t.invalidCerts, t.peerACL)
 }
 
 type tlsClientUpgrader struct {
 	config       *tls.Config
 	invalidCerts prometheus.Counter
+	peerACL      *acl.ACL
 }
 
-func NewTLSClientUpgrader(config *tls.Config, invalidCerts prometheus.Counter) Upgrader {
+func NewTLSClientUpgrader(config *tls.Config, invalidCerts prometheus.Counter, peerACL *acl.ACL) Upgrader {
 	return &tlsClientUpgrader{
 		config:       config,
 		invalidCerts: invalidCerts,
+		peerACL:      peerACL,
 	}
 }
 
 func (t *tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
-	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts)
+	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts, t.peerACL)
+}
+
+// quicUpgrader authenticates QUIC connections, in either direction. The TLS
+// handshake of a QUIC connection is finished when the connection is
+// established, so only the peer certificate remains to be checked.
+type quicUpgrader struct {
+	invalidCerts prometheus.Counter
+	peerACL      *acl.ACL
+}
+
+func NewQUICUpgrader(invalidCerts prometheus.Counter, peerACL *acl.ACL) Upgrader {
+	return &quicUpgrader{
+		invalidCerts: invalidCerts,
+		peerACL:      peerACL,
+	}
+}
+
+func (t *quicUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	quicConn, ok := conn.(*quic.Conn)
+	if !ok {
+		return ids.EmptyNodeID, nil, nil, errNoQUIC
+	}
+	return connToIDAndCert(quicConn, t.invalidCerts, t.peerACL)
 }
 
-func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+func connToIDAndCert(conn tlsConn, invalidCerts prometheus.Counter, peerACL *acl.ACL) (ids.NodeID, net.Conn, *staking.Certificate, error) {
 	if err := conn.Handshake(); err != nil {
 		return ids.EmptyNodeID, nil, nil, err
 	}
@@ -76,5 +120,16 @@ func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeI
 	}
 
 	nodeID := ids.NodeIDFromCert(peerCert)
+
+	// The nodeID of the peer is only known after the handshake, so this is
+	// the first point that the peer can be checked against the nodeID entries
+	// of the ACL.
+	var ip netip.Addr
+	if addrPort, err := ips.ParseAddrPort(conn.RemoteAddr().String()); err == nil {
+		ip = addrPort.Addr()
+	}
+	if !peerACL.Allow(nodeID, ip) {
+		return ids.EmptyNodeID, nil, nil, errDenied
+	}
 	return nodeID, conn, peerCert, nil
 }
@@ -0,0 +1,324 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// NextProto is the ALPN protocol that peers negotiate during the QUIC
+	// handshake.
+	NextProto = "avalanchego"
+
+	// Every connection has exactly two streams, which are opened by the
+	// dialer.
+	numStreams = 2
+
+	// maxIdleTimeout is the duration after which a connection without any
+	// traffic is closed. Peers regularly send pings, and the keep alive
+	// period keeps the connection open in between them.
+	maxIdleTimeout  = time.Minute
+	keepAlivePeriod = 15 * time.Second
+)
+
+// streamType is the first byte written to a stream by the dialer to identify
+// the messages that will be sent over it.
+type streamType byte
+
+const (
+	consensusStream streamType = iota
+	appStream
+)
+
+var (
+	errClosed              = errors.New("closed")
+	errUnknownStreamType   = errors.New("unknown stream type")
+	errDuplicateStreamType = errors.New("duplicate stream type")
+
+	_ net.Conn = (*Conn)(nil)
+	_ net.Conn = (*stream)(nil)
+)
+
+// Transport accepts and dials QUIC connections over a single UDP socket.
+//
+// The TLS handshake of a QUIC connection authenticates the peers in the same
+// way as the TLS handshake of a TCP connection, so the identity of the peer is
+// derived from its staking certificate.
+type Transport struct {
+	conn      net.PacketConn
+	transport *quicgo.Transport
+	listener  *quicgo.Listener
+	tlsConfig *tls.Config
+	config    *quicgo.Config
+	// limiter rate-limits the acceptance of inbound connections
+	limiter *rate.Limiter
+
+	accepted chan *Conn
+	// closed is closed when the transport is closed
+	closed chan struct{}
+	// ctx is cancelled when the transport is closed
+	ctx           context.Context
+	ctxCancelFunc func()
+	closeOnce     func() error
+}
+
+// NewTransport starts accepting QUIC connections on [conn]. The transport takes
+// ownership of [conn]. [tlsConfig] must be the TLS config of the staking
+// certificate. Inbound connections whose streams aren't opened within
+// [handshakeTimeout] are closed. At most [maxConnsPerSec] inbound connections
+// are accepted per second. [maxConnsPerSec] must be non-negative.
+func NewTransport(
+	conn net.PacketConn,
+	tlsConfig *tls.Config,
+	handshakeTimeout time.Duration,
+	maxConnsPerSec float64,
+) (*Transport, error) {
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{NextProto}
+
+	config := &quicgo.Config{
+		HandshakeIdleTimeout: handshakeTimeout,
+		MaxIdleTimeout:       maxIdleTimeout,
+		KeepAlivePeriod:      keepAlivePeriod,
+		MaxIncomingStreams:   numStreams,
+		// Unidirectional streams are never used
+		MaxIncomingUniStreams: -1,
+	}
+	transport := &quicgo.Transport{
+		Conn: conn,
+	}
+	listener, err := transport.Listen(tlsConfig, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Transport{
+		conn:          conn,
+		transport:     transport,
+		listener:      listener,
+		tlsConfig:     tlsConfig,
+		config:        config,
+		limiter:       rate.NewLimiter(rate.Limit(maxConnsPerSec), int(maxConnsPerSec)+1),
+		accepted:      make(chan *Conn),
+		closed:        make(chan struct{}),
+		ctx:           ctx,
+		ctxCancelFunc: cancel,
+	}
+	t.closeOnce = sync.OnceValue(t.close)
+	go t.acceptConns(handshakeTimeout)
+	return t, nil
+}
+
+// Addr returns the local address that the transport is listening on.
+func (t *Transport) Addr() net.Addr {
+	return t.listener.Addr()
+}
+
+// Accept waits for the next inbound connection whose streams have been
+// opened.
+func (t *Transport) Accept(ctx context.Context) (*Conn, error) {
+	select {
+	case conn := <-t.accepted:
+		return conn, nil
+	case <-t.closed:
+		return nil, errClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Dial connects to [ip] and opens the streams of the connection.
+func (t *Transport) Dial(ctx context.Context, ip netip.AddrPort) (*Conn, error) {
+	conn, err := t.transport.Dial(ctx, net.UDPAddrFromAddrPort(ip), t.tlsConfig, t.config)
+	if err != nil {
+		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
+	}
+
+	c, err := openStreams(ctx, conn)
+	if err != nil {
+		_ = conn.CloseWithError(0, "")
+		return nil, fmt.Errorf("failed to open streams to %s: %w", ip, err)
+	}
+	return c, nil
+}
+
+// Close stops accepting connections and closes all the connections of the
+// transport, as well as the underlying UDP socket.
+func (t *Transport) Close() error {
+	return t.closeOnce()
+}
+
+func (t *Transport) close() error {
+	close(t.closed)
+	t.ctxCancelFunc()
+	return errors.Join(
+		t.listener.Close(),
+		t.transport.Close(),
+		t.conn.Close(),
+	)
+}
+
+func (t *Transport) acceptConns(handshakeTimeout time.Duration) {
+	for {
+		// Wait until the rate-limiter says to accept the next inbound
+		// connection. Returns an error when the transport is closed.
+		if err := t.limiter.Wait(t.ctx); err != nil {
+			return
+		}
+
+		// Returns an error when the listener is closed
+		conn, err := t.listener.Accept(t.ctx)
+		if err != nil {
+			return
+		}
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+			defer cancel()
+
+			c, err := acceptStreams(ctx, conn)
+			if err != nil {
+				_ = conn.CloseWithError(0, "")
+				return
+			}
+
+			select {
+			case t.accepted <- c:
+			case <-t.closed:
+				_ = c.Close()
+			}
+		}()
+	}
+}
+
+func openStreams(ctx context.Context, conn quicgo.Connection) (*Conn, error) {
+	c := &Conn{
+		conn: conn,
+	}
+	for _, typ := range []streamType{consensusStream, appStream} {
+		s, err := conn.OpenStreamSync(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The peer only learns about the stream once data is written to it.
+		if _, err := s.Write([]byte{byte(typ)}); err != nil {
+			return nil, err
+		}
+		c.setStream(typ, s)
+	}
+	return c, nil
+}
+
+func acceptStreams(ctx context.Context, conn quicgo.Connection) (*Conn, error) {
+	c := &Conn{
+		conn: conn,
+	}
+	for i := 0; i < numStreams; i++ {
+		s, err := conn.AcceptStream(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			if err := s.SetReadDeadline(deadline); err != nil {
+				return nil, err
+			}
+		}
+
+		var typ [1]byte
+		if _, err := io.ReadFull(s, typ[:]); err != nil {
+			return nil, err
+		}
+		if err := s.SetReadDeadline(time.Time{}); err != nil {
+			return nil, err
+		}
+
+		switch t := streamType(typ[0]); {
+		case t != consensusStream && t != appStream:
+			return nil, fmt.Errorf("%w: %d", errUnknownStreamType, t)
+		case c.getStream(t) != nil:
+			return nil, fmt.Errorf("%w: %d", errDuplicateStreamType, t)
+		default:
+			c.setStream(t, s)
+		}
+	}
+	return c, nil
+}
+
+// Conn is a QUIC connection to a peer. Consensus and app messages are sent
+// over separate streams, so that app messages aren't blocked behind large
+// consensus messages, such as Ancestors, and vice versa.
+//
+// Conn implements [net.Conn] over the consensus stream.
+type Conn struct {
+	*stream
+	conn quicgo.Connection
+	app  *stream
+}
+
+// AppStream returns the stream that app messages are exchanged over.
+func (c *Conn) AppStream() net.Conn {
+	return c.app
+}
+
+// Handshake is a no-op because the TLS handshake is finished before the
+// connection is established.
+func (*Conn) Handshake() error {
+	return nil
+}
+
+// ConnectionState returns the state of the TLS handshake.
+func (c *Conn) ConnectionState() tls.ConnectionState {
+	return c.conn.ConnectionState().TLS
+}
+
+func (c *Conn) getStream(typ streamType) *stream {
+	if typ == consensusStream {
+		return c.stream
+	}
+	return c.app
+}
+
+func (c *Conn) setStream(typ streamType, s quicgo.Stream) {
+	wrapped := &stream{
+		Stream: s,
+		conn:   c.conn,
+	}
+	if typ == consensusStream {
+		c.stream = wrapped
+	} else {
+		c.app = wrapped
+	}
+}
+
+type stream struct {
+	quicgo.Stream
+	conn quicgo.Connection
+}
+
+func (s *stream) LocalAddr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+func (s *stream) RemoteAddr() net.Addr {
+	return s.conn.RemoteAddr()
+}
+
+// Close closes the whole connection, so that closing either stream closes the
+// other stream as well.
+func (s *stream) Close() error {
+	return s.conn.CloseWithError(0, "")
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+func newTransport(t *testing.T) (*Transport, *tls.Certificate) {
+	return newThrottledTransport(t, 100)
+}
+
+func newThrottledTransport(t *testing.T, maxConnsPerSec float64) (*Transport, *tls.Certificate) {
+	require := require.New(t)
+
+	cert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+
+	transport, err := NewTransport(
+		conn,
+		&tls.Config{
+			Certificates:       []tls.Certificate{*cert},
+			ClientAuth:         tls.RequireAnyClientCert,
+			InsecureSkipVerify: true, //#nosec G402
+			MinVersion:         tls.VersionTLS13,
+		},
+		time.Second,
+		maxConnsPerSec,
+	)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+	return transport, cert
+}
+
+func TestTransport(t *testing.T) {
+	require := require.New(t)
+
+	server, serverCert := newTransport(t)
+	client, clientCert := newTransport(t)
+
+	serverAddr, err := netip.ParseAddrPort(server.Addr().String())
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clientConn, err := client.Dial(ctx, serverAddr)
+	require.NoError(err)
+	defer clientConn.Close()
+
+	serverConn, err := server.Accept(ctx)
+	require.NoError(err)
+	defer serverConn.Close()
+
+	// Both peers are authenticated by their certificates
+	require.Equal(serverCert.Leaf.Raw, clientConn.ConnectionState().PeerCertificates[0].Raw)
+	require.Equal(clientCert.Leaf.Raw, serverConn.ConnectionState().PeerCertificates[0].Raw)
+
+	// Messages are delivered over the stream they were sent on, regardless of
+	// the order they were sent in.
+	_, err = clientConn.AppStream().Write([]byte("app"))
+	require.NoError(err)
+	_, err = clientConn.Write([]byte("consensus"))
+	require.NoError(err)
+
+	consensus := make([]byte, len("consensus"))
+	_, err = io.ReadFull(serverConn, consensus)
+	require.NoError(err)
+	require.Equal("consensus", string(consensus))
+
+	app := make([]byte, len("app"))
+	_, err = io.ReadFull(serverConn.AppStream(), app)
+	require.NoError(err)
+	require.Equal("app", string(app))
+
+	// Closing either stream closes the connection
+	require.NoError(serverConn.AppStream().Close())
+	_, err = clientConn.Read(consensus)
+	var appErr *quicgo.ApplicationError
+	require.ErrorAs(err, &appErr)
+}
+
+func TestTransportAcceptClosed(t *testing.T) {
+	require := require.New(t)
+
+	transport, _ := newTransport(t)
+	require.NoError(transport.Close())
+
+	_, err := transport.Accept(context.Background())
+	require.ErrorIs(err, errClosed)
+
+	// Closing the transport again is a no-op
+	require.NoError(transport.Close())
+}
+
+func TestTransportDialUnreachable(t *testing.T) {
+	require := require.New(t)
+
+	transport, _ := newTransport(t)
+
+	// Reserve a port that no transport is listening on
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	addr, err := netip.ParseAddrPort(conn.LocalAddr().String())
+	require.NoError(err)
+	require.NoError(conn.Close())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = transport.Dial(ctx, addr)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestTransportAcceptThrottled(t *testing.T) {
+	require := require.New(t)
+
+	// Only a single connection can be accepted before the limiter refills
+	server, _ := newThrottledTransport(t, 0.5)
+	client, _ := newTransport(t)
+
+	serverAddr, err := netip.ParseAddrPort(server.Addr().String())
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		clientConn, err := client.Dial(ctx, serverAddr)
+		require.NoError(err)
+		defer clientConn.Close()
+	}
+
+	serverConn, err := server.Accept(ctx)
+	require.NoError(err)
+	defer serverConn.Close()
+
+	throttledCtx, throttledCancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer throttledCancel()
+
+	_, err = server.Accept(throttledCtx)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
@@ -161,10 +161,14 @@ func NewTestNetwork(
 					},
 					MaxProcessingMsgsPerNode: constants.DefaultInboundThrottlerMaxProcessingMsgsPerNode,
 				},
-				OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
-					VdrAllocSize:        constants.DefaultOutboundThrottlerVdrAllocSize,
-					AtLargeAllocSize:    constants.DefaultOutboundThrottlerAtLargeAllocSize,
-					NodeMaxAtLargeBytes: constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes,
+				OutboundMsgThrottlerConfig: throttling.OutboundMsgThrottlerConfig{
+					MsgByteThrottlerConfig: throttling.MsgByteThrottlerConfig{
+						VdrAllocSize:        constants.DefaultOutboundThrottlerVdrAllocSize,
+						AtLargeAllocSize:    constants.DefaultOutboundThrottlerAtLargeAllocSize,
+						NodeMaxAtLargeBytes: constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes,
+					},
+					NodeMaxAppBytes:    constants.DefaultOutboundThrottlerNodeMaxAppBytes,
+					NodeMaxGossipBytes: constants.DefaultOutboundThrottlerNodeMaxGossipBytes,
 				},
 				MaxInboundConnsPerSec: constants.DefaultInboundThrottlerMaxConnsPerSec,
 			},
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ava-labs/avalanchego/network/acl"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -34,7 +35,8 @@ type InboundConnUpgradeThrottler interface {
 	Stop()
 	// Returns whether we should upgrade an inbound connection from [ipStr].
 	// Must only be called after [Dispatch] has been called.
-	// If [ip] is a local IP, this method always returns true.
+	// If [ip] is denied by the peer ACL, this method always returns false.
+	// Otherwise, if [ip] is a local IP, this method always returns true.
 	// Must not be called after [Stop] has been called.
 	ShouldUpgrade(ip netip.AddrPort) bool
 }
@@ -53,28 +55,40 @@ type InboundConnUpgradeThrottlerConfig struct {
 }
 
 // Returns an InboundConnUpgradeThrottler that upgrades an inbound
-// connection from a given IP at most every [UpgradeCooldown].
-func NewInboundConnUpgradeThrottler(log logging.Logger, config InboundConnUpgradeThrottlerConfig) InboundConnUpgradeThrottler {
+// connection from a given IP at most every [UpgradeCooldown]. Inbound
+// connections from IPs that are denied by [peerACL] are never upgraded. A nil
+// [peerACL] allows every IP.
+func NewInboundConnUpgradeThrottler(
+	log logging.Logger,
+	config InboundConnUpgradeThrottlerConfig,
+	peerACL *acl.ACL,
+) InboundConnUpgradeThrottler {
 	if config.UpgradeCooldown <= 0 || config.MaxRecentConnsUpgraded <= 0 {
-		return &noInboundConnUpgradeThrottler{}
+		return &noInboundConnUpgradeThrottler{
+			peerACL: peerACL,
+		}
 	}
 	return &inboundConnUpgradeThrottler{
 		InboundConnUpgradeThrottlerConfig: config,
 		log:                               log,
+		peerACL:                           peerACL,
 		done:                              make(chan struct{}),
 		recentIPsAndTimes:                 make(chan ipAndTime, config.MaxRecentConnsUpgraded),
 	}
 }
 
-// noInboundConnUpgradeThrottler upgrades all inbound connections
-type noInboundConnUpgradeThrottler struct{}
+// noInboundConnUpgradeThrottler upgrades all inbound connections that are
+// allowed by the peer ACL
+type noInboundConnUpgradeThrottler struct {
+	peerACL *acl.ACL
+}
 
 func (*noInboundConnUpgradeThrottler) Dispatch() {}
 
 func (*noInboundConnUpgradeThrottler) Stop() {}
 
-func (*noInboundConnUpgradeThrottler) ShouldUpgrade(netip.AddrPort) bool {
-	return true
+func (n *noInboundConnUpgradeThrottler) ShouldUpgrade(addrPort netip.AddrPort) bool {
+	return n.peerACL.AllowIP(addrPort.Addr())
 }
 
 type ipAndTime struct {
@@ -84,8 +98,9 @@ type ipAndTime struct {
 
 type inboundConnUpgradeThrottler struct {
 	InboundConnUpgradeThrottlerConfig
-	log  logging.Logger
-	lock sync.Mutex
+	log     logging.Logger
+	peerACL *acl.ACL
+	lock    sync.Mutex
 	// Useful for faking time in tests
 	clock mockable.Clock
 	// When [done] is closed, Dispatch returns.
@@ -105,6 +120,10 @@ func (n *inboundConnUpgradeThrottler) ShouldUpgrade(addrPort netip.AddrPort) boo
 	// Only use addr (not port). This mitigates DoS attacks from many nodes on one
 	// host.
 	addr := addrPort.Addr()
+	if !n.peerACL.AllowIP(addr) {
+		// Never upgrade connections from denied IPs
+		return false
+	}
 	if addr.IsLoopback() {
 		// Don't rate-limit loopback IPs
 		return true
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/network/acl"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -24,6 +25,9 @@ var (
 func TestNoInboundConnUpgradeThrottler(t *testing.T) {
 	require := require.New(t)
 
+	peerACL, err := acl.New("")
+	require.NoError(err)
+
 	{
 		throttler := NewInboundConnUpgradeThrottler(
 			logging.NoLog{},
@@ -31,6 +35,7 @@ func TestNoInboundConnUpgradeThrottler(t *testing.T) {
 				UpgradeCooldown:        0,
 				MaxRecentConnsUpgraded: 5,
 			},
+			peerACL,
 		)
 		// throttler should allow all
 		for i := 0; i < 10; i++ {
@@ -44,6 +49,7 @@ func TestNoInboundConnUpgradeThrottler(t *testing.T) {
 				UpgradeCooldown:        time.Second,
 				MaxRecentConnsUpgraded: 0,
 			},
+			peerACL,
 		)
 		// throttler should allow all
 		for i := 0; i < 10; i++ {
@@ -55,6 +61,9 @@ func TestNoInboundConnUpgradeThrottler(t *testing.T) {
 func TestInboundConnUpgradeThrottler(t *testing.T) {
 	require := require.New(t)
 
+	peerACL, err := acl.New("")
+	require.NoError(err)
+
 	cooldown := 5 * time.Second
 	throttlerIntf := NewInboundConnUpgradeThrottler(
 		logging.NoLog{},
@@ -62,6 +71,7 @@ func TestInboundConnUpgradeThrottler(t *testing.T) {
 			UpgradeCooldown:        cooldown,
 			MaxRecentConnsUpgraded: 3,
 		},
+		peerACL,
 	)
 
 	// Allow should always return true
@@ -104,3 +114,54 @@ func TestInboundConnUpgradeThrottler(t *testing.T) {
 		require.FailNow("should be done")
 	}
 }
+
+func TestInboundConnUpgradeThrottlerNilACL(t *testing.T) {
+	configs := []InboundConnUpgradeThrottlerConfig{
+		{
+			UpgradeCooldown:        0,
+			MaxRecentConnsUpgraded: 5,
+		},
+		{
+			UpgradeCooldown:        time.Second,
+			MaxRecentConnsUpgraded: 5,
+		},
+	}
+	for _, config := range configs {
+		throttler := NewInboundConnUpgradeThrottler(logging.NoLog{}, config, nil)
+		require.True(t, throttler.ShouldUpgrade(host1))
+	}
+}
+
+func TestInboundConnUpgradeThrottlerDenied(t *testing.T) {
+	require := require.New(t)
+
+	peerACL, err := acl.New("")
+	require.NoError(err)
+	require.NoError(peerACL.Set(acl.Config{
+		Deny: acl.List{
+			IPs:   []netip.Addr{host1.Addr(), loopbackIP.Addr()},
+			CIDRs: []netip.Prefix{netip.PrefixFrom(host2.Addr(), 32)},
+		},
+	}))
+
+	configs := []InboundConnUpgradeThrottlerConfig{
+		{},
+		{
+			UpgradeCooldown:        time.Second,
+			MaxRecentConnsUpgraded: 5,
+		},
+	}
+	for _, config := range configs {
+		throttler := NewInboundConnUpgradeThrottler(
+			logging.NoLog{},
+			config,
+			peerACL,
+		)
+
+		// Denied IPs are never upgraded, even if they are local
+		require.False(throttler.ShouldUpgrade(host1))
+		require.False(throttler.ShouldUpgrade(host2))
+		require.False(throttler.ShouldUpgrade(loopbackIP))
+		require.True(throttler.ShouldUpgrade(host3))
+	}
+}
@@ -36,16 +36,36 @@ type OutboundMsgThrottler interface {
 	Release(msg message.OutboundMessage, nodeID ids.NodeID)
 }
 
+type OutboundMsgThrottlerConfig struct {
+	MsgByteThrottlerConfig
+
+	// Max number of bytes of app request, response, and error messages that
+	// may be waiting to be sent to a given node. If 0, the app lane is only
+	// limited by the byte allocations.
+	NodeMaxAppBytes uint64 `json:"nodeMaxAppBytes"`
+
+	// Max number of bytes of app gossip messages that may be waiting to be
+	// sent to a given node. If 0, the gossip lane is only limited by the byte
+	// allocations.
+	NodeMaxGossipBytes uint64 `json:"nodeMaxGossipBytes"`
+}
+
 type outboundMsgThrottler struct {
 	commonMsgThrottler
 	metrics outboundMsgThrottlerMetrics
+
+	// Max number of bytes of each lane that may be waiting to be sent to a
+	// given node. 0 means the lane isn't limited.
+	nodeMaxLaneBytes [message.NumLanes]uint64
+	// Node ID --> Bytes of each limited lane that are waiting to be sent
+	nodeToLaneBytesUsed map[ids.NodeID][message.NumLanes]uint64
 }
 
 func NewSybilOutboundMsgThrottler(
 	log logging.Logger,
 	registerer prometheus.Registerer,
 	vdrs validators.Manager,
-	config MsgByteThrottlerConfig,
+	config OutboundMsgThrottlerConfig,
 ) (OutboundMsgThrottler, error) {
 	t := &outboundMsgThrottler{
 		commonMsgThrottler: commonMsgThrottler{
@@ -58,7 +78,10 @@ func NewSybilOutboundMsgThrottler(
 			nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
 			nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
 		},
+		nodeToLaneBytesUsed: make(map[ids.NodeID][message.NumLanes]uint64),
 	}
+	t.nodeMaxLaneBytes[message.AppLane] = config.NodeMaxAppBytes
+	t.nodeMaxLaneBytes[message.GossipLane] = config.NodeMaxGossipBytes
 	return t, t.metrics.initialize(registerer)
 }
 
@@ -71,8 +94,20 @@ func (t *outboundMsgThrottler) Acquire(msg message.OutboundMessage, nodeID ids.N
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
+	// Don't let a single lane, such as a burst of app gossip, take the bytes
+	// that are needed to send higher priority messages.
+	msgSize := uint64(len(msg.Bytes()))
+	lane := message.GetLane(msg.Op())
+	maxLaneBytes := t.nodeMaxLaneBytes[lane]
+	laneBytesUsed := t.nodeToLaneBytesUsed[nodeID]
+	if maxLaneBytes != 0 && laneBytesUsed[lane]+msgSize > maxLaneBytes {
+		t.metrics.acquireFailures.Inc()
+		t.metrics.laneAcquireFailures.WithLabelValues(lane.String()).Inc()
+		return false
+	}
+
 	// Take as many bytes as we can from the at-large allocation.
-	bytesNeeded := uint64(len(msg.Bytes()))
+	bytesNeeded := msgSize
 	atLargeBytesUsed := min(
 		// only give as many bytes as needed
 		bytesNeeded,
@@ -127,6 +162,10 @@ func (t *outboundMsgThrottler) Acquire(msg message.OutboundMessage, nodeID ids.N
 		t.nodeToVdrBytesUsed[nodeID] += vdrBytesUsed
 		t.metrics.remainingVdrBytes.Set(float64(t.remainingVdrBytes))
 	}
+	if maxLaneBytes != 0 {
+		laneBytesUsed[lane] += msgSize
+		t.nodeToLaneBytesUsed[nodeID] = laneBytesUsed
+	}
 	t.metrics.acquireSuccesses.Inc()
 	t.metrics.awaitingRelease.Inc()
 	return true
@@ -166,6 +205,18 @@ func (t *outboundMsgThrottler) Release(msg message.OutboundMessage, nodeID ids.N
 	if t.nodeToAtLargeBytesUsed[nodeID] == 0 {
 		delete(t.nodeToAtLargeBytesUsed, nodeID)
 	}
+
+	// Mark that [nodeID] has released these bytes from the lane of [msg].
+	lane := message.GetLane(msg.Op())
+	if t.nodeMaxLaneBytes[lane] != 0 {
+		laneBytesUsed := t.nodeToLaneBytesUsed[nodeID]
+		laneBytesUsed[lane] -= msgSize
+		if laneBytesUsed == ([message.NumLanes]uint64{}) {
+			delete(t.nodeToLaneBytesUsed, nodeID)
+		} else {
+			t.nodeToLaneBytesUsed[nodeID] = laneBytesUsed
+		}
+	}
 }
 
 type outboundMsgThrottlerMetrics struct {
@@ -174,6 +225,7 @@ type outboundMsgThrottlerMetrics struct {
 	remainingAtLargeBytes prometheus.Gauge
 	remainingVdrBytes     prometheus.Gauge
 	awaitingRelease       prometheus.Gauge
+	laneAcquireFailures   *prometheus.CounterVec
 }
 
 func (m *outboundMsgThrottlerMetrics) initialize(registerer prometheus.Registerer) error {
@@ -197,12 +249,20 @@ func (m *outboundMsgThrottlerMetrics) initialize(registerer prometheus.Registere
 		Name: "throttler_outbound_awaiting_release",
 		Help: "Number of messages waiting to be sent",
 	})
+	m.laneAcquireFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "throttler_outbound_lane_acquire_failures",
+			Help: "Outbound messages dropped due to the byte limit of their lane",
+		},
+		[]string{"lane"},
+	)
 	return errors.Join(
 		registerer.Register(m.acquireSuccesses),
 		registerer.Register(m.acquireFailures),
 		registerer.Register(m.remainingAtLargeBytes),
 		registerer.Register(m.remainingVdrBytes),
 		registerer.Register(m.awaitingRelease),
+		registerer.Register(m.laneAcquireFailures),
 	)
 }
 
@@ -20,10 +20,12 @@ import (
 func TestSybilOutboundMsgThrottler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
-	config := MsgByteThrottlerConfig{
-		VdrAllocSize:        1024,
-		AtLargeAllocSize:    1024,
-		NodeMaxAtLargeBytes: 1024,
+	config := OutboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        1024,
+			AtLargeAllocSize:    1024,
+			NodeMaxAtLargeBytes: 1024,
+		},
 	}
 	vdrs := validators.NewManager()
 	vdr1ID := ids.GenerateTestNodeID()
@@ -163,10 +165,12 @@ func TestSybilOutboundMsgThrottler(t *testing.T) {
 func TestSybilOutboundMsgThrottlerMaxNonVdr(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
-	config := MsgByteThrottlerConfig{
-		VdrAllocSize:        100,
-		AtLargeAllocSize:    100,
-		NodeMaxAtLargeBytes: 10,
+	config := OutboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        100,
+			AtLargeAllocSize:    100,
+			NodeMaxAtLargeBytes: 10,
+		},
 	}
 	vdrs := validators.NewManager()
 	vdr1ID := ids.GenerateTestNodeID()
@@ -209,10 +213,12 @@ func TestSybilOutboundMsgThrottlerMaxNonVdr(t *testing.T) {
 func TestBypassThrottling(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
-	config := MsgByteThrottlerConfig{
-		VdrAllocSize:        100,
-		AtLargeAllocSize:    100,
-		NodeMaxAtLargeBytes: 10,
+	config := OutboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        100,
+			AtLargeAllocSize:    100,
+			NodeMaxAtLargeBytes: 10,
+		},
 	}
 	vdrs := validators.NewManager()
 	vdr1ID := ids.GenerateTestNodeID()
@@ -258,6 +264,48 @@ func TestBypassThrottling(t *testing.T) {
 	require.Equal(config.AtLargeAllocSize-1, throttler.remainingAtLargeBytes)
 }
 
+func TestSybilOutboundMsgThrottlerLaneLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	config := OutboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        1024,
+			AtLargeAllocSize:    1024,
+			NodeMaxAtLargeBytes: 1024,
+		},
+		NodeMaxGossipBytes: 10,
+	}
+	throttlerIntf, err := NewSybilOutboundMsgThrottler(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		validators.NewManager(),
+		config,
+	)
+	require.NoError(err)
+	throttler := throttlerIntf.(*outboundMsgThrottler)
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+
+	// Gossip can take up to [NodeMaxGossipBytes] per node.
+	gossipMsg := testMsgWithSize(ctrl, config.NodeMaxGossipBytes)
+	require.True(throttlerIntf.Acquire(gossipMsg, nodeID1))
+	require.False(throttlerIntf.Acquire(testMsgWithSize(ctrl, 1), nodeID1))
+	require.True(throttlerIntf.Acquire(testMsgWithSize(ctrl, 1), nodeID2))
+
+	// Other lanes aren't limited by the gossip lane.
+	consensusMsg := testMsgWithOpAndSize(ctrl, message.ChitsOp, 100)
+	require.True(throttlerIntf.Acquire(consensusMsg, nodeID1))
+	appMsg := testMsgWithOpAndSize(ctrl, message.AppResponseOp, 100)
+	require.True(throttlerIntf.Acquire(appMsg, nodeID1))
+	require.Equal(config.NodeMaxGossipBytes, throttler.nodeToLaneBytesUsed[nodeID1][message.GossipLane])
+	require.Zero(throttler.nodeToLaneBytesUsed[nodeID1][message.ConsensusLane])
+
+	// Releasing the gossip message makes room in the lane again.
+	throttlerIntf.Release(gossipMsg, nodeID1)
+	require.NotContains(throttler.nodeToLaneBytesUsed, nodeID1)
+	require.True(throttlerIntf.Acquire(testMsgWithSize(ctrl, 1), nodeID1))
+}
+
 func testMsgWithSize(ctrl *gomock.Controller, size uint64) message.OutboundMessage {
 	msg := message.NewMockOutboundMessage(ctrl)
 	msg.EXPECT().BypassThrottling().Return(false).AnyTimes()
@@ -265,3 +313,11 @@ func testMsgWithSize(ctrl *gomock.Controller, size uint64) message.OutboundMessa
 	msg.EXPECT().Bytes().Return(make([]byte, size)).AnyTimes()
 	return msg
 }
+
+func testMsgWithOpAndSize(ctrl *gomock.Controller, op message.Op, size uint64) message.OutboundMessage {
+	msg := message.NewMockOutboundMessage(ctrl)
+	msg.EXPECT().BypassThrottling().Return(false).AnyTimes()
+	msg.EXPECT().Op().Return(op).AnyTimes()
+	msg.EXPECT().Bytes().Return(make([]byte, size)).AnyTimes()
+	return msg
+}
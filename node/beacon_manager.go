@@ -5,41 +5,110 @@ package node
 
 import (
 	"sync"
-	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
 )
 
-var _ router.Router = (*beaconManager)(nil)
+var (
+	_ router.Router                  = (*beaconManager)(nil)
+	_ validators.SetCallbackListener = (*beaconManager)(nil)
+)
 
+// beaconManager closes [onSufficientlyConnected] once this node is connected
+// to at least 3/4 of the beacons. Beacons may be added and removed after the
+// beaconManager was created.
 type beaconManager struct {
 	router.Router
-	beacons                     validators.Manager
-	requiredConns               int64
-	numConns                    int64
 	onSufficientlyConnected     chan struct{}
 	onceOnSufficientlyConnected sync.Once
+
+	lock sync.Mutex
+	// beacons is kept in sync with the primary network of the beacon
+	// validators.Manager. It is duplicated to avoid calling into the
+	// validators.Manager while holding [lock], as the validators.Manager
+	// holds its own lock while notifying us of changes.
+	beacons set.Set[ids.NodeID]
+	// connected contains the nodes that are connected on the primary network.
+	connected set.Set[ids.NodeID]
+	// numConns is the number of beacons that are connected.
+	numConns int
+}
+
+func newBeaconManager(
+	router router.Router,
+	beacons validators.Manager,
+	onSufficientlyConnected chan struct{},
+) *beaconManager {
+	b := &beaconManager{
+		Router:                  router,
+		onSufficientlyConnected: onSufficientlyConnected,
+	}
+	beacons.RegisterSetCallbackListener(constants.PrimaryNetworkID, b)
+	return b
 }
 
 func (b *beaconManager) Connected(nodeID ids.NodeID, nodeVersion *version.Application, subnetID ids.ID) {
-	_, isBeacon := b.beacons.GetValidator(constants.PrimaryNetworkID, nodeID)
-	if isBeacon &&
-		constants.PrimaryNetworkID == subnetID &&
-		atomic.AddInt64(&b.numConns, 1) >= b.requiredConns {
-		b.onceOnSufficientlyConnected.Do(func() {
-			close(b.onSufficientlyConnected)
-		})
+	if constants.PrimaryNetworkID == subnetID {
+		b.lock.Lock()
+		b.connected.Add(nodeID)
+		if b.beacons.Contains(nodeID) {
+			b.numConns++
+			b.checkSufficientlyConnected()
+		}
+		b.lock.Unlock()
 	}
 	b.Router.Connected(nodeID, nodeVersion, subnetID)
 }
 
 func (b *beaconManager) Disconnected(nodeID ids.NodeID) {
-	if _, isBeacon := b.beacons.GetValidator(constants.PrimaryNetworkID, nodeID); isBeacon {
-		atomic.AddInt64(&b.numConns, -1)
+	b.lock.Lock()
+	if b.connected.Contains(nodeID) {
+		b.connected.Remove(nodeID)
+		if b.beacons.Contains(nodeID) {
+			b.numConns--
+		}
 	}
+	b.lock.Unlock()
 	b.Router.Disconnected(nodeID)
 }
+
+func (b *beaconManager) OnValidatorAdded(nodeID ids.NodeID, _ *bls.PublicKey, _ ids.ID, _ uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.beacons.Add(nodeID)
+	if b.connected.Contains(nodeID) {
+		b.numConns++
+	}
+	b.checkSufficientlyConnected()
+}
+
+func (b *beaconManager) OnValidatorRemoved(nodeID ids.NodeID, _ uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.beacons.Remove(nodeID)
+	if b.connected.Contains(nodeID) {
+		b.numConns--
+	}
+	b.checkSufficientlyConnected()
+}
+
+func (*beaconManager) OnValidatorWeightChanged(ids.NodeID, uint64, uint64) {}
+
+// checkSufficientlyConnected assumes [lock] is held.
+func (b *beaconManager) checkSufficientlyConnected() {
+	requiredConns := (3*b.beacons.Len() + 3) / 4
+	if b.numConns < requiredConns {
+		return
+	}
+	b.onceOnSufficientlyConnected.Do(func() {
+		close(b.onSufficientlyConnected)
+	})
+}
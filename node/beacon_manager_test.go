@@ -38,12 +38,7 @@ func TestBeaconManager_DataRace(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRouter := router.NewMockRouter(ctrl)
 
-	b := beaconManager{
-		Router:                  mockRouter,
-		beacons:                 validatorSet,
-		requiredConns:           numValidators,
-		onSufficientlyConnected: make(chan struct{}),
-	}
+	b := newBeaconManager(mockRouter, validatorSet, make(chan struct{}))
 
 	// connect numValidators validators, each with a weight of 1
 	wg.Add(2 * numValidators)
@@ -64,7 +59,7 @@ func TestBeaconManager_DataRace(t *testing.T) {
 	wg.Wait()
 
 	// we should have a weight of numValidators now
-	require.Equal(int64(numValidators), b.numConns)
+	require.Equal(numValidators, b.numConns)
 
 	// disconnect numValidators validators
 	wg.Add(numValidators)
@@ -83,3 +78,37 @@ func TestBeaconManager_DataRace(t *testing.T) {
 	// we should a weight of zero now
 	require.Zero(b.numConns)
 }
+
+func TestBeaconManager_DynamicBeacons(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	mockRouter := router.NewMockRouter(ctrl)
+	mockRouter.EXPECT().Connected(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	var (
+		beacons                 = validators.NewManager()
+		onSufficientlyConnected = make(chan struct{})
+		beacon0                 = ids.GenerateTestNodeID()
+		beacon1                 = ids.GenerateTestNodeID()
+	)
+	require.NoError(beacons.AddStaker(constants.PrimaryNetworkID, beacon0, nil, ids.Empty, 1))
+	b := newBeaconManager(mockRouter, beacons, onSufficientlyConnected)
+
+	// Connecting to a node before it becomes a beacon should count towards
+	// being sufficiently connected once it becomes one.
+	b.Connected(beacon1, version.CurrentApp, constants.PrimaryNetworkID)
+	require.NoError(beacons.AddStaker(constants.PrimaryNetworkID, beacon1, nil, ids.Empty, 1))
+	require.Equal(1, b.numConns)
+
+	select {
+	case <-onSufficientlyConnected:
+		require.FailNow("shouldn't be sufficiently connected")
+	default:
+	}
+
+	// Removing the beacon that we aren't connected to should make us
+	// sufficiently connected.
+	require.NoError(beacons.RemoveWeight(constants.PrimaryNetworkID, beacon0, 1))
+	<-onSufficientlyConnected
+}
@@ -8,11 +8,13 @@ import (
 	"net/netip"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -27,8 +29,9 @@ import (
 )
 
 type APIIndexerConfig struct {
-	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
-	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	IndexAPIEnabled      bool   `json:"indexAPIEnabled"`
+	IndexAllowIncomplete bool   `json:"indexAllowIncomplete"`
+	IndexGRPCPort        uint16 `json:"indexGRPCPort"`
 }
 
 type HTTPConfig struct {
@@ -105,6 +108,27 @@ type BootstrapConfig struct {
 	BootstrapMaxTimeGetAncestors time.Duration `json:"bootstrapMaxTimeGetAncestors"`
 
 	Bootstrappers []genesis.Bootstrapper `json:"bootstrappers"`
+
+	// Domain names whose TXT records contain additional bootstrappers
+	BootstrapDNSSeeds []string `json:"bootstrapDNSSeeds"`
+
+	// Address of the DNS server used to resolve [BootstrapDNSSeeds]. If empty,
+	// the resolver of the system is used.
+	BootstrapDNSResolver string `json:"bootstrapDNSResolver"`
+
+	// File that contains additional bootstrappers. It is re-read periodically
+	// so that bootstrappers can be changed without restarting the node.
+	BootstrapPeersFile string `json:"bootstrapPeersFile"`
+
+	// Frequency at which [BootstrapDNSSeeds] and [BootstrapPeersFile] are
+	// refreshed
+	BootstrapPeersRefreshFrequency time.Duration `json:"bootstrapPeersRefreshFrequency"`
+}
+
+// bootstrapperDiscoveryEnabled returns true if bootstrappers are discovered
+// from DNS seeds or from a bootstrappers file.
+func (c *BootstrapConfig) bootstrapperDiscoveryEnabled() bool {
+	return len(c.BootstrapDNSSeeds) != 0 || len(c.BootstrapPeersFile) != 0
 }
 
 type DatabaseConfig struct {
@@ -119,6 +143,12 @@ type DatabaseConfig struct {
 
 	// Path to config file
 	Config []byte `json:"-"`
+
+	// Directory that database snapshots are exported to
+	SnapshotDir string `json:"snapshotDir"`
+
+	// Path to a database snapshot to populate an uninitialized database with
+	SnapshotImportFile string `json:"snapshotImportFile"`
 }
 
 // Config contains all of the configurations of an Avalanche node.
@@ -164,6 +194,7 @@ type Config struct {
 
 	RouterHealthConfig       router.HealthConfig `json:"routerHealthConfig"`
 	ConsensusShutdownTimeout time.Duration       `json:"consensusShutdownTimeout"`
+	ConsensusAuditLogConfig  audit.Config        `json:"consensusAuditLogConfig"`
 	// Poll for new frontiers every [FrontierPollFrequency]
 	FrontierPollFrequency time.Duration `json:"consensusGossipFreq"`
 	// ConsensusAppConcurrency defines the maximum number of goroutines to
@@ -205,6 +236,8 @@ type Config struct {
 
 	TraceConfig trace.Config `json:"traceConfig"`
 
+	MetricsExportConfig metrics.OTLPConfig `json:"metricsExportConfig"`
+
 	// See comment on [UseCurrentHeight] in platformvm.Config
 	UseCurrentHeight bool `json:"useCurrentHeight"`
 
@@ -24,6 +24,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/ava-labs/avalanchego/api/admin"
 	"github.com/ava-labs/avalanchego/api/health"
@@ -34,11 +35,11 @@ import (
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
-	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/factory"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/meterdb"
-	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/snapshot"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
@@ -46,8 +47,12 @@ import (
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/acl"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/discovery"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/quic"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
@@ -78,6 +83,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/registry"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 
 	avmconfig "github.com/ava-labs/avalanchego/vms/avm/config"
@@ -106,14 +112,19 @@ const (
 )
 
 var (
-	genesisHashKey     = []byte("genesisID")
-	ungracefulShutdown = []byte("ungracefulShutdown")
-
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
-
-	errInvalidTLSKey = errors.New("invalid TLS key")
-	errShuttingDown  = errors.New("server shutting down")
+	genesisHashKey       = []byte("genesisID")
+	ungracefulShutdown   = []byte("ungracefulShutdown")
+	snapshotImportingKey = []byte("snapshotImporting")
+
+	indexerDBPrefix        = []byte{0x00}
+	keystoreDBPrefix       = []byte("keystore")
+	peerReputationDBPrefix = []byte("peer reputation")
+
+	errInvalidTLSKey           = errors.New("invalid TLS key")
+	errShuttingDown            = errors.New("server shutting down")
+	errIncompleteImport        = errors.New("a previous database snapshot import did not complete")
+	errSnapshotNetworkMismatch = errors.New("snapshot network mismatch")
+	errSnapshotGenesisMismatch = errors.New("snapshot genesis mismatch")
 )
 
 // New returns an instance of Node
@@ -187,6 +198,10 @@ func New(
 		return nil, fmt.Errorf("couldn't initialize metrics API: %w", err)
 	}
 
+	if err := n.initMetricsExporter(); err != nil { // Start pushing metrics
+		return nil, fmt.Errorf("couldn't initialize metrics exporter: %w", err)
+	}
+
 	if err := n.initDatabase(); err != nil { // Set up the node's database
 		return nil, fmt.Errorf("problem initializing database: %w", err)
 	}
@@ -292,6 +307,11 @@ type Node struct {
 
 	// Storage for this node
 	DB database.Database
+	// dbSnapshotter takes snapshots of the underlying database, if supported.
+	dbSnapshotter database.Snapshotter
+	// dbSizeEstimator estimates the disk usage of the underlying database, if
+	// supported.
+	dbSizeEstimator database.SizeEstimator
 
 	router     nat.Router
 	portMapper *nat.Mapper
@@ -304,6 +324,9 @@ type Node struct {
 
 	// Indexes blocks, transactions and blocks
 	indexer indexer.Indexer
+	// Serves the indexer over gRPC. Nil if the gRPC server is disabled.
+	indexerGRPCServer   *grpc.Server
+	indexerGRPCListener net.Listener
 
 	// Handles calls to Keystore API
 	keystore keystore.Keystore
@@ -328,6 +351,9 @@ type Node struct {
 
 	uptimeCalculator uptime.LockedCalculator
 
+	// Scores peers by how well they served our requests
+	peerReputation *p2p.Reputation
+
 	// dispatcher for events as they happen in consensus
 	BlockAcceptorGroup  snow.AcceptorGroup
 	TxAcceptorGroup     snow.AcceptorGroup
@@ -347,6 +373,12 @@ type Node struct {
 
 	// this node's initial connections to the network
 	bootstrappers validators.Manager
+	// staticBootstrappers contains the bootstrappers that were explicitly
+	// configured, which are never removed from [bootstrappers].
+	staticBootstrappers set.Set[ids.NodeID]
+	// Keeps the bootstrappers discovered from DNS seeds and the bootstrappers
+	// file up to date. Nil if bootstrapper discovery is disabled.
+	bootstrapperUpdater *discovery.Updater
 
 	// current validators of the network
 	vdrs validators.Manager
@@ -361,6 +393,9 @@ type Node struct {
 
 	tracer trace.Tracer
 
+	// Pushes metrics over OTLP, if enabled
+	metricsExporter *metrics.OTLPExporter
+
 	// ensures that we only close the node once.
 	shutdownOnce sync.Once
 
@@ -528,6 +563,11 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 		)
 	}
 
+	n.Config.NetworkConfig.PeerACL, err = acl.New(n.Config.NetworkConfig.PeerACLFile)
+	if err != nil {
+		return fmt.Errorf("failed to initialize peer ACL: %w", err)
+	}
+
 	// We allow nodes to gossip unknown ACPs in case the current ACPs constant
 	// becomes out of date.
 	var unknownACPs set.Set[uint32]
@@ -572,7 +612,16 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
 
-	consensusRouter := n.chainRouter
+	n.peerReputation, err = p2p.NewReputation(n.Log, prefixdb.New(peerReputationDBPrefix, n.DB))
+	if err != nil {
+		return fmt.Errorf("problem initializing peer reputation: %w", err)
+	}
+	go n.Log.RecoverAndPanic(n.peerReputation.Dispatch)
+
+	var consensusRouter router.Router = &reputationTracker{
+		Router:     n.chainRouter,
+		reputation: n.peerReputation,
+	}
 	if !n.Config.SybilProtectionEnabled {
 		// Sybil protection is disabled so we don't have a txID that added us as
 		// a validator. Because each validator needs a txID associated with it,
@@ -603,13 +652,14 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	numBootstrappers := n.bootstrappers.Count(constants.PrimaryNetworkID)
 	requiredConns := (3*numBootstrappers + 3) / 4
 
-	if requiredConns > 0 {
-		consensusRouter = &beaconManager{
-			Router:                  consensusRouter,
-			beacons:                 n.bootstrappers,
-			requiredConns:           int64(requiredConns),
-			onSufficientlyConnected: n.onSufficientlyConnected,
-		}
+	// If bootstrappers are discovered, the node must wait for them to be
+	// discovered even if there are none yet.
+	if requiredConns > 0 || n.Config.bootstrapperDiscoveryEnabled() {
+		consensusRouter = newBeaconManager(
+			consensusRouter,
+			n.bootstrappers,
+			n.onSufficientlyConnected,
+		)
 	} else {
 		close(n.onSufficientlyConnected)
 	}
@@ -622,6 +672,28 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	n.Config.NetworkConfig.Beacons = n.bootstrappers
 	n.Config.NetworkConfig.TLSConfig = tlsConfig
 	n.Config.NetworkConfig.TLSKey = tlsKey
+	if n.Config.NetworkConfig.QUICEnabled {
+		// QUIC connections are accepted on the UDP port that matches the
+		// staking port, so that peers can derive it from our signed IP.
+		quicAddress := net.JoinHostPort(n.Config.ListenHost, strconv.FormatUint(uint64(stakingAddrPort.Port()), 10))
+		udpConn, err := net.ListenPacket("udp", quicAddress)
+		if err != nil {
+			return err
+		}
+		n.Config.NetworkConfig.QUICTransport, err = quic.NewTransport(
+			udpConn,
+			tlsConfig,
+			n.Config.NetworkConfig.ReadHandshakeTimeout,
+			n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec,
+		)
+		if err != nil {
+			_ = udpConn.Close()
+			return err
+		}
+		n.Log.Info("accepting QUIC connections",
+			zap.Stringer("address", udpConn.LocalAddr()),
+		)
+	}
 	n.Config.NetworkConfig.BLSKey = n.Config.StakingSigningKey
 	n.Config.NetworkConfig.TrackedSubnets = n.Config.TrackedSubnets
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
@@ -640,8 +712,91 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
 		consensusRouter,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	n.initBootstrapperDiscovery()
+	return nil
+}
+
+// initBootstrapperDiscovery adds the bootstrappers of the configured DNS seeds
+// and bootstrappers file, and keeps them up to date.
+// Assumes [n.bootstrappers] and [n.Net] have been initialized.
+func (n *Node) initBootstrapperDiscovery() {
+	if !n.Config.bootstrapperDiscoveryEnabled() {
+		return
+	}
+
+	source := discovery.NewSource(
+		discovery.NewResolver(n.Config.BootstrapDNSResolver),
+		n.Config.BootstrapDNSSeeds,
+		n.Config.BootstrapPeersFile,
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), discovery.RefreshTimeout)
+	discovered, err := source.Bootstrappers(ctx)
+	cancel()
+	if err != nil {
+		n.Log.Warn("couldn't discover all bootstrappers",
+			zap.Error(err),
+		)
+	}
+
+	n.updateBootstrappers(discovered, nil)
+	n.bootstrapperUpdater = discovery.NewUpdater(
+		source,
+		discovered,
+		n.Config.BootstrapPeersRefreshFrequency,
+		n.updateBootstrappers,
+	)
+	go n.Log.RecoverAndPanic(func() {
+		n.bootstrapperUpdater.Dispatch(n.Log)
+	})
+}
+
+// updateBootstrappers adds and removes discovered bootstrappers. The
+// bootstrappers that were explicitly configured are never removed.
+func (n *Node) updateBootstrappers(added []genesis.Bootstrapper, removed []genesis.Bootstrapper) {
+	for _, bootstrapper := range removed {
+		if n.staticBootstrappers.Contains(bootstrapper.ID) {
+			continue
+		}
+		if _, ok := n.bootstrappers.GetValidator(constants.PrimaryNetworkID, bootstrapper.ID); !ok {
+			continue
+		}
+		if err := n.bootstrappers.RemoveWeight(constants.PrimaryNetworkID, bootstrapper.ID, 1); err != nil {
+			n.Log.Error("failed to remove bootstrapper",
+				zap.Stringer("nodeID", bootstrapper.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		n.Net.ManuallyUntrack(bootstrapper.ID)
+		n.Log.Info("removed bootstrapper",
+			zap.Stringer("nodeID", bootstrapper.ID),
+			zap.Stringer("ip", bootstrapper.IP),
+		)
+	}
+	for _, bootstrapper := range added {
+		if n.staticBootstrappers.Contains(bootstrapper.ID) {
+			continue
+		}
+		if _, ok := n.bootstrappers.GetValidator(constants.PrimaryNetworkID, bootstrapper.ID); ok {
+			continue
+		}
+		if err := n.bootstrappers.AddStaker(constants.PrimaryNetworkID, bootstrapper.ID, nil, ids.Empty, 1); err != nil {
+			n.Log.Error("failed to add bootstrapper",
+				zap.Stringer("nodeID", bootstrapper.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		n.Net.ManuallyTrack(bootstrapper.ID, bootstrapper.IP)
+		n.Log.Info("added bootstrapper",
+			zap.Stringer("nodeID", bootstrapper.ID),
+			zap.Stringer("ip", bootstrapper.IP),
+		)
+	}
 }
 
 type NodeProcessContext struct {
@@ -702,6 +857,21 @@ func (n *Node) Dispatch() error {
 		n.Shutdown(1)
 	})
 
+	// Start the indexer gRPC server
+	if n.indexerGRPCServer != nil {
+		go n.Log.RecoverAndPanic(func() {
+			n.Log.Info("indexer gRPC server listening",
+				zap.Stringer("address", n.indexerGRPCListener.Addr()),
+			)
+			err := n.indexerGRPCServer.Serve(n.indexerGRPCListener)
+			if !n.shuttingDown.Get() {
+				n.Log.Error("indexer gRPC server failed",
+					zap.Error(err),
+				)
+			}
+		})
+	}
+
 	// Log a warning if we aren't able to connect to a sufficient portion of
 	// nodes.
 	go func() {
@@ -779,32 +949,18 @@ func (n *Node) initDatabase() error {
 	}
 
 	// start the db
-	switch n.Config.DatabaseConfig.Name {
-	case leveldb.Name:
-		// Prior to v1.10.15, the only on-disk database was leveldb, and its
-		// files went to [dbPath]/[networkID]/v1.4.5.
-		dbPath := filepath.Join(n.Config.DatabaseConfig.Path, version.CurrentDatabase.String())
-		n.DB, err = leveldb.New(dbPath, n.Config.DatabaseConfig.Config, n.Log, dbRegisterer)
-		if err != nil {
-			return fmt.Errorf("couldn't create %s at %s: %w", leveldb.Name, dbPath, err)
-		}
-	case memdb.Name:
-		n.DB = memdb.New()
-	case pebbledb.Name:
-		dbPath := filepath.Join(n.Config.DatabaseConfig.Path, "pebble")
-		n.DB, err = pebbledb.New(dbPath, n.Config.DatabaseConfig.Config, n.Log, dbRegisterer)
-		if err != nil {
-			return fmt.Errorf("couldn't create %s at %s: %w", pebbledb.Name, dbPath, err)
-		}
-	default:
-		return fmt.Errorf(
-			"db-type was %q but should have been one of {%s, %s, %s}",
-			n.Config.DatabaseConfig.Name,
-			leveldb.Name,
-			memdb.Name,
-			pebbledb.Name,
-		)
+	n.DB, err = factory.New(
+		n.Config.DatabaseConfig.Name,
+		n.Config.DatabaseConfig.Path,
+		n.Config.DatabaseConfig.Config,
+		n.Log,
+		dbRegisterer,
+	)
+	if err != nil {
+		return err
 	}
+	n.dbSnapshotter, _ = n.DB.(database.Snapshotter)
+	n.dbSizeEstimator, _ = n.DB.(database.SizeEstimator)
 
 	if n.Config.ReadOnly && n.Config.DatabaseConfig.Name != memdb.Name {
 		n.DB = versiondb.New(n.DB)
@@ -825,6 +981,20 @@ func (n *Node) initDatabase() error {
 
 	rawExpectedGenesisHash := hashing.ComputeHash256(n.Config.GenesisBytes)
 
+	if path := n.Config.DatabaseConfig.SnapshotImportFile; len(path) > 0 {
+		if err := n.importDatabaseSnapshot(path, ids.ID(rawExpectedGenesisHash)); err != nil {
+			return fmt.Errorf("failed to import database snapshot: %w", err)
+		}
+	}
+
+	importing, err := n.DB.Has(snapshotImportingKey)
+	if err != nil {
+		return err
+	}
+	if importing {
+		return errIncompleteImport
+	}
+
 	rawGenesisHash, err := n.DB.Get(genesisHashKey)
 	if err == database.ErrNotFound {
 		rawGenesisHash = rawExpectedGenesisHash
@@ -870,6 +1040,69 @@ func (n *Node) initDatabase() error {
 	return nil
 }
 
+// importDatabaseSnapshot populates an uninitialized database with the contents
+// of the snapshot at [path]. If the database was already initialized, the
+// import is skipped.
+func (n *Node) importDatabaseSnapshot(path string, genesisHash ids.ID) error {
+	initialized, err := n.DB.Has(genesisHashKey)
+	if err != nil {
+		return err
+	}
+	if initialized {
+		n.Log.Info("skipping database snapshot import",
+			zap.String("reason", "database is already initialized"),
+			zap.String("path", path),
+		)
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// If the import is interrupted, the marker prevents the node from starting
+	// with a partially imported database.
+	if err := n.DB.Put(snapshotImportingKey, nil); err != nil {
+		return err
+	}
+
+	n.Log.Info("importing database snapshot",
+		zap.String("path", path),
+	)
+
+	manifest, summary, err := snapshot.Import(file, n.DB, func(manifest *snapshot.Manifest) error {
+		if manifest.NetworkID != n.Config.NetworkID {
+			return fmt.Errorf("%w: snapshot is for network %d but node is configured for network %d",
+				errSnapshotNetworkMismatch,
+				manifest.NetworkID,
+				n.Config.NetworkID,
+			)
+		}
+		if manifest.GenesisHash != genesisHash {
+			return fmt.Errorf("%w: snapshot has genesis %s but node is configured with genesis %s",
+				errSnapshotGenesisMismatch,
+				manifest.GenesisHash,
+				genesisHash,
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	n.Log.Info("imported database snapshot",
+		zap.String("path", path),
+		zap.String("nodeVersion", manifest.NodeVersion),
+		zap.Time("timestamp", manifest.Timestamp),
+		zap.Reflect("chains", manifest.Chains),
+		zap.Uint64("numKeys", summary.NumKeys),
+	)
+	return n.DB.Delete(snapshotImportingKey)
+}
+
 // Set the node IDs of the peers this node should first connect to
 func (n *Node) initBootstrappers() error {
 	n.bootstrappers = validators.NewManager()
@@ -880,6 +1113,7 @@ func (n *Node) initBootstrappers() error {
 		if err := n.bootstrappers.AddStaker(constants.PrimaryNetworkID, bootstrapper.ID, nil, ids.Empty, 1); err != nil {
 			return err
 		}
+		n.staticBootstrappers.Add(bootstrapper.ID)
 	}
 	return nil
 }
@@ -897,6 +1131,18 @@ func (n *Node) initEventDispatchers() {
 // [n.ConsensusAcceptorGroup], [n.Log], [n.APIServer], [n.chainManager] are
 // initialized
 func (n *Node) initIndexer() error {
+	var grpcServer grpc.ServiceRegistrar
+	if n.Config.IndexAPIEnabled && n.Config.IndexGRPCPort != 0 {
+		listenAddress := net.JoinHostPort(n.Config.HTTPHost, strconv.FormatUint(uint64(n.Config.IndexGRPCPort), 10))
+		listener, err := net.Listen("tcp", listenAddress)
+		if err != nil {
+			return fmt.Errorf("couldn't listen for indexer gRPC requests: %w", err)
+		}
+		n.indexerGRPCListener = listener
+		n.indexerGRPCServer = grpcutils.NewServer()
+		grpcServer = n.indexerGRPCServer
+	}
+
 	txIndexerDB := prefixdb.New(indexerDBPrefix, n.DB)
 	var err error
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
@@ -908,6 +1154,8 @@ func (n *Node) initIndexer() error {
 		TxAcceptorGroup:      n.TxAcceptorGroup,
 		VertexAcceptorGroup:  n.VertexAcceptorGroup,
 		APIServer:            n.APIServer,
+		Validators:           n.vdrs,
+		GRPCServer:           grpcServer,
 		ShutdownF: func() {
 			n.Shutdown(0) // TODO put exit code here
 		},
@@ -1188,9 +1436,11 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			ResourceTracker:                         n.resourceTracker,
 			StateSyncBeacons:                        n.Config.StateSyncIDs,
 			TracingEnabled:                          n.Config.TraceConfig.Enabled,
+			AuditLogConfig:                          n.Config.ConsensusAuditLogConfig,
 			Tracer:                                  n.tracer,
 			ChainDataDir:                            n.Config.ChainDataDir,
 			Subnets:                                 subnets,
+			PeerReputation:                          n.peerReputation,
 		},
 	)
 	if err != nil {
@@ -1312,7 +1562,7 @@ func (n *Node) initKeystoreAPI() error {
 // initMetricsAPI initializes the Metrics API
 // Assumes n.APIServer is already set
 func (n *Node) initMetricsAPI() error {
-	if !n.Config.MetricsAPIEnabled {
+	if !n.Config.MetricsAPIEnabled && !n.Config.MetricsExportConfig.Enabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
 	}
@@ -1337,6 +1587,11 @@ func (n *Node) initMetricsAPI() error {
 		return err
 	}
 
+	if !n.Config.MetricsAPIEnabled {
+		n.Log.Info("skipping metrics API initialization because it has been disabled")
+		return nil
+	}
+
 	n.Log.Info("initializing metrics API")
 
 	return n.APIServer.AddRoute(
@@ -1349,6 +1604,28 @@ func (n *Node) initMetricsAPI() error {
 	)
 }
 
+// initMetricsExporter starts pushing the node's metrics to an OTLP collector
+// Assumes n.MetricsGatherer is already set
+func (n *Node) initMetricsExporter() error {
+	if !n.Config.MetricsExportConfig.Enabled {
+		return nil
+	}
+
+	n.Log.Info("initializing metrics exporter",
+		zap.Stringer("exporterType", n.Config.MetricsExportConfig.Type),
+		zap.String("endpoint", n.Config.MetricsExportConfig.Endpoint),
+		zap.Duration("interval", n.Config.MetricsExportConfig.Interval),
+	)
+
+	var err error
+	n.metricsExporter, err = metrics.NewOTLPExporter(
+		n.Log,
+		n.MetricsGatherer,
+		n.Config.MetricsExportConfig,
+	)
+	return err
+}
+
 // initAdminAPI initializes the Admin API service
 // Assumes n.log, n.chainManager, and n.ValidatorAPI already initialized
 func (n *Node) initAdminAPI() error {
@@ -1359,15 +1636,22 @@ func (n *Node) initAdminAPI() error {
 	n.Log.Info("initializing admin API")
 	service, err := admin.NewService(
 		admin.Config{
-			Log:          n.Log,
-			DB:           n.DB,
-			ChainManager: n.chainManager,
-			HTTPServer:   n.APIServer,
-			ProfileDir:   n.Config.ProfilerConfig.Dir,
-			LogFactory:   n.LogFactory,
-			NodeConfig:   n.Config,
-			VMManager:    n.VMManager,
-			VMRegistry:   n.VMRegistry,
+			Log:           n.Log,
+			DB:            n.DB,
+			ChainManager:  n.chainManager,
+			HTTPServer:    n.APIServer,
+			ProfileDir:    n.Config.ProfilerConfig.Dir,
+			LogFactory:    n.LogFactory,
+			NodeConfig:    n.Config,
+			VMManager:     n.VMManager,
+			VMRegistry:    n.VMRegistry,
+			Snapshotter:   n.dbSnapshotter,
+			SnapshotDir:   n.Config.DatabaseConfig.SnapshotDir,
+			SizeEstimator: n.dbSizeEstimator,
+			AuditLogDir:   n.Config.ConsensusAuditLogConfig.Directory,
+			PeerACL:       n.Config.NetworkConfig.PeerACL,
+			NetworkID:     n.Config.NetworkID,
+			GenesisHash:   ids.ID(hashing.ComputeHash256Array(n.Config.GenesisBytes)),
 		},
 	)
 	if err != nil {
@@ -1429,6 +1713,7 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkConfig.MyIPPort,
 		n.Net,
 		n.benchlistManager,
+		n.timeoutManager,
 	)
 	if err != nil {
 		return err
@@ -1698,6 +1983,13 @@ func (n *Node) shutdown() {
 	if n.Net != nil {
 		n.Net.StartClose()
 	}
+	if n.peerReputation != nil {
+		if err := n.peerReputation.Stop(); err != nil {
+			n.Log.Debug("error persisting peer reputations",
+				zap.Error(err),
+			)
+		}
+	}
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown",
 			zap.Error(err),
@@ -1705,6 +1997,12 @@ func (n *Node) shutdown() {
 	}
 	n.portMapper.UnmapAllPorts()
 	n.ipUpdater.Stop()
+	if n.bootstrapperUpdater != nil {
+		n.bootstrapperUpdater.Stop()
+	}
+	if n.indexerGRPCServer != nil {
+		n.indexerGRPCServer.Stop()
+	}
 	if err := n.indexer.Close(); err != nil {
 		n.Log.Debug("error closing tx indexer",
 			zap.Error(err),
@@ -1730,6 +2028,15 @@ func (n *Node) shutdown() {
 		}
 	}
 
+	if n.metricsExporter != nil {
+		n.Log.Info("shutting down metrics exporter")
+		if err := n.metricsExporter.Close(); err != nil {
+			n.Log.Warn("error during metrics exporter shutdown",
+				zap.Error(err),
+			)
+		}
+	}
+
 	if n.Config.TraceConfig.Enabled {
 		n.Log.Info("shutting down tracing")
 	}
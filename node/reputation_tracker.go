@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var _ router.Router = (*reputationTracker)(nil)
+
+// reputationTracker reports the connections of peers to their reputation, so
+// that the uptime of peers is accounted for in their score.
+type reputationTracker struct {
+	router.Router
+	reputation *p2p.Reputation
+}
+
+func (r *reputationTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Application, subnetID ids.ID) {
+	// Every peer is connected to the primary network, so it is used to track
+	// the connection of the peer regardless of the subnets it tracks.
+	if subnetID == constants.PrimaryNetworkID {
+		r.reputation.Connected(nodeID)
+	}
+	r.Router.Connected(nodeID, nodeVersion, subnetID)
+}
+
+func (r *reputationTracker) Disconnected(nodeID ids.NodeID) {
+	r.reputation.Disconnected(nodeID)
+	r.Router.Disconnected(nodeID)
+}
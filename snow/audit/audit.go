@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+)
+
+var _ Log = NoLog{}
+
+// Log records the consensus decisions of a chain, so that the decisions can be
+// reconstructed after the fact.
+//
+// [height] is the height of the block for accepted and rejected blocks. For
+// all other events, [height] is the next height to be accepted when the event
+// happened.
+type Log interface {
+	// PollIssued is called when a poll for [blkID] is sent to [nodeIDs].
+	PollIssued(requestID uint32, height uint64, blkID ids.ID, nodeIDs []ids.NodeID)
+
+	// ChitsReceived is called when [nodeID] responds to a poll.
+	ChitsReceived(
+		requestID uint32,
+		height uint64,
+		nodeID ids.NodeID,
+		preferredID ids.ID,
+		preferredIDAtHeight ids.ID,
+		acceptedID ids.ID,
+	)
+
+	// QueryFailed is called when [nodeID] failed to respond to a poll.
+	QueryFailed(requestID uint32, height uint64, nodeID ids.NodeID)
+
+	// PollRecorded is called after the result of a poll was applied to
+	// consensus.
+	PollRecorded(pollNumber uint64, height uint64, votes bag.Bag[ids.ID], preference ids.ID)
+
+	// Accepted is called when a block is accepted.
+	Accepted(blkID ids.ID, height uint64)
+
+	// Rejected is called when a block is rejected.
+	Rejected(blkID ids.ID, height uint64, reason string)
+}
+
+// NoLog is a Log that doesn't record anything.
+type NoLog struct{}
+
+func (NoLog) PollIssued(uint32, uint64, ids.ID, []ids.NodeID) {}
+
+func (NoLog) ChitsReceived(uint32, uint64, ids.NodeID, ids.ID, ids.ID, ids.ID) {}
+
+func (NoLog) QueryFailed(uint32, uint64, ids.NodeID) {}
+
+func (NoLog) PollRecorded(uint64, uint64, bag.Bag[ids.ID], ids.ID) {}
+
+func (NoLog) Accepted(ids.ID, uint64) {}
+
+func (NoLog) Rejected(ids.ID, uint64, string) {}
@@ -0,0 +1,267 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	PollIssuedType    = "pollIssued"
+	ChitsReceivedType = "chitsReceived"
+	QueryFailedType   = "queryFailed"
+	PollRecordedType  = "pollRecorded"
+	AcceptedType      = "accepted"
+	RejectedType      = "rejected"
+
+	fileExt = ".jsonl"
+)
+
+var (
+	_ Log = (*fileLog)(nil)
+
+	errInvalidRange = errors.New("start height must not be greater than end height")
+)
+
+type Config struct {
+	// Used to flag if the audit log should be recorded
+	Enabled bool `json:"enabled"`
+
+	// Directory the audit log of every chain is written to
+	Directory string `json:"directory"`
+
+	// The maximum size in megabytes of an audit log file before it is rotated
+	MaxSize int `json:"maxSize"`
+
+	// The maximum number of rotated audit log files to retain per chain
+	MaxFiles int `json:"maxFiles"`
+}
+
+// Entry is a single event of the audit log. Only the fields that are relevant
+// to the [Type] of the event are populated.
+type Entry struct {
+	Type   string         `json:"type"`
+	Time   time.Time      `json:"time"`
+	Height avajson.Uint64 `json:"height"`
+
+	RequestID *avajson.Uint32 `json:"requestID,omitempty"`
+	BlockID   *ids.ID         `json:"blockID,omitempty"`
+	NodeID    *ids.NodeID     `json:"nodeID,omitempty"`
+	NodeIDs   []ids.NodeID    `json:"nodeIDs,omitempty"`
+
+	// Populated by chitsReceived events
+	PreferredID         *ids.ID `json:"preferredID,omitempty"`
+	PreferredIDAtHeight *ids.ID `json:"preferredIDAtHeight,omitempty"`
+	AcceptedID          *ids.ID `json:"acceptedID,omitempty"`
+
+	// Populated by pollRecorded events
+	PollNumber *avajson.Uint64           `json:"pollNumber,omitempty"`
+	Votes      map[ids.ID]avajson.Uint64 `json:"votes,omitempty"`
+	Preference *ids.ID                   `json:"preference,omitempty"`
+
+	// Populated by rejected events
+	Reason string `json:"reason,omitempty"`
+}
+
+// fileLog writes the audit log of a chain to rotating files, with one JSON
+// encoded [Entry] per line.
+type fileLog struct {
+	log   logging.Logger
+	clock mockable.Clock
+
+	lock   sync.Mutex
+	writer io.WriteCloser
+}
+
+// New returns a Log that writes the audit log of [chainID] to the directory in
+// [config].
+func New(log logging.Logger, config Config, chainID ids.ID) Log {
+	return &fileLog{
+		log: log,
+		writer: &lumberjack.Logger{
+			Filename:   fileName(config.Directory, chainID),
+			MaxSize:    config.MaxSize,
+			MaxBackups: config.MaxFiles,
+		},
+	}
+}
+
+func (l *fileLog) PollIssued(requestID uint32, height uint64, blkID ids.ID, nodeIDs []ids.NodeID) {
+	l.write(&Entry{
+		Type:      PollIssuedType,
+		Height:    avajson.Uint64(height),
+		RequestID: (*avajson.Uint32)(&requestID),
+		BlockID:   &blkID,
+		NodeIDs:   nodeIDs,
+	})
+}
+
+func (l *fileLog) ChitsReceived(
+	requestID uint32,
+	height uint64,
+	nodeID ids.NodeID,
+	preferredID ids.ID,
+	preferredIDAtHeight ids.ID,
+	acceptedID ids.ID,
+) {
+	l.write(&Entry{
+		Type:                ChitsReceivedType,
+		Height:              avajson.Uint64(height),
+		RequestID:           (*avajson.Uint32)(&requestID),
+		NodeID:              &nodeID,
+		PreferredID:         &preferredID,
+		PreferredIDAtHeight: &preferredIDAtHeight,
+		AcceptedID:          &acceptedID,
+	})
+}
+
+func (l *fileLog) QueryFailed(requestID uint32, height uint64, nodeID ids.NodeID) {
+	l.write(&Entry{
+		Type:      QueryFailedType,
+		Height:    avajson.Uint64(height),
+		RequestID: (*avajson.Uint32)(&requestID),
+		NodeID:    &nodeID,
+	})
+}
+
+func (l *fileLog) PollRecorded(pollNumber uint64, height uint64, votes bag.Bag[ids.ID], preference ids.ID) {
+	votedIDs := votes.List()
+	voteCounts := make(map[ids.ID]avajson.Uint64, len(votedIDs))
+	for _, blkID := range votedIDs {
+		voteCounts[blkID] = avajson.Uint64(votes.Count(blkID))
+	}
+	l.write(&Entry{
+		Type:       PollRecordedType,
+		Height:     avajson.Uint64(height),
+		PollNumber: (*avajson.Uint64)(&pollNumber),
+		Votes:      voteCounts,
+		Preference: &preference,
+	})
+}
+
+func (l *fileLog) Accepted(blkID ids.ID, height uint64) {
+	l.write(&Entry{
+		Type:    AcceptedType,
+		Height:  avajson.Uint64(height),
+		BlockID: &blkID,
+	})
+}
+
+func (l *fileLog) Rejected(blkID ids.ID, height uint64, reason string) {
+	l.write(&Entry{
+		Type:    RejectedType,
+		Height:  avajson.Uint64(height),
+		BlockID: &blkID,
+		Reason:  reason,
+	})
+}
+
+// write never returns an error so that a failure to write the audit log
+// doesn't halt consensus.
+func (l *fileLog) write(entry *Entry) {
+	entry.Time = l.clock.Time()
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		l.log.Warn("failed to marshal audit log entry",
+			zap.String("type", entry.Type),
+			zap.Error(err),
+		)
+		return
+	}
+	bytes = append(bytes, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if _, err := l.writer.Write(bytes); err != nil {
+		l.log.Warn("failed to write audit log entry",
+			zap.String("type", entry.Type),
+			zap.Error(err),
+		)
+	}
+}
+
+// Read returns up to [limit] entries of the audit log of [chainID] in [dir]
+// with a height in [startHeight, endHeight], in the order they were written.
+func Read(dir string, chainID ids.ID, startHeight, endHeight uint64, limit int) ([]Entry, error) {
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("%w: %d > %d", errInvalidRange, startHeight, endHeight)
+	}
+
+	// Rotated files are named after the time they were rotated, so they sort
+	// from oldest to newest. The current file is the newest.
+	currentFile := fileName(dir, chainID)
+	rotatedFiles, err := filepath.Glob(filepath.Join(dir, chainID.String()+"-*"+fileExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(rotatedFiles)
+	files := append(rotatedFiles, currentFile)
+
+	var entries []Entry
+	for _, file := range files {
+		if len(entries) >= limit {
+			break
+		}
+
+		entries, err = readFile(file, startHeight, endHeight, limit, entries)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func readFile(file string, startHeight, endHeight uint64, limit int, entries []Entry) ([]Entry, error) {
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	for len(entries) < limit {
+		var entry Entry
+		err := decoder.Decode(&entry)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			// The last entry may have been partially written if the node was
+			// killed.
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		height := uint64(entry.Height)
+		if startHeight <= height && height <= endHeight {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func fileName(dir string, chainID ids.ID) string {
+	return filepath.Join(dir, chainID.String()+fileExt)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+func TestFileLog(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir     = t.TempDir()
+		chainID = ids.GenerateTestID()
+		nodeID  = ids.GenerateTestNodeID()
+		blkID0  = ids.GenerateTestID()
+		blkID1  = ids.GenerateTestID()
+	)
+	log := New(logging.NoLog{}, Config{
+		Enabled:   true,
+		Directory: dir,
+		MaxSize:   1,
+		MaxFiles:  1,
+	}, chainID)
+
+	log.PollIssued(1, 1, blkID0, []ids.NodeID{nodeID})
+	log.ChitsReceived(1, 1, nodeID, blkID0, blkID0, ids.Empty)
+	log.PollRecorded(1, 1, bag.Of(blkID0), blkID0)
+	log.Accepted(blkID0, 1)
+	log.QueryFailed(2, 2, nodeID)
+	log.Rejected(blkID1, 2, "conflict")
+	require.NoError(log.(*fileLog).writer.Close())
+
+	entries, err := Read(dir, chainID, 0, 10, 100)
+	require.NoError(err)
+	require.Len(entries, 6)
+
+	require.Equal(PollIssuedType, entries[0].Type)
+	require.Equal(avajson.Uint32(1), *entries[0].RequestID)
+	require.Equal(blkID0, *entries[0].BlockID)
+	require.Equal([]ids.NodeID{nodeID}, entries[0].NodeIDs)
+
+	require.Equal(ChitsReceivedType, entries[1].Type)
+	require.Equal(nodeID, *entries[1].NodeID)
+	require.Equal(ids.Empty, *entries[1].AcceptedID)
+
+	require.Equal(PollRecordedType, entries[2].Type)
+	require.Equal(map[ids.ID]avajson.Uint64{blkID0: 1}, entries[2].Votes)
+	require.Equal(blkID0, *entries[2].Preference)
+
+	require.Equal(AcceptedType, entries[3].Type)
+	require.Equal(QueryFailedType, entries[4].Type)
+
+	require.Equal(RejectedType, entries[5].Type)
+	require.Equal(avajson.Uint64(2), entries[5].Height)
+	require.Equal("conflict", entries[5].Reason)
+
+	// Only the requested heights are returned
+	entries, err = Read(dir, chainID, 2, 2, 100)
+	require.NoError(err)
+	require.Len(entries, 2)
+	require.Equal(QueryFailedType, entries[0].Type)
+
+	// No more than the limit is returned
+	entries, err = Read(dir, chainID, 0, 10, 3)
+	require.NoError(err)
+	require.Len(entries, 3)
+	require.Equal(PollRecordedType, entries[2].Type)
+
+	// The log of another chain is empty
+	entries, err = Read(dir, ids.GenerateTestID(), 0, 10, 100)
+	require.NoError(err)
+	require.Empty(entries)
+
+	_, err = Read(dir, chainID, 2, 1, 100)
+	require.ErrorIs(err, errInvalidRange)
+}
+
+func TestReadRotatedFiles(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir     = t.TempDir()
+		chainID = ids.GenerateTestID()
+		current = fileName(dir, chainID)
+		rotated = filepath.Join(dir, chainID.String()+"-2024-06-01T00-00-00.000"+fileExt)
+	)
+	require.NoError(os.WriteFile(
+		rotated,
+		[]byte(`{"type":"accepted","height":"1"}`+"\n"),
+		0o600,
+	))
+	// The last entry was only partially written
+	require.NoError(os.WriteFile(
+		current,
+		[]byte(`{"type":"accepted","height":"2"}`+"\n"+`{"type":"acc`),
+		0o600,
+	))
+
+	entries, err := Read(dir, chainID, 0, 10, 100)
+	require.NoError(err)
+	require.Len(entries, 2)
+	require.Equal(avajson.Uint64(1), entries[0].Height)
+	require.Equal(avajson.Uint64(2), entries[1].Height)
+}
@@ -37,6 +37,7 @@ var (
 		StatusOrProcessingUnissuedTest,
 		StatusOrProcessingIssuedTest,
 		RecordPollAcceptSingleBlockTest,
+		RecordPollWithoutAuditLogTest,
 		RecordPollAcceptAndRejectTest,
 		RecordPollSplitVoteNoChangeTest,
 		RecordPollWhenFinalizedTest,
@@ -446,6 +447,43 @@ func RecordPollAcceptSingleBlockTest(t *testing.T, factory Factory) {
 	require.Equal(snowtest.Accepted, block.Status)
 }
 
+// Make sure that a context without an audit log doesn't cause a panic
+func RecordPollWithoutAuditLogTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	ctx.AuditLog = nil
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		Beta:                  1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(
+		ctx,
+		params,
+		snowmantest.GenesisID,
+		snowmantest.GenesisHeight,
+		snowmantest.GenesisTimestamp,
+	))
+
+	block0 := snowmantest.BuildChild(snowmantest.Genesis)
+	block1 := snowmantest.BuildChild(snowmantest.Genesis)
+	require.NoError(sm.Add(block0))
+	require.NoError(sm.Add(block1))
+
+	require.NoError(sm.RecordPoll(context.Background(), bag.Of(block0.ID())))
+	require.Equal(snowtest.Accepted, block0.Status)
+	require.Equal(snowtest.Rejected, block1.Status)
+}
+
 func RecordPollAcceptAndRejectTest(t *testing.T, factory Factory) {
 	require := require.New(t)
 
@@ -13,11 +13,17 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
+const (
+	conflictReason         = "conflict with accepted block"
+	rejectedAncestorReason = "rejected ancestor"
+)
+
 var (
 	errDuplicateAdd            = errors.New("duplicate block add")
 	errUnknownParentBlock      = errors.New("unknown parent block")
@@ -47,6 +53,10 @@ type Topological struct {
 	// ctx is the context this snowman instance is executing in
 	ctx *snow.ConsensusContext
 
+	// auditLog records the polls and decisions of this instance. Defaults to
+	// [audit.NoLog] if the context doesn't provide one.
+	auditLog audit.Log
+
 	// params are the parameters that should be used to initialize snowball
 	// instances
 	params snowball.Parameters
@@ -122,6 +132,10 @@ func (ts *Topological) Initialize(
 	ts.leaves = set.Set[ids.ID]{}
 	ts.kahnNodes = make(map[ids.ID]kahnNode)
 	ts.ctx = ctx
+	ts.auditLog = ctx.AuditLog
+	if ts.auditLog == nil {
+		ts.auditLog = audit.NoLog{}
+	}
 	ts.params = params
 	ts.lastAcceptedID = lastAcceptedID
 	ts.lastAcceptedHeight = lastAcceptedHeight
@@ -237,6 +251,7 @@ func (ts *Topological) PreferenceAtHeight(height uint64) (ids.ID, bool) {
 func (ts *Topological) RecordPoll(ctx context.Context, voteBag bag.Bag[ids.ID]) error {
 	// Register a new poll call
 	ts.pollNumber++
+	height := ts.lastAcceptedHeight + 1
 
 	var voteStack []votes
 	if voteBag.Len() >= ts.params.AlphaPreference {
@@ -266,6 +281,7 @@ func (ts *Topological) RecordPoll(ctx context.Context, voteBag bag.Bag[ids.ID])
 	// preferred, then we know that following the preferences down the chain
 	// will return the current preference.
 	if ts.preferredIDs.Contains(preferred) {
+		ts.auditLog.PollRecorded(ts.pollNumber, height, voteBag, ts.preference)
 		return nil
 	}
 
@@ -300,6 +316,7 @@ func (ts *Topological) RecordPoll(ctx context.Context, voteBag bag.Bag[ids.ID])
 		// block.blk is non-nil here.
 		ts.preferredHeights[block.blk.Height()] = ts.preference
 	}
+	ts.auditLog.PollRecorded(ts.pollNumber, height, voteBag, ts.preference)
 	return nil
 }
 
@@ -599,6 +616,7 @@ func (ts *Topological) acceptPreferredChild(ctx context.Context, n *snowmanBlock
 	if err := child.Accept(ctx); err != nil {
 		return err
 	}
+	ts.auditLog.Accepted(pref, height)
 
 	// Update the last accepted values to the newly accepted block.
 	ts.lastAcceptedID = pref
@@ -627,7 +645,7 @@ func (ts *Topological) acceptPreferredChild(ctx context.Context, n *snowmanBlock
 		}
 
 		ts.ctx.Log.Trace("rejecting block",
-			zap.String("reason", "conflict with accepted block"),
+			zap.String("reason", conflictReason),
 			zap.Stringer("blkID", childID),
 			zap.Uint64("height", child.Height()),
 			zap.Stringer("conflictID", pref),
@@ -635,6 +653,7 @@ func (ts *Topological) acceptPreferredChild(ctx context.Context, n *snowmanBlock
 		if err := child.Reject(ctx); err != nil {
 			return err
 		}
+		ts.auditLog.Rejected(childID, child.Height(), conflictReason)
 		ts.metrics.Rejected(childID, ts.pollNumber, len(child.Bytes()))
 
 		// Track which blocks have been directly rejected
@@ -661,7 +680,7 @@ func (ts *Topological) rejectTransitively(ctx context.Context, rejected []ids.ID
 
 		for childID, child := range rejectedNode.children {
 			ts.ctx.Log.Trace("rejecting block",
-				zap.String("reason", "rejected ancestor"),
+				zap.String("reason", rejectedAncestorReason),
 				zap.Stringer("blkID", childID),
 				zap.Uint64("height", child.Height()),
 				zap.Stringer("parentID", rejectedID),
@@ -669,6 +688,7 @@ func (ts *Topological) rejectTransitively(ctx context.Context, rejected []ids.ID
 			if err := child.Reject(ctx); err != nil {
 				return err
 			}
+			ts.auditLog.Rejected(childID, child.Height(), rejectedAncestorReason)
 			ts.metrics.Rejected(childID, ts.pollNumber, len(child.Bytes()))
 
 			// add the newly rejected block to the end of the stack
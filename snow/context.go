@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -84,6 +85,9 @@ type ConsensusContext struct {
 	// accepted.
 	VertexAcceptor Acceptor
 
+	// AuditLog records the polls and decisions of this chain's consensus.
+	AuditLog audit.Log
+
 	// State indicates the current state of this consensus instance.
 	State utils.Atomic[EngineState]
 
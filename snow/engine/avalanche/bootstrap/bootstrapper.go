@@ -153,7 +153,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Uint32("requestID", requestID),
 		)
 
-		b.PeerTracker.RegisterFailure(nodeID)
+		b.PeerTracker.RegisterFailure(nodeID, requestID)
 		return b.fetch(ctx, requestedVtxID)
 	}
 
@@ -176,7 +176,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Error(err),
 		)
 
-		b.PeerTracker.RegisterFailure(nodeID)
+		b.registerInvalidResponse(nodeID, requestID)
 		return b.fetch(ctx, requestedVtxID)
 	}
 
@@ -187,7 +187,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Stringer("vtxID", actualID),
 		)
 
-		b.PeerTracker.RegisterFailure(nodeID)
+		b.registerInvalidResponse(nodeID, requestID)
 		return b.fetch(ctx, requestedVtxID)
 	}
 
@@ -249,11 +249,19 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		requestLatency = time.Since(requestTime).Seconds() + epsilon
 		bandwidth      = float64(numBytes) / requestLatency
 	)
-	b.PeerTracker.RegisterResponse(nodeID, bandwidth)
+	b.PeerTracker.RegisterResponse(nodeID, requestID, bandwidth)
 
 	return b.process(ctx, verticesToProcess...)
 }
 
+// registerInvalidResponse records that [nodeID] responded to [requestID] with
+// an invalid response.
+func (b *bootstrapper) registerInvalidResponse(nodeID ids.NodeID, requestID uint32) {
+	// Invalid responses don't contribute to the bandwidth of the peer.
+	b.PeerTracker.RegisterResponse(nodeID, requestID, 0)
+	b.PeerTracker.RegisterInvalidResponse(nodeID)
+}
+
 func (b *bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
 	request := common.Request{
 		NodeID:    nodeID,
@@ -270,7 +278,7 @@ func (b *bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 	delete(b.outstandingRequestTimes, request)
 
 	// This node timed out their request.
-	b.PeerTracker.RegisterFailure(nodeID)
+	b.PeerTracker.RegisterFailure(nodeID, requestID)
 
 	// Send another request for the vertex
 	return b.fetch(ctx, vtxID)
@@ -427,9 +435,8 @@ func (b *bootstrapper) fetch(ctx context.Context, vtxIDs ...ids.ID) error {
 			nodeID = b.Ctx.NodeID
 		}
 
-		b.PeerTracker.RegisterRequest(nodeID)
-
 		b.requestID++
+		b.PeerTracker.RegisterRequest(nodeID, b.requestID)
 		request := common.Request{
 			NodeID:    nodeID,
 			RequestID: b.requestID,
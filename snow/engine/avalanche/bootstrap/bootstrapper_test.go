@@ -97,6 +97,7 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *enginetest.Sender, *vertextes
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
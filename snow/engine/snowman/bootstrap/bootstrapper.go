@@ -421,9 +421,8 @@ func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 		nodeID = b.Ctx.NodeID
 	}
 
-	b.PeerTracker.RegisterRequest(nodeID)
-
 	b.requestID++
+	b.PeerTracker.RegisterRequest(nodeID, b.requestID)
 	request := common.Request{
 		NodeID:    nodeID,
 		RequestID: b.requestID,
@@ -460,7 +459,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Uint32("requestID", requestID),
 		)
 
-		b.PeerTracker.RegisterFailure(nodeID)
+		b.PeerTracker.RegisterFailure(nodeID, requestID)
 
 		// Send another request for this
 		return b.fetch(ctx, wantedBlkID)
@@ -482,7 +481,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Uint32("requestID", requestID),
 			zap.Error(err),
 		)
-		b.PeerTracker.RegisterFailure(nodeID)
+		b.registerInvalidResponse(nodeID, requestID)
 		return b.fetch(ctx, wantedBlkID)
 	}
 
@@ -491,7 +490,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Stringer("nodeID", nodeID),
 			zap.Uint32("requestID", requestID),
 		)
-		b.PeerTracker.RegisterFailure(nodeID)
+		b.registerInvalidResponse(nodeID, requestID)
 		return b.fetch(ctx, wantedBlkID)
 	}
 
@@ -501,7 +500,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Stringer("expectedBlkID", wantedBlkID),
 			zap.Stringer("blkID", actualID),
 		)
-		b.PeerTracker.RegisterFailure(nodeID)
+		b.registerInvalidResponse(nodeID, requestID)
 		return b.fetch(ctx, wantedBlkID)
 	}
 
@@ -520,7 +519,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		requestLatency = time.Since(requestTime).Seconds() + epsilon
 		bandwidth      = float64(numBytes) / requestLatency
 	)
-	b.PeerTracker.RegisterResponse(nodeID, bandwidth)
+	b.PeerTracker.RegisterResponse(nodeID, requestID, bandwidth)
 
 	if err := b.process(ctx, requestedBlock, ancestors); err != nil {
 		return err
@@ -529,6 +528,14 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 	return b.tryStartExecuting(ctx)
 }
 
+// registerInvalidResponse records that [nodeID] responded to [requestID] with
+// an invalid response.
+func (b *Bootstrapper) registerInvalidResponse(nodeID ids.NodeID, requestID uint32) {
+	// Invalid responses don't contribute to the bandwidth of the peer.
+	b.PeerTracker.RegisterResponse(nodeID, requestID, 0)
+	b.PeerTracker.RegisterInvalidResponse(nodeID)
+}
+
 func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
 	request := common.Request{
 		NodeID:    nodeID,
@@ -545,7 +552,7 @@ func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 	delete(b.outstandingRequestTimes, request)
 
 	// This node timed out their request.
-	b.PeerTracker.RegisterFailure(nodeID)
+	b.PeerTracker.RegisterFailure(nodeID, requestID)
 
 	// Send another request for this
 	return b.fetch(ctx, blkID)
@@ -84,6 +84,7 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *enginetest.Sender, *blocktest
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		nil,
 	)
 	require.NoError(err)
 
@@ -136,6 +137,7 @@ func TestBootstrapperStartsOnlyIfEnoughStakeIsConnected(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		nil,
 	)
 	require.NoError(err)
 
@@ -666,6 +668,7 @@ func TestBootstrapNoParseOnNew(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		nil,
 	)
 	require.NoError(err)
 
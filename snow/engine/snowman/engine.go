@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -54,6 +55,10 @@ type Engine struct {
 	common.AppHandler
 	validators.Connector
 
+	// auditLog records the polls of this engine. Defaults to [audit.NoLog] if
+	// the context doesn't provide one.
+	auditLog audit.Log
+
 	requestID uint32
 
 	// track outstanding preference requests
@@ -129,9 +134,15 @@ func New(config Config) (*Engine, error) {
 		return nil, err
 	}
 
+	auditLog := config.Ctx.AuditLog
+	if auditLog == nil {
+		auditLog = audit.NoLog{}
+	}
+
 	return &Engine{
 		Config:                      config,
 		metrics:                     metrics,
+		auditLog:                    auditLog,
 		StateSummaryFrontierHandler: common.NewNoOpStateSummaryFrontierHandler(config.Ctx.Log),
 		AcceptedStateSummaryHandler: common.NewNoOpAcceptedStateSummaryHandler(config.Ctx.Log),
 		AcceptedFrontierHandler:     common.NewNoOpAcceptedFrontierHandler(config.Ctx.Log),
@@ -364,6 +375,23 @@ func (e *Engine) Chits(ctx context.Context, nodeID ids.NodeID, requestID uint32,
 		zap.Stringer("acceptedID", acceptedID),
 	)
 
+	_, lastAcceptedHeight := e.Consensus.LastAccepted()
+	e.auditLog.ChitsReceived(
+		requestID,
+		lastAcceptedHeight+1,
+		nodeID,
+		preferredID,
+		preferredIDAtHeight,
+		acceptedID,
+	)
+
+	return e.chits(ctx, nodeID, requestID, preferredID, preferredIDAtHeight)
+}
+
+// chits applies the votes of [nodeID] for [requestID]. Unlike Chits, it
+// doesn't record an audit entry, so it can be used to apply votes that weren't
+// received from [nodeID].
+func (e *Engine) chits(ctx context.Context, nodeID ids.NodeID, requestID uint32, preferredID ids.ID, preferredIDAtHeight ids.ID) error {
 	issuedMetric := e.metrics.issued.WithLabelValues(pullGossipSource)
 	if err := e.issueFromByID(ctx, nodeID, preferredID, issuedMetric); err != nil {
 		return err
@@ -411,9 +439,12 @@ func (e *Engine) Chits(ctx context.Context, nodeID ids.NodeID, requestID uint32,
 }
 
 func (e *Engine) QueryFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	_, lastAcceptedHeight := e.Consensus.LastAccepted()
+	e.auditLog.QueryFailed(requestID, lastAcceptedHeight+1, nodeID)
+
 	lastAccepted, ok := e.acceptedFrontiers.LastAccepted(nodeID)
 	if ok {
-		return e.Chits(ctx, nodeID, requestID, lastAccepted, lastAccepted, lastAccepted)
+		return e.chits(ctx, nodeID, requestID, lastAccepted, lastAccepted)
 	}
 
 	v := &voter{
@@ -908,6 +939,8 @@ func (e *Engine) sendQuery(
 		return
 	}
 
+	e.auditLog.PollIssued(e.requestID, nextHeightToAccept, blkID, vdrIDs)
+
 	vdrSet := set.Of(vdrIDs...)
 	if push {
 		e.Sender.SendPushQuery(ctx, vdrSet, e.requestID, blkBytes, nextHeightToAccept)
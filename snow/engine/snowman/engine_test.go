@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman/snowmantest"
@@ -606,6 +607,104 @@ func TestEngineBuildBlock(t *testing.T) {
 	require.True(*pushSent)
 }
 
+func TestEngineWithoutAuditLog(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig(t)
+	config.Ctx.AuditLog = nil
+	vdr, _, sender, vm, te := setup(t, config)
+
+	sender.Default(true)
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case snowmantest.GenesisID:
+			return snowmantest.Genesis, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	var requestID uint32
+	sender.SendPushQueryF = func(_ context.Context, _ set.Set[ids.NodeID], reqID uint32, _ []byte, _ uint64) {
+		requestID = reqID
+	}
+
+	vm.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return blk, nil
+	}
+	require.NoError(te.Notify(context.Background(), common.PendingTxs))
+
+	// The failed poll is followed by a repoll
+	sender.SendPullQueryF = func(context.Context, set.Set[ids.NodeID], uint32, ids.ID, uint64) {}
+	require.NoError(te.QueryFailed(context.Background(), vdr, requestID))
+}
+
+// chitsAuditLog counts the chits and failed queries it records.
+type chitsAuditLog struct {
+	audit.NoLog
+
+	chitsReceived int
+	queryFailed   int
+}
+
+func (l *chitsAuditLog) ChitsReceived(uint32, uint64, ids.NodeID, ids.ID, ids.ID, ids.ID) {
+	l.chitsReceived++
+}
+
+func (l *chitsAuditLog) QueryFailed(uint32, uint64, ids.NodeID) {
+	l.queryFailed++
+}
+
+func TestEngineQueryFailedAuditLog(t *testing.T) {
+	require := require.New(t)
+
+	auditLog := &chitsAuditLog{}
+	config := DefaultConfig(t)
+	config.Ctx.AuditLog = auditLog
+	vdr, _, sender, vm, te := setup(t, config)
+
+	sender.Default(true)
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case snowmantest.GenesisID:
+			return snowmantest.Genesis, nil
+		case blk.ID():
+			return blk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	var requestID uint32
+	sender.SendPushQueryF = func(_ context.Context, _ set.Set[ids.NodeID], reqID uint32, _ []byte, _ uint64) {
+		requestID = reqID
+	}
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], reqID uint32, _ ids.ID, _ uint64) {
+		requestID = reqID
+	}
+
+	vm.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return blk, nil
+	}
+	require.NoError(te.Notify(context.Background(), common.PendingTxs))
+
+	// Record the last accepted block of [vdr]
+	require.NoError(te.Chits(context.Background(), vdr, requestID, blk.ID(), blk.ID(), snowmantest.GenesisID))
+	require.Equal(1, auditLog.chitsReceived)
+
+	// The last accepted block of [vdr] is applied as its vote, but [vdr]
+	// didn't send chits for this query.
+	require.NoError(te.QueryFailed(context.Background(), vdr, requestID))
+	require.Equal(1, auditLog.chitsReceived)
+	require.Equal(1, auditLog.queryFailed)
+}
+
 func TestEngineRepoll(t *testing.T) {
 	require := require.New(t)
 	vdr, _, sender, _, te := setup(t, DefaultConfig(t))
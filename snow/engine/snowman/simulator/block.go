@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const blockLen = ids.IDLen + 3*wrappers.LongLen
+
+var (
+	_ snowman.Block = (*block)(nil)
+
+	errInvalidBlockLen   = errors.New("invalid block length")
+	errTimestampTooEarly = errors.New("block timestamp is before its parent's")
+	errTimestampTooLate  = errors.New("block timestamp is too far in the future")
+)
+
+// blockData is the content of a block that is shared by every node.
+type blockData struct {
+	id        ids.ID
+	parentID  ids.ID
+	height    uint64
+	timestamp time.Time
+	// nonce allows conflicting blocks to be built on top of the same parent
+	// with the same timestamp.
+	nonce uint64
+	bytes []byte
+}
+
+func newBlockData(parentID ids.ID, height uint64, timestamp time.Time, nonce uint64) *blockData {
+	bytes := make([]byte, blockLen)
+	copy(bytes, parentID[:])
+	binary.BigEndian.PutUint64(bytes[ids.IDLen:], height)
+	binary.BigEndian.PutUint64(bytes[ids.IDLen+wrappers.LongLen:], uint64(timestamp.UnixNano()))
+	binary.BigEndian.PutUint64(bytes[ids.IDLen+2*wrappers.LongLen:], nonce)
+	return &blockData{
+		id:        hashing.ComputeHash256Array(bytes),
+		parentID:  parentID,
+		height:    height,
+		timestamp: timestamp,
+		nonce:     nonce,
+		bytes:     bytes,
+	}
+}
+
+func parseBlockData(bytes []byte) (*blockData, error) {
+	if len(bytes) != blockLen {
+		return nil, fmt.Errorf("%w: %d != %d", errInvalidBlockLen, len(bytes), blockLen)
+	}
+	return newBlockData(
+		ids.ID(bytes[:ids.IDLen]),
+		binary.BigEndian.Uint64(bytes[ids.IDLen:]),
+		time.Unix(0, int64(binary.BigEndian.Uint64(bytes[ids.IDLen+wrappers.LongLen:]))),
+		binary.BigEndian.Uint64(bytes[ids.IDLen+2*wrappers.LongLen:]),
+	), nil
+}
+
+// block is the instance of a block held by the VM of a single node.
+type block struct {
+	*blockData
+	vm *vm
+}
+
+func (b *block) ID() ids.ID {
+	return b.id
+}
+
+func (b *block) Parent() ids.ID {
+	return b.parentID
+}
+
+func (b *block) Height() uint64 {
+	return b.height
+}
+
+func (b *block) Timestamp() time.Time {
+	return b.timestamp
+}
+
+func (b *block) Bytes() []byte {
+	return b.bytes
+}
+
+func (b *block) Verify(ctx context.Context) error {
+	parent, err := b.vm.GetBlock(ctx, b.parentID)
+	if err != nil {
+		return err
+	}
+	if b.timestamp.Before(parent.Timestamp()) {
+		return errTimestampTooEarly
+	}
+	if maxTimestamp := b.vm.clock().Add(b.vm.maxFutureBlockTime); b.timestamp.After(maxTimestamp) {
+		return fmt.Errorf("%w: %s > %s", errTimestampTooLate, b.timestamp, maxTimestamp)
+	}
+
+	b.vm.verified[b.id] = b
+	return nil
+}
+
+func (b *block) Accept(context.Context) error {
+	delete(b.vm.verified, b.id)
+	b.vm.accepted[b.id] = b
+	b.vm.heights[b.height] = b.id
+	b.vm.lastAccepted = b
+	b.vm.onAccept(b)
+	return nil
+}
+
+func (b *block) Reject(context.Context) error {
+	delete(b.vm.verified, b.id)
+	return nil
+}
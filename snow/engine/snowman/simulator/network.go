@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ common.Sender = (*sender)(nil)
+
+type event struct {
+	time time.Duration
+	// seq orders events that happen at the same time by when they were
+	// scheduled.
+	seq    uint64
+	handle func(context.Context) error
+}
+
+func eventLess(a, b *event) bool {
+	if a.time != b.time {
+		return a.time < b.time
+	}
+	return a.seq < b.seq
+}
+
+// request is a request that [requester] sent to [responder].
+type request struct {
+	requester ids.NodeID
+	responder ids.NodeID
+	requestID uint32
+}
+
+// schedule [handle] to be called after [delay].
+func (s *Simulator) schedule(delay time.Duration, handle func(context.Context) error) {
+	s.seq++
+	s.events.Push(&event{
+		time:   s.now + delay,
+		seq:    s.seq,
+		handle: handle,
+	})
+}
+
+// send schedules the delivery of a message from [from] to [to] unless the
+// network drops it. Messages sent by a node to itself are delivered
+// immediately.
+func (s *Simulator) send(from, to *node, deliver func(context.Context) error) {
+	s.report.MessagesSent++
+	if from == to {
+		s.schedule(0, deliver)
+		return
+	}
+
+	for _, partition := range s.config.Partitions {
+		if partition.separates(s.now, from.index, to.index) {
+			s.report.MessagesDropped++
+			return
+		}
+	}
+	if s.rng.Float64() < s.config.DropRate {
+		s.report.MessagesDropped++
+		return
+	}
+	s.schedule(s.randDuration(s.config.MinLatency, s.config.MaxLatency), deliver)
+}
+
+// sendRequest sends a request from [from] to every node in [nodeIDs]. If a node
+// doesn't respond within the request timeout, [onFailed] is called with the
+// ID of the node.
+func (s *Simulator) sendRequest(
+	from *node,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	deliver func(ctx context.Context, to *node) error,
+	onFailed func(ctx context.Context, nodeID ids.NodeID) error,
+) {
+	// Sort the nodes to consume the source of randomness in a deterministic
+	// order.
+	sortedNodeIDs := nodeIDs.List()
+	utils.Sort(sortedNodeIDs)
+	for _, nodeID := range sortedNodeIDs {
+		index, ok := s.indices[nodeID]
+		if !ok {
+			continue
+		}
+		to := s.nodes[index]
+
+		req := request{
+			requester: from.nodeID,
+			responder: nodeID,
+			requestID: requestID,
+		}
+		s.outstanding.Add(req)
+		s.schedule(s.config.RequestTimeout, func(ctx context.Context) error {
+			if !s.outstanding.Contains(req) {
+				return nil
+			}
+			s.outstanding.Remove(req)
+			return onFailed(ctx, req.responder)
+		})
+
+		s.send(from, to, func(ctx context.Context) error {
+			return deliver(ctx, to)
+		})
+	}
+}
+
+// sendResponse sends a response from [from] to [nodeID]. The response is
+// dropped if it arrives after the request timed out.
+func (s *Simulator) sendResponse(
+	from *node,
+	nodeID ids.NodeID,
+	requestID uint32,
+	deliver func(ctx context.Context, to *node) error,
+) {
+	index, ok := s.indices[nodeID]
+	if !ok {
+		return
+	}
+	to := s.nodes[index]
+
+	req := request{
+		requester: nodeID,
+		responder: from.nodeID,
+		requestID: requestID,
+	}
+	s.send(from, to, func(ctx context.Context) error {
+		if !s.outstanding.Contains(req) {
+			return nil
+		}
+		s.outstanding.Remove(req)
+		return deliver(ctx, to)
+	})
+}
+
+// sender routes the consensus messages of a node through the simulated
+// network. Messages that aren't used by the snowman engine once it is
+// bootstrapped are dropped.
+type sender struct {
+	enginetest.Sender
+
+	sim  *Simulator
+	node *node
+	// conflicts maps the preferences of a byzantine node to the conflicting
+	// blocks it votes for instead.
+	conflicts map[ids.ID]ids.ID
+}
+
+func (s *sender) SendGet(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+	s.sim.sendRequest(
+		s.node,
+		set.Of(nodeID),
+		requestID,
+		func(ctx context.Context, to *node) error {
+			return to.engine.Get(ctx, s.node.nodeID, requestID, blkID)
+		},
+		func(ctx context.Context, nodeID ids.NodeID) error {
+			return s.node.engine.GetFailed(ctx, nodeID, requestID)
+		},
+	)
+}
+
+func (s *sender) SendPut(_ context.Context, nodeID ids.NodeID, requestID uint32, blkBytes []byte) {
+	s.sim.sendResponse(
+		s.node,
+		nodeID,
+		requestID,
+		func(ctx context.Context, to *node) error {
+			return to.engine.Put(ctx, s.node.nodeID, requestID, blkBytes)
+		},
+	)
+}
+
+func (s *sender) SendPushQuery(
+	_ context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	blkBytes []byte,
+	requestedHeight uint64,
+) {
+	s.sim.sendRequest(
+		s.node,
+		nodeIDs,
+		requestID,
+		func(ctx context.Context, to *node) error {
+			return to.engine.PushQuery(ctx, s.node.nodeID, requestID, blkBytes, requestedHeight)
+		},
+		s.queryFailed(requestID),
+	)
+}
+
+func (s *sender) SendPullQuery(
+	_ context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	blkID ids.ID,
+	requestedHeight uint64,
+) {
+	s.sim.sendRequest(
+		s.node,
+		nodeIDs,
+		requestID,
+		func(ctx context.Context, to *node) error {
+			return to.engine.PullQuery(ctx, s.node.nodeID, requestID, blkID, requestedHeight)
+		},
+		s.queryFailed(requestID),
+	)
+}
+
+func (s *sender) queryFailed(requestID uint32) func(context.Context, ids.NodeID) error {
+	return func(ctx context.Context, nodeID ids.NodeID) error {
+		return s.node.engine.QueryFailed(ctx, nodeID, requestID)
+	}
+}
+
+// SendChits sends the preferences of honest nodes. Byzantine nodes instead
+// vote for a block that conflicts with their preference, which they serve to
+// any node that requests it.
+func (s *sender) SendChits(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	preferredID ids.ID,
+	preferredIDAtHeight ids.ID,
+	acceptedID ids.ID,
+) {
+	if s.node.byzantine {
+		conflictID, ok := s.conflicts[preferredID]
+		if !ok {
+			conflict, err := s.node.vm.buildConflict(ctx, preferredID)
+			if err == nil {
+				conflictID = conflict.id
+				s.conflicts[preferredID] = conflictID
+				ok = true
+			}
+		}
+		if ok {
+			preferredID = conflictID
+			preferredIDAtHeight = conflictID
+		}
+	}
+
+	s.sim.sendResponse(
+		s.node,
+		nodeID,
+		requestID,
+		func(ctx context.Context, to *node) error {
+			return to.engine.Chits(ctx, s.node.nodeID, requestID, preferredID, preferredIDAtHeight, acceptedID)
+		},
+	)
+}
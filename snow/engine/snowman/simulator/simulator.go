@@ -0,0 +1,460 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs multiple snowman engines in a single process over a
+// virtual network to test the behavior of consensus under adverse network
+// conditions.
+//
+// Simulations are driven by a virtual clock and a single seeded source of
+// randomness, so running the same configuration twice produces the same
+// report.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
+
+const (
+	maxTimeGetAncestors       = time.Second
+	maxContainersGetAncestors = 2000
+)
+
+var (
+	genesisTimestamp = time.Unix(0, 0)
+
+	errNoHonestNodes          = errors.New("at least one honest node is required")
+	errInvalidDuration        = errors.New("duration must be positive")
+	errInvalidBlockInterval   = errors.New("block interval must be positive")
+	errInvalidLatency         = errors.New("latency must be non-negative with min latency <= max latency")
+	errInvalidDropRate        = errors.New("drop rate must be in [0, 1)")
+	errInvalidRequestTimeout  = errors.New("request timeout must be positive")
+	errInvalidClockSkew       = errors.New("clock skew must be non-negative")
+	errInvalidPartition       = errors.New("invalid partition")
+	errInsufficientValidators = errors.New("insufficient validators")
+)
+
+// Config describes the network a simulation is run on.
+type Config struct {
+	// Seed of the source of randomness of the simulation.
+	Seed int64
+	// NumNodes is the total number of validators, each of which runs its own
+	// engine.
+	NumNodes int
+	// NumByzantine is the number of validators, out of [NumNodes], that vote
+	// for conflicting blocks of their own rather than for their preference.
+	// The byzantine validators are the ones with the highest indices.
+	NumByzantine int
+	// Params are the consensus parameters used by every engine.
+	Params snowball.Parameters
+
+	// Duration is the amount of virtual time to simulate.
+	Duration time.Duration
+	// BlockInterval is how often a randomly chosen honest node is asked to
+	// build a block.
+	BlockInterval time.Duration
+
+	// MinLatency and MaxLatency bound the uniformly distributed latency of
+	// every message sent between two different nodes.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// DropRate is the probability of a message sent between two different
+	// nodes being dropped.
+	DropRate float64
+	// RequestTimeout is how long a node waits for a response to a request
+	// before considering it failed.
+	RequestTimeout time.Duration
+	// Partitions split the network at given times.
+	Partitions []Partition
+
+	// MaxClockSkew is the maximum offset of the clock of every node. Offsets
+	// are uniformly distributed in [-MaxClockSkew, MaxClockSkew].
+	MaxClockSkew time.Duration
+	// MaxFutureBlockTime is how far ahead of the local clock of a node the
+	// timestamp of a block may be for the block to pass verification.
+	MaxFutureBlockTime time.Duration
+}
+
+// Verify returns an error if the simulated network is invalid.
+func (c *Config) Verify() error {
+	switch {
+	case c.NumNodes <= c.NumByzantine || c.NumByzantine < 0:
+		return errNoHonestNodes
+	case c.Duration <= 0:
+		return errInvalidDuration
+	case c.BlockInterval <= 0:
+		return errInvalidBlockInterval
+	case c.MinLatency < 0 || c.MinLatency > c.MaxLatency:
+		return errInvalidLatency
+	case c.DropRate < 0 || c.DropRate >= 1:
+		return errInvalidDropRate
+	case c.RequestTimeout <= 0:
+		return errInvalidRequestTimeout
+	case c.MaxClockSkew < 0 || c.MaxFutureBlockTime < 0:
+		return errInvalidClockSkew
+	}
+	for i, partition := range c.Partitions {
+		if err := partition.verify(c.NumNodes); err != nil {
+			return fmt.Errorf("partition %d: %w", i, err)
+		}
+	}
+	return c.Params.Verify()
+}
+
+// Partition prevents nodes in different groups from communicating with each
+// other during [Start, End). Nodes that aren't in any group are unaffected by
+// the partition.
+//
+// Whether a message is delivered is decided when it is sent, so messages that
+// are in flight when a partition starts or ends are unaffected by it.
+type Partition struct {
+	Start time.Duration
+	End   time.Duration
+	// Groups contains the indices of the nodes in each group.
+	Groups [][]int
+}
+
+func (p *Partition) verify(numNodes int) error {
+	if p.End < p.Start {
+		return fmt.Errorf("%w: ends at %s before starting at %s", errInvalidPartition, p.End, p.Start)
+	}
+	seen := set.NewSet[int](numNodes)
+	for _, group := range p.Groups {
+		for _, index := range group {
+			if index < 0 || index >= numNodes || seen.Contains(index) {
+				return fmt.Errorf("%w: unexpected node %d", errInvalidPartition, index)
+			}
+			seen.Add(index)
+		}
+	}
+	return nil
+}
+
+// separates returns true if [a] and [b] can't communicate at [now].
+func (p *Partition) separates(now time.Duration, a, b int) bool {
+	if now < p.Start || now >= p.End {
+		return false
+	}
+	groupA, groupB := -1, -1
+	for i, group := range p.Groups {
+		for _, index := range group {
+			switch index {
+			case a:
+				groupA = i
+			case b:
+				groupB = i
+			}
+		}
+	}
+	return groupA != -1 && groupB != -1 && groupA != groupB
+}
+
+// SafetyViolation is reported when two honest nodes accept different blocks at
+// the same height.
+type SafetyViolation struct {
+	Height uint64
+	// FirstNodeID is the first honest node to have accepted a block at
+	// [Height] and FirstBlkID is the block it accepted.
+	FirstNodeID ids.NodeID
+	FirstBlkID  ids.ID
+	// NodeID accepted BlkID after FirstNodeID accepted FirstBlkID.
+	NodeID ids.NodeID
+	BlkID  ids.ID
+	// Time the conflicting block was accepted at.
+	Time time.Duration
+}
+
+// Report is the outcome of a simulation.
+type Report struct {
+	// Built is the number of blocks built by honest nodes.
+	Built int
+	// FinalityTimes contains, for every block built by an honest node that was
+	// accepted by every honest node, the time between it being built and it
+	// being accepted by the last honest node.
+	FinalityTimes []time.Duration
+	// LastAcceptedHeights contains the height of the last accepted block of
+	// every node, indexed by node.
+	LastAcceptedHeights []uint64
+	// MessagesSent and MessagesDropped count the messages sent between nodes,
+	// including the ones dropped by the network.
+	MessagesSent    int
+	MessagesDropped int
+	// SafetyViolations contains every conflicting acceptance of honest nodes.
+	SafetyViolations []SafetyViolation
+}
+
+type node struct {
+	index     int
+	nodeID    ids.NodeID
+	byzantine bool
+	// clockSkew is the offset of the clock of the node from the virtual
+	// clock.
+	clockSkew time.Duration
+	vm        *vm
+	engine    *smeng.Engine
+}
+
+type acceptance struct {
+	nodeID ids.NodeID
+	blkID  ids.ID
+}
+
+// Simulator runs a single simulation.
+type Simulator struct {
+	config  Config
+	rng     *rand.Rand
+	nodes   []*node
+	indices map[ids.NodeID]int
+
+	// now is the virtual time elapsed since the start of the simulation.
+	now    time.Duration
+	seq    uint64
+	events heap.Queue[*event]
+	// outstanding contains the requests that are neither answered nor timed
+	// out.
+	outstanding set.Set[request]
+
+	report Report
+	// builtAt is when each block built by an honest node was built.
+	builtAt map[ids.ID]time.Duration
+	// numAccepted is the number of honest nodes that accepted each block.
+	numAccepted map[ids.ID]int
+	// acceptedAt is the first acceptance of an honest node at each height.
+	acceptedAt map[uint64]acceptance
+}
+
+// New returns a simulator of the network described by [config]. Every node
+// starts from the same genesis block.
+func New(config Config) (*Simulator, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	s := &Simulator{
+		config:      config,
+		rng:         rand.New(rand.NewSource(config.Seed)), // #nosec G404
+		nodes:       make([]*node, config.NumNodes),
+		indices:     make(map[ids.NodeID]int, config.NumNodes),
+		events:      heap.NewQueue(eventLess),
+		outstanding: set.Set[request]{},
+		report: Report{
+			LastAcceptedHeights: make([]uint64, config.NumNodes),
+		},
+		builtAt:     make(map[ids.ID]time.Duration),
+		numAccepted: make(map[ids.ID]int),
+		acceptedAt:  make(map[uint64]acceptance),
+	}
+
+	vdrs := newValidatorSampler(s.rng)
+	for i := range s.nodes {
+		nodeID := ids.BuildTestNodeID([]byte{byte(i >> 8), byte(i), 1})
+		if err := vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1); err != nil {
+			return nil, err
+		}
+		vdrs.nodeIDs = append(vdrs.nodeIDs, nodeID)
+		s.indices[nodeID] = i
+	}
+
+	genesis := newBlockData(ids.Empty, 0, genesisTimestamp, 0)
+	for i, nodeID := range vdrs.nodeIDs {
+		n := &node{
+			index:     i,
+			nodeID:    nodeID,
+			byzantine: i >= config.NumNodes-config.NumByzantine,
+			clockSkew: s.randDuration(-config.MaxClockSkew, config.MaxClockSkew),
+		}
+		n.vm = newVM(
+			genesis,
+			uint64(i)<<32,
+			func() time.Time {
+				return genesisTimestamp.Add(s.now + n.clockSkew)
+			},
+			config.MaxFutureBlockTime,
+			func(blk *block) {
+				s.onBuild(n, blk)
+			},
+			func(blk *block) {
+				s.onAccept(n, blk)
+			},
+		)
+
+		if err := s.initEngine(n, vdrs); err != nil {
+			return nil, err
+		}
+		s.nodes[i] = n
+	}
+	return s, nil
+}
+
+func (s *Simulator) initEngine(n *node, vdrs validators.Manager) error {
+	snowCtx := &snow.Context{
+		NetworkID: constants.UnitTestID,
+		SubnetID:  constants.PrimaryNetworkID,
+		NodeID:    n.nodeID,
+		Log:       logging.NoLog{},
+	}
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	sender := &sender{
+		sim:       s,
+		node:      n,
+		conflicts: make(map[ids.ID]ids.ID),
+	}
+	getServer, err := getter.New(
+		n.vm,
+		sender,
+		snowCtx.Log,
+		maxTimeGetAncestors,
+		maxContainersGetAncestors,
+		ctx.Registerer,
+	)
+	if err != nil {
+		return err
+	}
+
+	n.engine, err = smeng.New(smeng.Config{
+		AllGetsServer:       getServer,
+		Ctx:                 ctx,
+		VM:                  n.vm,
+		Sender:              sender,
+		Validators:          vdrs,
+		ConnectedValidators: tracker.NewPeers(),
+		Params:              s.config.Params,
+		Consensus:           &snowman.Topological{},
+	})
+	return err
+}
+
+// Run starts every engine and simulates the network until [Config.Duration]
+// has elapsed. Run must only be called once.
+func (s *Simulator) Run(ctx context.Context) (*Report, error) {
+	for _, n := range s.nodes {
+		if err := n.engine.Start(ctx, 0); err != nil {
+			return nil, fmt.Errorf("failed to start %s: %w", n.nodeID, err)
+		}
+	}
+	s.schedule(s.config.BlockInterval, s.buildBlock)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		e, ok := s.events.Peek()
+		if !ok || e.time > s.config.Duration {
+			break
+		}
+		_, _ = s.events.Pop()
+
+		s.now = e.time
+		if err := e.handle(ctx); err != nil {
+			return nil, fmt.Errorf("failed to handle event at %s: %w", s.now, err)
+		}
+	}
+
+	for i, n := range s.nodes {
+		s.report.LastAcceptedHeights[i] = n.vm.lastAccepted.height
+	}
+	return &s.report, nil
+}
+
+// buildBlock asks a random honest node to build a block and schedules the next
+// block to be built.
+func (s *Simulator) buildBlock(ctx context.Context) error {
+	numHonest := s.config.NumNodes - s.config.NumByzantine
+	n := s.nodes[s.rng.Intn(numHonest)]
+	if err := n.engine.Notify(ctx, common.PendingTxs); err != nil {
+		return err
+	}
+	s.schedule(s.config.BlockInterval, s.buildBlock)
+	return nil
+}
+
+func (s *Simulator) onBuild(n *node, blk *block) {
+	if n.byzantine {
+		return
+	}
+
+	s.report.Built++
+	s.builtAt[blk.id] = s.now
+}
+
+func (s *Simulator) onAccept(n *node, blk *block) {
+	if n.byzantine {
+		return
+	}
+
+	if first, ok := s.acceptedAt[blk.height]; !ok {
+		s.acceptedAt[blk.height] = acceptance{
+			nodeID: n.nodeID,
+			blkID:  blk.id,
+		}
+	} else if first.blkID != blk.id {
+		s.report.SafetyViolations = append(s.report.SafetyViolations, SafetyViolation{
+			Height:      blk.height,
+			FirstNodeID: first.nodeID,
+			FirstBlkID:  first.blkID,
+			NodeID:      n.nodeID,
+			BlkID:       blk.id,
+			Time:        s.now,
+		})
+	}
+
+	s.numAccepted[blk.id]++
+	builtAt, ok := s.builtAt[blk.id]
+	if ok && s.numAccepted[blk.id] == s.config.NumNodes-s.config.NumByzantine {
+		s.report.FinalityTimes = append(s.report.FinalityTimes, s.now-builtAt)
+	}
+}
+
+// randDuration returns a uniformly distributed duration in [low, high].
+func (s *Simulator) randDuration(low, high time.Duration) time.Duration {
+	return low + time.Duration(s.rng.Int63n(int64(high-low)+1))
+}
+
+var _ validators.Manager = (*validatorSampler)(nil)
+
+// validatorSampler samples validators from the simulation's source of
+// randomness to keep simulations deterministic.
+type validatorSampler struct {
+	validators.Manager
+	rng     *rand.Rand
+	nodeIDs []ids.NodeID
+}
+
+func newValidatorSampler(rng *rand.Rand) *validatorSampler {
+	return &validatorSampler{
+		Manager: validators.NewManager(),
+		rng:     rng,
+	}
+}
+
+func (v *validatorSampler) Sample(_ ids.ID, size int) ([]ids.NodeID, error) {
+	if size > len(v.nodeIDs) {
+		return nil, errInsufficientValidators
+	}
+	sample := make([]ids.NodeID, size)
+	for i, index := range v.rng.Perm(len(v.nodeIDs))[:size] {
+		sample[i] = v.nodeIDs[index]
+	}
+	return sample, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+)
+
+func testConfig() Config {
+	return Config{
+		Seed:     1,
+		NumNodes: 10,
+		Params: snowball.Parameters{
+			K:                     5,
+			AlphaPreference:       3,
+			AlphaConfidence:       4,
+			Beta:                  8,
+			ConcurrentRepolls:     2,
+			OptimalProcessing:     10,
+			MaxOutstandingItems:   256,
+			MaxItemProcessingTime: 30 * time.Second,
+		},
+		Duration:           time.Minute,
+		BlockInterval:      2 * time.Second,
+		MinLatency:         10 * time.Millisecond,
+		MaxLatency:         100 * time.Millisecond,
+		RequestTimeout:     2 * time.Second,
+		MaxClockSkew:       500 * time.Millisecond,
+		MaxFutureBlockTime: time.Second,
+	}
+}
+
+func TestSimulatorFaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		config func(*Config)
+	}{
+		{
+			name:   "healthy network",
+			config: func(*Config) {},
+		},
+		{
+			name: "dropped messages",
+			config: func(c *Config) {
+				c.DropRate = 0.1
+			},
+		},
+		{
+			name: "byzantine nodes",
+			config: func(c *Config) {
+				c.NumByzantine = 2
+			},
+		},
+		{
+			name: "healed partition",
+			config: func(c *Config) {
+				c.Partitions = []Partition{{
+					Start:  10 * time.Second,
+					End:    30 * time.Second,
+					Groups: [][]int{{0, 1, 2, 3, 4}, {5, 6, 7, 8, 9}},
+				}}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := testConfig()
+			test.config(&config)
+			sim, err := New(config)
+			require.NoError(err)
+
+			report, err := sim.Run(context.Background())
+			require.NoError(err)
+			require.Empty(report.SafetyViolations)
+			require.NotEmpty(report.FinalityTimes)
+
+			honestHeights := report.LastAcceptedHeights[:config.NumNodes-config.NumByzantine]
+			for _, height := range honestHeights {
+				require.Positive(height)
+			}
+		})
+	}
+}
+
+func TestSimulatorDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumByzantine = 1
+	config.DropRate = 0.05
+
+	var reports []*Report
+	for i := 0; i < 2; i++ {
+		sim, err := New(config)
+		require.NoError(err)
+
+		report, err := sim.Run(context.Background())
+		require.NoError(err)
+		reports = append(reports, report)
+	}
+	require.Equal(reports[0], reports[1])
+}
+
+func TestSimulatorDetectsSafetyViolations(t *testing.T) {
+	require := require.New(t)
+
+	// Nodes that only query themselves accept their own blocks without
+	// reaching agreement with the rest of the network.
+	config := testConfig()
+	config.Params = snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		Beta:                  1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	config.Partitions = []Partition{{
+		End:    config.Duration,
+		Groups: [][]int{{0, 1, 2, 3, 4}, {5, 6, 7, 8, 9}},
+	}}
+
+	sim, err := New(config)
+	require.NoError(err)
+
+	report, err := sim.Run(context.Background())
+	require.NoError(err)
+	require.NotEmpty(report.SafetyViolations)
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			config: func(*Config) {},
+		},
+		{
+			name: "only byzantine nodes",
+			config: func(c *Config) {
+				c.NumByzantine = c.NumNodes
+			},
+			expectedErr: errNoHonestNodes,
+		},
+		{
+			name: "min latency greater than max latency",
+			config: func(c *Config) {
+				c.MinLatency = c.MaxLatency + 1
+			},
+			expectedErr: errInvalidLatency,
+		},
+		{
+			name: "drop everything",
+			config: func(c *Config) {
+				c.DropRate = 1
+			},
+			expectedErr: errInvalidDropRate,
+		},
+		{
+			name: "unknown partitioned node",
+			config: func(c *Config) {
+				c.Partitions = []Partition{{
+					End:    time.Second,
+					Groups: [][]int{{0}, {c.NumNodes}},
+				}}
+			},
+			expectedErr: errInvalidPartition,
+		},
+		{
+			name: "invalid consensus parameters",
+			config: func(c *Config) {
+				c.Params.K = 0
+			},
+			expectedErr: snowball.ErrParametersInvalid,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig()
+			test.config(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
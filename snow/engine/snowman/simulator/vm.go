@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
+
+	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var _ smblock.ChainVM = (*vm)(nil)
+
+// vm is a minimal in-memory ChainVM whose blocks carry no state other than
+// their timestamp.
+type vm struct {
+	enginetest.VM
+
+	// clock returns the local time of the node, including its clock skew.
+	clock func() time.Time
+	// maxFutureBlockTime is how far ahead of the local time a block's
+	// timestamp may be for it to pass verification.
+	maxFutureBlockTime time.Duration
+	// onBuild is called whenever a block is built.
+	onBuild func(*block)
+	// onAccept is called whenever a block is accepted.
+	onAccept func(*block)
+
+	// nonce is incremented whenever a block is built. It starts at a value
+	// that is unique to the node to ensure that every built block is unique.
+	nonce uint64
+
+	preferred    ids.ID
+	lastAccepted *block
+	verified     map[ids.ID]*block
+	accepted     map[ids.ID]*block
+	heights      map[uint64]ids.ID
+}
+
+func newVM(
+	genesis *blockData,
+	nonce uint64,
+	clock func() time.Time,
+	maxFutureBlockTime time.Duration,
+	onBuild func(*block),
+	onAccept func(*block),
+) *vm {
+	vm := &vm{
+		clock:              clock,
+		maxFutureBlockTime: maxFutureBlockTime,
+		onBuild:            onBuild,
+		onAccept:           onAccept,
+		nonce:              nonce,
+		preferred:          genesis.id,
+		verified:           make(map[ids.ID]*block),
+		accepted:           make(map[ids.ID]*block),
+		heights:            make(map[uint64]ids.ID),
+	}
+	vm.lastAccepted = &block{
+		blockData: genesis,
+		vm:        vm,
+	}
+	vm.accepted[genesis.id] = vm.lastAccepted
+	vm.heights[genesis.height] = genesis.id
+	return vm
+}
+
+func (vm *vm) BuildBlock(ctx context.Context) (snowman.Block, error) {
+	parent, err := vm.GetBlock(ctx, vm.preferred)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := vm.clock()
+	if parentTimestamp := parent.Timestamp(); timestamp.Before(parentTimestamp) {
+		timestamp = parentTimestamp
+	}
+
+	vm.nonce++
+	blk := &block{
+		blockData: newBlockData(vm.preferred, parent.Height()+1, timestamp, vm.nonce),
+		vm:        vm,
+	}
+	vm.onBuild(blk)
+	return blk, nil
+}
+
+// buildConflict returns a block that conflicts with [blkID] by having the same
+// parent if [blkID] is still processing, or that is a child of [blkID]
+// otherwise. The returned block can be fetched from this VM but is never
+// verified locally.
+func (vm *vm) buildConflict(ctx context.Context, blkID ids.ID) (*block, error) {
+	blk, err := vm.GetBlock(ctx, blkID)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := blk
+	if _, ok := vm.verified[blkID]; ok {
+		parent, err = vm.GetBlock(ctx, blk.Parent())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vm.nonce++
+	conflict := &block{
+		blockData: newBlockData(parent.ID(), parent.Height()+1, parent.Timestamp(), vm.nonce),
+		vm:        vm,
+	}
+	vm.verified[conflict.id] = conflict
+	return conflict, nil
+}
+
+func (vm *vm) SetPreference(_ context.Context, blkID ids.ID) error {
+	vm.preferred = blkID
+	return nil
+}
+
+func (vm *vm) LastAccepted(context.Context) (ids.ID, error) {
+	return vm.lastAccepted.id, nil
+}
+
+func (vm *vm) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, error) {
+	blkID, ok := vm.heights[height]
+	if !ok {
+		return ids.Empty, database.ErrNotFound
+	}
+	return blkID, nil
+}
+
+func (vm *vm) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+	if blk, ok := vm.verified[blkID]; ok {
+		return blk, nil
+	}
+	if blk, ok := vm.accepted[blkID]; ok {
+		return blk, nil
+	}
+	return nil, database.ErrNotFound
+}
+
+func (vm *vm) ParseBlock(ctx context.Context, bytes []byte) (snowman.Block, error) {
+	data, err := parseBlockData(bytes)
+	if err != nil {
+		return nil, err
+	}
+	if blk, err := vm.GetBlock(ctx, data.id); err == nil {
+		return blk, nil
+	}
+	return &block{
+		blockData: data,
+		vm:        vm,
+	}, nil
+}
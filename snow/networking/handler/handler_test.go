@@ -64,6 +64,7 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -171,6 +172,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -274,6 +276,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -365,6 +368,7 @@ func TestHandlerDispatchInternal(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -451,6 +455,7 @@ func TestHandlerSubnetConnector(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -633,6 +638,7 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 				prometheus.NewRegistry(),
 				nil,
 				version.CurrentApp,
+				nil,
 			)
 			require.NoError(err)
 
@@ -716,6 +722,7 @@ func TestHandlerStartError(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -79,6 +79,7 @@ func TestHealthCheckSubnet(t *testing.T) {
 				prometheus.NewRegistry(),
 				nil,
 				version.CurrentApp,
+				nil,
 			)
 			require.NoError(err)
 
@@ -100,6 +100,7 @@ func TestShutdown(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -226,6 +227,7 @@ func TestConnectedAfterShutdownErrorLogRegression(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -359,6 +361,7 @@ func TestShutdownTimesOut(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -529,6 +532,7 @@ func TestRouterTimeout(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -1112,6 +1116,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -1393,6 +1398,7 @@ func TestValidatorOnlyAllowedNodeMessageDrops(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -1733,6 +1739,7 @@ func newChainRouterTest(t *testing.T) (*ChainRouter, *enginetest.Engine) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(t, err)
 
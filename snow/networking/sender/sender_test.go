@@ -122,6 +122,7 @@ func TestTimeout(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -399,6 +400,7 @@ func TestReliableMessages(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
@@ -556,6 +558,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		nil,
 	)
 	require.NoError(err)
 
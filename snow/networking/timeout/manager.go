@@ -10,13 +10,19 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
+// latencyCacheSize is the maximum number of nodes whose response latency is
+// tracked.
+const latencyCacheSize = 4096
+
 var _ Manager = (*manager)(nil)
 
 // Manages timeouts for requests sent to peers.
@@ -63,6 +69,10 @@ type Manager interface {
 	// Mark that we no longer expect a response to this request we sent.
 	// Does not modify the timeout.
 	RemoveRequest(requestID ids.RequestID)
+	// AverageLatency returns the moving average of the latencies of the
+	// responses that [nodeID] sent us. Returns false if no responses from
+	// [nodeID] have been registered recently.
+	AverageLatency(nodeID ids.NodeID) (time.Duration, bool)
 
 	// Stops the manager.
 	Stop()
@@ -88,9 +98,13 @@ func NewManager(
 	}
 
 	return &manager{
-		tm:           tm,
-		benchlistMgr: benchlistMgr,
-		metrics:      m,
+		tm:              tm,
+		benchlistMgr:    benchlistMgr,
+		metrics:         m,
+		latencyHalflife: timeoutConfig.TimeoutHalflife,
+		latencies: &cache.LRU[ids.NodeID, math.Averager]{
+			Size: latencyCacheSize,
+		},
 	}, nil
 }
 
@@ -99,6 +113,10 @@ type manager struct {
 	benchlistMgr benchlist.Manager
 	metrics      *timeoutMetrics
 	stopOnce     sync.Once
+
+	latencyHalflife time.Duration
+	// nodeID -> moving average of the node's response latency
+	latencies *cache.LRU[ids.NodeID, math.Averager]
 }
 
 func (m *manager) Dispatch() {
@@ -158,12 +176,29 @@ func (m *manager) RegisterResponse(
 	m.metrics.Observe(chainID, op, latency)
 	m.benchlistMgr.RegisterResponse(chainID, nodeID)
 	m.tm.Remove(requestID)
+
+	now := time.Now()
+	if averager, ok := m.latencies.Get(nodeID); ok {
+		averager.Observe(float64(latency), now)
+		return
+	}
+	m.latencies.Put(nodeID, math.NewSyncAverager(
+		math.NewAverager(float64(latency), m.latencyHalflife, now),
+	))
 }
 
 func (m *manager) RemoveRequest(requestID ids.RequestID) {
 	m.tm.Remove(requestID)
 }
 
+func (m *manager) AverageLatency(nodeID ids.NodeID) (time.Duration, bool) {
+	averager, ok := m.latencies.Get(nodeID)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(averager.Read()), true
+}
+
 func (m *manager) RegisterRequestToUnreachableValidator() {
 	m.tm.ObserveLatency(m.TimeoutDuration())
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
@@ -47,3 +48,40 @@ func TestManagerFire(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestManagerAverageLatency(t *testing.T) {
+	require := require.New(t)
+
+	manager, err := NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Millisecond,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     10 * time.Second,
+			TimeoutCoefficient: 1.25,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		prometheus.NewRegistry(),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	_, ok := manager.AverageLatency(nodeID)
+	require.False(ok)
+
+	manager.RegisterResponse(nodeID, ids.Empty, ids.RequestID{}, message.ChitsOp, time.Second)
+	latency, ok := manager.AverageLatency(nodeID)
+	require.True(ok)
+	require.Equal(time.Second, latency)
+
+	manager.RegisterResponse(nodeID, ids.Empty, ids.RequestID{}, message.ChitsOp, 3*time.Second)
+	latency, ok = manager.AverageLatency(nodeID)
+	require.True(ok)
+	require.Greater(latency, time.Second)
+	require.Less(latency, 3*time.Second)
+
+	// Latencies are tracked per node
+	_, ok = manager.AverageLatency(ids.GenerateTestNodeID())
+	require.False(ok)
+}
@@ -42,6 +42,21 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// AverageLatency mocks base method.
+func (m *MockManager) AverageLatency(arg0 ids.NodeID) (time.Duration, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AverageLatency", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// AverageLatency indicates an expected call of AverageLatency.
+func (mr *MockManagerMockRecorder) AverageLatency(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AverageLatency", reflect.TypeOf((*MockManager)(nil).AverageLatency), arg0)
+}
+
 // Dispatch mocks base method.
 func (m *MockManager) Dispatch() {
 	m.ctrl.T.Helper()
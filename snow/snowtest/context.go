@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/audit"
 	"github.com/ava-labs/avalanchego/snow/validators/validatorstest"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -45,6 +46,7 @@ func ConsensusContext(ctx *snow.Context) *snow.ConsensusContext {
 		BlockAcceptor:  noOpAcceptor{},
 		TxAcceptor:     noOpAcceptor{},
 		VertexAcceptor: noOpAcceptor{},
+		AuditLog:       audit.NoLog{},
 	}
 }
 
@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type chainTracer struct {
+	Tracer
+
+	chain attribute.KeyValue
+}
+
+// WithChain returns a tracer that records [chain] as the [ChainKey] attribute
+// of every span it starts.
+func WithChain(tracer Tracer, chain string) Tracer {
+	return &chainTracer{
+		Tracer: tracer,
+		chain:  attribute.String(ChainKey, chain),
+	}
+}
+
+func (t *chainTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	opts = append(opts, trace.WithAttributes(t.chain))
+	return t.Tracer.Start(ctx, spanName, opts...)
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/trace"
+)
+
+var errNoTraceFiles = errors.New("no trace files found")
+
+// This summarizes the slow spans of each chain that were written by the file
+// trace exporter.
+func main() {
+	var (
+		traceDir  string
+		threshold time.Duration
+		chain     string
+		top       int
+	)
+	rootCmd := &cobra.Command{
+		Use:   "tracesummary",
+		Short: "Summarize the slow spans of each chain written by the file trace exporter",
+		RunE: func(*cobra.Command, []string) error {
+			spans, err := readSpans(os.ExpandEnv(traceDir))
+			if err != nil {
+				return err
+			}
+
+			if len(chain) > 0 {
+				filtered := spans[:0]
+				for _, span := range spans {
+					if span.Chain == chain {
+						filtered = append(filtered, span)
+					}
+				}
+				spans = filtered
+			}
+
+			printSummaries(trace.SummarizeSpans(spans, threshold), top)
+			return nil
+		},
+	}
+	flags := rootCmd.Flags()
+	flags.StringVar(&traceDir, "trace-dir", filepath.Join("$HOME", ".avalanchego", "logs", "traces"), "Path to the directory the node wrote traces to")
+	flags.DurationVar(&threshold, "threshold", 100*time.Millisecond, "Spans that took at least this long are considered slow")
+	flags.StringVar(&chain, "chain", "", "If provided, only summarize the spans of this chain")
+	flags.IntVar(&top, "top", 10, "Maximum number of span names to show per chain")
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "tracesummary failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// readSpans reads the spans of the current and rotated trace files in [dir].
+func readSpans(dir string) ([]trace.Span, error) {
+	ext := filepath.Ext(trace.FileName)
+	pattern := filepath.Join(dir, strings.TrimSuffix(trace.FileName, ext)+"*"+ext)
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: %s", errNoTraceFiles, pattern)
+	}
+
+	var spans []trace.Span
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		fileSpans, err := trace.ReadSpans(f)
+		_ = f.Close()
+		if err != nil {
+			// The last batch may have been partially written if the node was
+			// killed, so the spans that were read are still summarized.
+			fmt.Fprintf(os.Stderr, "failed to read all spans of %s: %v\n", file, err)
+		}
+		spans = append(spans, fileSpans...)
+	}
+	return spans, nil
+}
+
+func printSummaries(summaries []trace.SpanSummary, top int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	var (
+		chain   string
+		printed int
+	)
+	for i, summary := range summaries {
+		if i == 0 || summary.Chain != chain {
+			chain = summary.Chain
+			printed = 0
+
+			name := chain
+			if len(name) == 0 {
+				name = "<none>"
+			}
+			if i != 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "chain: %s\n", name)
+			fmt.Fprintln(w, "SPAN\tCOUNT\tSLOW\tMEAN\tMAX\tSLOWEST TRACE")
+		}
+		if printed == top {
+			continue
+		}
+		printed++
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n",
+			summary.Name,
+			summary.Count,
+			summary.SlowCount,
+			summary.Mean,
+			summary.Max,
+			summary.Slowest.TraceID,
+		)
+	}
+}
@@ -27,11 +27,16 @@ type ExporterConfig struct {
 
 	// If true, don't use TLS
 	Insecure bool `json:"insecure"`
+
+	// Directory to write traces to when using the file exporter
+	Directory string `json:"directory"`
 }
 
 func newExporter(config ExporterConfig) (sdktrace.SpanExporter, error) {
 	var client otlptrace.Client
 	switch config.Type {
+	case File:
+		return newFileExporter(config.Directory), nil
 	case GRPC:
 		opts := []otlptracegrpc.Option{
 			otlptracegrpc.WithEndpoint(config.Endpoint),
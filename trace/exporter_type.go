@@ -12,6 +12,7 @@ import (
 const (
 	GRPC ExporterType = iota + 1
 	HTTP
+	File
 )
 
 var errUnknownExporterType = errors.New("unknown exporter type")
@@ -22,6 +23,8 @@ func ExporterTypeFromString(exporterTypeStr string) (ExporterType, error) {
 		return GRPC, nil
 	case HTTP.String():
 		return HTTP, nil
+	case File.String():
+		return File, nil
 	default:
 		return 0, fmt.Errorf("%w: %q", errUnknownExporterType, exporterTypeStr)
 	}
@@ -39,6 +42,8 @@ func (t ExporterType) String() string {
 		return "grpc"
 	case HTTP:
 		return "http"
+	case File:
+		return "file"
 	default:
 		return "unknown"
 	}
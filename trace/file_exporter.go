@@ -0,0 +1,268 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// FileName is the name of the file that the file exporter writes traces
+	// to. Rotated files are named after it, with the time of the rotation.
+	FileName = "traces.json"
+
+	fileMaxSize  = 64 // megabytes
+	fileMaxFiles = 16
+)
+
+var _ sdktrace.SpanExporter = (*fileExporter)(nil)
+
+// fileExporter writes spans to rotating files in the OTLP JSON format. Every
+// batch of spans is written as one line, containing an
+// ExportTraceServiceRequest.
+type fileExporter struct {
+	lock   sync.Mutex
+	writer *lumberjack.Logger
+}
+
+func newFileExporter(dir string) *fileExporter {
+	return &fileExporter{
+		writer: &lumberjack.Logger{
+			Filename:   filepath.Join(dir, FileName),
+			MaxSize:    fileMaxSize,
+			MaxBackups: fileMaxFiles,
+		},
+	}
+}
+
+func (e *fileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	bytes, err := json.Marshal(newOTLPTraces(spans))
+	if err != nil {
+		return err
+	}
+	bytes = append(bytes, '\n')
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	_, err = e.writer.Write(bytes)
+	return err
+}
+
+func (e *fileExporter) Shutdown(context.Context) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.writer.Close()
+}
+
+// The following types are the subset of the OTLP JSON encoding that is
+// written by the file exporter.
+//
+// See https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano uint64         `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   uint64         `json:"endTimeUnixNano,string"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano uint64         `json:"timeUnixNano,string"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Message string `json:"message,omitempty"`
+	Code    int    `json:"code,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *int64          `json:"intValue,omitempty,string"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+// newOTLPTraces groups [spans] by their resource and instrumentation scope.
+func newOTLPTraces(spans []sdktrace.ReadOnlySpan) *otlpTraces {
+	var (
+		traces         = &otlpTraces{}
+		resourceSpans  = make(map[*resource.Resource]int)
+		scopeSpansByRS = make(map[int]map[instrumentation.Scope]int)
+	)
+	for _, span := range spans {
+		rsIndex, ok := resourceSpans[span.Resource()]
+		if !ok {
+			rsIndex = len(traces.ResourceSpans)
+			resourceSpans[span.Resource()] = rsIndex
+			scopeSpansByRS[rsIndex] = make(map[instrumentation.Scope]int)
+			traces.ResourceSpans = append(traces.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{
+					Attributes: newOTLPAttributes(span.Resource().Attributes()),
+				},
+			})
+		}
+
+		rs := &traces.ResourceSpans[rsIndex]
+		scope := span.InstrumentationScope()
+		ssIndex, ok := scopeSpansByRS[rsIndex][scope]
+		if !ok {
+			ssIndex = len(rs.ScopeSpans)
+			scopeSpansByRS[rsIndex][scope] = ssIndex
+			rs.ScopeSpans = append(rs.ScopeSpans, otlpScopeSpans{
+				Scope: otlpScope{
+					Name:    scope.Name,
+					Version: scope.Version,
+				},
+			})
+		}
+
+		ss := &rs.ScopeSpans[ssIndex]
+		ss.Spans = append(ss.Spans, newOTLPSpan(span))
+	}
+	return traces
+}
+
+func newOTLPSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	spanContext := span.SpanContext()
+	s := otlpSpan{
+		TraceID:           spanContext.TraceID().String(),
+		SpanID:            spanContext.SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: uint64(span.StartTime().UnixNano()),
+		EndTimeUnixNano:   uint64(span.EndTime().UnixNano()),
+		Attributes:        newOTLPAttributes(span.Attributes()),
+		Status: otlpStatus{
+			Message: span.Status().Description,
+		},
+	}
+	if parent := span.Parent(); parent.HasSpanID() {
+		s.ParentSpanID = parent.SpanID().String()
+	}
+	for _, event := range span.Events() {
+		s.Events = append(s.Events, otlpEvent{
+			TimeUnixNano: uint64(event.Time.UnixNano()),
+			Name:         event.Name,
+			Attributes:   newOTLPAttributes(event.Attributes),
+		})
+	}
+
+	// The OTLP status codes are ordered differently than the otel status
+	// codes.
+	switch span.Status().Code {
+	case codes.Ok:
+		s.Status.Code = 1
+	case codes.Error:
+		s.Status.Code = 2
+	}
+	return s
+}
+
+func newOTLPAttributes(attributes []attribute.KeyValue) []otlpKeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	keyValues := make([]otlpKeyValue, len(attributes))
+	for i, attr := range attributes {
+		keyValues[i] = otlpKeyValue{
+			Key:   string(attr.Key),
+			Value: newOTLPValue(attr.Value),
+		}
+	}
+	return keyValues
+}
+
+func newOTLPValue(value attribute.Value) otlpAnyValue {
+	switch value.Type() {
+	case attribute.BOOL:
+		v := value.AsBool()
+		return otlpAnyValue{BoolValue: &v}
+	case attribute.INT64:
+		v := value.AsInt64()
+		return otlpAnyValue{IntValue: &v}
+	case attribute.FLOAT64:
+		v := value.AsFloat64()
+		return otlpAnyValue{DoubleValue: &v}
+	case attribute.BOOLSLICE:
+		return newOTLPArrayValue(value.AsBoolSlice(), attribute.BoolValue)
+	case attribute.INT64SLICE:
+		return newOTLPArrayValue(value.AsInt64Slice(), attribute.Int64Value)
+	case attribute.FLOAT64SLICE:
+		return newOTLPArrayValue(value.AsFloat64Slice(), attribute.Float64Value)
+	case attribute.STRINGSLICE:
+		return newOTLPArrayValue(value.AsStringSlice(), attribute.StringValue)
+	default:
+		v := value.Emit()
+		return otlpAnyValue{StringValue: &v}
+	}
+}
+
+func newOTLPArrayValue[T any](values []T, newValue func(T) attribute.Value) otlpAnyValue {
+	array := &otlpArrayValue{
+		Values: make([]otlpAnyValue, len(values)),
+	}
+	for i, value := range values {
+		array.Values[i] = newOTLPValue(newValue(value))
+	}
+	return otlpAnyValue{ArrayValue: array}
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestFileExporter(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(newFileExporter(dir)),
+	)
+	tracer := &tracer{
+		Tracer: tracerProvider.Tracer("test"),
+		tp:     tracerProvider,
+	}
+	chainTracer := WithChain(tracer, "C")
+
+	start := time.Unix(100, 0)
+	ctx, parent := chainTracer.Start(context.Background(), "parent",
+		oteltrace.WithTimestamp(start),
+		oteltrace.WithAttributes(
+			attribute.Int64("height", 5),
+			attribute.StringSlice("ids", []string{"a", "b"}),
+		),
+	)
+	_, child := tracer.Start(ctx, "child", oteltrace.WithTimestamp(start))
+	child.End(oteltrace.WithTimestamp(start.Add(time.Second)))
+	parent.End(oteltrace.WithTimestamp(start.Add(2 * time.Second)))
+	require.NoError(tracer.Close())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, FileName))
+	require.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(bytes)), "\n")
+	require.Len(lines, 2)
+
+	// Every line is an OTLP JSON ExportTraceServiceRequest
+	var traces map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(lines[1]), &traces))
+	span := traces["resourceSpans"].([]interface{})[0].(map[string]interface{})["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})[0].(map[string]interface{})
+	require.Equal("parent", span["name"])
+	require.Equal("100000000000", span["startTimeUnixNano"])
+	require.Len(span["traceId"], 32)
+	require.Len(span["spanId"], 16)
+	require.Contains(span["attributes"], map[string]interface{}{
+		"key": "height",
+		"value": map[string]interface{}{
+			"intValue": "5",
+		},
+	})
+
+	spans, err := ReadSpans(strings.NewReader(string(bytes)))
+	require.NoError(err)
+	require.Len(spans, 2)
+	require.Equal("child", spans[0].Name)
+	require.Empty(spans[0].Chain)
+	require.Equal(time.Second, spans[0].Duration)
+	require.Equal("parent", spans[1].Name)
+	require.Equal("C", spans[1].Chain)
+	require.Equal(2*time.Second, spans[1].Duration)
+	require.Equal(spans[0].TraceID, spans[1].TraceID)
+
+	// A partially written batch doesn't prevent the previous batches from
+	// being read.
+	spans, err = ReadSpans(strings.NewReader(string(bytes) + lines[0][:10]))
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+	require.Len(spans, 2)
+}
+
+func TestSummarizeSpans(t *testing.T) {
+	require := require.New(t)
+
+	spans := []Span{
+		{Chain: "C", Name: "verify", SpanID: "0", Duration: 10 * time.Millisecond},
+		{Chain: "C", Name: "verify", SpanID: "1", Duration: 300 * time.Millisecond},
+		{Chain: "C", Name: "accept", SpanID: "2", Duration: 200 * time.Millisecond},
+		{Chain: "C", Name: "parse", SpanID: "3", Duration: time.Millisecond},
+		{Chain: "P", Name: "verify", SpanID: "4", Duration: 100 * time.Millisecond},
+	}
+	summaries := SummarizeSpans(spans, 100*time.Millisecond)
+	require.Equal([]SpanSummary{
+		{
+			Chain:     "C",
+			Name:      "verify",
+			Count:     2,
+			SlowCount: 1,
+			Mean:      155 * time.Millisecond,
+			Max:       300 * time.Millisecond,
+			Slowest:   spans[1],
+		},
+		{
+			Chain:     "C",
+			Name:      "accept",
+			Count:     1,
+			SlowCount: 1,
+			Mean:      200 * time.Millisecond,
+			Max:       200 * time.Millisecond,
+			Slowest:   spans[2],
+		},
+		{
+			Chain:     "P",
+			Name:      "verify",
+			Count:     1,
+			SlowCount: 1,
+			Mean:      100 * time.Millisecond,
+			Max:       100 * time.Millisecond,
+			Slowest:   spans[4],
+		},
+	}, summaries)
+}
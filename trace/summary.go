@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"time"
+)
+
+// ChainKey is the attribute that records which chain a span was recorded by.
+const ChainKey = "chain"
+
+// Span is a span that was written by the file exporter.
+type Span struct {
+	// Chain is the value of the [ChainKey] attribute, if the span has one.
+	Chain    string
+	Name     string
+	TraceID  string
+	SpanID   string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// ReadSpans reads the spans that were written by the file exporter to [r].
+//
+// If the last batch of spans was only partially written, for example because
+// the node was killed, the spans of the complete batches are returned along
+// with the error.
+func ReadSpans(r io.Reader) ([]Span, error) {
+	var (
+		decoder = json.NewDecoder(r)
+		spans   []Span
+	)
+	for {
+		var traces otlpTraces
+		err := decoder.Decode(&traces)
+		if errors.Is(err, io.EOF) {
+			return spans, nil
+		}
+		if err != nil {
+			return spans, err
+		}
+
+		for _, rs := range traces.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					start := time.Unix(0, int64(s.StartTimeUnixNano))
+					span := Span{
+						Name:     s.Name,
+						TraceID:  s.TraceID,
+						SpanID:   s.SpanID,
+						Start:    start,
+						Duration: time.Unix(0, int64(s.EndTimeUnixNano)).Sub(start),
+					}
+					for _, attr := range s.Attributes {
+						if attr.Key == ChainKey && attr.Value.StringValue != nil {
+							span.Chain = *attr.Value.StringValue
+							break
+						}
+					}
+					spans = append(spans, span)
+				}
+			}
+		}
+	}
+}
+
+// SpanSummary summarizes the spans of a chain with the same name.
+type SpanSummary struct {
+	Chain string
+	Name  string
+	// Count is the number of spans
+	Count int
+	// SlowCount is the number of spans that took at least the threshold
+	SlowCount int
+	Mean      time.Duration
+	Max       time.Duration
+	// Slowest is the span that took the longest
+	Slowest Span
+}
+
+// SummarizeSpans summarizes [spans] by chain and name. Only the spans with at
+// least one span that took at least [threshold] are returned.
+//
+// The summaries are sorted by chain and then by the longest span, slowest
+// first.
+func SummarizeSpans(spans []Span, threshold time.Duration) []SpanSummary {
+	type key struct {
+		chain string
+		name  string
+	}
+	var (
+		summaries = make(map[key]*SpanSummary)
+		totals    = make(map[key]time.Duration)
+	)
+	for _, span := range spans {
+		k := key{
+			chain: span.Chain,
+			name:  span.Name,
+		}
+		summary, ok := summaries[k]
+		if !ok {
+			summary = &SpanSummary{
+				Chain: span.Chain,
+				Name:  span.Name,
+			}
+			summaries[k] = summary
+		}
+
+		summary.Count++
+		totals[k] += span.Duration
+		if span.Duration >= threshold {
+			summary.SlowCount++
+		}
+		if summary.Count == 1 || span.Duration > summary.Max {
+			summary.Max = span.Duration
+			summary.Slowest = span
+		}
+	}
+
+	slowSummaries := make([]SpanSummary, 0, len(summaries))
+	for k, summary := range summaries {
+		if summary.SlowCount == 0 {
+			continue
+		}
+		summary.Mean = totals[k] / time.Duration(summary.Count)
+		slowSummaries = append(slowSummaries, *summary)
+	}
+	slices.SortFunc(slowSummaries, func(a, b SpanSummary) int {
+		if c := cmp.Compare(a.Chain, b.Chain); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Max, a.Max); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return slowSummaries
+}
@@ -86,6 +86,8 @@ const (
 	DefaultOutboundThrottlerAtLargeAllocSize    = 32 * units.MiB
 	DefaultOutboundThrottlerVdrAllocSize        = 32 * units.MiB
 	DefaultOutboundThrottlerNodeMaxAtLargeBytes = DefaultMaxMessageSize
+	DefaultOutboundThrottlerNodeMaxAppBytes     = 0
+	DefaultOutboundThrottlerNodeMaxGossipBytes  = DefaultMaxMessageSize
 
 	// Network Health
 	DefaultHealthCheckAveragerHalflife = 10 * time.Second
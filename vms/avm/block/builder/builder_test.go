@@ -31,6 +31,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	blkexecutor "github.com/ava-labs/avalanchego/vms/avm/block/executor"
@@ -547,7 +548,7 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 	metrics, err := metrics.New(registerer)
 	require.NoError(err)
 
-	manager := blkexecutor.NewManager(mempool, metrics, state, backend, clk, onAccept)
+	manager := blkexecutor.NewManager(mempool, metrics, state, backend, clk, onAccept, index.NewNoTxIndexer())
 
 	manager.SetPreference(parentBlk.ID())
 
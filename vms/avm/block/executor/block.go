@@ -246,6 +246,10 @@ func (b *Block) Accept(context.Context) error {
 		return err
 	}
 
+	if err := b.manager.txIndexer.Accept(b.Height()); err != nil {
+		return fmt.Errorf("failed to index block %s: %w", blkID, err)
+	}
+
 	txChecksum, utxoChecksum := b.manager.state.Checksums()
 	b.manager.backend.Ctx.Log.Trace(
 		"accepted block",
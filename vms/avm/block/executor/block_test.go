@@ -27,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 )
 
 func TestBlockVerify(t *testing.T) {
@@ -712,10 +713,11 @@ func TestBlockAccept(t *testing.T) {
 				return &Block{
 					Block: mockBlock,
 					manager: &manager{
-						state:   mockManagerState,
-						mempool: mempool,
-						metrics: metrics,
-						backend: defaultTestBackend(false, mockSharedMemory),
+						state:     mockManagerState,
+						mempool:   mempool,
+						metrics:   metrics,
+						txIndexer: index.NewNoTxIndexer(),
+						backend:   defaultTestBackend(false, mockSharedMemory),
 						blkIDToState: map[ids.ID]*blockState{
 							blockID: {
 								onAcceptState: mockOnAcceptState,
@@ -758,10 +760,11 @@ func TestBlockAccept(t *testing.T) {
 				return &Block{
 					Block: mockBlock,
 					manager: &manager{
-						state:   mockManagerState,
-						mempool: mempool,
-						metrics: metrics,
-						backend: defaultTestBackend(false, mockSharedMemory),
+						state:     mockManagerState,
+						mempool:   mempool,
+						metrics:   metrics,
+						txIndexer: index.NewNoTxIndexer(),
+						backend:   defaultTestBackend(false, mockSharedMemory),
 						blkIDToState: map[ids.ID]*blockState{
 							blockID: {
 								onAcceptState: mockOnAcceptState,
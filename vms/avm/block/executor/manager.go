@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 )
 
 var (
@@ -55,6 +56,7 @@ func NewManager(
 	backend *executor.Backend,
 	clk *mockable.Clock,
 	onAccept func(*txs.Tx) error,
+	txIndexer index.TxIndexer,
 ) Manager {
 	lastAccepted := state.GetLastAccepted()
 	return &manager{
@@ -64,6 +66,7 @@ func NewManager(
 		mempool:      mempool,
 		clk:          clk,
 		onAccept:     onAccept,
+		txIndexer:    txIndexer,
 		blkIDToState: map[ids.ID]*blockState{},
 		lastAccepted: lastAccepted,
 		preferred:    lastAccepted,
@@ -80,6 +83,8 @@ type manager struct {
 	// before its state changes are applied.
 	// Invariant: any error returned by onAccept should be considered fatal.
 	onAccept func(*txs.Tx) error
+	// txIndexer is notified of every block after it has been committed.
+	txIndexer index.TxIndexer
 
 	// blkIDToState is a map from a block's ID to the state of the block.
 	// Blocks are put into this map when they are verified.
@@ -35,11 +35,17 @@ type Client interface {
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
 	// GetHeight returns the height of the last accepted block.
 	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+	// GetIndexedTxs returns the IDs of the transactions indexed under [key]
+	// by the index named [index], starting at [cursor], and the cursor of
+	// the next page.
+	GetIndexedTxs(ctx context.Context, index string, key string, cursor uint64, pageSize uint64, options ...rpc.Option) ([]ids.ID, uint64, error)
 	// GetTxStatus returns the status of [txID]
 	//
 	// Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
 	// used instead to determine if the tx was accepted.
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error)
+	// GetMempool returns the txs in the mempool and the recently dropped txs
+	GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error)
 	// GetTx returns the byte representation of [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetUTXOs returns the byte representation of the UTXOs controlled by [addrs]
@@ -263,6 +269,24 @@ func (c *client) GetHeight(ctx context.Context, options ...rpc.Option) (uint64,
 	return uint64(res.Height), err
 }
 
+func (c *client) GetIndexedTxs(
+	ctx context.Context,
+	index string,
+	key string,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	res := &GetIndexedTxsReply{}
+	err := c.requester.SendRequest(ctx, "avm.getIndexedTxs", &GetIndexedTxsArgs{
+		Index:    index,
+		Key:      key,
+		Cursor:   json.Uint64(cursor),
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
 func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error) {
 	txStr, err := formatting.Encode(formatting.Hex, txBytes)
 	if err != nil {
@@ -284,6 +308,12 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res.Status, err
 }
 
+func (c *client) GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error) {
+	res := &api.GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "avm.getMempool", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "avm.getTx", &api.GetTxArgs{
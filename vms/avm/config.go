@@ -21,6 +21,8 @@ type Config struct {
 	IndexTransactions    bool           `json:"index-transactions"`
 	IndexAllowIncomplete bool           `json:"index-allow-incomplete"`
 	ChecksumsEnabled     bool           `json:"checksums-enabled"`
+	// Indexes are the names of the secondary transaction indexes to maintain.
+	Indexes []string `json:"indexes"`
 }
 
 func ParseConfig(configBytes []byte) (Config, error) {
@@ -33,6 +33,17 @@ func TestParseConfig(t *testing.T) {
 				ChecksumsEnabled:     true,
 			},
 		},
+		{
+			name:        "manually specified indexes",
+			configBytes: []byte(`{"indexes":["owner","asset"]}`),
+			expectedConfig: Config{
+				Network:              network.DefaultConfig,
+				IndexTransactions:    DefaultConfig.IndexTransactions,
+				IndexAllowIncomplete: DefaultConfig.IndexAllowIncomplete,
+				ChecksumsEnabled:     DefaultConfig.ChecksumsEnabled,
+				Indexes:              []string{"owner", "asset"},
+			},
+		},
 		{
 			name:        "manually specified network value",
 			configBytes: []byte(`{"network":{"max-validator-set-staleness":1}}`),
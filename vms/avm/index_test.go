@@ -4,6 +4,7 @@
 package avm
 
 import (
+	"context"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -61,6 +62,13 @@ func TestIndexTransaction_Ordered(t *testing.T) {
 		assertIndexedTX(t, env.vm.db, uint64(i), addr, txAssetID.ID, tx.ID())
 	}
 	assertLatestIdx(t, env.vm.db, addr, txAssetID.ID, 5)
+
+	// the owner-asset index also includes the genesis tx
+	expectedTxIDs := []ids.ID{env.genesisTx.ID()}
+	for _, tx := range txs {
+		expectedTxIDs = append(expectedTxIDs, tx.ID())
+	}
+	assertIndexedTxs(t, env, addr, txAssetID.ID, expectedTxIDs)
 }
 
 func TestIndexTransaction_MultipleTransactions(t *testing.T) {
@@ -103,6 +111,7 @@ func TestIndexTransaction_MultipleTransactions(t *testing.T) {
 	for addr, tx := range addressTxMap {
 		assertIndexedTX(t, env.vm.db, 0, addr, txAssetID.ID, tx.ID())
 		assertLatestIdx(t, env.vm.db, addr, txAssetID.ID, 1)
+		assertIndexedTxs(t, env, addr, txAssetID.ID, []ids.ID{env.genesisTx.ID(), tx.ID()})
 	}
 }
 
@@ -142,6 +151,37 @@ func TestIndexTransaction_MultipleAddresses(t *testing.T) {
 
 	assertIndexedTX(t, env.vm.db, 0, addr, txAssetID.ID, tx.ID())
 	assertLatestIdx(t, env.vm.db, addr, txAssetID.ID, 1)
+	for _, addr := range addrs {
+		assertIndexedTxs(t, env, addr, txAssetID.ID, []ids.ID{env.genesisTx.ID(), tx.ID()})
+	}
+}
+
+func TestIndexGenesisTxs(t *testing.T) {
+	env := setup(t, &envConfig{fork: durango})
+	defer env.vm.ctx.Lock.Unlock()
+
+	// The genesis tx was accepted before the chain was linearized.
+	for _, addr := range addrs {
+		assertIndexedTxs(t, env, addr, env.genesisTx.ID(), []ids.ID{env.genesisTx.ID()})
+	}
+}
+
+func TestTxIndexerNumDAGTxs(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{fork: durango})
+	defer env.vm.ctx.Lock.Unlock()
+
+	require.IsType(&linearTxIndexer{}, env.vm.txIndexer)
+	indexer := env.vm.txIndexer.(*linearTxIndexer)
+	numDAGTxs := env.vm.state.NumDAGTxs()
+	require.Equal(numDAGTxs, indexer.numDAGTxs)
+
+	// The number of DAG txs is only recorded when the index is first created.
+	env.vm.state.AddDAGTx(ids.GenerateTestID())
+	recordedNumDAGTxs, err := env.vm.getNumDAGTxs()
+	require.NoError(err)
+	require.Equal(numDAGTxs, recordedNumDAGTxs)
 }
 
 func TestIndexer_Read(t *testing.T) {
@@ -172,6 +212,52 @@ func TestIndexer_Read(t *testing.T) {
 	}
 }
 
+func TestOwnerAssetIndex_Read(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{fork: durango})
+	defer env.vm.ctx.Lock.Unlock()
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	txAssetID := avax.Asset{ID: env.genesisTx.ID()}
+
+	expectedTxIDs := []ids.ID{env.genesisTx.ID()}
+	for i := 0; i < 4; i++ {
+		utxoID := avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		}
+		env.vm.state.AddUTXO(buildUTXO(utxoID, txAssetID, addr))
+
+		tx := buildTX(env.vm.ctx.XChainID, utxoID, txAssetID, addr)
+		require.NoError(tx.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{{key}}))
+
+		env.vm.ctx.Lock.Unlock()
+		issueAndAccept(require, env.vm, env.issuer, tx)
+		env.vm.ctx.Lock.Lock()
+
+		expectedTxIDs = append(expectedTxIDs, tx.ID())
+	}
+	assertIndexedTxs(t, env, addr, txAssetID.ID, expectedTxIDs)
+
+	addrStr, err := env.vm.FormatLocalAddress(addr)
+	require.NoError(err)
+	indexKey := index.OwnerAssetKey(addrStr, txAssetID.ID.String())
+
+	// read the pages, 2 items at a time
+	var (
+		cursor   uint64
+		pageSize uint64 = 2
+	)
+	for cursor < uint64(len(expectedTxIDs)) {
+		txIDs, err := env.vm.txIndexer.Read(index.OwnerAssetIndexName, indexKey, cursor, pageSize)
+		require.NoError(err)
+		end := min(cursor+pageSize, uint64(len(expectedTxIDs)))
+		require.Equal(expectedTxIDs[cursor:end], txIDs)
+		cursor += pageSize
+	}
+}
+
 func TestIndexingNewInitWithIndexingEnabled(t *testing.T) {
 	require := require.New(t)
 
@@ -330,3 +416,27 @@ func initTestTxIndex(t *testing.T, db *versiondb.Database, address ids.ShortID,
 	require.NoError(db.Commit())
 	return testTxs
 }
+
+// assertIndexedTxs expects the context lock to be held
+func assertIndexedTxs(t *testing.T, env *environment, addr ids.ShortID, assetID ids.ID, expectedTxIDs []ids.ID) {
+	require := require.New(t)
+
+	// Wait for the blocks accepted before the index was caught up to be
+	// indexed.
+	env.vm.ctx.Lock.Unlock()
+	err := env.vm.txIndexer.Backfill(context.Background())
+	env.vm.ctx.Lock.Lock()
+	require.NoError(err)
+
+	addrStr, err := env.vm.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	txIDs, err := env.vm.txIndexer.Read(
+		index.OwnerAssetIndexName,
+		index.OwnerAssetKey(addrStr, assetID.String()),
+		0,
+		maxPageSize,
+	)
+	require.NoError(err)
+	require.Equal(expectedTxIDs, txIDs)
+}
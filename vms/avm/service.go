@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
@@ -278,8 +280,14 @@ func (s *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
 
-	// Read transactions from the indexer
-	reply.TxIDs, err = s.vm.addressTxsIndexer.Read(address[:], assetID, cursor, pageSize)
+	// Read transactions from the indexer. The deprecated index is used until
+	// the owner-asset index has indexed every accepted transaction.
+	if s.vm.addressTxsIndexSynced() {
+		key := index.OwnerAssetKey(args.Address, assetID.String())
+		reply.TxIDs, err = s.vm.txIndexer.Read(index.OwnerAssetIndexName, key, cursor, pageSize)
+	} else {
+		reply.TxIDs, err = s.vm.addressTxsIndexer.Read(address[:], assetID, cursor, pageSize)
+	}
 	if err != nil {
 		return err
 	}
@@ -296,6 +304,67 @@ func (s *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply
 	return nil
 }
 
+type GetIndexedTxsArgs struct {
+	// Index is the name of the index to read from
+	Index string `json:"index"`
+	// Key is the key, such as an address or assetID, to read the
+	// transactions of
+	Key string `json:"key"`
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize avajson.Uint64 `json:"pageSize"`
+}
+
+type GetIndexedTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+	// Synced is true if the index has indexed every accepted block
+	Synced bool `json:"synced"`
+}
+
+// GetIndexedTxs returns the IDs of the transactions indexed under the
+// provided key, in order of acceptance.
+func (s *Service) GetIndexedTxs(_ *http.Request, args *GetIndexedTxsArgs, reply *GetIndexedTxsReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getIndexedTxs"),
+		logging.UserString("index", args.Index),
+		logging.UserString("key", args.Key),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+	if pageSize > maxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.chainManager == nil {
+		return errNotLinearized
+	}
+
+	var err error
+	reply.TxIDs, err = s.vm.txIndexer.Read(args.Index, args.Key, cursor, pageSize)
+	if err != nil {
+		return err
+	}
+	reply.Synced, err = s.vm.txIndexer.Synced(args.Index)
+	if err != nil {
+		return err
+	}
+
+	// To get the next set of tx IDs, the user should provide this cursor.
+	reply.Cursor = avajson.Uint64(cursor + uint64(len(reply.TxIDs)))
+	return nil
+}
+
 // GetTxStatus returns the status of the specified transaction
 //
 // Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
@@ -326,6 +395,49 @@ func (s *Service) GetTxStatus(_ *http.Request, args *api.JSONTxID, reply *GetTxS
 	return nil
 }
 
+// GetMempool returns the txs in the mempool and the txs that were recently
+// dropped from it.
+func (s *Service) GetMempool(_ *http.Request, _ *struct{}, reply *api.GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getMempool"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.mempool == nil {
+		return errNotLinearized
+	}
+
+	entries := s.vm.mempool.Snapshot()
+	reply.Txs = make([]api.MempoolTx, len(entries))
+	for i, entry := range entries {
+		txID := entry.Tx.ID()
+		fee, err := txs.Burned(entry.Tx.Unsigned, s.vm.feeAssetID)
+		if err != nil {
+			return fmt.Errorf("failed to calculate fee of %s: %w", txID, err)
+		}
+
+		reply.Txs[i] = api.MempoolTx{
+			TxID: txID,
+			Size: avajson.Uint64(len(entry.Tx.Bytes())),
+			Fee:  avajson.Uint64(fee),
+			Age:  avajson.Uint64(time.Since(entry.AddedTime) / time.Second),
+		}
+	}
+
+	reply.Dropped = []api.DroppedTx{}
+	s.vm.mempool.IterateDropped(func(txID ids.ID, reason error) bool {
+		reply.Dropped = append(reply.Dropped, api.DroppedTx{
+			TxID:   txID,
+			Reason: reason.Error(),
+		})
+		return true
+	})
+	return nil
+}
+
 // GetTx returns the specified transaction
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, reply *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
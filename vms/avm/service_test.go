@@ -4,7 +4,9 @@
 package avm
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -99,6 +101,110 @@ func TestServiceGetTxStatus(t *testing.T) {
 	require.Equal(choices.Accepted, statusReply.Status)
 }
 
+func TestServiceGetMempool(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		fork: latest,
+	})
+	service := &Service{vm: env.vm}
+	env.vm.ctx.Lock.Unlock()
+
+	tx := newAvaxBaseTxWithOutputs(t, env)
+	txID := tx.ID()
+	_, err := env.vm.issueTxFromRPC(tx)
+	require.NoError(err)
+
+	droppedTxID := ids.GenerateTestID()
+	droppedErr := errors.New("dropped")
+	env.vm.mempool.MarkDropped(droppedTxID, droppedErr)
+
+	reply := &api.GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, nil, reply))
+	require.Len(reply.Txs, 1)
+	require.Equal(txID, reply.Txs[0].TxID)
+	require.Equal(avajson.Uint64(len(tx.Bytes())), reply.Txs[0].Size)
+	require.Equal(avajson.Uint64(env.vm.TxFee), reply.Txs[0].Fee)
+	require.Equal(
+		[]api.DroppedTx{{
+			TxID:   droppedTxID,
+			Reason: droppedErr.Error(),
+		}},
+		reply.Dropped,
+	)
+}
+
+func TestServiceGetMempoolConcurrentAdd(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		fork: latest,
+	})
+	service := &Service{vm: env.vm}
+	env.vm.ctx.Lock.Unlock()
+
+	newTx := func() *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: env.vm.feeAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: units.Avax,
+				},
+			}},
+		}}}
+		require.NoError(tx.Initialize(env.vm.parser.Codec()))
+		return tx
+	}
+	for i := 0; i < 100; i++ {
+		require.NoError(env.vm.mempool.Add(newTx()))
+	}
+
+	// Adding txs while the mempool is being read must not deadlock.
+	var (
+		stop = make(chan struct{})
+		done = make(chan error)
+	)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				done <- nil
+				return
+			default:
+			}
+
+			tx := newTx()
+			if err := env.vm.mempool.Add(tx); err != nil {
+				done <- err
+				return
+			}
+			env.vm.mempool.Remove(tx)
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		reply := &api.GetMempoolReply{}
+		require.NoError(service.GetMempool(nil, nil, reply))
+		require.GreaterOrEqual(len(reply.Txs), 100)
+	}
+	close(stop)
+	require.NoError(<-done)
+}
+
+func TestServiceGetMempoolNotLinearized(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		notLinearized: true,
+	})
+	service := &Service{vm: env.vm}
+	env.vm.ctx.Lock.Unlock()
+
+	err := service.GetMempool(nil, nil, &api.GetMempoolReply{})
+	require.ErrorIs(err, errNotLinearized)
+}
+
 // Test the GetBalance method when argument Strict is true
 func TestServiceGetBalanceStrict(t *testing.T) {
 	require := require.New(t)
@@ -257,8 +363,11 @@ func TestServiceGetBalanceStrict(t *testing.T) {
 
 func TestServiceGetTxs(t *testing.T) {
 	require := require.New(t)
+	// The deprecated index is read until the chain is linearized and the
+	// owner-asset index has caught up.
 	env := setup(t, &envConfig{
-		fork: latest,
+		fork:          latest,
+		notLinearized: true,
 	})
 	service := &Service{vm: env.vm}
 
@@ -295,6 +404,58 @@ func TestServiceGetTxs(t *testing.T) {
 	require.Equal(getTxsReply.TxIDs, testTxs[10:20])
 }
 
+func TestServiceGetTxsOwnerAssetIndex(t *testing.T) {
+	require := require.New(t)
+	env := setup(t, &envConfig{
+		fork: latest,
+	})
+	service := &Service{vm: env.vm}
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	addrStr, err := env.vm.FormatLocalAddress(addr)
+	require.NoError(err)
+	txAssetID := avax.Asset{ID: env.genesisTx.ID()}
+
+	testTxs := []ids.ID{env.genesisTx.ID()}
+	for i := 0; i < 4; i++ {
+		utxoID := avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		}
+		env.vm.state.AddUTXO(buildUTXO(utxoID, txAssetID, addr))
+
+		tx := buildTX(env.vm.ctx.XChainID, utxoID, txAssetID, addr)
+		require.NoError(tx.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{{key}}))
+
+		env.vm.ctx.Lock.Unlock()
+		issueAndAccept(require, env.vm, env.issuer, tx)
+		env.vm.ctx.Lock.Lock()
+
+		testTxs = append(testTxs, tx.ID())
+	}
+
+	env.vm.ctx.Lock.Unlock()
+	require.NoError(env.vm.txIndexer.Backfill(context.Background()))
+
+	// get the first page
+	getTxsArgs := &GetAddressTxsArgs{
+		PageSize:    2,
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		AssetID:     txAssetID.ID.String(),
+	}
+	getTxsReply := &GetAddressTxsReply{}
+	require.NoError(service.GetAddressTxs(nil, getTxsArgs, getTxsReply))
+	require.Len(getTxsReply.TxIDs, 2)
+	require.Equal(testTxs[:2], getTxsReply.TxIDs)
+
+	// get the second page
+	getTxsArgs.Cursor = getTxsReply.Cursor
+	getTxsReply = &GetAddressTxsReply{}
+	require.NoError(service.GetAddressTxs(nil, getTxsArgs, getTxsReply))
+	require.Len(getTxsReply.TxIDs, 2)
+	require.Equal(testTxs[2:4], getTxsReply.TxIDs)
+}
+
 func TestServiceGetAllBalances(t *testing.T) {
 	require := require.New(t)
 
@@ -251,6 +251,18 @@ func (mr *MockStateMockRecorder) AddBlock(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlock", reflect.TypeOf((*MockState)(nil).AddBlock), arg0)
 }
 
+// AddDAGTx mocks base method.
+func (m *MockState) AddDAGTx(arg0 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddDAGTx", arg0)
+}
+
+// AddDAGTx indicates an expected call of AddDAGTx.
+func (mr *MockStateMockRecorder) AddDAGTx(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDAGTx", reflect.TypeOf((*MockState)(nil).AddDAGTx), arg0)
+}
+
 // AddTx mocks base method.
 func (m *MockState) AddTx(arg0 *txs.Tx) {
 	m.ctrl.T.Helper()
@@ -375,6 +387,21 @@ func (mr *MockStateMockRecorder) GetBlockIDAtHeight(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockState)(nil).GetBlockIDAtHeight), arg0)
 }
 
+// GetDAGTxID mocks base method.
+func (m *MockState) GetDAGTxID(arg0 uint64) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDAGTxID", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDAGTxID indicates an expected call of GetDAGTxID.
+func (mr *MockStateMockRecorder) GetDAGTxID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDAGTxID", reflect.TypeOf((*MockState)(nil).GetDAGTxID), arg0)
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -462,6 +489,20 @@ func (mr *MockStateMockRecorder) IsInitialized() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInitialized", reflect.TypeOf((*MockState)(nil).IsInitialized))
 }
 
+// NumDAGTxs mocks base method.
+func (m *MockState) NumDAGTxs() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NumDAGTxs")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// NumDAGTxs indicates an expected call of NumDAGTxs.
+func (mr *MockStateMockRecorder) NumDAGTxs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumDAGTxs", reflect.TypeOf((*MockState)(nil).NumDAGTxs))
+}
+
 // SetInitialized mocks base method.
 func (m *MockState) SetInitialized() error {
 	m.ctrl.T.Helper()
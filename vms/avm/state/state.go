@@ -32,11 +32,13 @@ var (
 	txPrefix        = []byte("tx")
 	blockIDPrefix   = []byte("blockID")
 	blockPrefix     = []byte("block")
+	dagTxIDPrefix   = []byte("dagTxID")
 	singletonPrefix = []byte("singleton")
 
 	isInitializedKey = []byte{0x00}
 	timestampKey     = []byte{0x01}
 	lastAcceptedKey  = []byte{0x02}
+	numDAGTxsKey     = []byte{0x03}
 
 	_ State = (*state)(nil)
 )
@@ -71,6 +73,18 @@ type State interface {
 	IsInitialized() (bool, error)
 	SetInitialized() error
 
+	// AddDAGTx records that [txID] was accepted before the chain was
+	// linearized. Transactions are numbered in the order they are added.
+	AddDAGTx(txID ids.ID)
+
+	// GetDAGTxID returns the ID of the [index]th transaction accepted before
+	// the chain was linearized.
+	GetDAGTxID(index uint64) (ids.ID, error)
+
+	// NumDAGTxs returns the number of transactions accepted before the chain
+	// was linearized.
+	NumDAGTxs() uint64
+
 	// InitializeChainState is called after the VM has been linearized. Calling
 	// [GetLastAccepted] or [GetTimestamp] before calling this function will
 	// return uninitialized data.
@@ -105,10 +119,13 @@ type State interface {
  * | '-- height -> blockID
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. dagTxIDs
+ * | '-- index -> txID
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
- *   '-- lastAcceptedKey -> lastAccepted
+ *   |-- lastAcceptedKey -> lastAccepted
+ *   '-- numDAGTxsKey -> numDAGTxs
  */
 type state struct {
 	parser block.Parser
@@ -130,6 +147,10 @@ type state struct {
 	blockCache  cache.Cacher[ids.ID, block.Block] // cache of blockID -> Block. If the entry is nil, it is not in the database
 	blockDB     database.Database
 
+	addedDAGTxIDs      []ids.ID // txIDs accepted after [persistedNumDAGTxs]
+	persistedNumDAGTxs uint64
+	dagTxIDDB          database.Database
+
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	timestamp, persistedTimestamp       time.Time
@@ -149,8 +170,17 @@ func New(
 	txDB := prefixdb.New(txPrefix, db)
 	blockIDDB := prefixdb.New(blockIDPrefix, db)
 	blockDB := prefixdb.New(blockPrefix, db)
+	dagTxIDDB := prefixdb.New(dagTxIDPrefix, db)
 	singletonDB := prefixdb.New(singletonPrefix, db)
 
+	numDAGTxs, err := database.GetUInt64(singletonDB, numDAGTxsKey)
+	if err == database.ErrNotFound {
+		numDAGTxs, err = 0, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	txCache, err := metercacher.New[ids.ID, *txs.Tx](
 		"tx_cache",
 		metrics,
@@ -203,6 +233,9 @@ func New(
 		blockCache:  blockCache,
 		blockDB:     blockDB,
 
+		persistedNumDAGTxs: numDAGTxs,
+		dagTxIDDB:          dagTxIDDB,
+
 		singletonDB: singletonDB,
 
 		trackChecksum: trackChecksums,
@@ -331,6 +364,25 @@ func (s *state) AddBlock(block block.Block) {
 	s.addedBlocks[blkID] = block
 }
 
+func (s *state) AddDAGTx(txID ids.ID) {
+	s.addedDAGTxIDs = append(s.addedDAGTxIDs, txID)
+}
+
+func (s *state) GetDAGTxID(index uint64) (ids.ID, error) {
+	if index >= s.persistedNumDAGTxs {
+		offset := index - s.persistedNumDAGTxs
+		if offset >= uint64(len(s.addedDAGTxIDs)) {
+			return ids.Empty, database.ErrNotFound
+		}
+		return s.addedDAGTxIDs[offset], nil
+	}
+	return database.GetID(s.dagTxIDDB, database.PackUInt64(index))
+}
+
+func (s *state) NumDAGTxs() uint64 {
+	return s.persistedNumDAGTxs + uint64(len(s.addedDAGTxIDs))
+}
+
 func (s *state) InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.Time) error {
 	lastAccepted, err := database.GetID(s.singletonDB, lastAcceptedKey)
 	if err == database.ErrNotFound {
@@ -413,6 +465,7 @@ func (s *state) Close() error {
 		s.txDB.Close(),
 		s.blockIDDB.Close(),
 		s.blockDB.Close(),
+		s.dagTxIDDB.Close(),
 		s.singletonDB.Close(),
 		s.db.Close(),
 	)
@@ -424,6 +477,7 @@ func (s *state) write() error {
 		s.writeTxs(),
 		s.writeBlockIDs(),
 		s.writeBlocks(),
+		s.writeDAGTxIDs(),
 		s.writeMetadata(),
 	)
 }
@@ -486,6 +540,26 @@ func (s *state) writeBlocks() error {
 	return nil
 }
 
+func (s *state) writeDAGTxIDs() error {
+	if len(s.addedDAGTxIDs) == 0 {
+		return nil
+	}
+
+	for _, txID := range s.addedDAGTxIDs {
+		indexKey := database.PackUInt64(s.persistedNumDAGTxs)
+		if err := database.PutID(s.dagTxIDDB, indexKey, txID); err != nil {
+			return fmt.Errorf("failed to add DAG txID: %w", err)
+		}
+		s.persistedNumDAGTxs++
+	}
+	s.addedDAGTxIDs = s.addedDAGTxIDs[:0]
+
+	if err := database.PutUInt64(s.singletonDB, numDAGTxsKey, s.persistedNumDAGTxs); err != nil {
+		return fmt.Errorf("failed to write number of DAG txs: %w", err)
+	}
+	return nil
+}
+
 func (s *state) writeMetadata() error {
 	if !s.persistedTimestamp.Equal(s.timestamp) {
 		if err := database.PutTimestamp(s.singletonDB, timestampKey, s.timestamp); err != nil {
@@ -316,3 +316,43 @@ func TestInitializeChainState(t *testing.T) {
 	require.NoError(err)
 	require.Equal(genesis.ID(), lastAccepted.Parent())
 }
+
+func TestDAGTxs(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	vdb := versiondb.New(db)
+	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+	require.Zero(s.NumDAGTxs())
+
+	txIDs := []ids.ID{
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+	}
+	s.AddDAGTx(txIDs[0])
+	require.NoError(s.Commit())
+
+	s.AddDAGTx(txIDs[1])
+	s.AddDAGTx(txIDs[2])
+	require.Equal(uint64(3), s.NumDAGTxs())
+	for i, txID := range txIDs {
+		gotTxID, err := s.GetDAGTxID(uint64(i))
+		require.NoError(err)
+		require.Equal(txID, gotTxID)
+	}
+	require.NoError(s.Commit())
+
+	s, err = New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+	require.Equal(uint64(3), s.NumDAGTxs())
+	for i, txID := range txIDs {
+		gotTxID, err := s.GetDAGTxID(uint64(i))
+		require.NoError(err)
+		require.Equal(txID, gotTxID)
+	}
+
+	_, err = s.GetDAGTxID(3)
+	require.ErrorIs(err, database.ErrNotFound)
+}
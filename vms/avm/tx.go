@@ -55,6 +55,7 @@ func (tx *Tx) Accept(context.Context) error {
 	}
 
 	tx.vm.state.AddTx(tx.tx)
+	tx.vm.state.AddDAGTx(tx.tx.ID())
 
 	commitBatch, err := tx.vm.state.CommitBatch()
 	if err != nil {
@@ -0,0 +1,244 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/state"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/index"
+)
+
+var (
+	_ index.Source    = (*txIndexSource)(nil)
+	_ index.TxIndexer = (*linearTxIndexer)(nil)
+
+	txIndexPrefix         = []byte("tx index")
+	txIndexMetadataPrefix = []byte("tx index metadata")
+
+	numDAGTxsKey = []byte("numDAGTxs")
+
+	errUnknownIndex = errors.New("unknown index")
+)
+
+// newTxIndexer returns a TxIndexer that maintains the indexes named by
+// [indexNames].
+//
+// Must only be called after the chain has been linearized.
+func (vm *VM) newTxIndexer(indexNames []string) (index.TxIndexer, error) {
+	if len(indexNames) == 0 {
+		return index.NewNoTxIndexer(), nil
+	}
+
+	indexes := make(map[string]index.Index, len(indexNames))
+	for _, name := range indexNames {
+		switch name {
+		case index.OwnerIndexName:
+			indexes[name] = index.NewOwnerIndex(func(addr string) (ids.ShortID, error) {
+				return avax.ParseServiceAddress(vm, addr)
+			})
+		case index.AssetIndexName:
+			indexes[name] = index.NewAssetIndex(vm.lookupAssetID)
+		case index.OwnerAssetIndexName:
+			indexes[name] = index.NewOwnerAssetIndex(
+				func(addr string) (ids.ShortID, error) {
+					return avax.ParseServiceAddress(vm, addr)
+				},
+				vm.lookupAssetID,
+			)
+		default:
+			return nil, fmt.Errorf("%w: %q", errUnknownIndex, name)
+		}
+	}
+
+	numDAGTxs, err := vm.getNumDAGTxs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the number of DAG txs: %w", err)
+	}
+
+	// The genesis transactions are always recorded when the state is
+	// initialized, so there are no recorded transactions only if the state
+	// was initialized before they were recorded.
+	if numDAGTxs == 0 {
+		vm.ctx.Log.Warn("transactions accepted before linearization will not be indexed",
+			zap.String("reason", "the X-chain was synced before they were recorded"),
+		)
+	}
+
+	// The indexes are written directly to the base database because blocks
+	// are indexed after they have been committed.
+	txIndexer, err := index.NewTxIndexer(
+		prefixdb.New(txIndexPrefix, vm.baseDB),
+		vm.ctx.Log,
+		&vm.ctx.Lock,
+		&txIndexSource{
+			state:     vm.state,
+			numDAGTxs: numDAGTxs,
+		},
+		vm.registerer,
+		indexes,
+	)
+	return &linearTxIndexer{
+		TxIndexer: txIndexer,
+		numDAGTxs: numDAGTxs,
+	}, err
+}
+
+// getNumDAGTxs returns the number of transactions that were accepted before
+// the chain was linearized.
+//
+// The number is recorded the first time the tx indexer is created so that the
+// heights of [txIndexSource] never change once transactions have been indexed.
+func (vm *VM) getNumDAGTxs() (uint64, error) {
+	metadataDB := prefixdb.New(txIndexMetadataPrefix, vm.baseDB)
+	numDAGTxs, err := database.GetUInt64(metadataDB, numDAGTxsKey)
+	if err != database.ErrNotFound {
+		return numDAGTxs, err
+	}
+
+	numDAGTxs = vm.state.NumDAGTxs()
+	return numDAGTxs, database.PutUInt64(metadataDB, numDAGTxsKey, numDAGTxs)
+}
+
+// addressTxsIndexSynced returns true if the owner-asset index has indexed
+// every accepted transaction, in which case it can replace the deprecated
+// address transaction index.
+func (vm *VM) addressTxsIndexSynced() bool {
+	if vm.chainManager == nil {
+		return false
+	}
+
+	// Transactions accepted before linearization can only be indexed if they
+	// were recorded.
+	if indexer, ok := vm.txIndexer.(*linearTxIndexer); !ok || indexer.numDAGTxs == 0 {
+		return false
+	}
+
+	synced, err := vm.txIndexer.Synced(index.OwnerAssetIndexName)
+	return err == nil && synced
+}
+
+// linearTxIndexer converts the block heights it is notified of into the
+// heights of [txIndexSource].
+type linearTxIndexer struct {
+	index.TxIndexer
+	numDAGTxs uint64
+}
+
+func (i *linearTxIndexer) Accept(height uint64) error {
+	return i.TxIndexer.Accept(i.numDAGTxs + height)
+}
+
+// txIndexSource provides the transactions accepted by the X-chain.
+//
+// The transactions accepted before the chain was linearized, starting with
+// the genesis transactions, are provided one per height. The blocks accepted
+// after the chain was linearized follow them. That is, if n transactions were
+// accepted before the chain was linearized, height h < n contains the hth
+// transaction and height h >= n contains the transactions of block h-n.
+type txIndexSource struct {
+	state     state.State
+	numDAGTxs uint64
+}
+
+func (s *txIndexSource) LastAcceptedHeight() (uint64, error) {
+	blk, err := s.state.GetBlock(s.state.GetLastAccepted())
+	if err != nil {
+		return 0, err
+	}
+	return s.numDAGTxs + blk.Height(), nil
+}
+
+func (s *txIndexSource) GetTxs(height uint64) ([]*index.Tx, error) {
+	if height < s.numDAGTxs {
+		return s.getDAGTx(height)
+	}
+
+	blkID, err := s.state.GetBlockIDAtHeight(height - s.numDAGTxs)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := s.state.GetBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+
+	blkTxs := blk.Txs()
+	indexTxs := make([]*index.Tx, len(blkTxs))
+	for i, tx := range blkTxs {
+		indexTxs[i], err = s.indexTx(tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return indexTxs, nil
+}
+
+func (s *txIndexSource) getDAGTx(i uint64) ([]*index.Tx, error) {
+	txID, err := s.state.GetDAGTxID(i)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.state.GetTx(txID)
+	if err != nil {
+		return nil, err
+	}
+	indexTx, err := s.indexTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return []*index.Tx{indexTx}, nil
+}
+
+func (s *txIndexSource) indexTx(tx *txs.Tx) (*index.Tx, error) {
+	inputs, err := s.inputs(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &index.Tx{
+		ID:       tx.ID(),
+		Unsigned: tx.Unsigned,
+		Inputs:   inputs,
+		Outputs:  tx.UTXOs(),
+	}, nil
+}
+
+// inputs returns the UTXOs consumed by [tx] that were produced on the
+// X-chain. The UTXOs are fetched from the txs that produced them, so they
+// can be found after they have been consumed.
+func (s *txIndexSource) inputs(tx *txs.Tx) ([]*avax.UTXO, error) {
+	utxoIDs := tx.Unsigned.InputUTXOs()
+	utxos := make([]*avax.UTXO, 0, len(utxoIDs))
+	for _, utxoID := range utxoIDs {
+		// Symbolic UTXOs don't exist
+		if utxoID.Symbolic() {
+			continue
+		}
+
+		producingTx, err := s.state.GetTx(utxoID.TxID)
+		if err == database.ErrNotFound {
+			// The UTXO was imported from another chain
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, utxo := range producingTx.UTXOs() {
+			if utxo.OutputIndex == utxoID.OutputIndex {
+				utxos = append(utxos, utxo)
+				break
+			}
+		}
+	}
+	return utxos, nil
+}
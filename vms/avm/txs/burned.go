@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var _ Visitor = (*burnedVisitor)(nil)
+
+// Burned returns the amount of [assetID] that [tx] consumes without producing,
+// which for the fee asset is the fee that [tx] pays.
+//
+// Assets consumed by operations are not included.
+func Burned(tx UnsignedTx, assetID ids.ID) (uint64, error) {
+	v := &burnedVisitor{}
+	if err := tx.Visit(v); err != nil {
+		return 0, err
+	}
+	return avax.Burned(assetID, v.ins, v.outs)
+}
+
+type burnedVisitor struct {
+	ins  [][]*avax.TransferableInput
+	outs [][]*avax.TransferableOutput
+}
+
+func (v *burnedVisitor) BaseTx(tx *BaseTx) error {
+	v.ins = append(v.ins, tx.Ins)
+	v.outs = append(v.outs, tx.Outs)
+	return nil
+}
+
+func (v *burnedVisitor) CreateAssetTx(tx *CreateAssetTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) OperationTx(tx *OperationTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) ImportTx(tx *ImportTx) error {
+	v.ins = append(v.ins, tx.ImportedIns)
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) ExportTx(tx *ExportTx) error {
+	v.outs = append(v.outs, tx.ExportedOuts)
+	return v.BaseTx(&tx.BaseTx)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/gorilla/rpc/v2"
@@ -109,6 +110,10 @@ type VM struct {
 	walletService WalletService
 
 	addressTxsIndexer index.AddressTxsIndexer
+	// txIndexer is created when the chain is linearized, once the number of
+	// transactions accepted before linearization is known.
+	txIndexer  index.TxIndexer
+	indexNames []string
 
 	txBackend *txexecutor.Backend
 
@@ -122,6 +127,7 @@ type VM struct {
 	// These values are only initialized after the chain has been linearized.
 	blockbuilder.Builder
 	chainManager blockexecutor.Manager
+	mempool      xmempool.Mempool
 	network      *network.Network
 }
 
@@ -248,12 +254,19 @@ func (vm *VM) Initialize(
 	vm.walletService.pendingTxs = linked.NewHashmap[ids.ID, *txs.Tx]()
 
 	// use no op impl when disabled in config
+	vm.indexNames = slices.Clone(avmConfig.Indexes)
 	if avmConfig.IndexTransactions {
 		vm.ctx.Log.Warn("deprecated address transaction indexing is enabled")
 		vm.addressTxsIndexer, err = index.NewIndexer(vm.db, vm.ctx.Log, "", vm.registerer, avmConfig.IndexAllowIncomplete)
 		if err != nil {
 			return fmt.Errorf("failed to initialize address transaction indexer: %w", err)
 		}
+
+		// getAddressTxs switches to the owner-asset index once it has
+		// indexed every accepted transaction.
+		if !slices.Contains(vm.indexNames, index.OwnerAssetIndexName) {
+			vm.indexNames = append(vm.indexNames, index.OwnerAssetIndexName)
+		}
 	} else {
 		vm.ctx.Log.Info("address transaction indexing is disabled")
 		vm.addressTxsIndexer, err = index.NewNoIndexer(vm.db, avmConfig.IndexAllowIncomplete)
@@ -405,6 +418,12 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
+	vm.mempool = mempool
+
+	vm.txIndexer, err = vm.newTxIndexer(vm.indexNames)
+	if err != nil {
+		return fmt.Errorf("failed to initialize transaction indexer: %w", err)
+	}
 
 	vm.chainManager = blockexecutor.NewManager(
 		mempool,
@@ -413,6 +432,7 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 		vm.txBackend,
 		&vm.clock,
 		vm.onAccept,
+		vm.txIndexer,
 	)
 
 	vm.Builder = blockbuilder.New(
@@ -469,6 +489,16 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 		vm.network.PullGossip(vm.onShutdownCtx)
 	}()
 
+	// Incrementing [awaitShutdown] would cause a deadlock since Backfill grabs
+	// the context lock.
+	go func() {
+		if err := vm.txIndexer.Backfill(vm.onShutdownCtx); err != nil {
+			vm.ctx.Log.Warn("backfilling transaction indexes failed",
+				zap.Error(err),
+			)
+		}
+	}()
+
 	return nil
 }
 
@@ -578,6 +608,7 @@ func (vm *VM) initState(tx *txs.Tx) {
 		zap.Stringer("txID", txID),
 	)
 	vm.state.AddTx(tx)
+	vm.state.AddDAGTx(txID)
 	for _, utxo := range tx.UTXOs() {
 		vm.state.AddUTXO(utxo)
 	}
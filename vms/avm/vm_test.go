@@ -518,6 +518,7 @@ func TestIssueImportTx(t *testing.T) {
 
 	assertIndexedTX(t, env.vm.db, 0, key.PublicKey().Address(), txAssetID.AssetID(), tx.ID())
 	assertLatestIdx(t, env.vm.db, key.PublicKey().Address(), avaxID, 1)
+	assertIndexedTxs(t, env, key.PublicKey().Address(), avaxID, []ids.ID{avaxID, tx.ID()})
 
 	id := utxoID.InputID()
 	_, err = env.vm.ctx.SharedMemory.Get(constants.PlatformChainID, [][]byte{id[:]})
@@ -586,6 +587,17 @@ func TestForceAcceptImportTx(t *testing.T) {
 	assertIndexedTX(t, env.vm.db, 0, key.PublicKey().Address(), txAssetID.AssetID(), tx.ID())
 	assertLatestIdx(t, env.vm.db, key.PublicKey().Address(), avaxID, 1)
 
+	// Txs accepted before the chain was linearized are indexed once it is
+	// linearized.
+	require.NoError(env.vm.Linearize(context.Background(), ids.GenerateTestID(), env.issuer))
+	t.Cleanup(func() {
+		env.vm.ctx.Lock.Lock()
+		defer env.vm.ctx.Lock.Unlock()
+
+		require.NoError(env.vm.Shutdown(context.Background()))
+	})
+	assertIndexedTxs(t, env, key.PublicKey().Address(), avaxID, []ids.ID{avaxID, tx.ID()})
+
 	id := utxoID.InputID()
 	_, err = env.vm.ctx.SharedMemory.Get(constants.PlatformChainID, [][]byte{id[:]})
 	require.ErrorIs(err, database.ErrNotFound)
@@ -714,4 +726,5 @@ func TestClearForceAcceptedExportTx(t *testing.T) {
 
 	assertIndexedTX(t, env.vm.db, 0, key.PublicKey().Address(), assetID.AssetID(), tx.ID())
 	assertLatestIdx(t, env.vm.db, key.PublicKey().Address(), assetID.AssetID(), 1)
+	assertIndexedTxs(t, env, key.PublicKey().Address(), assetID.AssetID(), []ids.ID{avaxID, tx.ID()})
 }
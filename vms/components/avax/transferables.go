@@ -14,6 +14,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 var (
@@ -199,6 +201,45 @@ func SortTransferableInputsWithSigners(ins []*TransferableInput, signers [][]*se
 	sort.Sort(&innerSortTransferableInputsWithSigners{ins: ins, signers: signers})
 }
 
+// Burned returns the amount of [assetID] that is consumed by the inputs but
+// isn't produced by the outputs.
+func Burned(
+	assetID ids.ID,
+	allIns [][]*TransferableInput,
+	allOuts [][]*TransferableOutput,
+) (uint64, error) {
+	var consumed uint64
+	for _, ins := range allIns {
+		for _, in := range ins {
+			if in.AssetID() != assetID {
+				continue
+			}
+
+			var err error
+			consumed, err = safemath.Add(consumed, in.Input().Amount())
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var produced uint64
+	for _, outs := range allOuts {
+		for _, out := range outs {
+			if out.AssetID() != assetID {
+				continue
+			}
+
+			var err error
+			produced, err = safemath.Add(produced, out.Output().Amount())
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	return safemath.Sub(consumed, produced)
+}
+
 // VerifyTx verifies that the inputs and outputs flowcheck, including a fee.
 // Additionally, this verifies that the inputs and outputs are sorted.
 func VerifyTx(
@@ -4,6 +4,7 @@
 package avax
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,6 +14,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 func TestTransferableOutputVerifyNil(t *testing.T) {
@@ -288,3 +291,86 @@ func TestTransferableInputSerialization(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expected, inBytes)
 }
+
+func TestBurned(t *testing.T) {
+	var (
+		assetID      = ids.GenerateTestID()
+		otherAssetID = ids.GenerateTestID()
+	)
+	newIn := func(assetID ids.ID, amount uint64) *TransferableInput {
+		return &TransferableInput{
+			Asset: Asset{ID: assetID},
+			In:    &TestTransferable{Val: amount},
+		}
+	}
+	newOut := func(assetID ids.ID, amount uint64) *TransferableOutput {
+		return &TransferableOutput{
+			Asset: Asset{ID: assetID},
+			Out:   &TestTransferable{Val: amount},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		allIns         [][]*TransferableInput
+		allOuts        [][]*TransferableOutput
+		expectedBurned uint64
+		expectedErr    error
+	}{
+		{
+			name: "no burn",
+			allIns: [][]*TransferableInput{
+				{newIn(assetID, 5)},
+			},
+			allOuts: [][]*TransferableOutput{
+				{newOut(assetID, 5)},
+			},
+			expectedBurned: 0,
+		},
+		{
+			name: "burn across groups",
+			allIns: [][]*TransferableInput{
+				{newIn(assetID, 5)},
+				{newIn(assetID, 3), newIn(otherAssetID, 7)},
+			},
+			allOuts: [][]*TransferableOutput{
+				{newOut(assetID, 2)},
+				{newOut(assetID, 1), newOut(otherAssetID, 7)},
+			},
+			expectedBurned: 5,
+		},
+		{
+			name: "only other asset burned",
+			allIns: [][]*TransferableInput{
+				{newIn(otherAssetID, 7)},
+			},
+			expectedBurned: 0,
+		},
+		{
+			name: "produced more than consumed",
+			allIns: [][]*TransferableInput{
+				{newIn(assetID, 1)},
+			},
+			allOuts: [][]*TransferableOutput{
+				{newOut(assetID, 2)},
+			},
+			expectedErr: safemath.ErrUnderflow,
+		},
+		{
+			name: "consumed overflow",
+			allIns: [][]*TransferableInput{
+				{newIn(assetID, math.MaxUint64), newIn(assetID, 1)},
+			},
+			expectedErr: safemath.ErrOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			burned, err := Burned(assetID, test.allIns, test.allOuts)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedBurned, burned)
+		})
+	}
+}
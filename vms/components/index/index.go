@@ -134,38 +134,17 @@ func (i *indexer) Accept(txID ids.ID, inputUTXOs []*avax.UTXO, outputUTXOs []*av
 		for assetID := range assetIDs {
 			assetPrefixDB := prefixdb.New(assetID[:], addressPrefixDB)
 
-			var idx uint64
-			idxBytes, err := assetPrefixDB.Get(idxKey)
-			switch err {
-			case nil:
-				// index is found, parse stored [idxBytes]
-				idx = binary.BigEndian.Uint64(idxBytes)
-			case database.ErrNotFound:
-				// idx not found; this must be the first entry.
-				idxBytes = make([]byte, wrappers.LongLen)
-			default:
-				// Unexpected error
-				return fmt.Errorf("unexpected error when indexing txID %s: %w", txID, err)
+			idx, err := appendTx(assetPrefixDB, txID)
+			if err != nil {
+				return err
 			}
 
-			// write the [txID] at the index
-			i.log.Verbo("writing indexed tx to DB",
+			i.log.Verbo("wrote indexed tx to DB",
 				zap.String("address", address),
 				zap.Stringer("assetID", assetID),
 				zap.Uint64("index", idx),
 				zap.Stringer("txID", txID),
 			)
-			if err := assetPrefixDB.Put(idxBytes, txID[:]); err != nil {
-				return fmt.Errorf("failed to write txID while indexing %s: %w", txID, err)
-			}
-
-			// increment and store the index for next use
-			idx++
-			binary.BigEndian.PutUint64(idxBytes, idx)
-
-			if err := assetPrefixDB.Put(idxKey, idxBytes); err != nil {
-				return fmt.Errorf("failed to write index txID while indexing %s: %w", txID, err)
-			}
 		}
 	}
 	i.metrics.numTxsIndexed.Inc()
@@ -182,12 +161,52 @@ func (i *indexer) Read(address []byte, assetID ids.ID, cursor, pageSize uint64)
 	addressTxDB := prefixdb.New(address, i.db)
 	assetPrefixDB := prefixdb.New(assetID[:], addressTxDB)
 
+	return readTxs(assetPrefixDB, cursor, pageSize)
+}
+
+// appendTx writes [txID] at the next index of [db] and returns the index it
+// was written at.
+// The database structure is:
+// "idx" => 2 		Running transaction index key, represents the next index
+// "0"   => txID1
+// "1"   => txID2
+func appendTx(db database.KeyValueReaderWriter, txID ids.ID) (uint64, error) {
+	var idx uint64
+	idxBytes, err := db.Get(idxKey)
+	switch err {
+	case nil:
+		// index is found, parse stored [idxBytes]
+		idx = binary.BigEndian.Uint64(idxBytes)
+	case database.ErrNotFound:
+		// idx not found; this must be the first entry.
+		idxBytes = make([]byte, wrappers.LongLen)
+	default:
+		// Unexpected error
+		return 0, fmt.Errorf("unexpected error when indexing txID %s: %w", txID, err)
+	}
+
+	// write the [txID] at the index
+	if err := db.Put(idxBytes, txID[:]); err != nil {
+		return 0, fmt.Errorf("failed to write txID while indexing %s: %w", txID, err)
+	}
+
+	// increment and store the index for next use
+	binary.BigEndian.PutUint64(idxBytes, idx+1)
+	if err := db.Put(idxKey, idxBytes); err != nil {
+		return 0, fmt.Errorf("failed to write index txID while indexing %s: %w", txID, err)
+	}
+	return idx, nil
+}
+
+// readTxs returns at most [pageSize] transaction IDs written by [appendTx]
+// into [db], starting at [cursor].
+func readTxs(db database.Iteratee, cursor, pageSize uint64) ([]ids.ID, error) {
 	// get cursor in bytes
 	cursorBytes := make([]byte, wrappers.LongLen)
 	binary.BigEndian.PutUint64(cursorBytes, cursor)
 
-	// start reading from the cursor bytes, numeric keys maintain the order (see Accept)
-	iter := assetPrefixDB.NewIteratorWithStart(cursorBytes)
+	// start reading from the cursor bytes, numeric keys maintain the order (see appendTx)
+	iter := db.NewIteratorWithStart(cursorBytes)
 	defer iter.Release()
 
 	var txIDs []ids.ID
@@ -206,7 +225,7 @@ func (i *indexer) Read(address []byte, assetID ids.ID, cursor, pageSize uint64)
 
 		txIDs = append(txIDs, txID)
 	}
-	return txIDs, nil
+	return txIDs, iter.Error()
 }
 
 // checkIndexStatus checks the indexing status in the database, returning error if the state
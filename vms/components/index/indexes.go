@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+const (
+	OwnerIndexName      = "owner"
+	AssetIndexName      = "asset"
+	OwnerAssetIndexName = "owner-asset"
+
+	// ownerAssetKeySeparator separates the address from the assetID in the
+	// keys of the owner-asset index.
+	ownerAssetKeySeparator = ":"
+)
+
+var (
+	_ Index = (*ownerIndex)(nil)
+	_ Index = (*assetIndex)(nil)
+	_ Index = (*ownerAssetIndex)(nil)
+
+	errInvalidKey = errors.New("invalid key")
+)
+
+type ownerIndex struct {
+	parseAddress func(string) (ids.ShortID, error)
+}
+
+// NewOwnerIndex returns an Index from an address to the transactions that
+// consumed or produced UTXOs owned by the address. UTXOs whose outputs are not
+// [avax.Addressable] are ignored.
+func NewOwnerIndex(parseAddress func(string) (ids.ShortID, error)) Index {
+	return &ownerIndex{
+		parseAddress: parseAddress,
+	}
+}
+
+func (i *ownerIndex) ParseKey(key string) ([]byte, error) {
+	addr, err := i.parseAddress(key)
+	return addr[:], err
+}
+
+func (*ownerIndex) Keys(tx *Tx) ([][]byte, error) {
+	var keys [][]byte
+	for _, utxos := range [][]*avax.UTXO{tx.Inputs, tx.Outputs} {
+		for _, utxo := range utxos {
+			out, ok := utxo.Out.(avax.Addressable)
+			if !ok {
+				continue
+			}
+			keys = append(keys, out.Addresses()...)
+		}
+	}
+	return keys, nil
+}
+
+type assetIndex struct {
+	parseAssetID func(string) (ids.ID, error)
+}
+
+// NewAssetIndex returns an Index from an assetID to the transactions that
+// consumed or produced UTXOs of the asset.
+func NewAssetIndex(parseAssetID func(string) (ids.ID, error)) Index {
+	return &assetIndex{
+		parseAssetID: parseAssetID,
+	}
+}
+
+func (i *assetIndex) ParseKey(key string) ([]byte, error) {
+	assetID, err := i.parseAssetID(key)
+	return assetID[:], err
+}
+
+func (*assetIndex) Keys(tx *Tx) ([][]byte, error) {
+	var keys [][]byte
+	for _, utxos := range [][]*avax.UTXO{tx.Inputs, tx.Outputs} {
+		for _, utxo := range utxos {
+			assetID := utxo.AssetID()
+			keys = append(keys, assetID[:])
+		}
+	}
+	return keys, nil
+}
+
+type ownerAssetIndex struct {
+	parseAddress func(string) (ids.ShortID, error)
+	parseAssetID func(string) (ids.ID, error)
+}
+
+// NewOwnerAssetIndex returns an Index from an address and an assetID to the
+// transactions that consumed or produced UTXOs of the asset owned by the
+// address. Keys are of the form "address:assetID". UTXOs whose outputs are not
+// [avax.Addressable] are ignored.
+func NewOwnerAssetIndex(
+	parseAddress func(string) (ids.ShortID, error),
+	parseAssetID func(string) (ids.ID, error),
+) Index {
+	return &ownerAssetIndex{
+		parseAddress: parseAddress,
+		parseAssetID: parseAssetID,
+	}
+}
+
+// OwnerAssetKey returns the key of the owner-asset index for [addr] and
+// [assetID].
+func OwnerAssetKey(addr string, assetID string) string {
+	return addr + ownerAssetKeySeparator + assetID
+}
+
+func (i *ownerAssetIndex) ParseKey(key string) ([]byte, error) {
+	addrStr, assetIDStr, ok := strings.Cut(key, ownerAssetKeySeparator)
+	if !ok {
+		return nil, errInvalidKey
+	}
+	addr, err := i.parseAddress(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	assetID, err := i.parseAssetID(assetIDStr)
+	if err != nil {
+		return nil, err
+	}
+	return ownerAssetKey(addr[:], assetID), nil
+}
+
+func (*ownerAssetIndex) Keys(tx *Tx) ([][]byte, error) {
+	var keys [][]byte
+	for _, utxos := range [][]*avax.UTXO{tx.Inputs, tx.Outputs} {
+		for _, utxo := range utxos {
+			out, ok := utxo.Out.(avax.Addressable)
+			if !ok {
+				continue
+			}
+			assetID := utxo.AssetID()
+			for _, addr := range out.Addresses() {
+				keys = append(keys, ownerAssetKey(addr, assetID))
+			}
+		}
+	}
+	return keys, nil
+}
+
+func ownerAssetKey(addr []byte, assetID ids.ID) []byte {
+	key := make([]byte, 0, len(addr)+ids.IDLen)
+	key = append(key, addr...)
+	return append(key, assetID[:]...)
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestOwnerAssetIndex(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr  = ids.GenerateTestShortID()
+		tx    = newTestTx(addr)
+		index = NewOwnerAssetIndex(ids.ShortFromString, ids.FromString)
+	)
+
+	assetID := tx.Outputs[0].AssetID()
+	key, err := index.ParseKey(OwnerAssetKey(addr.String(), assetID.String()))
+	require.NoError(err)
+
+	keys, err := index.Keys(tx)
+	require.NoError(err)
+	require.Equal([][]byte{key, key}, keys)
+
+	_, err = index.ParseKey(addr.String())
+	require.ErrorIs(err, errInvalidKey)
+}
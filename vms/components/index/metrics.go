@@ -3,7 +3,11 @@
 
 package index
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 type metrics struct {
 	numTxsIndexed prometheus.Counter
@@ -17,3 +21,29 @@ func (m *metrics) initialize(namespace string, registerer prometheus.Registerer)
 	})
 	return registerer.Register(m.numTxsIndexed)
 }
+
+type txIndexerMetrics struct {
+	nextHeight    *prometheus.GaugeVec
+	numTxsIndexed *prometheus.CounterVec
+}
+
+func (m *txIndexerMetrics) initialize(registerer prometheus.Registerer) error {
+	m.nextHeight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tx_index_next_height",
+			Help: "Height of the next block to be indexed",
+		},
+		[]string{"index"},
+	)
+	m.numTxsIndexed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_index_txs_indexed",
+			Help: "Number of transactions indexed",
+		},
+		[]string{"index"},
+	)
+	return errors.Join(
+		registerer.Register(m.nextHeight),
+		registerer.Register(m.numTxsIndexed),
+	)
+}
@@ -0,0 +1,331 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// backfillBatchSize is the maximum number of heights that are indexed while
+// holding the lock during a backfill.
+const backfillBatchSize = 256
+
+var (
+	ErrUnknownIndex = errors.New("unknown index")
+
+	metadataPrefix = []byte("metadata")
+	txsPrefix      = []byte("txs")
+	nextHeightKey  = []byte("next height")
+
+	_ TxIndexer = (*txIndexer)(nil)
+	_ TxIndexer = (*noTxIndexer)(nil)
+)
+
+// Tx is an accepted transaction that is being indexed.
+type Tx struct {
+	ID ids.ID
+	// Unsigned is the VM specific unsigned transaction.
+	Unsigned interface{}
+	// Inputs are the UTXOs consumed by the transaction. UTXOs that could not
+	// be found, such as imported UTXOs, are not included.
+	Inputs []*avax.UTXO
+	// Outputs are the UTXOs produced by the transaction.
+	Outputs []*avax.UTXO
+}
+
+// Index defines a secondary index of accepted transactions.
+type Index interface {
+	// ParseKey converts the user provided [key] into the key that
+	// transactions are indexed under.
+	ParseKey(key string) ([]byte, error)
+
+	// Keys returns the keys that [tx] should be indexed under.
+	Keys(tx *Tx) ([][]byte, error)
+}
+
+// Source provides the transactions accepted by a chain.
+type Source interface {
+	// LastAcceptedHeight returns the height of the last accepted block.
+	LastAcceptedHeight() (uint64, error)
+
+	// GetTxs returns the transactions in the accepted block at [height], in
+	// the order they were accepted.
+	GetTxs(height uint64) ([]*Tx, error)
+}
+
+// TxIndexer maintains a set of secondary indexes over the transactions
+// accepted by a chain. Every index tracks the next height it needs to index,
+// so indexes that are enabled after the chain has accepted blocks are
+// backfilled from genesis.
+type TxIndexer interface {
+	// Accept indexes the transactions of the block at [height] into every
+	// index that is up to date. Indexes that are still being backfilled will
+	// index the block during the backfill.
+	//
+	// Must be called while holding the lock after the block at [height] has
+	// been committed.
+	Accept(height uint64) error
+
+	// Backfill indexes all accepted blocks that have not been indexed yet.
+	// The lock is grabbed periodically, so this should be called without
+	// holding the lock. Returns once every index is up to date or [ctx] is
+	// cancelled.
+	Backfill(ctx context.Context) error
+
+	// Read returns the IDs of transactions indexed under [key] by the index
+	// named [index], in order of acceptance. [cursor] is the offset to start
+	// reading from. The length of the returned slice is <= [pageSize].
+	Read(index string, key string, cursor, pageSize uint64) ([]ids.ID, error)
+
+	// Synced returns true if the index named [index] has indexed every
+	// accepted block.
+	Synced(index string) (bool, error)
+}
+
+type txIndex struct {
+	name       string
+	index      Index
+	db         database.Database
+	nextHeight uint64
+}
+
+type txIndexer struct {
+	log     logging.Logger
+	lock    sync.Locker
+	source  Source
+	metrics txIndexerMetrics
+	indexes map[string]*txIndex
+}
+
+// NewTxIndexer returns a TxIndexer that maintains [indexes] in [db].
+//
+// [lock] must be held whenever the state of [source] is modified.
+func NewTxIndexer(
+	db database.Database,
+	log logging.Logger,
+	lock sync.Locker,
+	source Source,
+	metricsRegisterer prometheus.Registerer,
+	indexes map[string]Index,
+) (TxIndexer, error) {
+	i := &txIndexer{
+		log:     log,
+		lock:    lock,
+		source:  source,
+		indexes: make(map[string]*txIndex, len(indexes)),
+	}
+	if err := i.metrics.initialize(metricsRegisterer); err != nil {
+		return nil, err
+	}
+
+	for name, index := range indexes {
+		indexDB := prefixdb.New([]byte(name), db)
+		metadataDB := prefixdb.NewNested(metadataPrefix, indexDB)
+		nextHeight, err := database.GetUInt64(metadataDB, nextHeightKey)
+		if err == database.ErrNotFound {
+			// This index has never been run, so it must start from genesis.
+			nextHeight, err = 0, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the next height of the %q index: %w", name, err)
+		}
+
+		i.indexes[name] = &txIndex{
+			name:       name,
+			index:      index,
+			db:         indexDB,
+			nextHeight: nextHeight,
+		}
+		i.metrics.nextHeight.WithLabelValues(name).Set(float64(nextHeight))
+	}
+	return i, nil
+}
+
+func (i *txIndexer) Accept(height uint64) error {
+	return i.indexHeight(height)
+}
+
+func (i *txIndexer) Backfill(ctx context.Context) error {
+	for {
+		done, err := i.backfillBatch(ctx)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// backfillBatch indexes up to [backfillBatchSize] heights while holding the
+// lock. Returns true once all the indexes are up to date.
+func (i *txIndexer) backfillBatch(ctx context.Context) (bool, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	// The chain may have been shutdown while waiting for the lock.
+	if ctx.Err() != nil {
+		return true, nil
+	}
+
+	if len(i.indexes) == 0 {
+		return true, nil
+	}
+
+	lastAcceptedHeight, err := i.source.LastAcceptedHeight()
+	if err != nil {
+		return false, err
+	}
+
+	startHeight := uint64(math.MaxUint64)
+	for _, index := range i.indexes {
+		startHeight = min(startHeight, index.nextHeight)
+	}
+	if startHeight > lastAcceptedHeight {
+		return true, nil
+	}
+
+	endHeight := min(lastAcceptedHeight, startHeight+backfillBatchSize-1)
+	for height := startHeight; height <= endHeight; height++ {
+		if err := i.indexHeight(height); err != nil {
+			return false, err
+		}
+	}
+
+	i.log.Info("backfilled transaction indexes",
+		zap.Uint64("height", endHeight),
+		zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
+	)
+	return endHeight == lastAcceptedHeight, nil
+}
+
+// indexHeight indexes the transactions of the block at [height] into every
+// index whose next height is [height].
+func (i *txIndexer) indexHeight(height uint64) error {
+	var (
+		txs     []*Tx
+		fetched bool
+	)
+	for _, index := range i.indexes {
+		if index.nextHeight != height {
+			continue
+		}
+
+		if !fetched {
+			var err error
+			txs, err = i.source.GetTxs(height)
+			if err != nil {
+				return fmt.Errorf("failed to get txs at height %d: %w", height, err)
+			}
+			fetched = true
+		}
+
+		if err := i.indexTxs(index, height, txs); err != nil {
+			return fmt.Errorf("failed to index height %d into the %q index: %w", height, index.name, err)
+		}
+	}
+	return nil
+}
+
+// indexTxs atomically writes [txs] and the next height into [index].
+func (i *txIndexer) indexTxs(index *txIndex, height uint64, txs []*Tx) error {
+	var (
+		vdb        = versiondb.New(index.db)
+		metadataDB = prefixdb.New(metadataPrefix, vdb)
+		txsDB      = prefixdb.New(txsPrefix, vdb)
+	)
+	for _, tx := range txs {
+		keys, err := index.index.Keys(tx)
+		if err != nil {
+			return err
+		}
+
+		indexedKeys := set.NewSet[string](len(keys))
+		for _, key := range keys {
+			if indexedKeys.Contains(string(key)) {
+				continue
+			}
+			indexedKeys.Add(string(key))
+
+			if _, err := appendTx(prefixdb.New(key, txsDB), tx.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	nextHeight := height + 1
+	if err := database.PutUInt64(metadataDB, nextHeightKey, nextHeight); err != nil {
+		return err
+	}
+	if err := vdb.Commit(); err != nil {
+		return err
+	}
+
+	index.nextHeight = nextHeight
+	i.metrics.nextHeight.WithLabelValues(index.name).Set(float64(nextHeight))
+	i.metrics.numTxsIndexed.WithLabelValues(index.name).Add(float64(len(txs)))
+	return nil
+}
+
+func (i *txIndexer) Read(name string, key string, cursor, pageSize uint64) ([]ids.ID, error) {
+	index, ok := i.indexes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownIndex, name)
+	}
+
+	keyBytes, err := index.index.ParseKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse key: %w", err)
+	}
+
+	// The prefixes must not be compressed so that the keys match the keys
+	// written through the versiondb in indexTxs.
+	txsDB := prefixdb.NewNested(txsPrefix, index.db)
+	return readTxs(prefixdb.New(keyBytes, txsDB), cursor, pageSize)
+}
+
+func (i *txIndexer) Synced(name string) (bool, error) {
+	index, ok := i.indexes[name]
+	if !ok {
+		return false, fmt.Errorf("%w: %q", ErrUnknownIndex, name)
+	}
+
+	lastAcceptedHeight, err := i.source.LastAcceptedHeight()
+	return index.nextHeight > lastAcceptedHeight, err
+}
+
+type noTxIndexer struct{}
+
+// NewNoTxIndexer returns a TxIndexer that doesn't maintain any indexes.
+func NewNoTxIndexer() TxIndexer {
+	return noTxIndexer{}
+}
+
+func (noTxIndexer) Accept(uint64) error {
+	return nil
+}
+
+func (noTxIndexer) Backfill(context.Context) error {
+	return nil
+}
+
+func (noTxIndexer) Read(name string, _ string, _, _ uint64) ([]ids.ID, error) {
+	return nil, fmt.Errorf("%w: %q", ErrUnknownIndex, name)
+}
+
+func (noTxIndexer) Synced(name string) (bool, error) {
+	return false, fmt.Errorf("%w: %q", ErrUnknownIndex, name)
+}
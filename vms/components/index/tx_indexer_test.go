@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+type testSource struct {
+	blocks [][]*Tx
+}
+
+func (s *testSource) LastAcceptedHeight() (uint64, error) {
+	return uint64(len(s.blocks) - 1), nil
+}
+
+func (s *testSource) GetTxs(height uint64) ([]*Tx, error) {
+	return s.blocks[height], nil
+}
+
+func newTestTx(addr ids.ShortID) *Tx {
+	return &Tx{
+		ID: ids.GenerateTestID(),
+		Outputs: []*avax.UTXO{{
+			Asset: avax.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				OutputOwners: secp256k1fx.OutputOwners{
+					Addrs: []ids.ShortID{addr, addr},
+				},
+			},
+		}},
+	}
+}
+
+func newTestTxIndexer(t *testing.T, db *memdb.Database, source Source) TxIndexer {
+	indexer, err := NewTxIndexer(
+		db,
+		logging.NoLog{},
+		&sync.Mutex{},
+		source,
+		prometheus.NewRegistry(),
+		map[string]Index{
+			OwnerIndexName: NewOwnerIndex(ids.ShortFromString),
+		},
+	)
+	require.NoError(t, err)
+	return indexer
+}
+
+func TestTxIndexer(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr   = ids.GenerateTestShortID()
+		tx0    = newTestTx(addr)
+		tx1    = newTestTx(addr)
+		tx2    = newTestTx(ids.GenerateTestShortID())
+		tx3    = newTestTx(addr)
+		source = &testSource{
+			blocks: [][]*Tx{
+				{tx0},
+				{tx1, tx2},
+			},
+		}
+		db = memdb.New()
+	)
+
+	indexer := newTestTxIndexer(t, db, source)
+
+	synced, err := indexer.Synced(OwnerIndexName)
+	require.NoError(err)
+	require.False(synced)
+
+	// Blocks accepted before the index was enabled are indexed by the
+	// backfill.
+	require.NoError(indexer.Backfill(context.Background()))
+
+	synced, err = indexer.Synced(OwnerIndexName)
+	require.NoError(err)
+	require.True(synced)
+
+	txIDs, err := indexer.Read(OwnerIndexName, addr.String(), 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{tx0.ID, tx1.ID}, txIDs)
+
+	source.blocks = append(source.blocks, []*Tx{tx3})
+	require.NoError(indexer.Accept(2))
+
+	txIDs, err = indexer.Read(OwnerIndexName, addr.String(), 1, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{tx1.ID, tx3.ID}, txIDs)
+
+	// Re-indexing an already indexed height is a no-op.
+	require.NoError(indexer.Accept(2))
+
+	// The progress of the index is persisted.
+	indexer = newTestTxIndexer(t, db, source)
+
+	synced, err = indexer.Synced(OwnerIndexName)
+	require.NoError(err)
+	require.True(synced)
+
+	txIDs, err = indexer.Read(OwnerIndexName, addr.String(), 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{tx0.ID, tx1.ID, tx3.ID}, txIDs)
+}
+
+func TestTxIndexerUnknownIndex(t *testing.T) {
+	require := require.New(t)
+
+	indexer := newTestTxIndexer(t, memdb.New(), &testSource{})
+
+	_, err := indexer.Read("unknown", "", 0, 10)
+	require.ErrorIs(err, ErrUnknownIndex)
+
+	_, err = indexer.Synced("unknown")
+	require.ErrorIs(err, ErrUnknownIndex)
+
+	_, err = NewNoTxIndexer().Read(OwnerIndexName, "", 0, 10)
+	require.ErrorIs(err, ErrUnknownIndex)
+}
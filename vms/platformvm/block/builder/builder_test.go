@@ -16,8 +16,10 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
@@ -74,6 +76,149 @@ func TestBuildBlockBasic(t *testing.T) {
 	require.NoError(env.mempool.GetDropReason(txID))
 }
 
+func TestBuildBlockOrdersTxsByFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, latestFork)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	// Create a transaction that doesn't pay a fee, as the create blockchain tx
+	// fee is the create asset tx fee prior to ApricotPhase3
+	createChainTxBuilder, createChainTxSigner := env.factory.NewWallet(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1])
+	createChainUTx, err := createChainTxBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+	)
+	require.NoError(err)
+	lowFeeTx, err := walletsigner.SignUnsigned(context.Background(), createChainTxSigner, createChainUTx)
+	require.NoError(err)
+
+	// Create a transaction paying the base tx fee
+	baseTxBuilder, baseTxSigner := env.factory.NewWallet(preFundedKeys[3])
+	baseUTx, err := baseTxBuilder.NewBaseTx([]*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}})
+	require.NoError(err)
+	highFeeTx, err := walletsigner.SignUnsigned(context.Background(), baseTxSigner, baseUTx)
+	require.NoError(err)
+
+	// Issue the lower fee transaction first
+	env.ctx.Lock.Unlock()
+	require.NoError(env.network.IssueTxFromRPC(lowFeeTx))
+	require.NoError(env.network.IssueTxFromRPC(highFeeTx))
+	env.ctx.Lock.Lock()
+
+	// [BuildBlock] should order the transactions by their fee rate
+	blkIntf, err := env.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+
+	require.IsType(&blockexecutor.Block{}, blkIntf)
+	blk := blkIntf.(*blockexecutor.Block)
+	blkTxs := blk.Txs()
+	require.Len(blkTxs, 2)
+	require.Equal(highFeeTx.ID(), blkTxs[0].ID())
+	require.Equal(lowFeeTx.ID(), blkTxs[1].ID())
+}
+
+func TestBuildBlockIncludesParentBeforeChild(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, latestFork)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		amount   = 10 * units.MilliAvax
+		childKey = preFundedKeys[1]
+		owner    = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{childKey.Address()},
+		}
+	)
+
+	// Create a parent transaction paying the base tx fee
+	parentTxBuilder, parentTxSigner := env.factory.NewWallet(preFundedKeys[0])
+	parentUTx, err := parentTxBuilder.NewBaseTx([]*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          amount,
+			OutputOwners: owner,
+		},
+	}})
+	require.NoError(err)
+	parentTx, err := walletsigner.SignUnsigned(context.Background(), parentTxSigner, parentUTx)
+	require.NoError(err)
+
+	var parentUTXO *avax.UTXO
+	for _, utxo := range parentTx.UTXOs() {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if ok && out.Amt == amount && out.OutputOwners.Equals(&owner) {
+			parentUTXO = utxo
+		}
+	}
+	require.NotNil(parentUTXO)
+
+	// Create a child transaction that spends the parent's output and pays a
+	// much higher fee
+	childTx, err := txs.NewSigned(
+		&txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: parentUTXO.UTXOID,
+				Asset:  parentUTXO.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt: amount,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          amount - units.MilliAvax,
+					OutputOwners: owner,
+				},
+			}},
+		}},
+		txs.Codec,
+		[][]*secp256k1.PrivateKey{{childKey}},
+	)
+	require.NoError(err)
+
+	// The child can't be verified against the current state, so it is added
+	// to the mempool directly.
+	require.NoError(env.mempool.Add(childTx))
+	require.NoError(env.mempool.Add(parentTx))
+
+	// [BuildBlock] should include the parent before the child
+	blkIntf, err := env.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+
+	require.IsType(&blockexecutor.Block{}, blkIntf)
+	blk := blkIntf.(*blockexecutor.Block)
+	blkTxs := blk.Txs()
+	require.Len(blkTxs, 2)
+	require.Equal(parentTx.ID(), blkTxs[0].ID())
+	require.Equal(childTx.ID(), blkTxs[1].ID())
+
+	// Neither transaction should have been marked as dropped
+	require.NoError(env.mempool.GetDropReason(parentTx.ID()))
+	require.NoError(env.mempool.GetDropReason(childTx.ID()))
+}
+
 func TestBuildBlockDoesNotBuildWithEmptyMempool(t *testing.T) {
 	require := require.New(t)
 
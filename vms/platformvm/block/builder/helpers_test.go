@@ -36,6 +36,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -177,7 +178,13 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 	metrics, err := metrics.New(registerer)
 	require.NoError(err)
 
-	res.mempool, err = mempool.New("mempool", registerer, nil)
+	res.mempool, err = mempool.New(
+		"mempool",
+		registerer,
+		nil,
+		res.ctx.AVAXAssetID,
+		res.config.DynamicFeeConfig.Weights,
+	)
 	require.NoError(err)
 
 	res.blkManager = blockexecutor.NewManager(
@@ -185,7 +192,9 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 		metrics,
 		res.state,
 		&res.backend,
+		&res.backend,
 		pvalidators.TestManager,
+		index.NewNoTxIndexer(),
 	)
 
 	txVerifier := network.NewLockedTxVerifier(&res.ctx.Lock, res.blkManager)
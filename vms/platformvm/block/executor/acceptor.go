@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
@@ -29,6 +30,7 @@ type acceptor struct {
 	*backend
 	metrics      metrics.Metrics
 	validators   validators.Manager
+	txIndexer    index.TxIndexer
 	bootstrapped *utils.Atomic[bool]
 }
 
@@ -104,6 +106,10 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		)
 	}
 
+	if err := a.txIndexer.Accept(b.Height()); err != nil {
+		return fmt.Errorf("failed to index block %s: %w", blkID, err)
+	}
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", "apricot atomic"),
@@ -173,6 +179,14 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 		onAcceptFunc()
 	}
 
+	// The parent of an option is always at the preceding height.
+	if err := a.txIndexer.Accept(b.Height() - 1); err != nil {
+		return fmt.Errorf("failed to index block %s: %w", parentID, err)
+	}
+	if err := a.txIndexer.Accept(b.Height()); err != nil {
+		return fmt.Errorf("failed to index block %s: %w", blkID, err)
+	}
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", blockType),
@@ -252,6 +266,10 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 		onAcceptFunc()
 	}
 
+	if err := a.txIndexer.Accept(b.Height()); err != nil {
+		return fmt.Errorf("failed to index block %s: %w", blkID, err)
+	}
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", blockType),
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
@@ -62,6 +63,7 @@ func TestAcceptorVisitProposalBlock(t *testing.T) {
 		},
 		metrics:    metrics.Noop,
 		validators: validators.TestManager,
+		txIndexer:  index.NewNoTxIndexer(),
 	}
 
 	require.NoError(acceptor.ApricotProposalBlock(blk))
@@ -97,6 +99,7 @@ func TestAcceptorVisitAtomicBlock(t *testing.T) {
 		},
 		metrics:    metrics.Noop,
 		validators: validators.TestManager,
+		txIndexer:  index.NewNoTxIndexer(),
 	}
 
 	blk, err := block.NewApricotAtomicBlock(
@@ -177,6 +180,7 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 		},
 		metrics:    metrics.Noop,
 		validators: validators.TestManager,
+		txIndexer:  index.NewNoTxIndexer(),
 	}
 
 	blk, err := block.NewBanffStandardBlock(
@@ -266,6 +270,7 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 		},
 		metrics:      metrics.Noop,
 		validators:   validators.TestManager,
+		txIndexer:    index.NewNoTxIndexer(),
 		bootstrapped: &utils.Atomic[bool]{},
 	}
 
@@ -376,6 +381,7 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 		},
 		metrics:      metrics.Noop,
 		validators:   validators.TestManager,
+		txIndexer:    index.NewNoTxIndexer(),
 		bootstrapped: &utils.Atomic[bool]{},
 	}
 
@@ -37,6 +37,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -197,7 +198,13 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f fork) *environment
 	metrics := metrics.Noop
 
 	var err error
-	res.mempool, err = mempool.New("mempool", registerer, nil)
+	res.mempool, err = mempool.New(
+		"mempool",
+		registerer,
+		nil,
+		res.ctx.AVAXAssetID,
+		res.config.DynamicFeeConfig.Weights,
+	)
 	if err != nil {
 		panic(fmt.Errorf("failed to create mempool: %w", err))
 	}
@@ -208,7 +215,9 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f fork) *environment
 			metrics,
 			res.state,
 			res.backend,
+			res.backend,
 			pvalidators.TestManager,
+			index.NewNoTxIndexer(),
 		)
 		addSubnet(res)
 	} else {
@@ -217,7 +226,9 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f fork) *environment
 			metrics,
 			res.mockedState,
 			res.backend,
+			res.backend,
 			pvalidators.TestManager,
+			index.NewNoTxIndexer(),
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
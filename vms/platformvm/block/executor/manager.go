@@ -9,11 +9,13 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validators"
 )
@@ -41,6 +43,13 @@ type Manager interface {
 	// preferred state. This should *not* be used to verify transactions in a block.
 	VerifyTx(tx *txs.Tx) error
 
+	// SimulateTx executes the transaction on top of the currently preferred
+	// state without committing it and returns the fee the transaction must
+	// pay. If [verifyCredentials] is false, the signatures of the transaction
+	// aren't verified, but the credentials must still have the expected
+	// number of signatures.
+	SimulateTx(tx *txs.Tx, verifyCredentials bool) (uint64, error)
+
 	// VerifyUniqueInputs verifies that the inputs are not duplicated in the
 	// provided blk or any of its ancestors pinned in memory.
 	VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
@@ -51,7 +60,9 @@ func NewManager(
 	metrics metrics.Metrics,
 	s state.State,
 	txExecutorBackend *executor.Backend,
+	simulationTxExecutorBackend *executor.Backend,
 	validatorManager validators.Manager,
+	txIndexer index.TxIndexer,
 ) Manager {
 	lastAccepted := s.GetLastAccepted()
 	backend := &backend{
@@ -68,14 +79,16 @@ func NewManager(
 			backend:      backend,
 			metrics:      metrics,
 			validators:   validatorManager,
+			txIndexer:    txIndexer,
 			bootstrapped: txExecutorBackend.Bootstrapped,
 		},
 		rejector: &rejector{
 			backend:         backend,
 			addTxsToMempool: !txExecutorBackend.Config.PartialSyncPrimaryNetwork,
 		},
-		preferred:         lastAccepted,
-		txExecutorBackend: txExecutorBackend,
+		preferred:                   lastAccepted,
+		txExecutorBackend:           txExecutorBackend,
+		simulationTxExecutorBackend: simulationTxExecutorBackend,
 	}
 }
 
@@ -86,6 +99,9 @@ type manager struct {
 
 	preferred         ids.ID
 	txExecutorBackend *executor.Backend
+	// simulationTxExecutorBackend is used to execute transactions without
+	// verifying their signatures.
+	simulationTxExecutorBackend *executor.Backend
 }
 
 func (m *manager) GetBlock(blkID ids.ID) (snowman.Block, error) {
@@ -118,28 +134,53 @@ func (m *manager) Preferred() ids.ID {
 }
 
 func (m *manager) VerifyTx(tx *txs.Tx) error {
-	if !m.txExecutorBackend.Bootstrapped.Get() {
-		return ErrChainNotSynced
+	_, err := m.executeTx(m.txExecutorBackend, tx)
+	return err
+}
+
+func (m *manager) SimulateTx(tx *txs.Tx, verifyCredentials bool) (uint64, error) {
+	backend := m.simulationTxExecutorBackend
+	if verifyCredentials {
+		backend = m.txExecutorBackend
+	}
+
+	feeCalculator, err := m.executeTx(backend, tx)
+	if feeCalculator == nil {
+		return 0, err
+	}
+	// The fee is reported even if the execution failed. Executing the
+	// transaction calculates its fee, so the fee can only fail to be calculated
+	// if the execution failed as well.
+	fee, _ := feeCalculator.CalculateFee(tx.Unsigned)
+	return fee, err
+}
+
+// executeTx executes [tx] with [backend] on top of the currently preferred
+// state and returns the fee calculator that was used. The fee calculator is nil
+// if [tx] couldn't be executed.
+func (m *manager) executeTx(backend *executor.Backend, tx *txs.Tx) (fee.Calculator, error) {
+	if !backend.Bootstrapped.Get() {
+		return nil, ErrChainNotSynced
 	}
 
 	stateDiff, err := state.NewDiff(m.preferred, m)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	nextBlkTime, _, err := state.NextBlockTime(stateDiff, m.txExecutorBackend.Clk)
+	nextBlkTime, _, err := state.NextBlockTime(stateDiff, backend.Clk)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = executor.AdvanceTimeTo(m.txExecutorBackend, stateDiff, nextBlkTime)
+	_, err = executor.AdvanceTimeTo(backend, stateDiff, nextBlkTime)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	feeCalculator := state.PickFeeCalculator(m.txExecutorBackend.Config, stateDiff)
-	return tx.Unsigned.Visit(&executor.StandardTxExecutor{
-		Backend:       m.txExecutorBackend,
+	feeCalculator := state.PickFeeCalculator(backend.Config, stateDiff)
+	return feeCalculator, tx.Unsigned.Visit(&executor.StandardTxExecutor{
+		Backend:       backend,
 		State:         stateDiff,
 		FeeCalculator: feeCalculator,
 		Tx:            tx,
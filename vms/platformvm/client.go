@@ -84,10 +84,17 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// SimulateTx executes the transaction, which may be unsigned, on top of the
+	// currently preferred state without issuing it
+	SimulateTx(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateTxReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxStatusResponse, error)
+	// GetMempool returns the txs in the mempool and the recently dropped txs
+	GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error)
+	// RemoveMempoolTx removes the tx corresponding to [txID] from the mempool
+	RemoveMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error
 	// GetStake returns the amount of nAVAX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	//
@@ -107,6 +114,10 @@ type Client interface {
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetIndexedTxs returns the IDs of the transactions indexed under [key]
+	// by the index named [index], starting at [cursor], and the cursor of
+	// the next page.
+	GetIndexedTxs(ctx context.Context, index string, key string, cursor uint64, pageSize uint64, options ...rpc.Option) ([]ids.ID, uint64, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
@@ -373,6 +384,20 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "platform.getTx", &api.GetTxArgs{
@@ -399,6 +424,18 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res, err
 }
 
+func (c *client) GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error) {
+	res := &api.GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempool", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) RemoveMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "platform.removeMempoolTx", &api.JSONTxID{
+		TxID: txID,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) GetStake(
 	ctx context.Context,
 	addrs []ids.ShortID,
@@ -472,6 +509,24 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetIndexedTxs(
+	ctx context.Context,
+	index string,
+	key string,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	res := &GetIndexedTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getIndexedTxs", &GetIndexedTxsArgs{
+		Index:    index,
+		Key:      key,
+		Cursor:   json.Uint64(cursor),
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
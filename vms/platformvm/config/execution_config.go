@@ -23,6 +23,7 @@ var DefaultExecutionConfig = ExecutionConfig{
 	FxOwnerCacheSize:             4 * units.MiB,
 	SubnetManagerCacheSize:       4 * units.MiB,
 	ChecksumsEnabled:             false,
+	ArchiveEnabled:               false,
 	MempoolPruneFrequency:        30 * time.Minute,
 }
 
@@ -39,7 +40,13 @@ type ExecutionConfig struct {
 	FxOwnerCacheSize             int            `json:"fx-owner-cache-size"`
 	SubnetManagerCacheSize       int            `json:"subnet-manager-cache-size"`
 	ChecksumsEnabled             bool           `json:"checksums-enabled"`
+	ArchiveEnabled               bool           `json:"archive-enabled"`
 	MempoolPruneFrequency        time.Duration  `json:"mempool-prune-frequency"`
+	// MempoolRemovalEnabled allows transactions to be removed from the mempool
+	// with the platform.removeMempoolTx API.
+	MempoolRemovalEnabled bool `json:"mempool-removal-enabled"`
+	// Indexes are the names of the secondary transaction indexes to maintain.
+	Indexes []string `json:"indexes"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
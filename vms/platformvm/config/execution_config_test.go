@@ -91,7 +91,10 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			FxOwnerCacheSize:             9,
 			SubnetManagerCacheSize:       10,
 			ChecksumsEnabled:             true,
+			ArchiveEnabled:               true,
 			MempoolPruneFrequency:        time.Minute,
+			MempoolRemovalEnabled:        true,
+			Indexes:                      []string{"owner"},
 		}
 		verifyInitializedStruct(t, *expected)
 		verifyInitializedStruct(t, expected.Network)
@@ -36,10 +36,12 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	avajson "github.com/ava-labs/avalanchego/utils/json"
 	safemath "github.com/ava-labs/avalanchego/utils/math"
+	feecomponent "github.com/ava-labs/avalanchego/vms/components/fee"
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
 )
 
@@ -63,8 +65,18 @@ var (
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errHeightOfAtomicUTXOs        = errors.New("height is only supported for UTXOs on this chain")
+	errMempoolRemovalDisabled     = errors.New("mempool removal is disabled")
+	errNotInMempool               = errors.New("tx not in mempool")
+	errRemovedFromMempool         = errors.New("removed from the mempool by the node operator")
 )
 
+// subnetGetter is implemented by both the current and the archived state.
+type subnetGetter interface {
+	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
+	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
+}
+
 // Service defines the API calls that can be made to the platform chain
 type Service struct {
 	vm                    *VM
@@ -142,6 +154,9 @@ func (s *Service) ExportKey(_ *http.Request, args *ExportKeyArgs, reply *ExportK
 
 type GetBalanceRequest struct {
 	Addresses []string `json:"addresses"`
+	// Height, if provided, is the height of the accepted block to calculate
+	// the balance as of. Requires the archive to be enabled.
+	Height *avajson.Uint64 `json:"height,omitempty"`
 }
 
 // Note: We explicitly duplicate AVAX out of the maps to ensure backwards
@@ -175,13 +190,28 @@ func (s *Service) GetBalance(_ *http.Request, args *GetBalanceRequest, response
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
 
-	utxos, err := avax.GetAllUTXOs(s.vm.state, addrs)
+	var (
+		utxoReader  avax.UTXOReader = s.vm.state
+		currentTime                 = s.vm.clock.Unix()
+	)
+	if args.Height != nil {
+		archive, err := s.vm.state.GetArchive(uint64(*args.Height))
+		if err != nil {
+			return err
+		}
+		timestamp, err := archive.GetTimestamp()
+		if err != nil {
+			return fmt.Errorf("couldn't get timestamp at height %d: %w", *args.Height, err)
+		}
+		utxoReader = archive
+		currentTime = uint64(timestamp.Unix())
+	}
+
+	utxos, err := avax.GetAllUTXOs(utxoReader, addrs)
 	if err != nil {
 		return fmt.Errorf("couldn't get UTXO set of %v: %w", args.Addresses, err)
 	}
 
-	currentTime := s.vm.clock.Unix()
-
 	unlockeds := map[ids.ID]uint64{}
 	lockedStakeables := map[ids.ID]uint64{}
 	lockedNotStakeables := map[ids.ID]uint64{}
@@ -318,8 +348,17 @@ type Index struct {
 	UTXO    string `json:"utxo"`    // The UTXO ID as a string
 }
 
+// GetUTXOsArgs are the arguments for calling GetUTXOs
+type GetUTXOsArgs struct {
+	api.GetUTXOsArgs
+	// Height, if provided, is the height of the accepted block to fetch the
+	// UTXOs as of. Requires the archive to be enabled and is only supported
+	// for UTXOs on this chain.
+	Height *avajson.Uint64 `json:"height,omitempty"`
+}
+
 // GetUTXOs returns the UTXOs controlled by the given addresses
-func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *api.GetUTXOsReply) error {
+func (s *Service) GetUTXOs(_ *http.Request, args *GetUTXOsArgs, response *api.GetUTXOsReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
 		zap.String("method", "getUTXOs"),
@@ -342,6 +381,9 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 		}
 		sourceChain = chainID
 	}
+	if args.Height != nil && sourceChain != s.vm.ctx.ChainID {
+		return errHeightOfAtomicUTXOs
+	}
 
 	addrSet, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
 	if err != nil {
@@ -375,8 +417,16 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 	defer s.vm.ctx.Lock.Unlock()
 
 	if sourceChain == s.vm.ctx.ChainID {
+		var utxoReader avax.UTXOReader = s.vm.state
+		if args.Height != nil {
+			utxoReader, err = s.vm.state.GetArchive(uint64(*args.Height))
+			if err != nil {
+				return err
+			}
+		}
+
 		utxos, endAddr, endUTXOID, err = avax.GetPaginatedUTXOs(
-			s.vm.state,
+			utxoReader,
 			addrSet,
 			startAddr,
 			startUTXO,
@@ -425,6 +475,9 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 type GetSubnetArgs struct {
 	// ID of the subnet to retrieve information about
 	SubnetID ids.ID `json:"subnetID"`
+	// Height, if provided, is the height of the accepted block to retrieve
+	// the subnet as of. Requires the archive to be enabled.
+	Height *avajson.Uint64 `json:"height,omitempty"`
 }
 
 // GetSubnetResponse is the response from calling GetSubnet
@@ -453,7 +506,16 @@ func (s *Service) GetSubnet(_ *http.Request, args *GetSubnetArgs, response *GetS
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
 
-	subnetOwner, err := s.vm.state.GetSubnetOwner(args.SubnetID)
+	var subnetState subnetGetter = s.vm.state
+	if args.Height != nil {
+		archive, err := s.vm.state.GetArchive(uint64(*args.Height))
+		if err != nil {
+			return err
+		}
+		subnetState = archive
+	}
+
+	subnetOwner, err := subnetState.GetSubnetOwner(args.SubnetID)
 	if err != nil {
 		return err
 	}
@@ -474,7 +536,7 @@ func (s *Service) GetSubnet(_ *http.Request, args *GetSubnetArgs, response *GetS
 	response.Threshold = avajson.Uint32(owner.Threshold)
 	response.Locktime = avajson.Uint64(owner.Locktime)
 
-	switch subnetTransformationTx, err := s.vm.state.GetSubnetTransformation(args.SubnetID); err {
+	switch subnetTransformationTx, err := subnetState.GetSubnetTransformation(args.SubnetID); err {
 	case nil:
 		response.IsPermissioned = false
 		response.SubnetTransformationTxID = subnetTransformationTx.ID()
@@ -690,6 +752,11 @@ type GetCurrentValidatorsArgs struct {
 	// some nodeIDs are not currently validators, they
 	// will be omitted from the response.
 	NodeIDs []ids.NodeID `json:"nodeIDs"`
+	// Height, if provided, is the height of the accepted block to list the
+	// validators as of. Requires the archive to be enabled. Uptimes,
+	// connectivity, and accrued delegatee rewards are not reported for
+	// historical queries.
+	Height *avajson.Uint64 `json:"height,omitempty"`
 }
 
 // GetCurrentValidatorsReply are the results from calling GetCurrentValidators.
@@ -762,7 +829,13 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 
 	numNodeIDs := nodeIDs.Len()
 	targetStakers := make([]*state.Staker, 0, numNodeIDs)
-	if numNodeIDs == 0 { // Include all nodes
+	if args.Height != nil {
+		var err error
+		targetStakers, err = s.getArchivedStakers(uint64(*args.Height), args.SubnetID, nodeIDs)
+		if err != nil {
+			return err
+		}
+	} else if numNodeIDs == 0 { // Include all nodes
 		currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
 		if err != nil {
 			return err
@@ -815,11 +888,26 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 		}
 		potentialReward := avajson.Uint64(currentStaker.PotentialReward)
 
-		delegateeReward, err := s.vm.state.GetDelegateeReward(currentStaker.SubnetID, currentStaker.NodeID)
-		if err != nil {
-			return err
+		// The following fields are only reported for the current validator
+		// set.
+		var (
+			jsonDelegateeReward *avajson.Uint64
+			uptime              *avajson.Float32
+			connected           bool
+		)
+		if args.Height == nil {
+			delegateeReward, err := s.vm.state.GetDelegateeReward(currentStaker.SubnetID, currentStaker.NodeID)
+			if err != nil {
+				return err
+			}
+			jsonDelegateeReward = (*avajson.Uint64)(&delegateeReward)
+
+			uptime, err = s.getAPIUptime(currentStaker)
+			if err != nil {
+				return err
+			}
+			connected = s.vm.uptimeManager.IsConnected(nodeID, args.SubnetID)
 		}
-		jsonDelegateeReward := avajson.Uint64(delegateeReward)
 
 		switch currentStaker.Priority {
 		case txs.PrimaryNetworkValidatorCurrentPriority, txs.SubnetPermissionlessValidatorCurrentPriority:
@@ -831,12 +919,6 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 			shares := attr.shares
 			delegationFee := avajson.Float32(100 * float32(shares) / float32(reward.PercentDenominator))
 
-			uptime, err := s.getAPIUptime(currentStaker)
-			if err != nil {
-				return err
-			}
-
-			connected := s.vm.uptimeManager.IsConnected(nodeID, args.SubnetID)
 			var (
 				validationRewardOwner *platformapi.Owner
 				delegationRewardOwner *platformapi.Owner
@@ -861,7 +943,7 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 				Uptime:                 uptime,
 				Connected:              connected,
 				PotentialReward:        &potentialReward,
-				AccruedDelegateeReward: &jsonDelegateeReward,
+				AccruedDelegateeReward: jsonDelegateeReward,
 				RewardOwner:            validationRewardOwner,
 				ValidationRewardOwner:  validationRewardOwner,
 				DelegationRewardOwner:  delegationRewardOwner,
@@ -896,11 +978,6 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 			vdrToDelegators[delegator.NodeID] = append(vdrToDelegators[delegator.NodeID], delegator)
 
 		case txs.SubnetPermissionedValidatorCurrentPriority:
-			uptime, err := s.getAPIUptime(currentStaker)
-			if err != nil {
-				return err
-			}
-			connected := s.vm.uptimeManager.IsConnected(nodeID, args.SubnetID)
 			reply.Validators = append(reply.Validators, platformapi.PermissionedValidator{
 				Staker:    apiStaker,
 				Connected: connected,
@@ -943,6 +1020,39 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 	return nil
 }
 
+// getArchivedStakers returns the validators of [subnetID] as of [height],
+// followed by their delegators. If [nodeIDs] is not empty, only the
+// validators in [nodeIDs] are returned.
+func (s *Service) getArchivedStakers(height uint64, subnetID ids.ID, nodeIDs set.Set[ids.NodeID]) ([]*state.Staker, error) {
+	archive, err := s.vm.state.GetArchive(height)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := archive.GetCurrentValidators(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		stakers    = make([]*state.Staker, 0, len(validators))
+		delegators []*state.Staker
+	)
+	for _, validator := range validators {
+		if nodeIDs.Len() != 0 && !nodeIDs.Contains(validator.NodeID) {
+			continue
+		}
+		stakers = append(stakers, validator)
+
+		validatorDelegators, err := archive.GetCurrentDelegators(subnetID, validator.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		delegators = append(delegators, validatorDelegators...)
+	}
+	return append(stakers, delegators...), nil
+}
+
 // GetCurrentSupplyArgs are the arguments for calling GetCurrentSupply
 type GetCurrentSupplyArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -1308,6 +1418,146 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.
 	return nil
 }
 
+const (
+	// SimulationStageExecution is the stage of a simulation that executes the
+	// transaction without verifying its signatures.
+	SimulationStageExecution = "execution"
+	// SimulationStageCredentials is the stage of a simulation that verifies the
+	// signatures of a signed transaction.
+	SimulationStageCredentials = "credentials"
+)
+
+// Complexity is the amount of each fee dimension that a transaction consumes.
+type Complexity struct {
+	Bandwidth avajson.Uint64 `json:"bandwidth"`
+	DBRead    avajson.Uint64 `json:"dbRead"`
+	DBWrite   avajson.Uint64 `json:"dbWrite"`
+	Compute   avajson.Uint64 `json:"compute"`
+}
+
+// SimulateTxError describes why a simulated transaction failed verification.
+type SimulateTxError struct {
+	// Stage is the stage of the simulation that failed.
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
+}
+
+type SimulateTxReply struct {
+	// TxID is the ID of the simulated transaction. If the transaction was
+	// unsigned, the ID will change once it is signed, as will the IDs of the
+	// produced UTXOs.
+	TxID ids.ID `json:"txID"`
+	// Signed is false if the transaction was provided without credentials, in
+	// which case its signatures weren't verified.
+	Signed bool `json:"signed"`
+	// Complexity and Gas are omitted for transactions that don't support
+	// dynamic fees.
+	Complexity *Complexity         `json:"complexity,omitempty"`
+	Gas        *avajson.Uint64     `json:"gas,omitempty"`
+	Fee        avajson.Uint64      `json:"fee"`
+	UTXOs      []string            `json:"utxos"`
+	Encoding   formatting.Encoding `json:"encoding"`
+	// Error is nil if the transaction passed verification.
+	Error *SimulateTxError `json:"error,omitempty"`
+}
+
+// SimulateTx executes a signed or unsigned transaction on top of the currently
+// preferred state without issuing it.
+func (s *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "simulateTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, signed, err := parseSimulatedTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	reply.Signed = signed
+	reply.Encoding = args.Encoding
+
+	if complexity, err := fee.TxComplexity(tx.Unsigned); err == nil {
+		reply.Complexity = &Complexity{
+			Bandwidth: avajson.Uint64(complexity[feecomponent.Bandwidth]),
+			DBRead:    avajson.Uint64(complexity[feecomponent.DBRead]),
+			DBWrite:   avajson.Uint64(complexity[feecomponent.DBWrite]),
+			Compute:   avajson.Uint64(complexity[feecomponent.Compute]),
+		}
+		gas, err := complexity.ToGas(s.vm.DynamicFeeConfig.Weights)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate gas: %w", err)
+		}
+		reply.Gas = (*avajson.Uint64)(&gas)
+	}
+
+	utxos := tx.UTXOs()
+	reply.UTXOs = make([]string, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't serialize utxo %s: %w", utxo.InputID(), err)
+		}
+		reply.UTXOs[i], err = formatting.Encode(args.Encoding, utxoBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode utxo %s as %s: %w", utxo.InputID(), args.Encoding, err)
+		}
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	txFee, err := s.vm.manager.SimulateTx(tx, false)
+	reply.Fee = avajson.Uint64(txFee)
+	if err != nil {
+		reply.Error = &SimulateTxError{
+			Stage:   SimulationStageExecution,
+			Message: err.Error(),
+		}
+		return nil
+	}
+	if !signed {
+		return nil
+	}
+
+	if _, err := s.vm.manager.SimulateTx(tx, true); err != nil {
+		reply.Error = &SimulateTxError{
+			Stage:   SimulationStageCredentials,
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
+
+// parseSimulatedTx parses either a signed or an unsigned transaction. Unsigned
+// transactions are given credentials with the expected number of empty
+// signatures.
+func parseSimulatedTx(txBytes []byte) (*txs.Tx, bool, error) {
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err == nil {
+		return tx, true, nil
+	}
+
+	var utx txs.UnsignedTx
+	if _, unsignedErr := txs.Codec.Unmarshal(txBytes, &utx); unsignedErr != nil {
+		return nil, false, err
+	}
+	creds, err := txs.EmptyCredentials(utx)
+	if err != nil {
+		return nil, false, err
+	}
+	tx = &txs.Tx{
+		Unsigned: utx,
+		Creds:    creds,
+	}
+	return tx, false, tx.Initialize(txs.Codec)
+}
+
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -1407,6 +1657,65 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	return nil
 }
 
+// GetMempool returns the txs in the mempool and the txs that were recently
+// dropped from it.
+func (s *Service) GetMempool(_ *http.Request, _ *struct{}, reply *api.GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getMempool"),
+	)
+
+	entries := s.vm.Builder.Snapshot()
+	reply.Txs = make([]api.MempoolTx, len(entries))
+	for i, entry := range entries {
+		txID := entry.Tx.ID()
+		fee, err := txs.Burned(entry.Tx.Unsigned, s.vm.ctx.AVAXAssetID)
+		if err != nil {
+			return fmt.Errorf("failed to calculate fee of %s: %w", txID, err)
+		}
+
+		reply.Txs[i] = api.MempoolTx{
+			TxID: txID,
+			Size: avajson.Uint64(len(entry.Tx.Bytes())),
+			Fee:  avajson.Uint64(fee),
+			Age:  avajson.Uint64(time.Since(entry.AddedTime) / time.Second),
+		}
+	}
+
+	reply.Dropped = []api.DroppedTx{}
+	s.vm.Builder.IterateDropped(func(txID ids.ID, reason error) bool {
+		reply.Dropped = append(reply.Dropped, api.DroppedTx{
+			TxID:   txID,
+			Reason: reason.Error(),
+		})
+		return true
+	})
+	return nil
+}
+
+// RemoveMempoolTx removes a tx from the mempool and marks it as dropped, so
+// that it isn't re-added when it is gossiped back to this node.
+func (s *Service) RemoveMempoolTx(_ *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "removeMempoolTx"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	if !s.vm.mempoolRemovalEnabled {
+		return errMempoolRemovalDisabled
+	}
+
+	tx, ok := s.vm.Builder.Get(args.TxID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errNotInMempool, args.TxID)
+	}
+
+	s.vm.Builder.Remove(tx)
+	s.vm.Builder.MarkDropped(args.TxID, errRemovedFromMempool)
+	return nil
+}
+
 type GetStakeArgs struct {
 	api.JSONAddresses
 	ValidatorsOnly bool                `json:"validatorsOnly"`
@@ -1641,6 +1950,66 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+// GetIndexedTxsArgs are the arguments for GetIndexedTxs
+type GetIndexedTxsArgs struct {
+	// Index is the name of the index to read from
+	Index string `json:"index"`
+	// Key is the key, such as an address or subnetID, to read the
+	// transactions of
+	Key string `json:"key"`
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize avajson.Uint64 `json:"pageSize"`
+}
+
+// GetIndexedTxsReply is the response from GetIndexedTxs
+type GetIndexedTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+	// Synced is true if the index has indexed every accepted block
+	Synced bool `json:"synced"`
+}
+
+// GetIndexedTxs returns the IDs of the transactions indexed under the
+// provided key, in order of acceptance.
+func (s *Service) GetIndexedTxs(_ *http.Request, args *GetIndexedTxsArgs, reply *GetIndexedTxsReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getIndexedTxs"),
+		logging.UserString("index", args.Index),
+		logging.UserString("key", args.Key),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	if pageSize > maxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	var err error
+	reply.TxIDs, err = s.vm.txIndexer.Read(args.Index, args.Key, cursor, pageSize)
+	if err != nil {
+		return err
+	}
+	reply.Synced, err = s.vm.txIndexer.Synced(args.Index)
+	if err != nil {
+		return err
+	}
+
+	// To get the next set of tx IDs, the user should provide this cursor.
+	reply.Cursor = avajson.Uint64(cursor + uint64(len(reply.TxIDs)))
+	return nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
@@ -371,6 +373,238 @@ func TestGetTx(t *testing.T) {
 	}
 }
 
+func TestSimulateTx(t *testing.T) {
+	service, _, factory := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	builder, signer := factory.NewWallet(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1])
+	utx, err := builder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+	)
+	require.NoError(t, err)
+	tx, err := walletsigner.SignUnsigned(context.Background(), signer, utx)
+	require.NoError(t, err)
+	service.vm.ctx.Lock.Unlock()
+
+	// Replace a signature of the subnet authorization with a signature from a
+	// key that isn't an owner of the subnet.
+	wrongSig, err := keys[4].Sign(tx.Unsigned.Bytes())
+	require.NoError(t, err)
+	subnetAuthCred := tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential)
+	wrongCred := &secp256k1fx.Credential{
+		Sigs: slices.Clone(subnetAuthCred.Sigs),
+	}
+	copy(wrongCred.Sigs[0][:], wrongSig)
+	wrongTx := &txs.Tx{
+		Unsigned: tx.Unsigned,
+		Creds:    append(tx.Creds[:len(tx.Creds)-1:len(tx.Creds)-1], wrongCred),
+	}
+	require.NoError(t, wrongTx.Initialize(txs.Codec))
+
+	var missingUTXOTx txs.UnsignedTx = &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    service.vm.ctx.NetworkID,
+		BlockchainID: service.vm.ctx.ChainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: service.vm.StaticFeeConfig.TxFee,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+	}}
+	missingUTXOTxBytes, err := txs.Codec.Marshal(txs.CodecVersion, &missingUTXOTx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		txBytes        []byte
+		expectedSigned bool
+		expectedFee    uint64
+		expectedStage  string
+	}{
+		{
+			name:           "signed",
+			txBytes:        tx.Bytes(),
+			expectedSigned: true,
+			expectedFee:    service.vm.StaticFeeConfig.CreateBlockchainTxFee,
+		},
+		{
+			name:           "unsigned",
+			txBytes:        tx.Unsigned.Bytes(),
+			expectedSigned: false,
+			expectedFee:    service.vm.StaticFeeConfig.CreateBlockchainTxFee,
+		},
+		{
+			name:           "wrong signature",
+			txBytes:        wrongTx.Bytes(),
+			expectedSigned: true,
+			expectedFee:    service.vm.StaticFeeConfig.CreateBlockchainTxFee,
+			expectedStage:  SimulationStageCredentials,
+		},
+		{
+			name:           "missing utxo",
+			txBytes:        missingUTXOTxBytes,
+			expectedSigned: false,
+			expectedFee:    service.vm.StaticFeeConfig.TxFee,
+			expectedStage:  SimulationStageExecution,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			txStr, err := formatting.Encode(formatting.Hex, test.txBytes)
+			require.NoError(err)
+
+			reply := SimulateTxReply{}
+			require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+				Tx:       txStr,
+				Encoding: formatting.Hex,
+			}, &reply))
+			require.Equal(test.expectedSigned, reply.Signed)
+			require.Equal(avajson.Uint64(test.expectedFee), reply.Fee)
+			require.NotNil(reply.Complexity)
+			require.NotNil(reply.Gas)
+			if test.expectedStage == "" {
+				require.Nil(reply.Error)
+			} else {
+				require.NotNil(reply.Error)
+				require.Equal(test.expectedStage, reply.Error.Stage)
+			}
+		})
+	}
+
+	// Simulating a transaction doesn't modify the state.
+	require := require.New(t)
+
+	reply := SimulateTxReply{}
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Equal(tx.ID(), reply.TxID)
+	require.Nil(reply.Error)
+	require.Len(reply.UTXOs, len(tx.UTXOs()))
+}
+
+func TestGetMempool(t *testing.T) {
+	require := require.New(t)
+	service, _, factory := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	builder, signer := factory.NewWallet(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1])
+	utx, err := builder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+	)
+	require.NoError(err)
+	tx, err := walletsigner.SignUnsigned(context.Background(), signer, utx)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	txID := tx.ID()
+	require.NoError(service.vm.Network.IssueTxFromRPC(tx))
+
+	var reply api.GetMempoolReply
+	require.NoError(service.GetMempool(nil, nil, &reply))
+	require.Len(reply.Txs, 1)
+	require.Equal(txID, reply.Txs[0].TxID)
+	require.Equal(avajson.Uint64(len(tx.Bytes())), reply.Txs[0].Size)
+	require.Equal(avajson.Uint64(service.vm.StaticFeeConfig.CreateBlockchainTxFee), reply.Txs[0].Fee)
+	require.Empty(reply.Dropped)
+
+	args := &api.JSONTxID{TxID: txID}
+	err = service.RemoveMempoolTx(nil, args, &api.EmptyReply{})
+	require.ErrorIs(err, errMempoolRemovalDisabled)
+
+	service.vm.mempoolRemovalEnabled = true
+	require.NoError(service.RemoveMempoolTx(nil, args, &api.EmptyReply{}))
+
+	err = service.RemoveMempoolTx(nil, args, &api.EmptyReply{})
+	require.ErrorIs(err, errNotInMempool)
+
+	reply = api.GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, nil, &reply))
+	require.Empty(reply.Txs)
+	require.Equal(
+		[]api.DroppedTx{{
+			TxID:   txID,
+			Reason: errRemovedFromMempool.Error(),
+		}},
+		reply.Dropped,
+	)
+
+	var statusReply GetTxStatusResponse
+	require.NoError(service.GetTxStatus(nil, &GetTxStatusArgs{TxID: txID}, &statusReply))
+	require.Equal(status.Dropped, statusReply.Status)
+	require.Equal(errRemovedFromMempool.Error(), statusReply.Reason)
+}
+
+func TestGetMempoolConcurrentAdd(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	newTx := func() *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: units.Avax,
+				},
+			}},
+		}}}
+		require.NoError(tx.Initialize(txs.Codec))
+		return tx
+	}
+	for i := 0; i < 100; i++ {
+		require.NoError(service.vm.Builder.Add(newTx()))
+	}
+
+	// Adding txs while the mempool is being read must not deadlock.
+	var (
+		stop = make(chan struct{})
+		done = make(chan error)
+	)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				done <- nil
+				return
+			default:
+			}
+
+			tx := newTx()
+			if err := service.vm.Builder.Add(tx); err != nil {
+				done <- err
+				return
+			}
+			service.vm.Builder.Remove(tx)
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		var reply api.GetMempoolReply
+		require.NoError(service.GetMempool(nil, nil, &reply))
+		require.GreaterOrEqual(len(reply.Txs), 100)
+	}
+	close(stop)
+	require.NoError(<-done)
+}
+
 func TestGetBalance(t *testing.T) {
 	require := require.New(t)
 	service, _, _ := defaultService(t)
@@ -403,6 +637,22 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetBalanceArchiveDisabled(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	genesis, _ := defaultGenesis(t, service.vm.ctx.AVAXAssetID)
+	height := avajson.Uint64(0)
+	request := GetBalanceRequest{
+		Addresses: []string{
+			"P-" + genesis.UTXOs[0].Address,
+		},
+		Height: &height,
+	}
+	err := service.GetBalance(nil, &request, &GetBalanceResponse{})
+	require.ErrorIs(err, state.ErrArchiveDisabled)
+}
+
 func TestGetStake(t *testing.T) {
 	require := require.New(t)
 	service, _, factory := defaultService(t)
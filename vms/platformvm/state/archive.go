@@ -0,0 +1,517 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/x/archivedb"
+)
+
+// Keys in the archive are prefixed with a single byte to separate the
+// different types of archived values.
+const (
+	archiveTimestampPrefix byte = iota
+	archiveUTXOPrefix
+	archiveAddressPrefix
+	archiveSubnetOwnerPrefix
+	archiveSubnetTransformationPrefix
+	archiveChainsPrefix
+	archiveValidatorsPrefix
+	archiveDelegatorsPrefix
+)
+
+var (
+	_ Archive = (*archive)(nil)
+
+	ErrArchiveDisabled   = errors.New("archive is disabled")
+	ErrHeightNotArchived = errors.New("height has not been archived")
+
+	errArchiveNotInitialized = errors.New("archive must be enabled when the chain is initialized")
+	errArchiveOutOfSync      = errors.New("archive is out of sync")
+	errUnexpectedStakerType  = errors.New("unexpected staker type")
+)
+
+// Archive provides read access to the state as of a previously accepted
+// height.
+type Archive interface {
+	avax.UTXOReader
+
+	GetTimestamp() (time.Time, error)
+	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
+	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
+	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
+
+	// GetCurrentValidators returns the validators of [subnetID] sorted by
+	// txID.
+	GetCurrentValidators(subnetID ids.ID) ([]*Staker, error)
+	GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error)
+	// GetCurrentDelegators returns the delegators of [nodeID] on [subnetID]
+	// sorted by txID.
+	GetCurrentDelegators(subnetID ids.ID, nodeID ids.NodeID) ([]*Staker, error)
+}
+
+// archivedStaker contains the fields of a current staker that can't be
+// derived from its transaction.
+type archivedStaker struct {
+	TxID            ids.ID `serialize:"true"`
+	StartTime       uint64 `serialize:"true"`
+	PotentialReward uint64 `serialize:"true"`
+}
+
+func newArchivedStakers(stakers []*Staker) []archivedStaker {
+	archivedStakers := make([]archivedStaker, len(stakers))
+	for i, staker := range stakers {
+		archivedStakers[i] = archivedStaker{
+			TxID:            staker.TxID,
+			StartTime:       uint64(staker.StartTime.Unix()),
+			PotentialReward: staker.PotentialReward,
+		}
+	}
+	slices.SortFunc(archivedStakers, func(a, b archivedStaker) int {
+		return a.TxID.Compare(b.TxID)
+	})
+	return archivedStakers
+}
+
+func archiveKey(prefix byte, ids ...[]byte) []byte {
+	key := []byte{prefix}
+	for _, id := range ids {
+		key = append(key, id...)
+	}
+	return key
+}
+
+// GetArchive returns the state as of [height].
+func (s *state) GetArchive(height uint64) (Archive, error) {
+	if s.archive == nil {
+		return nil, ErrArchiveDisabled
+	}
+
+	archivedHeight, err := s.archive.Height()
+	if err != nil {
+		return nil, err
+	}
+	if height > archivedHeight {
+		return nil, fmt.Errorf("%w: requested height %d but last accepted height is %d",
+			ErrHeightNotArchived,
+			height,
+			archivedHeight,
+		)
+	}
+	return &archive{
+		state:  s,
+		reader: s.archive.Open(height),
+	}, nil
+}
+
+// verifyArchive ensures that the archive contains every height up to the last
+// accepted block.
+func (s *state) verifyArchive() error {
+	if s.archive == nil {
+		return nil
+	}
+
+	archivedHeight, err := s.archive.Height()
+	if err == database.ErrNotFound {
+		return errArchiveNotInitialized
+	}
+	if err != nil {
+		return err
+	}
+
+	lastAccepted, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return err
+	}
+	if height := lastAccepted.Height(); archivedHeight != height {
+		return fmt.Errorf("%w: archived height %d but last accepted height is %d",
+			errArchiveOutOfSync,
+			archivedHeight,
+			height,
+		)
+	}
+	return nil
+}
+
+// writeArchive records the modifications at [height] into the archive.
+//
+// Invariant: writeArchive must be called before the modifications are
+// flushed to the current state.
+func (s *state) writeArchive(height uint64) error {
+	if s.archive == nil {
+		return nil
+	}
+
+	var (
+		batch  = s.archive.NewBatch(height)
+		reader = s.archive.Open(height)
+	)
+	err := errors.Join(
+		s.writeArchivedTimestamp(batch),
+		s.writeArchivedUTXOs(batch, reader),
+		s.writeArchivedSubnets(batch),
+		s.writeArchivedChains(batch, reader),
+		s.writeArchivedStakers(batch),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return batch.Write()
+}
+
+func (s *state) writeArchivedTimestamp(batch database.KeyValueWriterDeleter) error {
+	if s.persistedTimestamp.Equal(s.timestamp) {
+		return nil
+	}
+	return database.PutTimestamp(batch, archiveKey(archiveTimestampPrefix), s.timestamp)
+}
+
+func (s *state) writeArchivedUTXOs(batch database.KeyValueWriterDeleter, reader database.KeyValueReader) error {
+	// addr -> utxoID -> true if the UTXO was added, false if it was removed
+	modifiedAddrs := make(map[string]map[ids.ID]bool)
+	modifyAddrs := func(utxo *avax.UTXO, added bool) {
+		addressable, ok := utxo.Out.(avax.Addressable)
+		if !ok {
+			return
+		}
+		utxoID := utxo.InputID()
+		for _, addr := range addressable.Addresses() {
+			addrStr := string(addr)
+			modifiedUTXOs, ok := modifiedAddrs[addrStr]
+			if !ok {
+				modifiedUTXOs = make(map[ids.ID]bool)
+				modifiedAddrs[addrStr] = modifiedUTXOs
+			}
+			modifiedUTXOs[utxoID] = added
+		}
+	}
+
+	for utxoID, utxo := range s.modifiedUTXOs {
+		key := archiveKey(archiveUTXOPrefix, utxoID[:])
+		if utxo != nil {
+			utxoBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, utxo)
+			if err != nil {
+				return fmt.Errorf("failed to serialize UTXO: %w", err)
+			}
+			if err := batch.Put(key, utxoBytes); err != nil {
+				return err
+			}
+			modifyAddrs(utxo, true)
+			continue
+		}
+
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+
+		// The UTXO may have been created and consumed during this height, in
+		// which case it was never indexed.
+		utxo, err := s.utxoState.GetUTXO(utxoID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		modifyAddrs(utxo, false)
+	}
+
+	for addr, modifiedUTXOs := range modifiedAddrs {
+		key := archiveKey(archiveAddressPrefix, []byte(addr))
+		utxoIDs, err := getArchivedIDs(reader, key)
+		if err != nil {
+			return err
+		}
+
+		utxoIDSet := set.Of(utxoIDs...)
+		for utxoID, added := range modifiedUTXOs {
+			if added {
+				utxoIDSet.Add(utxoID)
+			} else {
+				utxoIDSet.Remove(utxoID)
+			}
+		}
+		if utxoIDSet.Len() == 0 {
+			if err := batch.Delete(key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		utxoIDs = utxoIDSet.List()
+		utils.Sort(utxoIDs)
+		if err := putArchivedIDs(batch, key, utxoIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) writeArchivedSubnets(batch database.KeyValueWriterDeleter) error {
+	for subnetID, owner := range s.subnetOwners {
+		ownerBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &owner)
+		if err != nil {
+			return fmt.Errorf("failed to marshal subnet owner: %w", err)
+		}
+		if err := batch.Put(archiveKey(archiveSubnetOwnerPrefix, subnetID[:]), ownerBytes); err != nil {
+			return err
+		}
+	}
+	for subnetID, tx := range s.transformedSubnets {
+		txID := tx.ID()
+		if err := database.PutID(batch, archiveKey(archiveSubnetTransformationPrefix, subnetID[:]), txID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) writeArchivedChains(batch database.KeyValueWriterDeleter, reader database.KeyValueReader) error {
+	for subnetID, chains := range s.addedChains {
+		key := archiveKey(archiveChainsPrefix, subnetID[:])
+		chainIDs, err := getArchivedIDs(reader, key)
+		if err != nil {
+			return err
+		}
+		for _, chain := range chains {
+			chainIDs = append(chainIDs, chain.ID())
+		}
+		if err := putArchivedIDs(batch, key, chainIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) writeArchivedStakers(batch database.KeyValueWriterDeleter) error {
+	for subnetID, validatorDiffs := range s.currentStakers.validatorDiffs {
+		var (
+			subnetValidators = s.currentStakers.validators[subnetID]
+			validators       = make([]*Staker, 0, len(subnetValidators))
+		)
+		for _, validator := range subnetValidators {
+			if validator.validator != nil {
+				validators = append(validators, validator.validator)
+			}
+		}
+		err := putArchivedStakers(
+			batch,
+			archiveKey(archiveValidatorsPrefix, subnetID[:]),
+			validators,
+		)
+		if err != nil {
+			return err
+		}
+
+		for nodeID := range validatorDiffs {
+			var (
+				delegatorIt = s.currentStakers.GetDelegatorIterator(subnetID, nodeID)
+				delegators  []*Staker
+			)
+			for delegatorIt.Next() {
+				delegators = append(delegators, delegatorIt.Value())
+			}
+			delegatorIt.Release()
+
+			err := putArchivedStakers(
+				batch,
+				archiveKey(archiveDelegatorsPrefix, subnetID[:], nodeID.Bytes()),
+				delegators,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func getArchivedIDs(db database.KeyValueReader, key []byte) ([]ids.ID, error) {
+	idsBytes, err := db.Get(key)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var archivedIDs []ids.ID
+	if _, err := block.GenesisCodec.Unmarshal(idsBytes, &archivedIDs); err != nil {
+		return nil, err
+	}
+	return archivedIDs, nil
+}
+
+func putArchivedIDs(db database.KeyValueWriter, key []byte, archivedIDs []ids.ID) error {
+	idsBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, archivedIDs)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, idsBytes)
+}
+
+func putArchivedStakers(db database.KeyValueWriterDeleter, key []byte, stakers []*Staker) error {
+	if len(stakers) == 0 {
+		return db.Delete(key)
+	}
+
+	archivedStakers := newArchivedStakers(stakers)
+	stakersBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, archivedStakers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stakers: %w", err)
+	}
+	return db.Put(key, stakersBytes)
+}
+
+// archive reads the state at a previously accepted height.
+//
+// Transactions are immutable, so they are read from the current state.
+type archive struct {
+	state  *state
+	reader *archivedb.Reader
+}
+
+func (a *archive) GetTimestamp() (time.Time, error) {
+	return database.GetTimestamp(a.reader, archiveKey(archiveTimestampPrefix))
+}
+
+func (a *archive) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
+	utxoBytes, err := a.reader.Get(archiveKey(archiveUTXOPrefix, utxoID[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	utxo := &avax.UTXO{}
+	if _, err := txs.GenesisCodec.Unmarshal(utxoBytes, utxo); err != nil {
+		return nil, err
+	}
+	return utxo, nil
+}
+
+func (a *archive) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error) {
+	utxoIDs, err := getArchivedIDs(a.reader, archiveKey(archiveAddressPrefix, addr))
+	if err != nil {
+		return nil, err
+	}
+
+	// UTXO IDs are stored in sorted order, so we can skip directly to the
+	// first ID after [start].
+	i, found := slices.BinarySearchFunc(utxoIDs, start, ids.ID.Compare)
+	if found {
+		i++
+	}
+	utxoIDs = utxoIDs[i:]
+	if len(utxoIDs) > limit {
+		utxoIDs = utxoIDs[:limit]
+	}
+	return utxoIDs, nil
+}
+
+func (a *archive) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	ownerBytes, err := a.reader.Get(archiveKey(archiveSubnetOwnerPrefix, subnetID[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	var owner fx.Owner
+	if _, err := block.GenesisCodec.Unmarshal(ownerBytes, &owner); err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+func (a *archive) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
+	txID, err := database.GetID(a.reader, archiveKey(archiveSubnetTransformationPrefix, subnetID[:]))
+	if err != nil {
+		return nil, err
+	}
+	tx, _, err := a.state.GetTx(txID)
+	return tx, err
+}
+
+func (a *archive) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
+	chainIDs, err := getArchivedIDs(a.reader, archiveKey(archiveChainsPrefix, subnetID[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	chains := make([]*txs.Tx, len(chainIDs))
+	for i, chainID := range chainIDs {
+		chains[i], _, err = a.state.GetTx(chainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return chains, nil
+}
+
+func (a *archive) GetCurrentValidators(subnetID ids.ID) ([]*Staker, error) {
+	return a.getStakers(archiveKey(archiveValidatorsPrefix, subnetID[:]))
+}
+
+func (a *archive) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
+	validators, err := a.GetCurrentValidators(subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, validator := range validators {
+		if validator.NodeID == nodeID {
+			return validator, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+func (a *archive) GetCurrentDelegators(subnetID ids.ID, nodeID ids.NodeID) ([]*Staker, error) {
+	return a.getStakers(archiveKey(archiveDelegatorsPrefix, subnetID[:], nodeID.Bytes()))
+}
+
+func (a *archive) getStakers(key []byte) ([]*Staker, error) {
+	stakersBytes, err := a.reader.Get(key)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var archivedStakers []archivedStaker
+	if _, err := block.GenesisCodec.Unmarshal(stakersBytes, &archivedStakers); err != nil {
+		return nil, err
+	}
+
+	stakers := make([]*Staker, len(archivedStakers))
+	for i, archivedStaker := range archivedStakers {
+		tx, _, err := a.state.GetTx(archivedStaker.TxID)
+		if err != nil {
+			return nil, err
+		}
+		stakerTx, ok := tx.Unsigned.(txs.Staker)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", errUnexpectedStakerType, tx.Unsigned)
+		}
+		stakers[i], err = NewCurrentStaker(
+			archivedStaker.TxID,
+			stakerTx,
+			time.Unix(int64(archivedStaker.StartTime), 0),
+			archivedStaker.PotentialReward,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stakers, nil
+}
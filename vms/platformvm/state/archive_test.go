@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newArchivedStateFromDB(require *require.Assertions, db database.Database) *state {
+	execCfg, _ := config.GetExecutionConfig(nil)
+	execCfg.ArchiveEnabled = true
+	state, err := newState(
+		db,
+		metrics.Noop,
+		&config.Config{
+			Validators: validators.NewManager(),
+		},
+		execCfg,
+		&snow.Context{},
+		prometheus.NewRegistry(),
+		reward.NewCalculator(reward.Config{
+			MaxConsumptionRate: .12 * reward.PercentDenominator,
+			MinConsumptionRate: .1 * reward.PercentDenominator,
+			MintingPeriod:      365 * 24 * time.Hour,
+			SupplyCap:          720 * units.MegaAvax,
+		}),
+	)
+	require.NoError(err)
+	return state
+}
+
+func newOwnedUTXO(addr ids.ShortID) *avax.UTXO {
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Avax,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+}
+
+func TestArchive(t *testing.T) {
+	require := require.New(t)
+
+	s := newArchivedStateFromDB(require, memdb.New())
+	initializeState(require, s)
+	require.NoError(s.Commit())
+	require.NoError(s.load())
+
+	var (
+		addr      = ids.GenerateTestShortID()
+		utxo      = newOwnedUTXO(addr)
+		subnetID  = ids.GenerateTestID()
+		owner0    = &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}}
+		owner1    = &secp256k1fx.OutputOwners{Addrs: []ids.ShortID{}}
+		height1TS = initialTime.Add(time.Second)
+	)
+
+	// Height 1: Create a UTXO and a subnet.
+	s.AddUTXO(utxo)
+	s.AddSubnet(subnetID)
+	s.SetSubnetOwner(subnetID, owner0)
+	s.SetTimestamp(height1TS)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Height 2: Consume the UTXO, transfer the subnet, and remove the initial
+	// validator.
+	s.DeleteUTXO(utxo.InputID())
+	s.SetSubnetOwner(subnetID, owner1)
+	validator, err := s.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	s.DeleteCurrentValidator(validator)
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	{
+		archive, err := s.GetArchive(0)
+		require.NoError(err)
+
+		timestamp, err := archive.GetTimestamp()
+		require.NoError(err)
+		require.Equal(initialTime.Unix(), timestamp.Unix())
+
+		validators, err := archive.GetCurrentValidators(constants.PrimaryNetworkID)
+		require.NoError(err)
+		require.Len(validators, 1)
+		require.Equal(validator.TxID, validators[0].TxID)
+		require.Equal(validator.StartTime.Unix(), validators[0].StartTime.Unix())
+		require.Equal(validator.PotentialReward, validators[0].PotentialReward)
+
+		chains, err := archive.GetChains(constants.PrimaryNetworkID)
+		require.NoError(err)
+		require.Len(chains, 1)
+
+		_, err = archive.GetSubnetOwner(subnetID)
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	{
+		archive, err := s.GetArchive(1)
+		require.NoError(err)
+
+		timestamp, err := archive.GetTimestamp()
+		require.NoError(err)
+		require.Equal(height1TS.Unix(), timestamp.Unix())
+
+		utxos, err := avax.GetAllUTXOs(archive, set.Of(addr))
+		require.NoError(err)
+		require.Len(utxos, 1)
+		require.Equal(utxo.InputID(), utxos[0].InputID())
+		require.Equal(utxo.Out, utxos[0].Out)
+
+		subnetOwner, err := archive.GetSubnetOwner(subnetID)
+		require.NoError(err)
+		require.Equal(owner0, subnetOwner)
+
+		_, err = archive.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+		require.NoError(err)
+	}
+
+	{
+		archive, err := s.GetArchive(2)
+		require.NoError(err)
+
+		utxos, err := avax.GetAllUTXOs(archive, set.Of(addr))
+		require.NoError(err)
+		require.Empty(utxos)
+
+		_, err = archive.GetUTXO(utxo.InputID())
+		require.ErrorIs(err, database.ErrNotFound)
+
+		subnetOwner, err := archive.GetSubnetOwner(subnetID)
+		require.NoError(err)
+		require.Equal(owner1, subnetOwner)
+
+		validators, err := archive.GetCurrentValidators(constants.PrimaryNetworkID)
+		require.NoError(err)
+		require.Empty(validators)
+	}
+
+	_, err = s.GetArchive(3)
+	require.ErrorIs(err, ErrHeightNotArchived)
+}
+
+func TestArchiveDisabled(t *testing.T) {
+	s := newInitializedState(require.New(t))
+	_, err := s.GetArchive(0)
+	require.ErrorIs(t, err, ErrArchiveDisabled)
+}
+
+func TestArchiveNotInitialized(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s := newStateFromDB(require, db)
+	initializeState(require, s)
+	require.NoError(s.Commit())
+
+	archivedState := newArchivedStateFromDB(require, db)
+	require.NoError(archivedState.loadMetadata())
+	require.ErrorIs(archivedState.verifyArchive(), errArchiveNotInitialized)
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/x/archivedb"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -80,6 +81,7 @@ var (
 	SupplyPrefix                  = []byte("supply")
 	ChainPrefix                   = []byte("chain")
 	SingletonPrefix               = []byte("singleton")
+	ArchivePrefix                 = []byte("archive")
 
 	TimestampKey       = []byte("timestamp")
 	FeeStateKey        = []byte("fee state")
@@ -141,6 +143,11 @@ type State interface {
 
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 
+	// GetArchive returns the state as of the accepted block at [height].
+	//
+	// Returns [ErrArchiveDisabled] if the archive is not enabled.
+	GetArchive(height uint64) (Archive, error)
+
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
 	GetSubnetIDs() ([]ids.ID, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
@@ -271,6 +278,8 @@ type stateBlk struct {
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
+ * |-. archive
+ * | '-- archivedb of the state at every height, if enabled
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- blocksReindexedKey -> nil
@@ -374,6 +383,9 @@ type state struct {
 	// TODO: Remove indexedHeights once v1.11.3 has been released.
 	indexedHeights *heightRange
 	singletonDB    database.Database
+
+	// archive is nil if archiving is disabled.
+	archive *archivedb.Database
 }
 
 // heightRange is used to track which heights are safe to use the native DB
@@ -614,6 +626,11 @@ func newState(
 		return nil, err
 	}
 
+	var archive *archivedb.Database
+	if execCfg.ArchiveEnabled {
+		archive = archivedb.New(prefixdb.New(ArchivePrefix, baseDB))
+	}
+
 	return &state{
 		validatorState: newValidatorState(),
 
@@ -694,6 +711,8 @@ func newState(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
+
+		archive: archive,
 	}, nil
 }
 
@@ -1349,6 +1368,7 @@ func (s *state) load() error {
 		s.loadCurrentValidators(),
 		s.loadPendingValidators(),
 		s.initValidatorSets(),
+		s.verifyArchive(),
 	)
 }
 
@@ -1707,6 +1727,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 	}
 
 	return errors.Join(
+		s.writeArchive(height), // Must be called before the other writes
 		s.writeBlocks(),
 		s.writeCurrentStakers(updateValidators, height, codecVersion),
 		s.writePendingStakers(),
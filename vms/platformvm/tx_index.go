@@ -0,0 +1,317 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/index"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+const (
+	subnetIndexName = "subnet"
+	nodeIDIndexName = "nodeID"
+)
+
+var (
+	_ index.Source = (*txIndexSource)(nil)
+	_ index.Index  = (*subnetIndex)(nil)
+	_ index.Index  = (*nodeIDIndex)(nil)
+
+	txIndexPrefix = []byte("tx index")
+
+	errUnknownIndex = errors.New("unknown index")
+)
+
+// newTxIndexer returns a TxIndexer that maintains the indexes named by
+// [indexNames].
+func newTxIndexer(
+	db database.Database,
+	ctx *snow.Context,
+	s state.State,
+	genesisBytes []byte,
+	registerer prometheus.Registerer,
+	indexNames []string,
+) (index.TxIndexer, error) {
+	if len(indexNames) == 0 {
+		return index.NewNoTxIndexer(), nil
+	}
+
+	addrManager := avax.NewAddressManager(ctx)
+	parseAddress := func(addr string) (ids.ShortID, error) {
+		return avax.ParseServiceAddress(addrManager, addr)
+	}
+
+	indexes := make(map[string]index.Index, len(indexNames))
+	for _, name := range indexNames {
+		switch name {
+		case index.OwnerIndexName:
+			indexes[name] = index.NewOwnerIndex(parseAddress)
+		case subnetIndexName:
+			indexes[name] = &subnetIndex{}
+		case nodeIDIndexName:
+			indexes[name] = &nodeIDIndex{}
+		default:
+			return nil, fmt.Errorf("%w: %q", errUnknownIndex, name)
+		}
+	}
+
+	genesisState, err := genesis.Parse(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisTxs := make([]*txs.Tx, 0, len(genesisState.Validators)+len(genesisState.Chains))
+	genesisTxs = append(genesisTxs, genesisState.Validators...)
+	genesisTxs = append(genesisTxs, genesisState.Chains...)
+
+	return index.NewTxIndexer(
+		prefixdb.New(txIndexPrefix, db),
+		ctx.Log,
+		&ctx.Lock,
+		&txIndexSource{
+			state:      s,
+			genesisTxs: genesisTxs,
+		},
+		registerer,
+		indexes,
+	)
+}
+
+// txIndexSource provides the transactions accepted by the P-chain. The
+// transactions included in the genesis are treated as if they were accepted
+// in the genesis block.
+type txIndexSource struct {
+	state      state.State
+	genesisTxs []*txs.Tx
+}
+
+func (s *txIndexSource) LastAcceptedHeight() (uint64, error) {
+	blk, err := s.state.GetStatelessBlock(s.state.GetLastAccepted())
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
+func (s *txIndexSource) GetTxs(height uint64) ([]*index.Tx, error) {
+	blkID, err := s.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := s.state.GetStatelessBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+
+	blkTxs := blk.Txs()
+	if height == 0 {
+		blkTxs = append(blkTxs, s.genesisTxs...)
+	}
+
+	indexTxs := make([]*index.Tx, len(blkTxs))
+	for i, tx := range blkTxs {
+		indexTxs[i], err = s.indexTx(tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return indexTxs, nil
+}
+
+func (s *txIndexSource) indexTx(tx *txs.Tx) (*index.Tx, error) {
+	txID := tx.ID()
+	inputs, err := s.inputs(tx.Unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := tx.UTXOs()
+	if rewardTx, ok := tx.Unsigned.(*txs.RewardValidatorTx); ok {
+		stakerTx, _, err := s.state.GetTx(rewardTx.TxID)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err = s.removedStakerUTXOs(stakerTx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	indexTx := &index.Tx{
+		ID:       txID,
+		Unsigned: tx.Unsigned,
+		Inputs:   inputs,
+		Outputs:  make([]*avax.UTXO, len(outputs)),
+	}
+	for i, utxo := range outputs {
+		indexTx.Outputs[i] = unlockedUTXO(utxo)
+	}
+	return indexTx, nil
+}
+
+// inputs returns the UTXOs consumed by [tx] that were produced on the
+// P-chain.
+func (s *txIndexSource) inputs(tx txs.UnsignedTx) ([]*avax.UTXO, error) {
+	var utxoIDs []*avax.UTXOID
+	switch tx := tx.(type) {
+	case *txs.ImportTx:
+		// Imported UTXOs were produced on another chain.
+		utxoIDs = tx.BaseTx.InputUTXOs()
+	case interface{ InputUTXOs() []*avax.UTXOID }:
+		utxoIDs = tx.InputUTXOs()
+	}
+
+	utxos := make([]*avax.UTXO, 0, len(utxoIDs))
+	for _, utxoID := range utxoIDs {
+		utxo, err := s.producedUTXO(utxoID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, unlockedUTXO(utxo))
+	}
+	return utxos, nil
+}
+
+// producedUTXO returns the UTXO [utxoID] from the tx that produced it. This
+// allows UTXOs to be found after they have been consumed.
+func (s *txIndexSource) producedUTXO(utxoID *avax.UTXOID) (*avax.UTXO, error) {
+	tx, _, err := s.state.GetTx(utxoID.TxID)
+	if err != nil {
+		return nil, err
+	}
+
+	removedStakerUTXOs, err := s.removedStakerUTXOs(tx)
+	if err != nil {
+		return nil, err
+	}
+	utxos := append(tx.UTXOs(), removedStakerUTXOs...)
+
+	for _, utxo := range utxos {
+		if utxo.OutputIndex == utxoID.OutputIndex {
+			return utxo, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+// removedStakerUTXOs returns the UTXOs produced when the staker added by
+// [stakerTx] was removed. If [stakerTx] doesn't add a staker, or the staker
+// hasn't been removed, no UTXOs are returned.
+func (s *txIndexSource) removedStakerUTXOs(stakerTx *txs.Tx) ([]*avax.UTXO, error) {
+	txID := stakerTx.ID()
+	rewardUTXOs, err := s.state.GetRewardUTXOs(txID)
+	if err != nil {
+		return nil, err
+	}
+
+	staker, ok := stakerTx.Unsigned.(txs.PermissionlessStaker)
+	if !ok {
+		return rewardUTXOs, nil
+	}
+
+	// The stake is returned with output indices following the outputs of the
+	// staker tx.
+	var (
+		outputs = staker.Outputs()
+		stake   = staker.Stake()
+		utxos   = make([]*avax.UTXO, 0, len(stake)+len(rewardUTXOs))
+	)
+	for i, out := range stake {
+		utxos = append(utxos, &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(len(outputs) + i),
+			},
+			Asset: out.Asset,
+			Out:   out.Output(),
+		})
+	}
+	return append(utxos, rewardUTXOs...), nil
+}
+
+// unlockedUTXO returns [utxo] without its stakeable lock, so that the owners
+// of the UTXO can be indexed.
+func unlockedUTXO(utxo *avax.UTXO) *avax.UTXO {
+	lockedOut, ok := utxo.Out.(*stakeable.LockOut)
+	if !ok {
+		return utxo
+	}
+	return &avax.UTXO{
+		UTXOID: utxo.UTXOID,
+		Asset:  utxo.Asset,
+		Out:    lockedOut.TransferableOut,
+	}
+}
+
+// subnetIndex indexes the transactions that create or modify a subnet. The
+// primary network is not indexed.
+type subnetIndex struct{}
+
+func (*subnetIndex) ParseKey(key string) ([]byte, error) {
+	subnetID, err := ids.FromString(key)
+	return subnetID[:], err
+}
+
+func (*subnetIndex) Keys(tx *index.Tx) ([][]byte, error) {
+	var subnetID ids.ID
+	switch utx := tx.Unsigned.(type) {
+	case *txs.CreateSubnetTx:
+		subnetID = tx.ID
+	case *txs.CreateChainTx:
+		subnetID = utx.SubnetID
+	case *txs.RemoveSubnetValidatorTx:
+		subnetID = utx.Subnet
+	case *txs.TransformSubnetTx:
+		subnetID = utx.Subnet
+	case *txs.TransferSubnetOwnershipTx:
+		subnetID = utx.Subnet
+	case txs.Staker:
+		subnetID = utx.SubnetID()
+	default:
+		return nil, nil
+	}
+
+	if subnetID == constants.PrimaryNetworkID {
+		return nil, nil
+	}
+	return [][]byte{subnetID[:]}, nil
+}
+
+// nodeIDIndex indexes the transactions that add or remove a staker.
+type nodeIDIndex struct{}
+
+func (*nodeIDIndex) ParseKey(key string) ([]byte, error) {
+	nodeID, err := ids.NodeIDFromString(key)
+	return nodeID.Bytes(), err
+}
+
+func (*nodeIDIndex) Keys(tx *index.Tx) ([][]byte, error) {
+	var nodeID ids.NodeID
+	switch utx := tx.Unsigned.(type) {
+	case *txs.RemoveSubnetValidatorTx:
+		nodeID = utx.NodeID
+	case txs.Staker:
+		nodeID = utx.NodeID()
+	default:
+		return nil, nil
+	}
+	return [][]byte{nodeID.Bytes()}, nil
+}
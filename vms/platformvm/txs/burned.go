@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var _ Visitor = (*burnedVisitor)(nil)
+
+// Burned returns the amount of [assetID] that [tx] consumes without producing,
+// which for the fee asset is the fee that [tx] pays.
+func Burned(tx UnsignedTx, assetID ids.ID) (uint64, error) {
+	v := &burnedVisitor{}
+	if err := tx.Visit(v); err != nil {
+		return 0, err
+	}
+	return avax.Burned(assetID, v.ins, v.outs)
+}
+
+type burnedVisitor struct {
+	ins  [][]*avax.TransferableInput
+	outs [][]*avax.TransferableOutput
+}
+
+func (v *burnedVisitor) AddValidatorTx(tx *AddValidatorTx) error {
+	v.add(tx.Ins, tx.Outs, tx.StakeOuts)
+	return nil
+}
+
+func (v *burnedVisitor) AddSubnetValidatorTx(tx *AddSubnetValidatorTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) AddDelegatorTx(tx *AddDelegatorTx) error {
+	v.add(tx.Ins, tx.Outs, tx.StakeOuts)
+	return nil
+}
+
+func (v *burnedVisitor) CreateChainTx(tx *CreateChainTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) CreateSubnetTx(tx *CreateSubnetTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) ImportTx(tx *ImportTx) error {
+	v.ins = append(v.ins, tx.ImportedInputs)
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) ExportTx(tx *ExportTx) error {
+	v.add(tx.Ins, tx.Outs, tx.ExportedOutputs)
+	return nil
+}
+
+func (*burnedVisitor) AdvanceTimeTx(*AdvanceTimeTx) error {
+	return nil
+}
+
+func (*burnedVisitor) RewardValidatorTx(*RewardValidatorTx) error {
+	return nil
+}
+
+func (v *burnedVisitor) RemoveSubnetValidatorTx(tx *RemoveSubnetValidatorTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) TransformSubnetTx(tx *TransformSubnetTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) AddPermissionlessValidatorTx(tx *AddPermissionlessValidatorTx) error {
+	v.add(tx.Ins, tx.Outs, tx.StakeOuts)
+	return nil
+}
+
+func (v *burnedVisitor) AddPermissionlessDelegatorTx(tx *AddPermissionlessDelegatorTx) error {
+	v.add(tx.Ins, tx.Outs, tx.StakeOuts)
+	return nil
+}
+
+func (v *burnedVisitor) TransferSubnetOwnershipTx(tx *TransferSubnetOwnershipTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *burnedVisitor) BaseTx(tx *BaseTx) error {
+	v.add(tx.Ins, tx.Outs)
+	return nil
+}
+
+func (v *burnedVisitor) add(ins []*avax.TransferableInput, allOuts ...[]*avax.TransferableOutput) {
+	v.ins = append(v.ins, ins)
+	v.outs = append(v.outs, allOuts...)
+}
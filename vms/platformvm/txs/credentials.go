@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ Visitor = (*credentialsVisitor)(nil)
+
+	errUnsupportedInput = errors.New("unsupported input type")
+	errUnsupportedAuth  = errors.New("unsupported authorization type")
+)
+
+// EmptyCredentials returns the credentials that [tx] is expected to be signed
+// with, with every signature left empty.
+func EmptyCredentials(tx UnsignedTx) ([]verify.Verifiable, error) {
+	v := &credentialsVisitor{}
+	if err := tx.Visit(v); err != nil {
+		return nil, err
+	}
+	return v.creds, nil
+}
+
+type credentialsVisitor struct {
+	creds []verify.Verifiable
+}
+
+func (v *credentialsVisitor) AddValidatorTx(tx *AddValidatorTx) error {
+	return v.inputs(tx.Ins)
+}
+
+func (v *credentialsVisitor) AddSubnetValidatorTx(tx *AddSubnetValidatorTx) error {
+	return v.inputsAndAuth(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *credentialsVisitor) AddDelegatorTx(tx *AddDelegatorTx) error {
+	return v.inputs(tx.Ins)
+}
+
+func (v *credentialsVisitor) CreateChainTx(tx *CreateChainTx) error {
+	return v.inputsAndAuth(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *credentialsVisitor) CreateSubnetTx(tx *CreateSubnetTx) error {
+	return v.inputs(tx.Ins)
+}
+
+func (v *credentialsVisitor) ImportTx(tx *ImportTx) error {
+	if err := v.inputs(tx.Ins); err != nil {
+		return err
+	}
+	return v.inputs(tx.ImportedInputs)
+}
+
+func (v *credentialsVisitor) ExportTx(tx *ExportTx) error {
+	return v.inputs(tx.Ins)
+}
+
+func (*credentialsVisitor) AdvanceTimeTx(*AdvanceTimeTx) error {
+	return nil
+}
+
+func (*credentialsVisitor) RewardValidatorTx(*RewardValidatorTx) error {
+	return nil
+}
+
+func (v *credentialsVisitor) RemoveSubnetValidatorTx(tx *RemoveSubnetValidatorTx) error {
+	return v.inputsAndAuth(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *credentialsVisitor) TransformSubnetTx(tx *TransformSubnetTx) error {
+	return v.inputsAndAuth(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *credentialsVisitor) AddPermissionlessValidatorTx(tx *AddPermissionlessValidatorTx) error {
+	return v.inputs(tx.Ins)
+}
+
+func (v *credentialsVisitor) AddPermissionlessDelegatorTx(tx *AddPermissionlessDelegatorTx) error {
+	return v.inputs(tx.Ins)
+}
+
+func (v *credentialsVisitor) TransferSubnetOwnershipTx(tx *TransferSubnetOwnershipTx) error {
+	return v.inputsAndAuth(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *credentialsVisitor) BaseTx(tx *BaseTx) error {
+	return v.inputs(tx.Ins)
+}
+
+func (v *credentialsVisitor) inputsAndAuth(ins []*avax.TransferableInput, authIntf verify.Verifiable) error {
+	if err := v.inputs(ins); err != nil {
+		return err
+	}
+
+	auth, ok := authIntf.(*secp256k1fx.Input)
+	if !ok {
+		return errUnsupportedAuth
+	}
+	v.add(len(auth.SigIndices))
+	return nil
+}
+
+func (v *credentialsVisitor) inputs(ins []*avax.TransferableInput) error {
+	for _, in := range ins {
+		inIntf := in.In
+		if stakeableIn, ok := inIntf.(*stakeable.LockIn); ok {
+			inIntf = stakeableIn.TransferableIn
+		}
+
+		secp256k1In, ok := inIntf.(*secp256k1fx.TransferInput)
+		if !ok {
+			return errUnsupportedInput
+		}
+		v.add(len(secp256k1In.SigIndices))
+	}
+	return nil
+}
+
+func (v *credentialsVisitor) add(numSigs int) {
+	v.creds = append(v.creds, &secp256k1fx.Credential{
+		Sigs: make([][secp256k1.SignatureLen]byte, numSigs),
+	})
+}
@@ -5,20 +5,50 @@ package mempool
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/linked"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/setmap"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+	feecomponent "github.com/ava-labs/avalanchego/vms/components/fee"
 	txmempool "github.com/ava-labs/avalanchego/vms/txs/mempool"
 )
 
+const (
+	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to track
+	droppedTxIDsCacheSize = 64
+
+	// maxMempoolSize is the maximum number of bytes allowed in the mempool
+	maxMempoolSize = 64 * units.MiB
+
+	// MinReplacementFeeRateIncrease is the percentage by which the fee per
+	// unit of gas of a tx must exceed the fee per unit of gas of every tx that
+	// it replaces.
+	MinReplacementFeeRateIncrease = 10
+)
+
 var (
 	_ Mempool = (*mempool)(nil)
 
 	ErrCantIssueAdvanceTimeTx     = errors.New("can not issue an advance time tx")
 	ErrCantIssueRewardValidatorTx = errors.New("can not issue a reward validator tx")
+	ErrReplacementFeeTooLow       = errors.New("replacement fee too low")
+	ErrReplaced                   = errors.New("replaced by a tx paying a higher fee")
+	ErrEvicted                    = errors.New("evicted by a tx paying a higher fee")
 )
 
 type Mempool interface {
@@ -32,28 +62,72 @@ type Mempool interface {
 	RequestBuildBlock(emptyBlockPermitted bool)
 }
 
+// meteredTx is a tx in the mempool along with the fee it pays and the gas it
+// consumes.
+type meteredTx struct {
+	*txs.Tx
+
+	fee   uint64
+	gas   uint64
+	added time.Time
+	// index is used to order txs that pay the same fee per unit of gas by
+	// their arrival.
+	index uint64
+}
+
+// mempool orders txs by the fee they pay per unit of gas. A tx that conflicts
+// with txs in the mempool replaces them if it pays a sufficiently higher fee,
+// and when the mempool is full, txs paying the lowest fee per unit of gas are
+// evicted to make room for txs paying more.
 type mempool struct {
-	txmempool.Mempool[*txs.Tx]
+	avaxAssetID ids.ID
+	weights     feecomponent.Dimensions
+	toEngine    chan<- common.Message
+	metrics     txmempool.Metrics
 
-	toEngine chan<- common.Message
+	lock           sync.RWMutex
+	unissuedTxs    *linked.Hashmap[ids.ID, *meteredTx] // Ordered by arrival
+	highestFirst   heap.Map[ids.ID, *meteredTx]
+	lowestFirst    heap.Map[ids.ID, *meteredTx]
+	consumedUTXOs  *setmap.SetMap[ids.ID, ids.ID] // TxID -> Consumed UTXOs
+	producedUTXOs  *setmap.SetMap[ids.ID, ids.ID] // TxID -> Produced UTXOs
+	bytesAvailable int
+	nextIndex      uint64
+	droppedTxIDs   *linked.Hashmap[ids.ID, error] // TxID -> Verification error
 }
 
+// New returns a mempool that prioritizes txs by the amount of [avaxAssetID]
+// they burn per unit of gas, where gas is calculated using [weights].
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
 	toEngine chan<- common.Message,
+	avaxAssetID ids.ID,
+	weights feecomponent.Dimensions,
 ) (Mempool, error) {
 	metrics, err := txmempool.NewMetrics(namespace, registerer)
 	if err != nil {
 		return nil, err
 	}
-	pool := txmempool.New[*txs.Tx](
-		metrics,
-	)
-	return &mempool{
-		Mempool:  pool,
-		toEngine: toEngine,
-	}, nil
+	m := &mempool{
+		avaxAssetID:    avaxAssetID,
+		weights:        weights,
+		toEngine:       toEngine,
+		metrics:        metrics,
+		unissuedTxs:    linked.NewHashmap[ids.ID, *meteredTx](),
+		highestFirst:   heap.NewMap[ids.ID, *meteredTx](hasHigherPriority),
+		lowestFirst:    heap.NewMap[ids.ID, *meteredTx](hasLowerPriority),
+		consumedUTXOs:  setmap.New[ids.ID, ids.ID](),
+		producedUTXOs:  setmap.New[ids.ID, ids.ID](),
+		bytesAvailable: maxMempoolSize,
+		droppedTxIDs:   linked.NewHashmap[ids.ID, error](),
+	}
+	m.updateMetrics()
+	return m, nil
+}
+
+func (m *mempool) updateMetrics() {
+	m.metrics.Update(m.unissuedTxs.Len(), m.bytesAvailable)
 }
 
 func (m *mempool) Add(tx *txs.Tx) error {
@@ -65,7 +139,382 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	default:
 	}
 
-	return m.Mempool.Add(tx)
+	txID := tx.ID()
+	txSize := tx.Size()
+	if txSize > txmempool.MaxTxSize {
+		return fmt.Errorf("%w: %s size (%d) > max size (%d)",
+			txmempool.ErrTxTooLarge,
+			txID,
+			txSize,
+			txmempool.MaxTxSize,
+		)
+	}
+
+	newTx, err := m.meter(tx)
+	if err != nil {
+		return fmt.Errorf("failed to calculate priority of %s: %w", txID, err)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.unissuedTxs.Get(txID); ok {
+		return fmt.Errorf("%w: %s", txmempool.ErrDuplicateTx, txID)
+	}
+
+	// The index must be assigned before [newTx] is compared against the txs
+	// in the mempool, so that it loses ties against txs that arrived earlier.
+	newTx.index = m.nextIndex
+
+	inputs := tx.InputIDs()
+	conflicts, err := m.replaceableConflicts(newTx, inputs)
+	if err != nil {
+		return err
+	}
+
+	bytesAvailable := m.bytesAvailable
+	for _, conflict := range conflicts {
+		bytesAvailable += conflict.Size()
+	}
+	evicted, err := m.evictable(newTx, conflicts, txSize-bytesAvailable)
+	if err != nil {
+		return err
+	}
+
+	for _, conflict := range conflicts {
+		m.remove(conflict)
+		m.markDropped(conflict.ID(), fmt.Errorf("%w: %s", ErrReplaced, txID))
+	}
+	for _, evictedTx := range evicted {
+		m.remove(evictedTx)
+		m.markDropped(evictedTx.ID(), fmt.Errorf("%w: %s", ErrEvicted, txID))
+	}
+
+	newTx.added = time.Now()
+	m.nextIndex++
+
+	m.bytesAvailable -= txSize
+	m.unissuedTxs.Put(txID, newTx)
+	m.highestFirst.Push(txID, newTx)
+	m.lowestFirst.Push(txID, newTx)
+	m.updateMetrics()
+
+	// Mark these UTXOs as consumed in the mempool
+	m.consumedUTXOs.Put(txID, inputs)
+
+	// Track the UTXOs this tx produces so that it can be issued before any txs
+	// in the mempool that spend them.
+	utxos := tx.UTXOs()
+	outputs := set.NewSet[ids.ID](len(utxos))
+	for _, utxo := range utxos {
+		outputs.Add(utxo.InputID())
+	}
+	m.producedUTXOs.Put(txID, outputs)
+
+	// An added tx must not be marked as dropped.
+	m.droppedTxIDs.Delete(txID)
+	return nil
+}
+
+// meter calculates the fee that [tx] pays and the gas that it consumes. Txs
+// that don't support dynamic fees are charged only for their bandwidth.
+func (m *mempool) meter(tx *txs.Tx) (*meteredTx, error) {
+	burned, err := txs.Burned(tx.Unsigned, m.avaxAssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	complexity, err := fee.TxComplexity(tx.Unsigned)
+	if errors.Is(err, fee.ErrUnsupportedTx) {
+		complexity = feecomponent.Dimensions{
+			feecomponent.Bandwidth: uint64(tx.Size()),
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	gas, err := complexity.ToGas(m.weights)
+	if err != nil {
+		return nil, err
+	}
+	return &meteredTx{
+		Tx:  tx,
+		fee: burned,
+		// Every tx is treated as consuming gas so that priorities are well
+		// defined even if no weights are configured.
+		gas: max(uint64(gas), 1),
+	}, nil
+}
+
+// replaceableConflicts returns the txs that consume any of [inputs]. An error
+// is returned if [newTx] doesn't pay a sufficiently higher fee than them.
+func (m *mempool) replaceableConflicts(newTx *meteredTx, inputs set.Set[ids.ID]) ([]*meteredTx, error) {
+	if !m.consumedUTXOs.HasOverlap(inputs) {
+		return nil, nil
+	}
+
+	var (
+		conflicts   []*meteredTx
+		conflictIDs set.Set[ids.ID]
+		totalFee    uint64
+	)
+	for inputID := range inputs {
+		conflictID, ok := m.consumedUTXOs.GetKey(inputID)
+		if !ok || conflictIDs.Contains(conflictID) {
+			continue
+		}
+		conflictIDs.Add(conflictID)
+
+		conflict, _ := m.unissuedTxs.Get(conflictID)
+		if !exceedsFeeRate(newTx, conflict, MinReplacementFeeRateIncrease) {
+			return nil, fmt.Errorf("%w: %w: %s must pay %d%% more per unit of gas than %s",
+				txmempool.ErrConflictsWithOtherTx,
+				ErrReplacementFeeTooLow,
+				newTx.ID(),
+				MinReplacementFeeRateIncrease,
+				conflictID,
+			)
+		}
+
+		var err error
+		totalFee, err = safemath.Add(totalFee, conflict.fee)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	if newTx.fee < totalFee {
+		return nil, fmt.Errorf("%w: %w: %s pays %d but the txs it replaces pay %d",
+			txmempool.ErrConflictsWithOtherTx,
+			ErrReplacementFeeTooLow,
+			newTx.ID(),
+			newTx.fee,
+			totalFee,
+		)
+	}
+	return conflicts, nil
+}
+
+// evictable returns the lowest priority txs, other than [replaced], that must
+// be evicted to free [bytesNeeded]. An error is returned if any of them has at
+// least the priority of [newTx].
+func (m *mempool) evictable(newTx *meteredTx, replaced []*meteredTx, bytesNeeded int) ([]*meteredTx, error) {
+	if bytesNeeded <= 0 {
+		return nil, nil
+	}
+
+	replacedIDs := set.NewSet[ids.ID](len(replaced))
+	for _, tx := range replaced {
+		replacedIDs.Add(tx.ID())
+	}
+
+	// Txs are popped from the heap to find the next lowest priority tx, so
+	// they must be pushed back before returning.
+	var popped []*meteredTx
+	defer func() {
+		for _, tx := range popped {
+			m.lowestFirst.Push(tx.ID(), tx)
+		}
+	}()
+
+	var evicted []*meteredTx
+	for bytesNeeded > 0 {
+		txID, tx, ok := m.lowestFirst.Pop()
+		if !ok {
+			break
+		}
+		popped = append(popped, tx)
+		if replacedIDs.Contains(txID) {
+			continue
+		}
+		if !hasHigherPriority(newTx, tx) {
+			break
+		}
+
+		evicted = append(evicted, tx)
+		bytesNeeded -= tx.Size()
+	}
+	if bytesNeeded > 0 {
+		return nil, fmt.Errorf("%w: %s needs %d more bytes than can be evicted",
+			txmempool.ErrMempoolFull,
+			newTx.ID(),
+			bytesNeeded,
+		)
+	}
+	return evicted, nil
+}
+
+func (m *mempool) Get(txID ids.ID) (*txs.Tx, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	tx, ok := m.unissuedTxs.Get(txID)
+	if !ok {
+		return nil, false
+	}
+	return tx.Tx, true
+}
+
+func (m *mempool) Remove(txs ...*txs.Tx) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, tx := range txs {
+		// If the transaction is in the mempool, remove it.
+		if meteredTx, ok := m.unissuedTxs.Get(tx.ID()); ok {
+			m.remove(meteredTx)
+			continue
+		}
+
+		// If the transaction isn't in the mempool, remove any conflicts it has.
+		inputs := tx.InputIDs()
+		for _, removed := range m.consumedUTXOs.DeleteOverlapping(inputs) {
+			meteredTx, _ := m.unissuedTxs.Get(removed.Key)
+			m.remove(meteredTx)
+		}
+	}
+	m.updateMetrics()
+}
+
+func (m *mempool) remove(tx *meteredTx) {
+	txID := tx.ID()
+	m.consumedUTXOs.DeleteKey(txID)
+	m.producedUTXOs.DeleteKey(txID)
+	m.unissuedTxs.Delete(txID)
+	m.highestFirst.Remove(txID)
+	m.lowestFirst.Remove(txID)
+	m.bytesAvailable += tx.Size()
+}
+
+// Peek returns the tx paying the highest fee per unit of gas. If that tx
+// spends UTXOs produced by other txs in the mempool, one of its ancestors is
+// returned instead so that parents are always issued before their children.
+func (m *mempool) Peek() (*txs.Tx, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	_, tx, ok := m.highestFirst.Peek()
+	if !ok {
+		return nil, false
+	}
+	for {
+		parent, ok := m.highestPriorityParent(tx)
+		if !ok {
+			return tx.Tx, true
+		}
+		tx = parent
+	}
+}
+
+// highestPriorityParent returns the highest priority tx in the mempool that
+// produces a UTXO consumed by [tx], if there is one.
+func (m *mempool) highestPriorityParent(tx *meteredTx) (*meteredTx, bool) {
+	var parent *meteredTx
+	for inputID := range tx.InputIDs() {
+		parentID, ok := m.producedUTXOs.GetKey(inputID)
+		if !ok {
+			continue
+		}
+		candidate, _ := m.unissuedTxs.Get(parentID)
+		if parent == nil || hasHigherPriority(candidate, parent) {
+			parent = candidate
+		}
+	}
+	return parent, parent != nil
+}
+
+// Iterate iterates over the txs in the order they were added.
+func (m *mempool) Iterate(f func(*txs.Tx) bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	it := m.unissuedTxs.NewIterator()
+	for it.Next() {
+		if !f(it.Value().Tx) {
+			return
+		}
+	}
+}
+
+func (m *mempool) AddedTime(txID ids.ID) (time.Time, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	tx, ok := m.unissuedTxs.Get(txID)
+	if !ok {
+		return time.Time{}, false
+	}
+	return tx.added, true
+}
+
+// Snapshot returns the txs in the order they were added.
+func (m *mempool) Snapshot() []txmempool.Entry[*txs.Tx] {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	entries := make([]txmempool.Entry[*txs.Tx], 0, m.unissuedTxs.Len())
+	it := m.unissuedTxs.NewIterator()
+	for it.Next() {
+		tx := it.Value()
+		entries = append(entries, txmempool.Entry[*txs.Tx]{
+			Tx:        tx.Tx,
+			AddedTime: tx.added,
+		})
+	}
+	return entries
+}
+
+func (m *mempool) MarkDropped(txID ids.ID, reason error) {
+	if errors.Is(reason, txmempool.ErrMempoolFull) {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.unissuedTxs.Get(txID); ok {
+		return
+	}
+
+	m.markDropped(txID, reason)
+}
+
+func (m *mempool) markDropped(txID ids.ID, reason error) {
+	// Re-dropping a tx moves it to the end of the dropped txs.
+	m.droppedTxIDs.Delete(txID)
+	if m.droppedTxIDs.Len() >= droppedTxIDsCacheSize {
+		oldestTxID, _, _ := m.droppedTxIDs.Oldest()
+		m.droppedTxIDs.Delete(oldestTxID)
+	}
+	m.droppedTxIDs.Put(txID, reason)
+}
+
+func (m *mempool) GetDropReason(txID ids.ID) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	err, _ := m.droppedTxIDs.Get(txID)
+	return err
+}
+
+func (m *mempool) IterateDropped(f func(txID ids.ID, reason error) bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	it := m.droppedTxIDs.NewIterator()
+	for it.Next() {
+		if !f(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
+func (m *mempool) Len() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.unissuedTxs.Len()
 }
 
 func (m *mempool) RequestBuildBlock(emptyBlockPermitted bool) {
@@ -78,3 +527,38 @@ func (m *mempool) RequestBuildBlock(emptyBlockPermitted bool) {
 	default:
 	}
 }
+
+// hasHigherPriority returns true if [a] pays a higher fee per unit of gas than
+// [b], or if they pay the same fee per unit of gas and [a] arrived first.
+func hasHigherPriority(a, b *meteredTx) bool {
+	// a.fee / a.gas > b.fee / b.gas <=> a.fee * b.gas > b.fee * a.gas
+	aHi, aLo := bits.Mul64(a.fee, b.gas)
+	bHi, bLo := bits.Mul64(b.fee, a.gas)
+	switch {
+	case aHi != bHi:
+		return aHi > bHi
+	case aLo != bLo:
+		return aLo > bLo
+	default:
+		return a.index < b.index
+	}
+}
+
+func hasLowerPriority(a, b *meteredTx) bool {
+	return hasHigherPriority(b, a)
+}
+
+// exceedsFeeRate returns true if [a] pays at least [percent]% more per unit of
+// gas than [b].
+func exceedsFeeRate(a, b *meteredTx, percent uint64) bool {
+	// a.fee / a.gas >= (100 + percent) / 100 * b.fee / b.gas
+	// <=> 100 * a.fee * b.gas >= (100 + percent) * b.fee * a.gas
+	lhs := new(big.Int).SetUint64(100)
+	lhs.Mul(lhs, new(big.Int).SetUint64(a.fee))
+	lhs.Mul(lhs, new(big.Int).SetUint64(b.gas))
+
+	rhs := new(big.Int).SetUint64(100 + percent)
+	rhs.Mul(rhs, new(big.Int).SetUint64(b.fee))
+	rhs.Mul(rhs, new(big.Int).SetUint64(a.gas))
+	return lhs.Cmp(rhs) >= 0
+}
@@ -0,0 +1,335 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	feecomponent "github.com/ava-labs/avalanchego/vms/components/fee"
+	txmempool "github.com/ava-labs/avalanchego/vms/txs/mempool"
+)
+
+var (
+	avaxAssetID = ids.GenerateTestID()
+	weights     = feecomponent.Dimensions{1, 1, 1, 1}
+)
+
+func newMempool(t *testing.T) *mempool {
+	m, err := New("", prometheus.NewRegistry(), nil, avaxAssetID, weights)
+	require.NoError(t, err)
+	return m.(*mempool)
+}
+
+// newTx returns a tx that spends the UTXO produced by [utxoTxID] and burns
+// [fee]. Txs created by this function all consume the same amount of gas.
+func newTx(t *testing.T, utxoTxID ids.ID, fee uint64) *txs.Tx {
+	const amount = units.Avax
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{TxID: utxoTxID},
+			Asset:  avax.Asset{ID: avaxAssetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: amount,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount - fee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+		}},
+	}}}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+func TestAddUnissuableTxs(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool(t)
+
+	err := mempool.Add(&txs.Tx{Unsigned: &txs.AdvanceTimeTx{}})
+	require.ErrorIs(err, ErrCantIssueAdvanceTimeTx)
+
+	err = mempool.Add(&txs.Tx{Unsigned: &txs.RewardValidatorTx{}})
+	require.ErrorIs(err, ErrCantIssueRewardValidatorTx)
+}
+
+func TestPeekHighestFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool(t)
+
+	var (
+		lowFeeTx   = newTx(t, ids.GenerateTestID(), units.MilliAvax)
+		highFeeTx  = newTx(t, ids.GenerateTestID(), 2*units.MilliAvax)
+		highFeeTx2 = newTx(t, ids.GenerateTestID(), 2*units.MilliAvax)
+	)
+	require.NoError(mempool.Add(lowFeeTx))
+	require.NoError(mempool.Add(highFeeTx))
+	require.NoError(mempool.Add(highFeeTx2))
+
+	// Txs paying the same fee rate are ordered by arrival.
+	for _, expectedTx := range []*txs.Tx{highFeeTx, highFeeTx2, lowFeeTx} {
+		tx, ok := mempool.Peek()
+		require.True(ok)
+		require.Equal(expectedTx, tx)
+		mempool.Remove(tx)
+	}
+
+	_, ok := mempool.Peek()
+	require.False(ok)
+}
+
+func TestPeekParentBeforeChild(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool(t)
+
+	var (
+		parentTx     = newTx(t, ids.GenerateTestID(), units.MilliAvax)
+		childTx      = newTx(t, parentTx.ID(), 2*units.MilliAvax)
+		grandchildTx = newTx(t, childTx.ID(), 3*units.MilliAvax)
+	)
+	require.NoError(mempool.Add(grandchildTx))
+	require.NoError(mempool.Add(childTx))
+	require.NoError(mempool.Add(parentTx))
+
+	// Txs are returned after the txs that produce the UTXOs they spend, even
+	// though they pay a higher fee rate.
+	for _, expectedTx := range []*txs.Tx{parentTx, childTx, grandchildTx} {
+		tx, ok := mempool.Peek()
+		require.True(ok)
+		require.Equal(expectedTx, tx)
+		mempool.Remove(tx)
+	}
+
+	_, ok := mempool.Peek()
+	require.False(ok)
+}
+
+func TestIterateByArrival(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool(t)
+
+	expectedTxs := []*txs.Tx{
+		newTx(t, ids.GenerateTestID(), units.MilliAvax),
+		newTx(t, ids.GenerateTestID(), 2*units.MilliAvax),
+	}
+	for _, tx := range expectedTxs {
+		require.NoError(mempool.Add(tx))
+	}
+
+	var iteratedTxs []*txs.Tx
+	mempool.Iterate(func(tx *txs.Tx) bool {
+		iteratedTxs = append(iteratedTxs, tx)
+		return true
+	})
+	require.Equal(expectedTxs, iteratedTxs)
+}
+
+func TestReplaceByFee(t *testing.T) {
+	utxoTxID := ids.GenerateTestID()
+	originalTx := newTx(t, utxoTxID, 10*units.MilliAvax)
+
+	tests := []struct {
+		name        string
+		replacement *txs.Tx
+		expectedErr error
+	}{
+		{
+			name:        "same fee",
+			replacement: newTx(t, utxoTxID, 10*units.MilliAvax),
+			expectedErr: ErrReplacementFeeTooLow,
+		},
+		{
+			name:        "insufficient fee increase",
+			replacement: newTx(t, utxoTxID, 10*units.MilliAvax+units.MilliAvax/2),
+			expectedErr: ErrReplacementFeeTooLow,
+		},
+		{
+			name:        "sufficient fee increase",
+			replacement: newTx(t, utxoTxID, 11*units.MilliAvax),
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			mempool := newMempool(t)
+			require.NoError(mempool.Add(originalTx))
+
+			err := mempool.Add(test.replacement)
+			require.ErrorIs(err, test.expectedErr)
+			if err != nil {
+				require.ErrorIs(err, txmempool.ErrConflictsWithOtherTx)
+
+				_, ok := mempool.Get(originalTx.ID())
+				require.True(ok)
+				_, ok = mempool.Get(test.replacement.ID())
+				require.False(ok)
+				return
+			}
+
+			_, ok := mempool.Get(originalTx.ID())
+			require.False(ok)
+			_, ok = mempool.AddedTime(originalTx.ID())
+			require.False(ok)
+			require.ErrorIs(mempool.GetDropReason(originalTx.ID()), ErrReplaced)
+
+			tx, ok := mempool.Peek()
+			require.True(ok)
+			require.Equal(test.replacement, tx)
+			require.Equal(1, mempool.Len())
+		})
+	}
+}
+
+func TestReplaceMultipleTxs(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool(t)
+
+	var (
+		utxoTxID0 = ids.GenerateTestID()
+		utxoTxID1 = ids.GenerateTestID()
+		tx0       = newTx(t, utxoTxID0, 10*units.MilliAvax)
+		tx1       = newTx(t, utxoTxID1, 10*units.MilliAvax)
+	)
+	require.NoError(mempool.Add(tx0))
+	require.NoError(mempool.Add(tx1))
+
+	// The replacement pays a higher fee rate than each of the txs it
+	// replaces, but not more than their combined fee.
+	replacement := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		Ins: append(
+			tx0.Unsigned.(*txs.BaseTx).Ins,
+			tx1.Unsigned.(*txs.BaseTx).Ins...,
+		),
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 2*units.Avax - 19*units.MilliAvax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+		}},
+	}}}
+	require.NoError(replacement.Initialize(txs.Codec))
+
+	meteredReplacement, err := mempool.meter(replacement)
+	require.NoError(err)
+	for _, tx := range []*txs.Tx{tx0, tx1} {
+		meteredTx, err := mempool.meter(tx)
+		require.NoError(err)
+		require.True(exceedsFeeRate(meteredReplacement, meteredTx, MinReplacementFeeRateIncrease))
+	}
+
+	err = mempool.Add(replacement)
+	require.ErrorIs(err, ErrReplacementFeeTooLow)
+	require.Equal(2, mempool.Len())
+}
+
+func TestEvictLowestFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool(t)
+
+	var (
+		lowFeeTx  = newTx(t, ids.GenerateTestID(), units.MilliAvax)
+		midFeeTx  = newTx(t, ids.GenerateTestID(), 2*units.MilliAvax)
+		highFeeTx = newTx(t, ids.GenerateTestID(), 3*units.MilliAvax)
+	)
+
+	// Only allow two txs to fit into the mempool.
+	mempool.bytesAvailable = lowFeeTx.Size() + midFeeTx.Size()
+	require.NoError(mempool.Add(lowFeeTx))
+	require.NoError(mempool.Add(midFeeTx))
+
+	// A tx that doesn't pay more than the lowest priority tx can't evict it.
+	err := mempool.Add(newTx(t, ids.GenerateTestID(), units.MilliAvax))
+	require.ErrorIs(err, txmempool.ErrMempoolFull)
+	require.Equal(2, mempool.Len())
+
+	require.NoError(mempool.Add(highFeeTx))
+	require.Equal(2, mempool.Len())
+
+	_, ok := mempool.Get(lowFeeTx.ID())
+	require.False(ok)
+	require.ErrorIs(mempool.GetDropReason(lowFeeTx.ID()), ErrEvicted)
+
+	var iteratedTxs []*txs.Tx
+	mempool.Iterate(func(tx *txs.Tx) bool {
+		iteratedTxs = append(iteratedTxs, tx)
+		return true
+	})
+	require.Equal([]*txs.Tx{midFeeTx, highFeeTx}, iteratedTxs)
+}
+
+func TestEvictTiesFavorEarlierTxs(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool(t)
+
+	var (
+		highFeeTx = newTx(t, ids.GenerateTestID(), 2*units.MilliAvax)
+		lowFeeTx  = newTx(t, ids.GenerateTestID(), units.MilliAvax)
+	)
+
+	// Only allow two txs to fit into the mempool. The lowest priority tx is
+	// added last, so it doesn't have the lowest index.
+	mempool.bytesAvailable = highFeeTx.Size() + lowFeeTx.Size()
+	require.NoError(mempool.Add(highFeeTx))
+	require.NoError(mempool.Add(lowFeeTx))
+
+	// A tx paying the same fee rate as the lowest priority tx arrived later,
+	// so it can't evict it.
+	tiedTx := newTx(t, ids.GenerateTestID(), units.MilliAvax)
+	err := mempool.Add(tiedTx)
+	require.ErrorIs(err, txmempool.ErrMempoolFull)
+
+	_, ok := mempool.Get(lowFeeTx.ID())
+	require.True(ok)
+	_, ok = mempool.Get(tiedTx.ID())
+	require.False(ok)
+}
+
+func TestRemoveConflicts(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool(t)
+
+	utxoTxID := ids.GenerateTestID()
+	tx := newTx(t, utxoTxID, units.MilliAvax)
+	require.NoError(mempool.Add(tx))
+
+	// Removing a tx that isn't in the mempool removes its conflicts.
+	mempool.Remove(newTx(t, utxoTxID, 2*units.MilliAvax))
+	require.Zero(mempool.Len())
+	require.Equal(maxMempoolSize, mempool.bytesAvailable)
+
+	_, ok := mempool.Peek()
+	require.False(ok)
+}
@@ -11,9 +11,11 @@ package mempool
 
 import (
 	reflect "reflect"
+	time "time"
 
 	ids "github.com/ava-labs/avalanchego/ids"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	mempool0 "github.com/ava-labs/avalanchego/vms/txs/mempool"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -54,6 +56,21 @@ func (mr *MockMempoolMockRecorder) Add(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockMempool)(nil).Add), arg0)
 }
 
+// AddedTime mocks base method.
+func (m *MockMempool) AddedTime(arg0 ids.ID) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddedTime", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// AddedTime indicates an expected call of AddedTime.
+func (mr *MockMempoolMockRecorder) AddedTime(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddedTime", reflect.TypeOf((*MockMempool)(nil).AddedTime), arg0)
+}
+
 // Get mocks base method.
 func (m *MockMempool) Get(arg0 ids.ID) (*txs.Tx, bool) {
 	m.ctrl.T.Helper()
@@ -95,6 +112,18 @@ func (mr *MockMempoolMockRecorder) Iterate(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockMempool)(nil).Iterate), arg0)
 }
 
+// IterateDropped mocks base method.
+func (m *MockMempool) IterateDropped(arg0 func(ids.ID, error) bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IterateDropped", arg0)
+}
+
+// IterateDropped indicates an expected call of IterateDropped.
+func (mr *MockMempoolMockRecorder) IterateDropped(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateDropped", reflect.TypeOf((*MockMempool)(nil).IterateDropped), arg0)
+}
+
 // Len mocks base method.
 func (m *MockMempool) Len() int {
 	m.ctrl.T.Helper()
@@ -163,3 +192,17 @@ func (mr *MockMempoolMockRecorder) RequestBuildBlock(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestBuildBlock", reflect.TypeOf((*MockMempool)(nil).RequestBuildBlock), arg0)
 }
+
+// Snapshot mocks base method.
+func (m *MockMempool) Snapshot() []mempool0.Entry[*txs.Tx] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].([]mempool0.Entry[*txs.Tx])
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockMempoolMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockMempool)(nil).Snapshot))
+}
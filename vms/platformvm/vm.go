@@ -31,6 +31,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -77,6 +78,8 @@ type VM struct {
 
 	state state.State
 
+	txIndexer index.TxIndexer
+
 	fx            fx.Fx
 	codecRegistry codec.Registry
 
@@ -85,6 +88,9 @@ type VM struct {
 
 	manager blockexecutor.Manager
 
+	// mempoolRemovalEnabled allows the API to remove txs from the mempool
+	mempoolRemovalEnabled bool
+
 	// Cancelled on shutdown
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
@@ -111,6 +117,7 @@ func (vm *VM) Initialize(
 		return err
 	}
 	chainCtx.Log.Info("using VM execution config", zap.Reflect("config", execConfig))
+	vm.mempoolRemovalEnabled = execConfig.MempoolRemovalEnabled
 
 	registerer, err := metrics.MakeAndRegister(chainCtx.Metrics, "")
 	if err != nil {
@@ -149,6 +156,18 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	vm.txIndexer, err = newTxIndexer(
+		vm.db,
+		vm.ctx,
+		vm.state,
+		genesisBytes,
+		registerer,
+		execConfig.Indexes,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize transaction indexer: %w", err)
+	}
+
 	validatorManager := pvalidators.NewManager(chainCtx.Log, vm.Config, vm.state, vm.metrics, &vm.clock)
 	vm.State = validatorManager
 	utxoVerifier := utxo.NewVerifier(vm.ctx, &vm.clock, vm.fx)
@@ -166,7 +185,23 @@ func (vm *VM) Initialize(
 		Bootstrapped: &vm.bootstrapped,
 	}
 
-	mempool, err := pmempool.New("mempool", registerer, toEngine)
+	// The simulation fx is never marked as bootstrapped, so it doesn't verify
+	// signatures. Its types were already registered by [vm.fx].
+	simulationFx := &secp256k1fx.Fx{}
+	if err := simulationFx.InitializeVM(vm); err != nil {
+		return err
+	}
+	simulationTxExecutorBackend := *txExecutorBackend
+	simulationTxExecutorBackend.Fx = simulationFx
+	simulationTxExecutorBackend.FlowChecker = utxo.NewVerifier(vm.ctx, &vm.clock, simulationFx)
+
+	mempool, err := pmempool.New(
+		"mempool",
+		registerer,
+		toEngine,
+		vm.ctx.AVAXAssetID,
+		vm.DynamicFeeConfig.Weights,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
@@ -176,7 +211,9 @@ func (vm *VM) Initialize(
 		vm.metrics,
 		vm.state,
 		txExecutorBackend,
+		&simulationTxExecutorBackend,
 		validatorManager,
+		vm.txIndexer,
 	)
 
 	txVerifier := network.NewLockedTxVerifier(&txExecutorBackend.Ctx.Lock, vm.manager)
@@ -240,6 +277,14 @@ func (vm *VM) Initialize(
 		}
 	}()
 
+	go func() {
+		if err := vm.txIndexer.Backfill(vm.onShutdownCtx); err != nil {
+			vm.ctx.Log.Warn("backfilling transaction indexes failed",
+				zap.Error(err),
+			)
+		}
+	}()
+
 	return nil
 }
 
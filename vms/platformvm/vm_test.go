@@ -1511,6 +1511,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		consensusCtx.Registerer,
 		set.Of(ctx.NodeID),
 		nil,
+		nil,
 	)
 	require.NoError(err)
 
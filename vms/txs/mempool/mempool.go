@@ -7,8 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
-	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/linked"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -21,7 +21,7 @@ const (
 	// allowed into the mempool.
 	MaxTxSize = 64 * units.KiB
 
-	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to cache
+	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to track
 	droppedTxIDsCacheSize = 64
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
@@ -41,6 +41,12 @@ type Tx interface {
 	Size() int
 }
 
+// Entry is a tx in the mempool along with the time it was added.
+type Entry[T Tx] struct {
+	Tx        T
+	AddedTime time.Time
+}
+
 type Metrics interface {
 	Update(numTxs, bytesAvailable int)
 }
@@ -57,12 +63,23 @@ type Mempool[T Tx] interface {
 	// Iterate iterates over the txs until f returns false
 	Iterate(f func(tx T) bool)
 
+	// AddedTime returns the time [txID] was added to the mempool.
+	AddedTime(txID ids.ID) (time.Time, bool)
+
+	// Snapshot returns the txs in the order they are iterated in, along with
+	// the times they were added to the mempool.
+	Snapshot() []Entry[T]
+
 	// Note: dropped txs are added to droppedTxIDs but are not evicted from
 	// unissued decision/staker txs. This allows previously dropped txs to be
 	// possibly reissued.
 	MarkDropped(txID ids.ID, reason error)
 	GetDropReason(txID ids.ID) error
 
+	// IterateDropped iterates over the recently dropped txs, from the least
+	// recently dropped to the most recently dropped, until f returns false.
+	IterateDropped(f func(txID ids.ID, reason error) bool)
+
 	// Len returns the number of txs in the mempool.
 	Len() int
 }
@@ -70,9 +87,10 @@ type Mempool[T Tx] interface {
 type mempool[T Tx] struct {
 	lock           sync.RWMutex
 	unissuedTxs    *linked.Hashmap[ids.ID, T]
+	addedTimes     map[ids.ID]time.Time           // TxID -> Time added
 	consumedUTXOs  *setmap.SetMap[ids.ID, ids.ID] // TxID -> Consumed UTXOs
 	bytesAvailable int
-	droppedTxIDs   *cache.LRU[ids.ID, error] // TxID -> Verification error
+	droppedTxIDs   *linked.Hashmap[ids.ID, error] // TxID -> Verification error
 
 	metrics Metrics
 }
@@ -82,9 +100,10 @@ func New[T Tx](
 ) *mempool[T] {
 	m := &mempool[T]{
 		unissuedTxs:    linked.NewHashmap[ids.ID, T](),
+		addedTimes:     make(map[ids.ID]time.Time),
 		consumedUTXOs:  setmap.New[ids.ID, ids.ID](),
 		bytesAvailable: maxMempoolSize,
-		droppedTxIDs:   &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
+		droppedTxIDs:   linked.NewHashmap[ids.ID, error](),
 		metrics:        metrics,
 	}
 	m.updateMetrics()
@@ -131,13 +150,14 @@ func (m *mempool[T]) Add(tx T) error {
 
 	m.bytesAvailable -= txSize
 	m.unissuedTxs.Put(txID, tx)
+	m.addedTimes[txID] = time.Now()
 	m.updateMetrics()
 
 	// Mark these UTXOs as consumed in the mempool
 	m.consumedUTXOs.Put(txID, inputs)
 
 	// An added tx must not be marked as dropped.
-	m.droppedTxIDs.Evict(txID)
+	m.droppedTxIDs.Delete(txID)
 	return nil
 }
 
@@ -157,6 +177,7 @@ func (m *mempool[T]) Remove(txs ...T) {
 		// If the transaction is in the mempool, remove it.
 		if _, ok := m.consumedUTXOs.DeleteKey(txID); ok {
 			m.unissuedTxs.Delete(txID)
+			delete(m.addedTimes, txID)
 			m.bytesAvailable += tx.Size()
 			continue
 		}
@@ -166,6 +187,7 @@ func (m *mempool[T]) Remove(txs ...T) {
 		for _, removed := range m.consumedUTXOs.DeleteOverlapping(inputs) {
 			tx, _ := m.unissuedTxs.Get(removed.Key)
 			m.unissuedTxs.Delete(removed.Key)
+			delete(m.addedTimes, removed.Key)
 			m.bytesAvailable += tx.Size()
 		}
 	}
@@ -192,26 +214,71 @@ func (m *mempool[T]) Iterate(f func(T) bool) {
 	}
 }
 
+func (m *mempool[_]) AddedTime(txID ids.ID) (time.Time, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	addedTime, ok := m.addedTimes[txID]
+	return addedTime, ok
+}
+
+func (m *mempool[T]) Snapshot() []Entry[T] {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	entries := make([]Entry[T], 0, m.unissuedTxs.Len())
+	it := m.unissuedTxs.NewIterator()
+	for it.Next() {
+		txID, tx := it.Key(), it.Value()
+		entries = append(entries, Entry[T]{
+			Tx:        tx,
+			AddedTime: m.addedTimes[txID],
+		})
+	}
+	return entries
+}
+
 func (m *mempool[_]) MarkDropped(txID ids.ID, reason error) {
 	if errors.Is(reason, ErrMempoolFull) {
 		return
 	}
 
-	m.lock.RLock()
-	defer m.lock.RUnlock()
+	m.lock.Lock()
+	defer m.lock.Unlock()
 
 	if _, ok := m.unissuedTxs.Get(txID); ok {
 		return
 	}
 
+	// Re-dropping a tx moves it to the end of the dropped txs.
+	m.droppedTxIDs.Delete(txID)
+	if m.droppedTxIDs.Len() >= droppedTxIDsCacheSize {
+		oldestTxID, _, _ := m.droppedTxIDs.Oldest()
+		m.droppedTxIDs.Delete(oldestTxID)
+	}
 	m.droppedTxIDs.Put(txID, reason)
 }
 
 func (m *mempool[_]) GetDropReason(txID ids.ID) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
 	err, _ := m.droppedTxIDs.Get(txID)
 	return err
 }
 
+func (m *mempool[_]) IterateDropped(f func(txID ids.ID, reason error) bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	it := m.droppedTxIDs.NewIterator()
+	for it.Next() {
+		if !f(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
 func (m *mempool[_]) Len() int {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
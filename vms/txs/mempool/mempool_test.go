@@ -6,6 +6,7 @@ package mempool
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -255,6 +256,87 @@ func TestDropped(t *testing.T) {
 	require.NoError(mempool.GetDropReason(txID))
 }
 
+func TestAddedTime(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool()
+
+	tx := newTx(0, 32)
+	txID := tx.ID()
+
+	_, ok := mempool.AddedTime(txID)
+	require.False(ok)
+
+	before := time.Now()
+	require.NoError(mempool.Add(tx))
+	after := time.Now()
+
+	addedTime, ok := mempool.AddedTime(txID)
+	require.True(ok)
+	require.False(addedTime.Before(before))
+	require.False(addedTime.After(after))
+
+	mempool.Remove(tx)
+	_, ok = mempool.AddedTime(txID)
+	require.False(ok)
+}
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool()
+	require.Empty(mempool.Snapshot())
+
+	txs := []*dummyTx{
+		newTx(0, 32),
+		newTx(1, 32),
+	}
+	for _, tx := range txs {
+		require.NoError(mempool.Add(tx))
+	}
+
+	entries := mempool.Snapshot()
+	require.Len(entries, len(txs))
+	for i, entry := range entries {
+		require.Equal(txs[i], entry.Tx)
+
+		addedTime, ok := mempool.AddedTime(txs[i].ID())
+		require.True(ok)
+		require.Equal(addedTime, entry.AddedTime)
+	}
+}
+
+func TestIterateDropped(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool()
+
+	var (
+		txIDs  = make([]ids.ID, droppedTxIDsCacheSize+1)
+		reason = errors.New("test")
+	)
+	for i := range txIDs {
+		txIDs[i] = ids.GenerateTestID()
+		mempool.MarkDropped(txIDs[i], reason)
+	}
+
+	// Re-dropping the oldest remaining tx should make it the most recently
+	// dropped tx.
+	mempool.MarkDropped(txIDs[1], reason)
+	expectedTxIDs := make([]ids.ID, 0, droppedTxIDsCacheSize)
+	expectedTxIDs = append(expectedTxIDs, txIDs[2:]...)
+	expectedTxIDs = append(expectedTxIDs, txIDs[1])
+
+	var iteratedTxIDs []ids.ID
+	mempool.IterateDropped(func(txID ids.ID, err error) bool {
+		require.ErrorIs(err, reason)
+		iteratedTxIDs = append(iteratedTxIDs, txID)
+		return true
+	})
+	require.Equal(expectedTxIDs, iteratedTxIDs)
+	require.NoError(mempool.GetDropReason(txIDs[0]))
+}
+
 func newTxs(num int, size int) []*dummyTx {
 	txs := make([]*dummyTx, num)
 	for i := range txs {
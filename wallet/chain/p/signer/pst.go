@@ -0,0 +1,423 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	stdcontext "context"
+)
+
+var (
+	_ Backend           = (*PST)(nil)
+	_ Backend           = (*recordingBackend)(nil)
+	_ keychain.Keychain = addressKeychain{}
+	_ keychain.Signer   = addressSigner{}
+
+	ErrInvalidPST           = errors.New("invalid partially signed transaction")
+	ErrMismatchedPST        = errors.New("partially signed transactions are for different transactions")
+	ErrConflictingSignature = errors.New("conflicting signature")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrMissingSignature     = errors.New("missing signature")
+
+	errAddressOnly = errors.New("signer only knows its address")
+)
+
+// PST is a partially signed transaction. It carries everything that is needed
+// to sign a transaction without access to the chain, which allows the owners of
+// multisig outputs to sign a transaction on separate machines and then merge
+// their signatures.
+type PST struct {
+	// Tx is the transaction being signed. Signatures that haven't been
+	// collected yet are left empty.
+	Tx *txs.Tx `serialize:"true" json:"tx"`
+	// Signers contains the address that is expected to produce each signature
+	// of [Tx], indexed by credential and signature. Signers are derived from
+	// [UTXOs] and [SubnetOwners], and a PST whose signers don't match them is
+	// invalid.
+	Signers [][]ids.ShortID `serialize:"true" json:"signers"`
+	// UTXOs consumed by [Tx].
+	UTXOs []*avax.UTXO `serialize:"true" json:"utxos"`
+	// SubnetOwners of the subnets that must authorize [Tx].
+	SubnetOwners []*SubnetOwner `serialize:"true" json:"subnetOwners"`
+}
+
+type SubnetOwner struct {
+	SubnetID ids.ID   `serialize:"true" json:"subnetID"`
+	Owner    fx.Owner `serialize:"true" json:"owner"`
+}
+
+// Signature is the state of a signature of a PST.
+type Signature struct {
+	// Signer is the address that is expected to produce the signature.
+	Signer ids.ShortID
+	Signed bool
+}
+
+// NewPST returns an unsigned PST of [utx] that contains the UTXOs and subnet
+// owners fetched from [backend] that are needed to sign [utx].
+func NewPST(ctx stdcontext.Context, backend Backend, utx txs.UnsignedTx) (*PST, error) {
+	pst := &PST{
+		Tx: &txs.Tx{Unsigned: utx},
+	}
+
+	var txSigners [][]keychain.Signer
+	err := utx.Visit(&visitor{
+		kc: addressKeychain{},
+		backend: &recordingBackend{
+			backend: backend,
+			pst:     pst,
+		},
+		ctx: ctx,
+		tx:  pst.Tx,
+		onSigners: func(signers [][]keychain.Signer) {
+			txSigners = signers
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pst.Signers = signerAddresses(txSigners)
+
+	// Populate the credentials with empty signatures.
+	return pst, pst.Sign(ctx, secp256k1fx.NewKeychain())
+}
+
+// ParsePST parses a PST serialized with Bytes. Returns an error if the signers
+// of the PST don't match its UTXOs and subnet owners, or if any of its
+// signatures is invalid.
+func ParsePST(pstBytes []byte) (*PST, error) {
+	pst := &PST{}
+	if _, err := txs.Codec.Unmarshal(pstBytes, pst); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPST, err)
+	}
+	if err := pst.Tx.Initialize(txs.Codec); err != nil {
+		return nil, err
+	}
+	if err := pst.verify(false); err != nil {
+		return nil, err
+	}
+	return pst, nil
+}
+
+func (p *PST) Bytes() ([]byte, error) {
+	return txs.Codec.Marshal(txs.CodecVersion, p)
+}
+
+// Sign adds the signatures that [kc] is able to provide to [Tx]. Signing
+// doesn't require access to the chain.
+func (p *PST) Sign(ctx stdcontext.Context, kc keychain.Keychain) error {
+	return New(kc, p).Sign(ctx, p.Tx)
+}
+
+// Merge adds the signatures of [other] that are missing from [p]. The
+// signatures of [other] are verified against the signers derived from the
+// UTXOs and subnet owners of [p], so nothing else of [other] is trusted. If
+// [other] is for a different transaction or contains invalid or different
+// signatures than [p], [p] is left unmodified.
+func (p *PST) Merge(other *PST) error {
+	if !bytes.Equal(p.Tx.Unsigned.Bytes(), other.Tx.Unsigned.Bytes()) {
+		return ErrMismatchedPST
+	}
+	if err := p.verify(false); err != nil {
+		return err
+	}
+	if len(p.Tx.Creds) != len(other.Tx.Creds) {
+		return ErrMismatchedPST
+	}
+
+	// verify checked that every credential is a secp256k1fx credential.
+	unsignedBytes := p.Tx.Unsigned.Bytes()
+	creds := make([]*secp256k1fx.Credential, len(p.Tx.Creds))
+	for credIndex := range creds {
+		cred, _ := p.Tx.Creds[credIndex].(*secp256k1fx.Credential)
+		otherCred, ok := other.Tx.Creds[credIndex].(*secp256k1fx.Credential)
+		if !ok || len(cred.Sigs) != len(otherCred.Sigs) {
+			return ErrMismatchedPST
+		}
+
+		sigs := make([][secp256k1.SignatureLen]byte, len(cred.Sigs))
+		for sigIndex, sig := range cred.Sigs {
+			otherSig := otherCred.Sigs[sigIndex]
+			signer := p.Signers[credIndex][sigIndex]
+			switch {
+			case otherSig == emptySig || sig == otherSig:
+				sigs[sigIndex] = sig
+			case sig == emptySig:
+				if err := verifySignature(unsignedBytes, otherSig, signer); err != nil {
+					return fmt.Errorf("credential %d signature %d: %w", credIndex, sigIndex, err)
+				}
+				sigs[sigIndex] = otherSig
+			default:
+				return fmt.Errorf("%w: credential %d signature %d from %s",
+					ErrConflictingSignature,
+					credIndex,
+					sigIndex,
+					signer,
+				)
+			}
+		}
+		creds[credIndex] = &secp256k1fx.Credential{Sigs: sigs}
+	}
+
+	for credIndex, cred := range creds {
+		p.Tx.Creds[credIndex] = cred
+	}
+	return p.Tx.Initialize(txs.Codec)
+}
+
+// verify returns an error if [Signers] doesn't match the signers derived from
+// [UTXOs] and [SubnetOwners], or if a signature of [Tx] wasn't produced by its
+// signer. If [complete] is true, missing signatures are reported as well.
+func (p *PST) verify(complete bool) error {
+	signatures, err := p.Signatures()
+	if err != nil {
+		return err
+	}
+
+	var txSigners [][]keychain.Signer
+	err = p.Tx.Unsigned.Visit(&visitor{
+		kc:      addressKeychain{},
+		backend: p,
+		ctx:     stdcontext.Background(),
+		tx:      p.Tx,
+		onSigners: func(signers [][]keychain.Signer) {
+			txSigners = signers
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPST, err)
+	}
+	if !slices.EqualFunc(p.Signers, signerAddresses(txSigners), slices.Equal[[]ids.ShortID]) {
+		return fmt.Errorf("%w: signers don't match the UTXOs and subnet owners", ErrInvalidPST)
+	}
+
+	unsignedBytes := p.Tx.Unsigned.Bytes()
+	for credIndex, credSignatures := range signatures {
+		// Signatures verified that every credential is a secp256k1fx
+		// credential.
+		cred, _ := p.Tx.Creds[credIndex].(*secp256k1fx.Credential)
+		for sigIndex, signature := range credSignatures {
+			if !signature.Signed {
+				if !complete {
+					continue
+				}
+				return fmt.Errorf("%w: credential %d signature %d from %s",
+					ErrMissingSignature,
+					credIndex,
+					sigIndex,
+					signature.Signer,
+				)
+			}
+			if err := verifySignature(unsignedBytes, cred.Sigs[sigIndex], signature.Signer); err != nil {
+				return fmt.Errorf("credential %d signature %d: %w", credIndex, sigIndex, err)
+			}
+		}
+	}
+	return nil
+}
+
+// verifySignature returns an error if [sig] wasn't produced by [signer] over
+// [unsignedBytes].
+func verifySignature(unsignedBytes []byte, sig [secp256k1.SignatureLen]byte, signer ids.ShortID) error {
+	pk, err := secp256k1.RecoverPublicKey(unsignedBytes, sig[:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if addr := pk.Address(); addr != signer {
+		return fmt.Errorf("%w: signed by %s rather than %s", ErrInvalidSignature, addr, signer)
+	}
+	return nil
+}
+
+// Signatures returns the state of every signature of [Tx], indexed by
+// credential and signature.
+func (p *PST) Signatures() ([][]Signature, error) {
+	if len(p.Signers) != len(p.Tx.Creds) {
+		return nil, fmt.Errorf("%w: %d signers for %d credentials",
+			ErrInvalidPST,
+			len(p.Signers),
+			len(p.Tx.Creds),
+		)
+	}
+
+	signatures := make([][]Signature, len(p.Tx.Creds))
+	for credIndex, credIntf := range p.Tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			return nil, ErrUnknownCredentialType
+		}
+		signers := p.Signers[credIndex]
+		if len(signers) != len(cred.Sigs) {
+			return nil, fmt.Errorf("%w: %d signers for %d signatures of credential %d",
+				ErrInvalidPST,
+				len(signers),
+				len(cred.Sigs),
+				credIndex,
+			)
+		}
+
+		signatures[credIndex] = make([]Signature, len(cred.Sigs))
+		for sigIndex, sig := range cred.Sigs {
+			signatures[credIndex][sigIndex] = Signature{
+				Signer: signers[sigIndex],
+				Signed: sig != emptySig,
+			}
+		}
+	}
+	return signatures, nil
+}
+
+// Missing returns the signers whose signatures haven't been collected yet.
+func (p *PST) Missing() (set.Set[ids.ShortID], error) {
+	signatures, err := p.Signatures()
+	if err != nil {
+		return nil, err
+	}
+
+	var missing set.Set[ids.ShortID]
+	for _, credSignatures := range signatures {
+		for _, signature := range credSignatures {
+			if !signature.Signed {
+				missing.Add(signature.Signer)
+			}
+		}
+	}
+	return missing, nil
+}
+
+// Finalize returns [Tx] if all of its signatures have been collected and are
+// valid.
+func (p *PST) Finalize() (*txs.Tx, error) {
+	if err := p.verify(true); err != nil {
+		return nil, err
+	}
+	return p.Tx, nil
+}
+
+func (p *PST) GetUTXO(_ stdcontext.Context, _, utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, ok := p.getUTXO(utxoID)
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func (p *PST) GetSubnetOwner(_ stdcontext.Context, subnetID ids.ID) (fx.Owner, error) {
+	owner, ok := p.getSubnetOwner(subnetID)
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return owner, nil
+}
+
+func (p *PST) getUTXO(utxoID ids.ID) (*avax.UTXO, bool) {
+	for _, utxo := range p.UTXOs {
+		if utxo.InputID() == utxoID {
+			return utxo, true
+		}
+	}
+	return nil, false
+}
+
+func (p *PST) getSubnetOwner(subnetID ids.ID) (fx.Owner, bool) {
+	for _, owner := range p.SubnetOwners {
+		if owner.SubnetID == subnetID {
+			return owner.Owner, true
+		}
+	}
+	return nil, false
+}
+
+// signerAddresses returns the addresses of [txSigners]. Signers that are nil
+// are left empty.
+func signerAddresses(txSigners [][]keychain.Signer) [][]ids.ShortID {
+	addrs := make([][]ids.ShortID, len(txSigners))
+	for credIndex, inputSigners := range txSigners {
+		addrs[credIndex] = make([]ids.ShortID, len(inputSigners))
+		for sigIndex, signer := range inputSigners {
+			if signer != nil {
+				addrs[credIndex][sigIndex] = signer.Address()
+			}
+		}
+	}
+	return addrs
+}
+
+// recordingBackend adds the UTXOs and subnet owners it fetches from [backend]
+// to [pst].
+type recordingBackend struct {
+	backend Backend
+	pst     *PST
+}
+
+func (b *recordingBackend) GetUTXO(ctx stdcontext.Context, chainID, utxoID ids.ID) (*avax.UTXO, error) {
+	if utxo, ok := b.pst.getUTXO(utxoID); ok {
+		return utxo, nil
+	}
+
+	utxo, err := b.backend.GetUTXO(ctx, chainID, utxoID)
+	if err != nil {
+		return nil, err
+	}
+	b.pst.UTXOs = append(b.pst.UTXOs, utxo)
+	return utxo, nil
+}
+
+func (b *recordingBackend) GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (fx.Owner, error) {
+	if owner, ok := b.pst.getSubnetOwner(subnetID); ok {
+		return owner, nil
+	}
+
+	owner, err := b.backend.GetSubnetOwner(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	b.pst.SubnetOwners = append(b.pst.SubnetOwners, &SubnetOwner{
+		SubnetID: subnetID,
+		Owner:    owner,
+	})
+	return owner, nil
+}
+
+// addressKeychain provides a signer for every address. The signers can't sign
+// anything and are only used to find out which addresses must sign a
+// transaction.
+type addressKeychain struct{}
+
+func (addressKeychain) Get(addr ids.ShortID) (keychain.Signer, bool) {
+	return addressSigner(addr), true
+}
+
+func (addressKeychain) Addresses() set.Set[ids.ShortID] {
+	return nil
+}
+
+type addressSigner ids.ShortID
+
+func (addressSigner) SignHash([]byte) ([]byte, error) {
+	return nil, errAddressOnly
+}
+
+func (addressSigner) Sign([]byte) ([]byte, error) {
+	return nil, errAddressOnly
+}
+
+func (s addressSigner) Address() ids.ShortID {
+	return ids.ShortID(s)
+}
@@ -0,0 +1,329 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var testKeys = secp256k1.TestKeys()
+
+type testBackend struct {
+	utxos  map[ids.ID]*avax.UTXO
+	owners map[ids.ID]fx.Owner
+}
+
+func (b *testBackend) GetUTXO(_ context.Context, _, utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, ok := b.utxos[utxoID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func (b *testBackend) GetSubnetOwner(_ context.Context, subnetID ids.ID) (fx.Owner, error) {
+	owner, ok := b.owners[subnetID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return owner, nil
+}
+
+// newTestTx returns a tx that spends a 2-of-3 multisig UTXO and must be
+// authorized by a 1-of-3 subnet owner, along with a backend that knows about
+// both of them.
+func newTestTx(chainName string) (txs.UnsignedTx, *testBackend) {
+	var (
+		addrs = []ids.ShortID{
+			testKeys[0].Address(),
+			testKeys[1].Address(),
+			testKeys[2].Address(),
+		}
+		subnetID = ids.GenerateTestID()
+		utxo     = &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 100,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 2,
+					Addrs:     addrs,
+				},
+			},
+		}
+	)
+
+	utx := &txs.CreateChainTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt: 100,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0, 2},
+					},
+				},
+			}},
+		}},
+		SubnetID:  subnetID,
+		ChainName: chainName,
+		VMID:      ids.GenerateTestID(),
+		SubnetAuth: &secp256k1fx.Input{
+			SigIndices: []uint32{1},
+		},
+	}
+	backend := &testBackend{
+		utxos: map[ids.ID]*avax.UTXO{
+			utxo.InputID(): utxo,
+		},
+		owners: map[ids.ID]fx.Owner{
+			subnetID: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     addrs,
+			},
+		},
+	}
+	return utx, backend
+}
+
+// coSign parses [pstBytes] and signs it with [keys] without access to the
+// chain.
+func coSign(t *testing.T, pstBytes []byte, keys ...*secp256k1.PrivateKey) *PST {
+	require := require.New(t)
+
+	pst, err := ParsePST(pstBytes)
+	require.NoError(err)
+	require.NoError(pst.Sign(context.Background(), secp256k1fx.NewKeychain(keys...)))
+	return pst
+}
+
+func TestPSTMultisig(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	utx, backend := newTestTx("treasury")
+	pst, err := NewPST(ctx, backend, utx)
+	require.NoError(err)
+
+	addrs := []ids.ShortID{
+		testKeys[0].Address(),
+		testKeys[1].Address(),
+		testKeys[2].Address(),
+	}
+	require.Equal(
+		[][]ids.ShortID{
+			{addrs[0], addrs[2]},
+			{addrs[1]},
+		},
+		pst.Signers,
+	)
+	missing, err := pst.Missing()
+	require.NoError(err)
+	require.Equal(set.Of(addrs...), missing)
+
+	pstBytes, err := pst.Bytes()
+	require.NoError(err)
+
+	first := coSign(t, pstBytes, testKeys[0])
+	second := coSign(t, pstBytes, testKeys[1], testKeys[2])
+
+	_, err = first.Finalize()
+	require.ErrorIs(err, ErrMissingSignature)
+	missing, err = first.Missing()
+	require.NoError(err)
+	require.Equal(set.Of(addrs[1], addrs[2]), missing)
+
+	require.NoError(first.Merge(second))
+	signatures, err := first.Signatures()
+	require.NoError(err)
+	require.Equal(
+		[][]Signature{
+			{{Signer: addrs[0], Signed: true}, {Signer: addrs[2], Signed: true}},
+			{{Signer: addrs[1], Signed: true}},
+		},
+		signatures,
+	)
+
+	tx, err := first.Finalize()
+	require.NoError(err)
+
+	parsedTx, err := txs.Parse(txs.Codec, tx.Bytes())
+	require.NoError(err)
+	require.Equal(tx.ID(), parsedTx.ID())
+
+	unsignedBytes := tx.Unsigned.Bytes()
+	for credIndex, cred := range tx.Creds {
+		for sigIndex, sig := range cred.(*secp256k1fx.Credential).Sigs {
+			require.NoError(verifySignature(unsignedBytes, sig, first.Signers[credIndex][sigIndex]))
+		}
+	}
+}
+
+func TestPSTMergeErrors(t *testing.T) {
+	utx, backend := newTestTx("treasury")
+	pst, err := NewPST(context.Background(), backend, utx)
+	require.NoError(t, err)
+	pstBytes, err := pst.Bytes()
+	require.NoError(t, err)
+
+	otherUTX, otherBackend := newTestTx("other")
+	otherPST, err := NewPST(context.Background(), otherBackend, otherUTX)
+	require.NoError(t, err)
+	otherPSTBytes, err := otherPST.Bytes()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		other       func(t *testing.T) *PST
+		expectedErr error
+	}{
+		{
+			name: "different transaction",
+			other: func(t *testing.T) *PST {
+				return coSign(t, otherPSTBytes, testKeys[0])
+			},
+			expectedErr: ErrMismatchedPST,
+		},
+		{
+			name: "signature from the wrong signer",
+			other: func(t *testing.T) *PST {
+				other := coSign(t, pstBytes)
+				sig, err := testKeys[3].Sign(other.Tx.Unsigned.Bytes())
+				require.NoError(t, err)
+
+				cred := other.Tx.Creds[0].(*secp256k1fx.Credential)
+				copy(cred.Sigs[0][:], sig)
+				return other
+			},
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name: "signature from a signer claimed by the other PST",
+			other: func(t *testing.T) *PST {
+				other := coSign(t, pstBytes, testKeys[3])
+				other.Signers[0][0] = testKeys[3].Address()
+				sig, err := testKeys[3].Sign(other.Tx.Unsigned.Bytes())
+				require.NoError(t, err)
+
+				cred := other.Tx.Creds[0].(*secp256k1fx.Credential)
+				copy(cred.Sigs[0][:], sig)
+				return other
+			},
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name: "conflicting signature",
+			other: func(t *testing.T) *PST {
+				other := coSign(t, pstBytes)
+				cred := other.Tx.Creds[1].(*secp256k1fx.Credential)
+				cred.Sigs[0][0] = 1
+				return other
+			},
+			expectedErr: ErrConflictingSignature,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			// The PST being merged into already has the signature of the
+			// subnet owner.
+			pst := coSign(t, pstBytes, testKeys[1])
+			expectedBytes, err := pst.Bytes()
+			require.NoError(err)
+
+			err = pst.Merge(test.other(t))
+			require.ErrorIs(err, test.expectedErr)
+
+			pstBytes, err := pst.Bytes()
+			require.NoError(err)
+			require.Equal(expectedBytes, pstBytes)
+		})
+	}
+}
+
+func TestParsePSTErrors(t *testing.T) {
+	utx, backend := newTestTx("treasury")
+
+	tests := []struct {
+		name        string
+		modify      func(t *testing.T, pst *PST)
+		expectedErr error
+	}{
+		{
+			name: "signer doesn't match the UTXO",
+			modify: func(_ *testing.T, pst *PST) {
+				pst.Signers[0][0] = testKeys[3].Address()
+			},
+			expectedErr: ErrInvalidPST,
+		},
+		{
+			name: "missing UTXO",
+			modify: func(_ *testing.T, pst *PST) {
+				pst.UTXOs = nil
+			},
+			expectedErr: ErrInvalidPST,
+		},
+		{
+			name: "signature from the wrong signer",
+			modify: func(t *testing.T, pst *PST) {
+				sig, err := testKeys[3].Sign(pst.Tx.Unsigned.Bytes())
+				require.NoError(t, err)
+
+				cred := pst.Tx.Creds[0].(*secp256k1fx.Credential)
+				copy(cred.Sigs[0][:], sig)
+			},
+			expectedErr: ErrInvalidSignature,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			pst, err := NewPST(context.Background(), backend, utx)
+			require.NoError(err)
+			test.modify(t, pst)
+
+			pstBytes, err := pst.Bytes()
+			require.NoError(err)
+
+			_, err = ParsePST(pstBytes)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestPSTFinalizeInvalidSignature(t *testing.T) {
+	require := require.New(t)
+
+	utx, backend := newTestTx("treasury")
+	pst, err := NewPST(context.Background(), backend, utx)
+	require.NoError(err)
+	require.NoError(pst.Sign(context.Background(), secp256k1fx.NewKeychain(testKeys[0], testKeys[1], testKeys[2])))
+
+	// Replace a valid signature with a signature of the wrong key
+	sig, err := testKeys[3].Sign(pst.Tx.Unsigned.Bytes())
+	require.NoError(err)
+	cred := pst.Tx.Creds[1].(*secp256k1fx.Credential)
+	copy(cred.Sigs[0][:], sig)
+
+	_, err = pst.Finalize()
+	require.ErrorIs(err, ErrInvalidSignature)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+var testKeys = secp256k1.TestKeys()
+
+type testBackend map[ids.ID]*avax.UTXO
+
+func (b testBackend) GetUTXO(_ context.Context, _, utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, ok := b[utxoID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func TestPSTMultisig(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		addrs = []ids.ShortID{
+			testKeys[0].Address(),
+			testKeys[1].Address(),
+			testKeys[2].Address(),
+		}
+		utxo = &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 100,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 2,
+					Addrs:     addrs,
+				},
+			},
+		}
+		utx = &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: ids.GenerateTestID(),
+			Ins: []*avax.TransferableInput{{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt: 100,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{1, 2},
+					},
+				},
+			}},
+		}}
+	)
+
+	pst, err := NewPST(ctx, testBackend{utxo.InputID(): utxo}, utx)
+	require.NoError(err)
+	require.Equal([][]ids.ShortID{{addrs[1], addrs[2]}}, pst.Signers)
+
+	pstBytes, err := pst.Bytes()
+	require.NoError(err)
+
+	// Co-signers sign without access to the chain.
+	psts := make([]*PST, 2)
+	for i, key := range []*secp256k1.PrivateKey{testKeys[1], testKeys[2]} {
+		psts[i], err = ParsePST(pstBytes)
+		require.NoError(err)
+		require.NoError(psts[i].Sign(ctx, secp256k1fx.NewKeychain(key)))
+	}
+
+	_, err = psts[0].Finalize()
+	require.ErrorIs(err, ErrMissingSignature)
+	missing, err := psts[0].Missing()
+	require.NoError(err)
+	require.Equal(set.Of(addrs[2]), missing)
+
+	require.NoError(psts[0].Merge(psts[1]))
+	tx, err := psts[0].Finalize()
+	require.NoError(err)
+
+	parsedTx, err := builder.Parser.ParseTx(tx.Bytes())
+	require.NoError(err)
+	require.Equal(tx.ID(), parsedTx.ID())
+
+	// Signers are derived from the UTXOs rather than taken from the PST, so a
+	// signature of a key that doesn't own the UTXO is rejected.
+	unsignedPST, err := ParsePST(pstBytes)
+	require.NoError(err)
+	forgedPST, err := ParsePST(pstBytes)
+	require.NoError(err)
+	forgedPST.Signers[0][0] = testKeys[3].Address()
+	sig, err := testKeys[3].Sign(forgedPST.Tx.Unsigned.Bytes())
+	require.NoError(err)
+	copy(forgedPST.Tx.Creds[0].Credential.(*secp256k1fx.Credential).Sigs[0][:], sig)
+
+	err = unsignedPST.Merge(forgedPST)
+	require.ErrorIs(err, ErrInvalidSignature)
+	forgedBytes, err := forgedPST.Bytes()
+	require.NoError(err)
+	_, err = ParsePST(forgedBytes)
+	require.ErrorIs(err, ErrInvalidPST)
+
+	// Merging a PST of another transaction fails without modifying the PST.
+	otherPST, err := NewPST(ctx, testBackend{}, &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: ids.GenerateTestID(),
+	}})
+	require.NoError(err)
+	err = psts[0].Merge(otherPST)
+	require.ErrorIs(err, ErrMismatchedPST)
+	require.Equal(tx.ID(), psts[0].Tx.ID())
+}
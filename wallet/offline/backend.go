@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+// NewPBackend returns a P-chain backend that only knows about [utxos].
+func NewPBackend(ctx context.Context, context *pbuilder.Context, utxos []*avax.UTXO) (p.Backend, error) {
+	chainUTXOs, err := newChainUTXOs(ctx, constants.PlatformChainID, utxos)
+	if err != nil {
+		return nil, err
+	}
+	return p.NewBackend(context, chainUTXOs, nil), nil
+}
+
+// NewXBackend returns an X-chain backend that only knows about [utxos].
+func NewXBackend(ctx context.Context, context *xbuilder.Context, utxos []*avax.UTXO) (x.Backend, error) {
+	chainUTXOs, err := newChainUTXOs(ctx, context.BlockchainID, utxos)
+	if err != nil {
+		return nil, err
+	}
+	return x.NewBackend(context, chainUTXOs), nil
+}
+
+func newChainUTXOs(ctx context.Context, chainID ids.ID, utxos []*avax.UTXO) (common.ChainUTXOs, error) {
+	chainUTXOs := common.NewChainUTXOs(chainID, common.NewUTXOs())
+	for _, utxo := range utxos {
+		if err := chainUTXOs.AddUTXO(ctx, chainID, utxo); err != nil {
+			return nil, err
+		}
+	}
+	return chainUTXOs, nil
+}
+
+// Addresses returns the addresses that own [utxos].
+func Addresses(utxos []*avax.UTXO) set.Set[ids.ShortID] {
+	var addrs set.Set[ids.ShortID]
+	for _, utxo := range utxos {
+		addressable, ok := utxo.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				continue
+			}
+			addrs.Add(addr)
+		}
+	}
+	return addrs
+}
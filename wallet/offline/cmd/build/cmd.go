@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package build
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/offline"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+func Command() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "build",
+		Short: "Builds unsigned transactions from UTXO snapshots",
+	}
+	c.AddCommand(
+		transferCommand(),
+		validatorCommand(),
+	)
+	return c
+}
+
+func transferCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "transfer",
+		Short: "Builds a transaction that transfers funds on the P-chain or X-chain",
+		RunE:  transferFunc,
+	}
+	flags := c.Flags()
+	AddFlags(flags)
+	AddTransferFlags(flags)
+	return c
+}
+
+func validatorCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "add-validator",
+		Short: "Builds a transaction that adds a validator to the primary network",
+		RunE:  validatorFunc,
+	}
+	flags := c.Flags()
+	AddFlags(flags)
+	AddValidatorFlags(flags)
+	return c
+}
+
+func transferFunc(c *cobra.Command, args []string) error {
+	flags := c.Flags()
+	config, err := ParseTransferFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Context()
+	outputs := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: config.AssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: config.Amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{config.To},
+			},
+		},
+	}}
+
+	pst := &offline.PST{
+		NetworkID: config.NetworkID,
+	}
+	if config.Chain == pbuilder.Alias {
+		builder, backend, err := newPBuilder(ctx, &config.Config)
+		if err != nil {
+			return err
+		}
+		utx, err := builder.NewBaseTx(outputs, options(ctx, &config.Config)...)
+		if err != nil {
+			return err
+		}
+		pst.P, err = psigner.NewPST(ctx, backend, utx)
+		if err != nil {
+			return err
+		}
+	} else {
+		builder, backend, err := newXBuilder(ctx, &config.Config)
+		if err != nil {
+			return err
+		}
+		utx, err := builder.NewBaseTx(outputs, options(ctx, &config.Config)...)
+		if err != nil {
+			return err
+		}
+		pst.X, err = xsigner.NewPST(ctx, backend, utx)
+		if err != nil {
+			return err
+		}
+	}
+	return write(pst, config.Output)
+}
+
+func validatorFunc(c *cobra.Command, args []string) error {
+	flags := c.Flags()
+	config, err := ParseValidatorFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Context()
+	builder, backend, err := newPBuilder(ctx, &config.Config)
+	if err != nil {
+		return err
+	}
+
+	rewardsOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{config.RewardAddress},
+	}
+	utx, err := builder.NewAddPermissionlessValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: config.NodeID,
+				Start:  uint64(config.StartTime.Unix()),
+				End:    uint64(config.EndTime.Unix()),
+				Wght:   config.Weight,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		config.Signer,
+		config.AVAXAssetID,
+		rewardsOwner,
+		rewardsOwner,
+		config.DelegationFee,
+		options(ctx, &config.Config)...,
+	)
+	if err != nil {
+		return err
+	}
+
+	pst, err := psigner.NewPST(ctx, backend, utx)
+	if err != nil {
+		return err
+	}
+	return write(&offline.PST{
+		NetworkID: config.NetworkID,
+		P:         pst,
+	}, config.Output)
+}
+
+func newPBuilder(ctx context.Context, config *Config) (pbuilder.Builder, psigner.Backend, error) {
+	utxos, err := readUTXOs(config.UTXOs, txs.Codec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	context := offline.NewPContext(config.NetworkID, config.AVAXAssetID)
+	backend, err := offline.NewPBackend(ctx, context, utxos)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pbuilder.New(offline.Addresses(utxos), context, backend), backend, nil
+}
+
+func newXBuilder(ctx context.Context, config *Config) (xbuilder.Builder, xsigner.Backend, error) {
+	utxos, err := readUTXOs(config.UTXOs, xbuilder.Parser.Codec())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	context := offline.NewXContext(config.NetworkID, config.XChainID, config.AVAXAssetID)
+	backend, err := offline.NewXBackend(ctx, context, utxos)
+	if err != nil {
+		return nil, nil, err
+	}
+	return xbuilder.New(offline.Addresses(utxos), context, backend), backend, nil
+}
+
+func readUTXOs(paths []string, c codec.Manager) ([]*avax.UTXO, error) {
+	var utxos []*avax.UTXO
+	for _, path := range paths {
+		snapshotUTXOs, err := offline.ReadUTXOs(path, c)
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, snapshotUTXOs...)
+	}
+	return utxos, nil
+}
+
+func options(ctx context.Context, config *Config) []common.Option {
+	options := []common.Option{
+		common.WithContext(ctx),
+	}
+	if config.ChangeAddress != ids.ShortEmpty {
+		options = append(options, common.WithChangeOwner(&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{config.ChangeAddress},
+		}))
+	}
+	return options
+}
+
+func write(pst *offline.PST, path string) error {
+	if err := pst.Write(path); err != nil {
+		return err
+	}
+	log.Printf("wrote unsigned %s-chain transaction to %s\n", pst.Chain(), path)
+	return nil
+}
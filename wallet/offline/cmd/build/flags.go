@@ -0,0 +1,320 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package build
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/wallet/offline"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+const (
+	UTXOsKey         = "utxos"
+	NetworkIDKey     = "network-id"
+	AVAXAssetIDKey   = "avax-asset-id"
+	XChainIDKey      = "x-chain-id"
+	ChangeAddressKey = "change-address"
+	OutputKey        = "output"
+
+	ChainKey   = "chain"
+	AssetIDKey = "asset-id"
+	AmountKey  = "amount"
+	ToKey      = "to"
+
+	NodeIDKey               = "node-id"
+	BLSPublicKeyKey         = "bls-public-key"
+	BLSProofOfPossessionKey = "bls-proof-of-possession"
+	WeightKey               = "weight"
+	StartTimeKey            = "start-time"
+	EndTimeKey              = "end-time"
+	RewardAddressKey        = "reward-address"
+	DelegationFeeKey        = "delegation-fee"
+
+	defaultDelegationFee     = 20_000
+	defaultValidatorDuration = 14 * 24 * time.Hour
+)
+
+var (
+	errMissingUTXOs  = errors.New("--utxos is required")
+	errMissingTo     = errors.New("--to is required")
+	errMissingNodeID = errors.New("--node-id is required")
+	errMissingReward = errors.New("--reward-address is required")
+	errInvalidFee    = fmt.Errorf("--delegation-fee must be at most %d", reward.PercentDenominator)
+)
+
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringSlice(UTXOsKey, nil, "UTXO snapshots exported with platform.getUTXOs or avm.getUTXOs")
+	flags.Uint32(NetworkIDKey, constants.MainnetID, "Network the transaction is issued on")
+	flags.String(AVAXAssetIDKey, "", "ID of the AVAX asset, defaults to the asset of the network's genesis")
+	flags.String(XChainIDKey, "", "ID of the X-chain, defaults to the X-chain of the network's genesis")
+	flags.String(ChangeAddressKey, "", "Address to send the change to, defaults to an address of the UTXOs")
+	flags.String(OutputKey, "tx.pst.json", "File to write the partially signed transaction to")
+}
+
+func AddTransferFlags(flags *pflag.FlagSet) {
+	flags.String(ChainKey, pbuilder.Alias, "Chain to transfer funds on, either P or X")
+	flags.String(AssetIDKey, "", "Asset to send, defaults to AVAX")
+	flags.Uint64(AmountKey, 0, "Amount to send")
+	flags.String(ToKey, "", "Destination address")
+}
+
+func AddValidatorFlags(flags *pflag.FlagSet) {
+	flags.String(NodeIDKey, "", "Node ID of the validator")
+	flags.String(BLSPublicKeyKey, "", "Hex encoded BLS public key of the validator")
+	flags.String(BLSProofOfPossessionKey, "", "Hex encoded BLS proof of possession of the validator")
+	flags.Uint64(WeightKey, 0, "Amount to stake")
+	flags.String(StartTimeKey, "", "RFC3339 start time of the validation period, defaults to now")
+	flags.String(EndTimeKey, "", fmt.Sprintf("RFC3339 end time of the validation period, defaults to %s after the start time", defaultValidatorDuration))
+	flags.String(RewardAddressKey, "", "Address to send the validation and delegation rewards to")
+	flags.Uint32(DelegationFeeKey, defaultDelegationFee, fmt.Sprintf("Fraction, out of %d, of the delegation rewards taken by the validator", reward.PercentDenominator))
+}
+
+type Config struct {
+	UTXOs         []string
+	NetworkID     uint32
+	AVAXAssetID   ids.ID
+	XChainID      ids.ID
+	ChangeAddress ids.ShortID
+	Output        string
+}
+
+type TransferConfig struct {
+	Config
+
+	Chain   string
+	AssetID ids.ID
+	Amount  uint64
+	To      ids.ShortID
+}
+
+type ValidatorConfig struct {
+	Config
+
+	NodeID        ids.NodeID
+	Signer        *signer.ProofOfPossession
+	Weight        uint64
+	StartTime     time.Time
+	EndTime       time.Time
+	RewardAddress ids.ShortID
+	DelegationFee uint32
+}
+
+func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	utxos, err := flags.GetStringSlice(UTXOsKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(utxos) == 0 {
+		return nil, errMissingUTXOs
+	}
+
+	networkID, err := flags.GetUint32(NetworkIDKey)
+	if err != nil {
+		return nil, err
+	}
+
+	avaxAssetID, xChainID, genesisErr := offline.GenesisIDs(networkID)
+	avaxAssetID, err = getID(flags, AVAXAssetIDKey, avaxAssetID, genesisErr)
+	if err != nil {
+		return nil, err
+	}
+	xChainID, err = getID(flags, XChainIDKey, xChainID, genesisErr)
+	if err != nil {
+		return nil, err
+	}
+
+	changeAddress, err := getAddress(flags, ChangeAddressKey)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := flags.GetString(OutputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		UTXOs:         utxos,
+		NetworkID:     networkID,
+		AVAXAssetID:   avaxAssetID,
+		XChainID:      xChainID,
+		ChangeAddress: changeAddress,
+		Output:        output,
+	}, nil
+}
+
+func ParseTransferFlags(flags *pflag.FlagSet, args []string) (*TransferConfig, error) {
+	config, err := ParseFlags(flags, args)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := flags.GetString(ChainKey)
+	if err != nil {
+		return nil, err
+	}
+	if chain != pbuilder.Alias && chain != xbuilder.Alias {
+		return nil, fmt.Errorf("%w: %q", offline.ErrUnknownChain, chain)
+	}
+
+	assetID, err := getID(flags, AssetIDKey, config.AVAXAssetID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := flags.GetUint64(AmountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := getAddress(flags, ToKey)
+	if err != nil {
+		return nil, err
+	}
+	if to == ids.ShortEmpty {
+		return nil, errMissingTo
+	}
+
+	return &TransferConfig{
+		Config:  *config,
+		Chain:   chain,
+		AssetID: assetID,
+		Amount:  amount,
+		To:      to,
+	}, nil
+}
+
+func ParseValidatorFlags(flags *pflag.FlagSet, args []string) (*ValidatorConfig, error) {
+	config, err := ParseFlags(flags, args)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeIDStr, err := flags.GetString(NodeIDKey)
+	if err != nil {
+		return nil, err
+	}
+	if nodeIDStr == "" {
+		return nil, errMissingNodeID
+	}
+	nodeID, err := ids.NodeIDFromString(nodeIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := flags.GetString(BLSPublicKeyKey)
+	if err != nil {
+		return nil, err
+	}
+	proofOfPossession, err := flags.GetString(BLSProofOfPossessionKey)
+	if err != nil {
+		return nil, err
+	}
+	popJSON, err := json.Marshal(map[string]string{
+		"publicKey":         publicKey,
+		"proofOfPossession": proofOfPossession,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pop := &signer.ProofOfPossession{}
+	if err := pop.UnmarshalJSON(popJSON); err != nil {
+		return nil, fmt.Errorf("invalid BLS key: %w", err)
+	}
+	if err := pop.Verify(); err != nil {
+		return nil, err
+	}
+
+	weight, err := flags.GetUint64(WeightKey)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, err := getTime(flags, StartTimeKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := getTime(flags, EndTimeKey, startTime.Add(defaultValidatorDuration))
+	if err != nil {
+		return nil, err
+	}
+
+	rewardAddress, err := getAddress(flags, RewardAddressKey)
+	if err != nil {
+		return nil, err
+	}
+	if rewardAddress == ids.ShortEmpty {
+		return nil, errMissingReward
+	}
+
+	delegationFee, err := flags.GetUint32(DelegationFeeKey)
+	if err != nil {
+		return nil, err
+	}
+	if delegationFee > reward.PercentDenominator {
+		return nil, errInvalidFee
+	}
+
+	return &ValidatorConfig{
+		Config:        *config,
+		NodeID:        nodeID,
+		Signer:        pop,
+		Weight:        weight,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		RewardAddress: rewardAddress,
+		DelegationFee: delegationFee,
+	}, nil
+}
+
+// getID parses the ID of [key]. If the flag wasn't provided, [defaultID] is
+// returned unless it couldn't be determined, as reported by [defaultErr].
+func getID(flags *pflag.FlagSet, key string, defaultID ids.ID, defaultErr error) (ids.ID, error) {
+	idStr, err := flags.GetString(key)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if idStr == "" {
+		if defaultErr != nil {
+			return ids.Empty, fmt.Errorf("--%s is required: %w", key, defaultErr)
+		}
+		return defaultID, nil
+	}
+	return ids.FromString(idStr)
+}
+
+// getAddress parses the address of [key], or returns the empty address if the
+// flag wasn't provided.
+func getAddress(flags *pflag.FlagSet, key string) (ids.ShortID, error) {
+	addrStr, err := flags.GetString(key)
+	if err != nil || addrStr == "" {
+		return ids.ShortEmpty, err
+	}
+	return address.ParseToID(addrStr)
+}
+
+func getTime(flags *pflag.FlagSet, key string, defaultTime time.Time) (time.Time, error) {
+	timeStr, err := flags.GetString(key)
+	if err != nil || timeStr == "" {
+		return defaultTime, err
+	}
+	return time.Parse(time.RFC3339, timeStr)
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finalize
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/wallet/offline"
+)
+
+func Command() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "finalize",
+		Short: "Outputs a fully signed transaction for issuance",
+		RunE:  finalizeFunc,
+	}
+	flags := c.Flags()
+	AddFlags(flags)
+	return c
+}
+
+func finalizeFunc(c *cobra.Command, args []string) error {
+	flags := c.Flags()
+	config, err := ParseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	pst, err := offline.ReadPST(config.PST)
+	if err != nil {
+		return err
+	}
+	txBytes, err := pst.Finalize()
+	if err != nil {
+		return err
+	}
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return err
+	}
+
+	if config.Output == "" {
+		fmt.Fprintln(os.Stdout, txStr)
+	} else if err := os.WriteFile(config.Output, []byte(txStr+"\n"), 0o600); err != nil {
+		return err
+	}
+	log.Printf("finalized %s-chain transaction %s\n", pst.Chain(), pst.TxID())
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finalize
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	PSTKey    = "pst"
+	OutputKey = "output"
+)
+
+var errMissingPST = errors.New("--pst is required")
+
+func AddFlags(flags *pflag.FlagSet) {
+	flags.String(PSTKey, "", "Partially signed transaction to finalize")
+	flags.String(OutputKey, "", "File to write the hex encoded signed transaction to, defaults to stdout")
+}
+
+type Config struct {
+	PST    string
+	Output string
+}
+
+func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	pst, err := flags.GetString(PSTKey)
+	if err != nil {
+		return nil, err
+	}
+	if pst == "" {
+		return nil, errMissingPST
+	}
+
+	output, err := flags.GetString(OutputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		PST:    pst,
+		Output: output,
+	}, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package inspect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/wallet/offline"
+)
+
+var errMissingPST = errors.New("expected a single partially signed transaction")
+
+// Inspection is the human readable description of a PST, which signers should
+// review before signing it.
+type Inspection struct {
+	Chain     string   `json:"chain"`
+	NetworkID uint32   `json:"networkID"`
+	TxID      ids.ID   `json:"txID"`
+	Tx        any      `json:"unsignedTx"`
+	Signers   []string `json:"signers"`
+	Missing   []string `json:"missing"`
+}
+
+func Command() *cobra.Command {
+	return &cobra.Command{
+		Use:   "inspect [pst]",
+		Short: "Displays a partially signed transaction and its missing signatures",
+		RunE:  inspectFunc,
+	}
+}
+
+func inspectFunc(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errMissingPST
+	}
+
+	pst, err := offline.ReadPST(args[0])
+	if err != nil {
+		return err
+	}
+
+	missing, err := pst.Missing()
+	if err != nil {
+		return err
+	}
+	signers, err := formatAddresses(pst, pst.Signers())
+	if err != nil {
+		return err
+	}
+	missingSigners, err := formatAddresses(pst, missing)
+	if err != nil {
+		return err
+	}
+
+	inspectionBytes, err := json.MarshalIndent(Inspection{
+		Chain:     pst.Chain(),
+		NetworkID: pst.NetworkID,
+		TxID:      pst.TxID(),
+		Tx:        pst.Unsigned(),
+		Signers:   signers,
+		Missing:   missingSigners,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, string(inspectionBytes))
+	return nil
+}
+
+func formatAddresses(pst *offline.PST, addrs set.Set[ids.ShortID]) ([]string, error) {
+	hrp := constants.GetHRP(pst.NetworkID)
+	addrList := addrs.List()
+	utils.Sort(addrList)
+
+	formatted := make([]string, len(addrList))
+	for i, addr := range addrList {
+		var err error
+		formatted[i], err = address.Format(pst.Chain(), hrp, addr.Bytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return formatted, nil
+}
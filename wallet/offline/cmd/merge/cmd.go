@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merge
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/wallet/offline"
+)
+
+func Command() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "merge [pst]...",
+		Short: "Merges the signatures of partially signed transactions that were signed separately",
+		RunE:  mergeFunc,
+	}
+	flags := c.Flags()
+	AddFlags(flags)
+	return c
+}
+
+func mergeFunc(c *cobra.Command, args []string) error {
+	flags := c.Flags()
+	config, err := ParseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	pst, err := offline.ReadPST(config.PSTs[0])
+	if err != nil {
+		return err
+	}
+	for _, path := range config.PSTs[1:] {
+		other, err := offline.ReadPST(path)
+		if err != nil {
+			return err
+		}
+		if err := pst.Merge(other); err != nil {
+			return fmt.Errorf("failed to merge %s: %w", path, err)
+		}
+	}
+	if err := pst.Write(config.Output); err != nil {
+		return err
+	}
+
+	missing, err := pst.Missing()
+	if err != nil {
+		return err
+	}
+	log.Printf("wrote transaction to %s, %d signers remaining\n", config.Output, missing.Len())
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merge
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+)
+
+const OutputKey = "output"
+
+var (
+	errMissingPSTs   = errors.New("at least two partially signed transactions are required")
+	errMissingOutput = errors.New("--output is required")
+)
+
+func AddFlags(flags *pflag.FlagSet) {
+	flags.String(OutputKey, "", "File to write the merged transaction to")
+}
+
+type Config struct {
+	PSTs   []string
+	Output string
+}
+
+func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	psts := flags.Args()
+	if len(psts) < 2 {
+		return nil, errMissingPSTs
+	}
+
+	output, err := flags.GetString(OutputKey)
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, errMissingOutput
+	}
+
+	return &Config{
+		PSTs:   psts,
+		Output: output,
+	}, nil
+}
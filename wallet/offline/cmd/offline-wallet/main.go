@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/wallet/offline/cmd/build"
+	"github.com/ava-labs/avalanchego/wallet/offline/cmd/finalize"
+	"github.com/ava-labs/avalanchego/wallet/offline/cmd/inspect"
+	"github.com/ava-labs/avalanchego/wallet/offline/cmd/merge"
+	"github.com/ava-labs/avalanchego/wallet/offline/cmd/sign"
+)
+
+func init() {
+	cobra.EnablePrefixMatching = true
+}
+
+func main() {
+	cmd := &cobra.Command{
+		Use:   "offline-wallet",
+		Short: "Builds and signs P-chain and X-chain transactions without access to the network",
+	}
+	cmd.AddCommand(
+		build.Command(),
+		sign.Command(),
+		merge.Command(),
+		inspect.Command(),
+		finalize.Command(),
+	)
+	ctx := context.Background()
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sign
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/ledger"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/offline"
+)
+
+func Command() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sign",
+		Short: "Signs a partially signed transaction without access to the network",
+		RunE:  signFunc,
+	}
+	flags := c.Flags()
+	AddFlags(flags)
+	return c
+}
+
+func signFunc(c *cobra.Command, args []string) error {
+	flags := c.Flags()
+	config, err := ParseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	pst, err := offline.ReadPST(config.PST)
+	if err != nil {
+		return err
+	}
+
+	var kc keychain.Keychain = secp256k1fx.NewKeychain(config.PrivateKeys...)
+	if config.Ledger {
+		l, err := ledger.New()
+		if err != nil {
+			return err
+		}
+		defer l.Disconnect()
+
+		kc, err = keychain.NewLedgerKeychainFromIndices(l, config.LedgerIndices)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := pst.Sign(c.Context(), kc); err != nil {
+		return err
+	}
+	if err := pst.Write(config.Output); err != nil {
+		return err
+	}
+
+	missing, err := pst.Missing()
+	if err != nil {
+		return err
+	}
+	log.Printf("wrote transaction to %s, %d signers remaining\n", config.Output, missing.Len())
+	return nil
+}
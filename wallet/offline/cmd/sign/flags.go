@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sign
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+)
+
+const (
+	PSTKey            = "pst"
+	OutputKey         = "output"
+	PrivateKeyFileKey = "private-key-file"
+	LedgerKey         = "ledger"
+	LedgerIndicesKey  = "ledger-indices"
+)
+
+var (
+	errMissingPST     = errors.New("--pst is required")
+	errMissingSigners = errors.New("either --private-key-file or --ledger is required")
+	errTooManySigners = errors.New("--private-key-file and --ledger are mutually exclusive")
+)
+
+func AddFlags(flags *pflag.FlagSet) {
+	flags.String(PSTKey, "", "Partially signed transaction to sign")
+	flags.String(OutputKey, "", "File to write the signed transaction to, defaults to overwriting --pst")
+	flags.StringSlice(PrivateKeyFileKey, nil, "Files containing one private key per line to sign with")
+	flags.Bool(LedgerKey, false, "Sign with a connected ledger")
+	flags.UintSlice(LedgerIndicesKey, []uint{0}, "Indices of the ledger addresses to sign with")
+}
+
+type Config struct {
+	PST           string
+	Output        string
+	PrivateKeys   []*secp256k1.PrivateKey
+	Ledger        bool
+	LedgerIndices []uint32
+}
+
+func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	pst, err := flags.GetString(PSTKey)
+	if err != nil {
+		return nil, err
+	}
+	if pst == "" {
+		return nil, errMissingPST
+	}
+
+	output, err := flags.GetString(OutputKey)
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		output = pst
+	}
+
+	keyFiles, err := flags.GetStringSlice(PrivateKeyFileKey)
+	if err != nil {
+		return nil, err
+	}
+	var privateKeys []*secp256k1.PrivateKey
+	for _, keyFile := range keyFiles {
+		keys, err := readPrivateKeys(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		privateKeys = append(privateKeys, keys...)
+	}
+
+	ledger, err := flags.GetBool(LedgerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(privateKeys) == 0 && !ledger:
+		return nil, errMissingSigners
+	case len(privateKeys) != 0 && ledger:
+		return nil, errTooManySigners
+	}
+
+	indices, err := flags.GetUintSlice(LedgerIndicesKey)
+	if err != nil {
+		return nil, err
+	}
+	ledgerIndices := make([]uint32, len(indices))
+	for i, index := range indices {
+		ledgerIndices[i] = uint32(index)
+	}
+
+	return &Config{
+		PST:           pst,
+		Output:        output,
+		PrivateKeys:   privateKeys,
+		Ledger:        ledger,
+		LedgerIndices: ledgerIndices,
+	}, nil
+}
+
+// readPrivateKeys reads the keys of [path], formatted as PrivateKey-..., one
+// per line. Empty lines are ignored.
+func readPrivateKeys(path string) ([]*secp256k1.PrivateKey, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		keys    []*secp256k1.PrivateKey
+		scanner = bufio.NewScanner(file)
+	)
+	for scanner.Scan() {
+		skStr := strings.TrimSpace(scanner.Text())
+		if skStr == "" {
+			continue
+		}
+
+		sk := &secp256k1.PrivateKey{}
+		if err := sk.UnmarshalText([]byte(`"` + skStr + `"`)); err != nil {
+			return nil, err
+		}
+		keys = append(keys, sk)
+	}
+	return keys, scanner.Err()
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+var errUnknownGenesis = errors.New("genesis isn't known offline")
+
+// GenesisIDs returns the ID of the AVAX asset and of the X-chain of
+// [networkID]. Only the genesis of the public networks is known without
+// access to the network.
+func GenesisIDs(networkID uint32) (ids.ID, ids.ID, error) {
+	if networkID != constants.MainnetID && networkID != constants.FujiID {
+		return ids.Empty, ids.Empty, fmt.Errorf("%w: network %d", errUnknownGenesis, networkID)
+	}
+
+	genesisBytes, avaxAssetID, err := genesis.FromConfig(genesis.GetConfig(networkID))
+	if err != nil {
+		return ids.Empty, ids.Empty, err
+	}
+	xChainTx, err := genesis.VMGenesis(genesisBytes, constants.AVMID)
+	if err != nil {
+		return ids.Empty, ids.Empty, err
+	}
+	return avaxAssetID, xChainTx.ID(), nil
+}
+
+// NewPContext returns the context needed to build P-chain transactions of
+// [networkID] using the fees of the network's genesis parameters.
+func NewPContext(networkID uint32, avaxAssetID ids.ID) *pbuilder.Context {
+	txFees := genesis.GetTxFeeConfig(networkID)
+	return &pbuilder.Context{
+		NetworkID:       networkID,
+		AVAXAssetID:     avaxAssetID,
+		StaticFeeConfig: txFees.StaticFeeConfig,
+	}
+}
+
+// NewXContext returns the context needed to build X-chain transactions of
+// [networkID] using the fees of the network's genesis parameters.
+func NewXContext(networkID uint32, xChainID ids.ID, avaxAssetID ids.ID) *xbuilder.Context {
+	txFees := genesis.GetTxFeeConfig(networkID)
+	return &xbuilder.Context{
+		NetworkID:        networkID,
+		BlockchainID:     xChainID,
+		AVAXAssetID:      avaxAssetID,
+		BaseTxFee:        txFees.StaticFeeConfig.TxFee,
+		CreateAssetTxFee: txFees.CreateAssetTxFee,
+	}
+}
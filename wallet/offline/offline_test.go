@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	avmtxs "github.com/ava-labs/avalanchego/vms/avm/txs"
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+var testKeys = secp256k1.TestKeys()
+
+func TestGenesisIDs(t *testing.T) {
+	require := require.New(t)
+
+	avaxAssetID, xChainID, err := GenesisIDs(constants.MainnetID)
+	require.NoError(err)
+	require.Equal("FvwEAhmxKfeiG8SnEvq42hc6whRyY3EFYAvebMqDNDGCgxN5Z", avaxAssetID.String())
+	require.Equal("2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM", xChainID.String())
+
+	_, _, err = GenesisIDs(constants.LocalID)
+	require.ErrorIs(err, errUnknownGenesis)
+}
+
+// newSnapshot returns the JSON-RPC response of a getUTXOs call that returned
+// [utxos].
+func newSnapshot(t *testing.T, utxos []*avax.UTXO) []byte {
+	require := require.New(t)
+
+	reply := &api.GetUTXOsReply{
+		UTXOs:    make([]string, len(utxos)),
+		Encoding: formatting.Hex,
+	}
+	for i, utxo := range utxos {
+		utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		require.NoError(err)
+		reply.UTXOs[i], err = formatting.Encode(formatting.Hex, utxoBytes)
+		require.NoError(err)
+	}
+
+	snapshotBytes, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"result":  reply,
+		"id":      1,
+	})
+	require.NoError(err)
+	return snapshotBytes
+}
+
+func TestParseUTXOs(t *testing.T) {
+	require := require.New(t)
+
+	utxos := []*avax.UTXO{
+		{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{testKeys[0].Address()},
+				},
+			},
+		},
+	}
+	parsedUTXOs, err := ParseUTXOs(newSnapshot(t, utxos), txs.Codec)
+	require.NoError(err)
+	require.Equal(utxos, parsedUTXOs)
+
+	_, err = ParseUTXOs([]byte(`{"utxos":[]}`), txs.Codec)
+	require.ErrorIs(err, errMissingUTXOs)
+}
+
+// TestOfflineMultisig builds a P-chain transfer out of a 2-of-2 multisig UTXO
+// and signs it on separate machines.
+func TestOfflineMultisig(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		avaxAssetID = ids.GenerateTestID()
+		addrs       = []ids.ShortID{
+			testKeys[0].Address(),
+			testKeys[1].Address(),
+		}
+		utxo = &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 10 * units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 2,
+					Addrs:     addrs,
+				},
+			},
+		}
+	)
+	utxos, err := ParseUTXOs(newSnapshot(t, []*avax.UTXO{utxo}), txs.Codec)
+	require.NoError(err)
+	require.Equal(set.Of(addrs...), Addresses(utxos))
+
+	pContext := NewPContext(constants.FujiID, avaxAssetID)
+	backend, err := NewPBackend(ctx, pContext, utxos)
+	require.NoError(err)
+	builder := pbuilder.New(Addresses(utxos), pContext, backend)
+	utx, err := builder.NewBaseTx([]*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: avaxAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Avax,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{testKeys[2].Address()},
+			},
+		},
+	}})
+	require.NoError(err)
+
+	pPST, err := psigner.NewPST(ctx, backend, utx)
+	require.NoError(err)
+
+	pstPath := filepath.Join(t.TempDir(), "tx.pst.json")
+	require.NoError((&PST{
+		NetworkID: constants.FujiID,
+		P:         pPST,
+	}).Write(pstPath))
+
+	signed := make([]*PST, len(addrs))
+	for i, key := range []*secp256k1.PrivateKey{testKeys[0], testKeys[1]} {
+		signed[i], err = ReadPST(pstPath)
+		require.NoError(err)
+		require.Equal(pbuilder.Alias, signed[i].Chain())
+		require.Equal(set.Of(addrs...), signed[i].Signers())
+		require.NoError(signed[i].Sign(ctx, secp256k1fx.NewKeychain(key)))
+	}
+
+	_, err = signed[0].Finalize()
+	require.ErrorIs(err, psigner.ErrMissingSignature)
+
+	require.NoError(signed[0].Merge(signed[1]))
+	missing, err := signed[0].Missing()
+	require.NoError(err)
+	require.Empty(missing)
+
+	txBytes, err := signed[0].Finalize()
+	require.NoError(err)
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	require.NoError(err)
+	require.Equal(signed[0].TxID(), tx.ID())
+}
+
+func TestMergeMismatchedChain(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	pPST, err := psigner.NewPST(ctx, nil, &txs.BaseTx{})
+	require.NoError(err)
+	xPST, err := xsigner.NewPST(ctx, nil, &avmtxs.BaseTx{})
+	require.NoError(err)
+
+	err = (&PST{P: pPST}).Merge(&PST{X: xPST})
+	require.ErrorIs(err, ErrMismatchedChain)
+}
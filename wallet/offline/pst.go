@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+var (
+	ErrUnknownChain    = errors.New("unknown chain")
+	ErrMismatchedChain = errors.New("partially signed transactions are for different chains")
+)
+
+// PST is a partially signed transaction of either the P-chain or the X-chain.
+// Exactly one of [P] and [X] is set.
+type PST struct {
+	NetworkID uint32
+	P         *psigner.PST
+	X         *xsigner.PST
+}
+
+type jsonPST struct {
+	Chain     string `json:"chain"`
+	NetworkID uint32 `json:"networkID"`
+	PST       string `json:"pst"`
+}
+
+// ReadPST reads a PST that was written with Write.
+func ReadPST(path string) (*PST, error) {
+	pstBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pst := &PST{}
+	return pst, json.Unmarshal(pstBytes, pst)
+}
+
+// Write writes the PST to [path] so that it can be carried to another machine.
+func (p *PST) Write(path string) error {
+	pstBytes, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(pstBytes, '\n'), 0o600)
+}
+
+// Chain returns the alias of the chain the transaction is issued on.
+func (p *PST) Chain() string {
+	if p.P != nil {
+		return pbuilder.Alias
+	}
+	return xbuilder.Alias
+}
+
+func (p *PST) MarshalJSON() ([]byte, error) {
+	var (
+		pstBytes []byte
+		err      error
+	)
+	switch {
+	case p.P != nil:
+		pstBytes, err = p.P.Bytes()
+	case p.X != nil:
+		pstBytes, err = p.X.Bytes()
+	default:
+		return nil, ErrUnknownChain
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pstStr, err := formatting.Encode(formatting.Hex, pstBytes)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonPST{
+		Chain:     p.Chain(),
+		NetworkID: p.NetworkID,
+		PST:       pstStr,
+	})
+}
+
+func (p *PST) UnmarshalJSON(b []byte) error {
+	parsed := jsonPST{}
+	if err := json.Unmarshal(b, &parsed); err != nil {
+		return err
+	}
+
+	pstBytes, err := formatting.Decode(formatting.Hex, parsed.PST)
+	if err != nil {
+		return err
+	}
+
+	p.NetworkID = parsed.NetworkID
+	switch parsed.Chain {
+	case pbuilder.Alias:
+		p.P, err = psigner.ParsePST(pstBytes)
+	case xbuilder.Alias:
+		p.X, err = xsigner.ParsePST(pstBytes)
+	default:
+		err = fmt.Errorf("%w: %q", ErrUnknownChain, parsed.Chain)
+	}
+	return err
+}
+
+// Sign adds the signatures that [kc] is able to provide.
+func (p *PST) Sign(ctx context.Context, kc keychain.Keychain) error {
+	if p.P != nil {
+		return p.P.Sign(ctx, kc)
+	}
+	return p.X.Sign(ctx, kc)
+}
+
+// Merge adds the signatures of [other] that are missing from [p].
+func (p *PST) Merge(other *PST) error {
+	if p.Chain() != other.Chain() || p.NetworkID != other.NetworkID {
+		return ErrMismatchedChain
+	}
+	if p.P != nil {
+		return p.P.Merge(other.P)
+	}
+	return p.X.Merge(other.X)
+}
+
+// Signers returns the addresses that are expected to sign the transaction.
+// Signers that are unknown are omitted.
+func (p *PST) Signers() set.Set[ids.ShortID] {
+	var txSigners [][]ids.ShortID
+	if p.P != nil {
+		txSigners = p.P.Signers
+	} else {
+		txSigners = p.X.Signers
+	}
+
+	var signers set.Set[ids.ShortID]
+	for _, credSigners := range txSigners {
+		for _, signer := range credSigners {
+			if signer != ids.ShortEmpty {
+				signers.Add(signer)
+			}
+		}
+	}
+	return signers
+}
+
+// Missing returns the signers whose signatures haven't been collected yet.
+func (p *PST) Missing() (set.Set[ids.ShortID], error) {
+	if p.P != nil {
+		return p.P.Missing()
+	}
+	return p.X.Missing()
+}
+
+// TxID returns the ID of the transaction being signed.
+func (p *PST) TxID() ids.ID {
+	if p.P != nil {
+		return p.P.Tx.ID()
+	}
+	return p.X.Tx.ID()
+}
+
+// Unsigned returns the unsigned transaction being signed.
+func (p *PST) Unsigned() any {
+	if p.P != nil {
+		return p.P.Tx.Unsigned
+	}
+	return p.X.Tx.Unsigned
+}
+
+// Finalize returns the bytes of the signed transaction if all of its
+// signatures have been collected.
+func (p *PST) Finalize() ([]byte, error) {
+	if p.P != nil {
+		tx, err := p.P.Finalize()
+		if err != nil {
+			return nil, err
+		}
+		return tx.Bytes(), nil
+	}
+
+	tx, err := p.X.Finalize()
+	if err != nil {
+		return nil, err
+	}
+	return tx.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var errMissingUTXOs = errors.New("snapshot doesn't contain any utxos")
+
+// snapshot is the response of a getUTXOs call. The response may optionally be
+// wrapped in its JSON-RPC envelope.
+type snapshot struct {
+	api.GetUTXOsReply
+
+	Result *api.GetUTXOsReply `json:"result"`
+}
+
+// ReadUTXOs parses the UTXOs of a snapshot exported with platform.getUTXOs or
+// avm.getUTXOs. The UTXOs are parsed with [c], which must be the codec of the
+// chain the snapshot was exported from.
+func ReadUTXOs(path string, c codec.Manager) ([]*avax.UTXO, error) {
+	snapshotBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUTXOs(snapshotBytes, c)
+}
+
+// ParseUTXOs parses the UTXOs of a JSON encoded snapshot.
+func ParseUTXOs(snapshotBytes []byte, c codec.Manager) ([]*avax.UTXO, error) {
+	s := snapshot{}
+	if err := json.Unmarshal(snapshotBytes, &s); err != nil {
+		return nil, err
+	}
+	reply := &s.GetUTXOsReply
+	if s.Result != nil {
+		reply = s.Result
+	}
+	if len(reply.UTXOs) == 0 {
+		return nil, errMissingUTXOs
+	}
+
+	utxos := make([]*avax.UTXO, len(reply.UTXOs))
+	for i, utxoStr := range reply.UTXOs {
+		utxoBytes, err := formatting.Decode(reply.Encoding, utxoStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode utxo %d: %w", i, err)
+		}
+		utxo := &avax.UTXO{}
+		if _, err := c.Unmarshal(utxoBytes, utxo); err != nil {
+			return nil, fmt.Errorf("failed to parse utxo %d: %w", i, err)
+		}
+		utxos[i] = utxo
+	}
+	return utxos, nil
+}
@@ -319,6 +319,9 @@ func getAndParse[T any](
 			if response, err = parseFn(ctx, responseBytes); err == nil {
 				return response, nil
 			}
+			if ctx.Err() == nil || !errors.Is(err, ctx.Err()) {
+				client.networkClient.RegisterInvalidResponse(nodeID)
+			}
 		}
 
 		if errors.Is(err, errAppSendFailed) {
@@ -117,6 +117,8 @@ func sendRangeProofRequest(
 			return serverNodeID, serverResponse, nil
 		},
 	).AnyTimes()
+	// Responses that fail verification are reported to the network client.
+	networkClient.EXPECT().RegisterInvalidResponse(serverNodeID).AnyTimes()
 
 	// The server should expect to "send" a response to the client.
 	sender.EXPECT().SendAppResponse(
@@ -416,6 +418,8 @@ func sendChangeProofRequest(
 			return serverNodeID, serverResponse, nil
 		},
 	).AnyTimes()
+	// Responses that fail verification are reported to the network client.
+	networkClient.EXPECT().RegisterInvalidResponse(serverNodeID).AnyTimes()
 
 	// Expect server (serverDB) to send app response to client (clientDB)
 	sender.EXPECT().SendAppResponse(
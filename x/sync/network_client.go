@@ -53,6 +53,11 @@ type NetworkClient interface {
 		request []byte,
 	) ([]byte, error)
 
+	// RegisterInvalidResponse records that [nodeID] responded to a request
+	// with a response that failed verification, so that it is less likely to
+	// be selected by RequestAny.
+	RegisterInvalidResponse(nodeID ids.NodeID)
+
 	// The following declarations allow this interface to be embedded in the VM
 	// to handle incoming responses from peers.
 
@@ -96,6 +101,7 @@ func NewNetworkClient(
 	metricsNamespace string,
 	registerer prometheus.Registerer,
 	minVersion *version.Application,
+	reputation *p2p.Reputation,
 ) (NetworkClient, error) {
 	peerTracker, err := p2p.NewPeerTracker(
 		log,
@@ -103,6 +109,7 @@ func NewNetworkClient(
 		registerer,
 		set.Of(myNodeID),
 		minVersion,
+		reputation,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create peer tracker: %w", err)
@@ -202,30 +209,30 @@ func (c *networkClient) RequestAny(
 	}
 	defer c.activeRequests.Release(1)
 
-	nodeID, responseChan, err := c.sendRequestAny(ctx, request)
+	nodeID, requestID, responseChan, err := c.sendRequestAny(ctx, request)
 	if err != nil {
 		return ids.EmptyNodeID, nil, err
 	}
 
-	response, err := c.awaitResponse(ctx, nodeID, responseChan)
+	response, err := c.awaitResponse(ctx, nodeID, requestID, responseChan)
 	return nodeID, response, err
 }
 
 func (c *networkClient) sendRequestAny(
 	ctx context.Context,
 	request []byte,
-) (ids.NodeID, chan []byte, error) {
+) (ids.NodeID, uint32, chan []byte, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	nodeID, ok := c.peers.SelectPeer()
 	if !ok {
 		numPeers := c.peers.Size()
-		return ids.EmptyNodeID, nil, fmt.Errorf("no peers found from %d peers", numPeers)
+		return ids.EmptyNodeID, 0, nil, fmt.Errorf("no peers found from %d peers", numPeers)
 	}
 
-	responseChan, err := c.sendRequestLocked(ctx, nodeID, request)
-	return nodeID, responseChan, err
+	requestID, responseChan, err := c.sendRequestLocked(ctx, nodeID, request)
+	return nodeID, requestID, responseChan, err
 }
 
 // If [errAppSendFailed] is returned this should be considered fatal.
@@ -241,27 +248,27 @@ func (c *networkClient) Request(
 	}
 	defer c.activeRequests.Release(1)
 
-	responseChan, err := c.sendRequest(ctx, nodeID, request)
+	requestID, responseChan, err := c.sendRequest(ctx, nodeID, request)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.awaitResponse(ctx, nodeID, responseChan)
+	return c.awaitResponse(ctx, nodeID, requestID, responseChan)
 }
 
 func (c *networkClient) sendRequest(
 	ctx context.Context,
 	nodeID ids.NodeID,
 	request []byte,
-) (chan []byte, error) {
+) (uint32, chan []byte, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	return c.sendRequestLocked(ctx, nodeID, request)
 }
 
-// Sends [request] to [nodeID] and returns a channel that will populate the
-// response.
+// Sends [request] to [nodeID] and returns the ID of the request and a channel
+// that will populate the response.
 //
 // If [errAppSendFailed] is returned this should be considered fatal.
 //
@@ -273,7 +280,7 @@ func (c *networkClient) sendRequestLocked(
 	ctx context.Context,
 	nodeID ids.NodeID,
 	request []byte,
-) (chan []byte, error) {
+) (uint32, chan []byte, error) {
 	requestID := c.requestID
 	c.requestID++
 
@@ -282,7 +289,7 @@ func (c *networkClient) sendRequestLocked(
 		zap.Uint32("requestID", requestID),
 		zap.Int("requestLen", len(request)),
 	)
-	c.peers.RegisterRequest(nodeID)
+	c.peers.RegisterRequest(nodeID, requestID)
 
 	// Send an app request to the peer.
 	nodeIDs := set.Of(nodeID)
@@ -301,15 +308,15 @@ func (c *networkClient) sendRequestLocked(
 			zap.Int("requestLen", len(request)),
 			zap.Error(err),
 		)
-		return nil, fmt.Errorf("%w: %w", errAppSendFailed, err)
+		return 0, nil, fmt.Errorf("%w: %w", errAppSendFailed, err)
 	}
 
 	handler := newResponseHandler()
 	c.outstandingRequestHandlers[requestID] = handler
-	return handler.responseChan, nil
+	return requestID, handler.responseChan, nil
 }
 
-// awaitResponse from [nodeID] and returns the response.
+// awaitResponse to [requestID] from [nodeID] and returns the response.
 //
 // Returns an error if the request failed or [ctx] is canceled.
 //
@@ -322,6 +329,7 @@ func (c *networkClient) sendRequestLocked(
 func (c *networkClient) awaitResponse(
 	ctx context.Context,
 	nodeID ids.NodeID,
+	requestID uint32,
 	responseChan chan []byte,
 ) ([]byte, error) {
 	var (
@@ -331,18 +339,18 @@ func (c *networkClient) awaitResponse(
 	)
 	select {
 	case <-ctx.Done():
-		c.peers.RegisterFailure(nodeID)
+		c.peers.RegisterFailure(nodeID, requestID)
 		return nil, ctx.Err()
 	case response, responded = <-responseChan:
 	}
 	if !responded {
-		c.peers.RegisterFailure(nodeID)
+		c.peers.RegisterFailure(nodeID, requestID)
 		return nil, errRequestFailed
 	}
 
 	elapsedSeconds := time.Since(startTime).Seconds()
 	bandwidth := float64(len(response)) / (elapsedSeconds + epsilon)
-	c.peers.RegisterResponse(nodeID, bandwidth)
+	c.peers.RegisterResponse(nodeID, requestID, bandwidth)
 
 	c.log.Debug("received response from peer",
 		zap.Stringer("nodeID", nodeID),
@@ -351,6 +359,10 @@ func (c *networkClient) awaitResponse(
 	return response, nil
 }
 
+func (c *networkClient) RegisterInvalidResponse(nodeID ids.NodeID) {
+	c.peers.RegisterInvalidResponse(nodeID)
+}
+
 func (c *networkClient) Connected(
 	_ context.Context,
 	nodeID ids.NodeID,